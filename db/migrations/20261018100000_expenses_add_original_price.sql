@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Сумма и валюта, в которых расход был фактически оплачен.
+-- Для старых расходов валюта неизвестна, сумма совпадает с ценой в базовой валюте.
+ALTER TABLE expenses
+    ADD COLUMN original_price NUMERIC(20, 10),
+    ADD COLUMN original_currency VARCHAR(5);
+UPDATE expenses SET original_price = price;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE expenses
+    DROP COLUMN original_price,
+    DROP COLUMN original_currency;
+-- +goose StatementEnd
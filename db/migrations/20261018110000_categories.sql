@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Справочник категорий пользователя. Расходы по-прежнему хранят имя категории,
+-- справочник задает иерархию и синонимы.
+CREATE TABLE categories (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    parent_id BIGINT REFERENCES categories (id) ON DELETE SET NULL,
+    CONSTRAINT category_name_non_empty CHECK (char_length(name) > 0 AND name = lower(name)),
+    CONSTRAINT category_name_uniq UNIQUE (user_id, name)
+);
+
+CREATE TABLE category_aliases (
+    user_id BIGINT NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    CONSTRAINT category_alias_non_empty CHECK (char_length(alias) > 0 AND alias = lower(alias)),
+    CONSTRAINT category_alias_uniq UNIQUE (user_id, alias)
+);
+
+CREATE INDEX category_aliases_category_id_idx ON category_aliases (category_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX category_aliases_category_id_idx;
+DROP TABLE category_aliases;
+DROP TABLE categories;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Лимиты расходов. Пустая категория означает лимит на все расходы за интервал.
+CREATE TABLE limits (
+    user_id BIGINT NOT NULL,
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    interval_type SMALLINT NOT NULL,
+    amount NUMERIC(20, 10) NOT NULL,
+    CONSTRAINT limit_amount_positive CHECK (amount > 0),
+    CONSTRAINT limit_category_lower CHECK (category = lower(category)),
+    PRIMARY KEY (user_id, category, interval_type)
+);
+
+INSERT INTO limits (user_id, interval_type, amount)
+    SELECT id, 1, day_limit FROM users WHERE day_limit > 0
+    UNION ALL
+    SELECT id, 2, week_limit FROM users WHERE week_limit > 0
+    UNION ALL
+    SELECT id, 3, month_limit FROM users WHERE month_limit > 0;
+
+ALTER TABLE users
+    DROP COLUMN day_limit,
+    DROP COLUMN week_limit,
+    DROP COLUMN month_limit;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN day_limit NUMERIC(10, 5) NOT NULL DEFAULT 0,
+    ADD COLUMN week_limit NUMERIC(10, 5) NOT NULL DEFAULT 0,
+    ADD COLUMN month_limit NUMERIC(10, 5) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT day_limit CHECK (day_limit >= 0),
+    ADD CONSTRAINT week_limit CHECK (week_limit >= 0),
+    ADD CONSTRAINT month_limit CHECK (month_limit >= 0);
+
+UPDATE users u SET
+    day_limit = COALESCE((SELECT amount FROM limits l
+        WHERE l.user_id = u.id AND l.category = '' AND l.interval_type = 1), 0),
+    week_limit = COALESCE((SELECT amount FROM limits l
+        WHERE l.user_id = u.id AND l.category = '' AND l.interval_type = 2), 0),
+    month_limit = COALESCE((SELECT amount FROM limits l
+        WHERE l.user_id = u.id AND l.category = '' AND l.interval_type = 3), 0);
+
+DROP TABLE limits;
+-- +goose StatementEnd
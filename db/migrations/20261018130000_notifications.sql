@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отправленные уведомления о достижении порога лимита, по одному на порог за интервал
+CREATE TABLE limit_notifications (
+    user_id BIGINT NOT NULL,
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    interval_type SMALLINT NOT NULL,
+    interval_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    threshold SMALLINT NOT NULL,
+    PRIMARY KEY (user_id, category, interval_type, interval_start, threshold)
+);
+
+-- Периодическая сводка расходов. Нулевой интервал - сводка отключена
+ALTER TABLE users
+    ADD COLUMN summary_interval SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN summary_sent_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX users_summary_idx ON users USING btree (summary_interval) WHERE summary_interval > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_summary_idx;
+ALTER TABLE users
+    DROP COLUMN summary_interval,
+    DROP COLUMN summary_sent_at;
+DROP TABLE limit_notifications;
+-- +goose StatementEnd
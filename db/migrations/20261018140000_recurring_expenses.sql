@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Регулярные расходы. Сумма хранится в валюте, в которой ее указал пользователь.
+-- next_date - дата следующего расхода, start_date нужна для расчета дат в конце месяца
+CREATE TABLE recurring_expenses (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    category VARCHAR(256) NOT NULL,
+    price NUMERIC(20, 10) NOT NULL,
+    currency VARCHAR(5) NOT NULL,
+    interval_type SMALLINT NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT recurring_price_positive CHECK (price > 0)
+);
+CREATE INDEX recurring_expenses_user_idx ON recurring_expenses USING btree (user_id);
+CREATE INDEX recurring_expenses_next_date_idx ON recurring_expenses USING btree (next_date);
+
+-- Ключ идемпотентности для расходов, добавленных автоматически
+ALTER TABLE expenses ADD COLUMN external_id VARCHAR(64);
+CREATE UNIQUE INDEX expenses_external_id_idx ON expenses (user_id, external_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX expenses_external_id_idx;
+ALTER TABLE expenses DROP COLUMN external_id;
+DROP INDEX recurring_expenses_next_date_idx;
+DROP INDEX recurring_expenses_user_idx;
+DROP TABLE recurring_expenses;
+-- +goose StatementEnd
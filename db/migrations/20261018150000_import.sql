@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Колонки выписки банка, номера начинаются с единицы. Без записи колонки определяются по заголовку.
+CREATE TABLE import_columns (
+    user_id BIGINT PRIMARY KEY,
+    date_column SMALLINT NOT NULL,
+    amount_column SMALLINT NOT NULL,
+    description_column SMALLINT NOT NULL DEFAULT 0,
+    currency_column SMALLINT NOT NULL DEFAULT 0,
+    CONSTRAINT import_columns_positive CHECK (date_column > 0 AND amount_column > 0 AND
+        description_column >= 0 AND currency_column >= 0)
+);
+
+-- Правила отнесения операций выписки к категориям по ключевому слову в описании.
+CREATE TABLE import_rules (
+    user_id BIGINT NOT NULL,
+    keyword VARCHAR(255) NOT NULL,
+    category VARCHAR(255) NOT NULL,
+    CONSTRAINT import_rule_keyword_lower CHECK (keyword = lower(keyword)),
+    PRIMARY KEY (user_id, keyword)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE import_rules;
+DROP TABLE import_columns;
+-- +goose StatementEnd
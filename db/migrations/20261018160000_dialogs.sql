@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Незаконченный диалог: команда, ответы пользователя на уже заданные вопросы и срок,
+-- после которого диалог забывается. Хранится в базе, чтобы пережить перезапуск клиента.
+-- Диалоги пользователя в личном и групповых чатах независимы.
+CREATE TABLE dialogs (
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    answers TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (chat_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE dialogs;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Общий бюджет. Его данные хранятся под ID владельца: расходы, лимиты и категории владельца
+-- становятся общими, а участник, пока состоит в бюджете, работает с ними вместо своих.
+CREATE TABLE budgets (
+    owner_id BIGINT PRIMARY KEY,
+    invite_code VARCHAR(32) NOT NULL UNIQUE
+);
+
+CREATE TABLE budget_members (
+    user_id BIGINT PRIMARY KEY,
+    owner_id BIGINT NOT NULL REFERENCES budgets (owner_id) ON DELETE CASCADE
+);
+
+CREATE INDEX budget_members_owner_id_idx ON budget_members (owner_id);
+
+-- Кто из участников добавил расход. NULL - сам владелец данных.
+ALTER TABLE expenses ADD COLUMN member_id BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE expenses DROP COLUMN member_id;
+DROP TABLE budget_members;
+DROP TABLE budgets;
+-- +goose StatementEnd
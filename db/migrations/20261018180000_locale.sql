@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Язык, выбранный пользователем. NULL - язык берется из настроек Telegram
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(8);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN locale;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Журнал изменений для отмены последнего действия. user_id - кто выполнил действие,
+-- owner_id - чьи данные изменены. undone_at заполняется, когда действие отменено.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    owner_id BIGINT NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    old_value JSONB NOT NULL,
+    new_value JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    undone_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX audit_log_user_id_owner_id_idx ON audit_log (user_id, owner_id, id) WHERE undone_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Необязательная заметка к расходу. Поиск идет по заметке и категории, подкатегории
+-- разделяются "/", поэтому он заменяется пробелом, чтобы каждая часть была словом.
+ALTER TABLE expenses
+    ADD COLUMN note TEXT,
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('russian', replace(category, '/', ' ') || ' ' || COALESCE(note, ''))
+    ) STORED;
+
+CREATE INDEX expenses_search_idx ON expenses USING GIN (search);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX expenses_search_idx;
+ALTER TABLE expenses
+    DROP COLUMN search,
+    DROP COLUMN note;
+-- +goose StatementEnd
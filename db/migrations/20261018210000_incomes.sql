@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Доходы хранятся отдельно от расходов, поэтому лимиты и отчеты по категориям
+-- расходов их не учитывают. Сумма в базовой валюте, как и цена расхода.
+CREATE TABLE incomes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    source VARCHAR(256) NOT NULL,
+    amount NUMERIC(20, 10) NOT NULL,
+    original_amount NUMERIC(20, 10) NOT NULL,
+    original_currency VARCHAR(5),
+    member_id BIGINT,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT amount_positive CHECK (amount > 0),
+    CONSTRAINT source_non_empty CHECK (char_length(source) > 0 AND source = lower(source))
+);
+
+CREATE INDEX incomes_idx ON incomes USING btree (user_id, time);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX incomes_idx;
+DROP TABLE incomes;
+-- +goose StatementEnd
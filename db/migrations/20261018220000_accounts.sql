@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Счета пользователя: карта, наличные, накопительный счет. Остаток счета хранится
+-- в его валюте и складывается из начального остатка, доходов, расходов и переводов.
+CREATE TABLE accounts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    currency VARCHAR(5) NOT NULL,
+    initial_balance NUMERIC(20, 10) NOT NULL DEFAULT 0,
+    CONSTRAINT account_name_non_empty CHECK (char_length(name) > 0 AND name = lower(name))
+);
+
+CREATE UNIQUE INDEX accounts_user_name_idx ON accounts (user_id, name);
+
+-- Суммы перевода в валютах счетов, курс фиксируется на момент перевода
+CREATE TABLE transfers (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    from_account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    to_account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    amount_from NUMERIC(20, 10) NOT NULL,
+    amount_to NUMERIC(20, 10) NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT transfer_amount_positive CHECK (amount_from > 0 AND amount_to > 0),
+    CONSTRAINT transfer_accounts_differ CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX transfers_from_idx ON transfers USING btree (from_account_id);
+CREATE INDEX transfers_to_idx ON transfers USING btree (to_account_id);
+
+-- Сумма в валюте счета, в которой операция изменила его остаток
+ALTER TABLE expenses
+    ADD COLUMN account_id BIGINT REFERENCES accounts (id) ON DELETE SET NULL,
+    ADD COLUMN account_amount NUMERIC(20, 10);
+ALTER TABLE incomes
+    ADD COLUMN account_id BIGINT REFERENCES accounts (id) ON DELETE SET NULL,
+    ADD COLUMN account_amount NUMERIC(20, 10);
+
+CREATE INDEX expenses_account_idx ON expenses USING btree (account_id) WHERE account_id IS NOT NULL;
+CREATE INDEX incomes_account_idx ON incomes USING btree (account_id) WHERE account_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX incomes_account_idx;
+DROP INDEX expenses_account_idx;
+ALTER TABLE incomes
+    DROP COLUMN account_amount,
+    DROP COLUMN account_id;
+ALTER TABLE expenses
+    DROP COLUMN account_amount,
+    DROP COLUMN account_id;
+DROP INDEX transfers_to_idx;
+DROP INDEX transfers_from_idx;
+DROP TABLE transfers;
+DROP INDEX accounts_user_name_idx;
+DROP TABLE accounts;
+-- +goose StatementEnd
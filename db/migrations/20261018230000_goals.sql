@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Цели накоплений. Сумма цели и взносы хранятся в валюте цели, nudged_at - когда
+-- последний раз отправлялось напоминание о ежемесячной сумме.
+CREATE TABLE goals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    target NUMERIC(20, 10) NOT NULL,
+    currency VARCHAR(5) NOT NULL,
+    deadline TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    nudged_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT goal_name_non_empty CHECK (char_length(name) > 0 AND name = lower(name)),
+    CONSTRAINT goal_target_positive CHECK (target > 0)
+);
+
+CREATE UNIQUE INDEX goals_user_name_idx ON goals (user_id, name);
+
+-- Отрицательный взнос - деньги, взятые из накоплений
+CREATE TABLE goal_contributions (
+    id BIGSERIAL PRIMARY KEY,
+    goal_id BIGINT NOT NULL REFERENCES goals (id) ON DELETE CASCADE,
+    amount NUMERIC(20, 10) NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT goal_contribution_non_zero CHECK (amount <> 0)
+);
+
+CREATE INDEX goal_contributions_goal_idx ON goal_contributions USING btree (goal_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goal_contributions_goal_idx;
+DROP TABLE goal_contributions;
+DROP INDEX goals_user_name_idx;
+DROP TABLE goals;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История курсов по дням, currencies хранит только последний курс.
+CREATE TABLE currency_rates (
+    code VARCHAR(5) NOT NULL,
+    date DATE NOT NULL,
+    ratio NUMERIC(20, 10) NOT NULL,
+    CONSTRAINT currency_rates_ratio_positive CHECK (ratio > 0),
+    PRIMARY KEY (code, date)
+);
+
+INSERT INTO currency_rates(code, date, ratio)
+SELECT code, time::date, ratio FROM currencies;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE currency_rates;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Источник курса: cbr, exchangerate, file. Для курсов, полученных раньше, источник неизвестен.
+ALTER TABLE currencies ADD COLUMN source VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE currency_rates ADD COLUMN source VARCHAR(32) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE currency_rates DROP COLUMN source;
+ALTER TABLE currencies DROP COLUMN source;
+-- +goose StatementEnd
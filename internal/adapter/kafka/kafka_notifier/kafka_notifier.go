@@ -0,0 +1,36 @@
+package kafkanotifier
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type writer interface {
+	Write(ctx context.Context, key, value []byte) error
+}
+
+// KafkaNotifier публикует команду в топик обработанных команд, откуда ее
+// доставляет пользователю tgclient writer, как ответ на обычное сообщение.
+type KafkaNotifier struct {
+	writer writer
+}
+
+func New(writer writer) *KafkaNotifier {
+	return &KafkaNotifier{
+		writer: writer,
+	}
+}
+
+func (n *KafkaNotifier) Notify(ctx context.Context, cmd usecase.Command) error {
+	buf, err := json.Marshal(cmd)
+	if err != nil {
+		return errors.Wrap(err, "KafkaNotifier.Notify")
+	}
+
+	err = n.writer.Write(ctx, []byte(cmd.Name), buf)
+
+	return errors.Wrap(err, "KafkaNotifier.Notify")
+}
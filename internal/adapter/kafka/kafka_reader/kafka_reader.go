@@ -35,8 +35,9 @@ func (k *KafkaReader) Read(ctx context.Context, callback MsgCallback) {
 			break
 		}
 
-		logger.Infof("KafkaReader: read: %v/%v/%v: %s = %s", msg.Topic, msg.Partition, msg.Offset,
-			string(msg.Key), string(msg.Value))
+		// Команда может содержать файлы пользователя, поэтому в лог попадает только ее размер
+		logger.Infof("KafkaReader: read: %v/%v/%v: %s = %d bytes", msg.Topic, msg.Partition, msg.Offset,
+			string(msg.Key), len(msg.Value))
 
 		ctx, span := otel.Tracer("KafkaReader").Start(ctx, "Read")
 		callback(ctx, msg.Key, msg.Value)
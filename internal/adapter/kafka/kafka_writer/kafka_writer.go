@@ -25,7 +25,8 @@ func New(addr, topic string) *KafkaWriter {
 }
 
 func (k *KafkaWriter) Write(ctx context.Context, key, value []byte) error {
-	logger.Infof("kafka.write [%s][%s]", string(key), string(value))
+	// Команда может содержать файлы пользователя, поэтому в лог попадает только ее размер
+	logger.Infof("kafka.write [%s][%d bytes]", string(key), len(value))
 
 	ctx, span := otel.Tracer("KafkaWriter").Start(ctx, "Write")
 	defer span.End()
@@ -3,6 +3,7 @@ package ratesupdaterservicecbr
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -14,12 +15,34 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-var errUnknownCurrencyCode = errors.New("unknown code")
+var (
+	errUnknownCurrencyCode = errors.New("unknown code")
+	errNoArchiveRates      = errors.New("no archive rates")
+	errUnexpectedStatus    = errors.New("unexpected status")
+)
+
+// DefaultURL адрес зеркала курсов ЦБ.
+const DefaultURL = "https://www.cbr-xml-daily.ru"
 
-type RatesUpdaterService struct{}
+// archiveMaxDaysBack в выходные и праздники ЦБ курс не устанавливает, действует курс
+// предыдущего рабочего дня. Дальше этого числа дней назад он не ищется.
+const archiveMaxDaysBack = 10
 
-func New() *RatesUpdaterService {
-	return &RatesUpdaterService{}
+type RatesUpdaterService struct {
+	client *http.Client
+	url    string
+}
+
+// New url - адрес зеркала курсов ЦБ, пустой адрес заменяется на DefaultURL.
+func New(client *http.Client, url string) *RatesUpdaterService {
+	if len(url) == 0 {
+		url = DefaultURL
+	}
+
+	return &RatesUpdaterService{
+		client: client,
+		url:    url,
+	}
 }
 
 type RatesCBR struct {
@@ -28,45 +51,106 @@ type RatesCBR struct {
 	Rates map[string]float64
 }
 
+// ArchiveCBR курсы ЦБ за день: сколько рублей стоит Nominal единиц валюты.
+type ArchiveCBR struct {
+	Date   string
+	Valute map[string]struct {
+		Nominal float64
+		Value   float64
+	}
+}
+
 func (s RatesUpdaterService) Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error) {
 	logger.Infof("RatesUpdaterService.Get: %v %v", base, codes)
 
 	ctx, span := otel.Tracer("RatesUpdaterService").Start(ctx, "Get")
 	defer span.End()
 
-	url := "https://www.cbr-xml-daily.ru/latest.js"
+	var ratesCBR RatesCBR
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	_, err := s.getJSON(ctx, s.url+"/latest.js", &ratesCBR)
 	if err != nil {
 		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	return newRates(base, codes, ratesCBR.Rates, time.Now())
+}
+
+// GetForDate курсы ЦБ на день date. Время курсов - date.
+func (s RatesUpdaterService) GetForDate(ctx context.Context, base string, codes []string, date time.Time,
+) ([]entity.Rate, error) {
+	logger.Infof("RatesUpdaterService.GetForDate: %v %v %v", base, codes, date.Format("2006-01-02"))
+
+	ctx, span := otel.Tracer("RatesUpdaterService").Start(ctx, "GetForDate")
+	defer span.End()
+
+	for i := 0; i < archiveMaxDaysBack; i++ {
+		day := date.AddDate(0, 0, -i)
+		url := fmt.Sprintf("%s/archive/%s/daily_json.js", s.url, day.Format("2006/01/02"))
+
+		var archive ArchiveCBR
+
+		found, err := s.getJSON(ctx, url, &archive)
+		if err != nil {
+			return nil, errors.Wrap(err, "RatesUpdaterService.GetForDate")
+		}
+
+		if !found {
+			continue
+		}
+
+		ratios := make(map[string]float64, len(archive.Valute))
+		for code, valute := range archive.Valute {
+			ratios[code] = valute.Nominal / valute.Value
+		}
+
+		return newRates(base, codes, ratios, date)
+	}
+
+	return nil, errNoArchiveRates
+}
+
+// getJSON разбирает ответ в out. На 404 возвращает false без ошибки.
+func (s RatesUpdaterService) getJSON(ctx context.Context, url string, out any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return false, errors.Wrap(err, "RatesUpdaterService.getJSON")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "RatesUpdaterService.getJSON")
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Wrap(errUnexpectedStatus, resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return false, errors.Wrap(err, "RatesUpdaterService.getJSON")
 	}
 
-	var ratesCBR RatesCBR
-
-	err = json.Unmarshal(body, &ratesCBR)
+	err = json.Unmarshal(body, out)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return false, errors.Wrap(err, "RatesUpdaterService.getJSON")
 	}
 
-	time := time.Now()
+	return true, nil
+}
 
+func newRates(base string, codes []string, ratios map[string]float64, time time.Time) ([]entity.Rate, error) {
 	rates := make([]entity.Rate, 0, len(codes))
 	rates = append(rates, entity.NewRate(base, decimal.New(1, 0), time))
 
 	for _, code := range codes {
-		ratio, ok := ratesCBR.Rates[code]
+		ratio, ok := ratios[code]
 		if !ok {
 			return nil, errUnknownCurrencyCode
 		}
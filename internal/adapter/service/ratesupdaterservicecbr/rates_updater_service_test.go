@@ -2,12 +2,16 @@ package ratesupdaterservicecbr_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicecbr"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 )
 
 func TestRatesUpdaterServiceCBR(t *testing.T) {
@@ -17,7 +21,7 @@ func TestRatesUpdaterServiceCBR(t *testing.T) {
 		t.Skip("skip test with http request")
 	}
 
-	service := ratesupdaterservicecbr.New()
+	service := ratesupdaterservicecbr.New(http.DefaultClient, "")
 	ctx := context.Background()
 
 	base := "RUB"
@@ -46,7 +50,7 @@ func TestRatesUpdaterServiceCBR_TimeUpdate(t *testing.T) {
 		t.Skip("skip test with http request")
 	}
 
-	service := ratesupdaterservicecbr.New()
+	service := ratesupdaterservicecbr.New(http.DefaultClient, "")
 	ctx := context.Background()
 
 	base := "RUB"
@@ -74,3 +78,69 @@ func TestRatesUpdaterServiceCBR_TimeUpdate(t *testing.T) {
 		assert.Greater(t, rates2[i].GetTime(), rates[i].GetTime())
 	}
 }
+
+func TestRatesUpdaterServiceCBR_GetForDate(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skip test with http request")
+	}
+
+	service := ratesupdaterservicecbr.New(http.DefaultClient, "")
+	ctx := context.Background()
+
+	// Воскресенье, берется курс пятницы
+	date := time.Date(2022, 11, 13, 0, 0, 0, 0, time.UTC)
+
+	rates, err := service.GetForDate(ctx, "RUB", []string{"USD", "EUR"}, date)
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 3)
+
+	for _, rate := range rates {
+		assert.Equal(t, date, rate.GetTime())
+		assert.True(t, rate.GetRatio().IsPositive())
+	}
+}
+
+func TestRatesUpdaterServiceCBR_GetForDateWeekend(t *testing.T) {
+	t.Parallel()
+
+	// В субботу курса нет, берется курс пятницы
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/archive/2022/11/11/daily_json.js" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"Date":"2022-11-11T11:30:00+03:00","Valute":{` +
+			`"USD":{"Nominal":1,"Value":62.5},"JPY":{"Nominal":100,"Value":40}}}`))
+	}))
+	defer server.Close()
+
+	service := ratesupdaterservicecbr.New(server.Client(), server.URL)
+	date := time.Date(2022, 11, 12, 0, 0, 0, 0, time.UTC)
+
+	rates, err := service.GetForDate(context.Background(), "RUB", []string{"USD", "JPY"}, date)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Rate{
+		entity.NewRate("RUB", decimal.New(1, 0), date),
+		entity.NewRate("USD", decimal.NewFromFloat(0.016), date),
+		entity.NewRate("JPY", decimal.NewFromFloat(2.5), date),
+	}, rates)
+}
+
+func TestRatesUpdaterServiceCBR_ServerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	service := ratesupdaterservicecbr.New(server.Client(), server.URL)
+
+	_, err := service.Get(context.Background(), "RUB", []string{"USD"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package ratesupdaterservicecomposite
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker размыкается после failures неудач подряд, и источник пропускается в течение cooldown.
+// Затем источнику дается одна пробная попытка: успех замыкает breaker, неудача снова размыкает.
+// Нулевой failures отключает breaker.
+type breaker struct {
+	mu sync.Mutex
+
+	failures int
+	cooldown time.Duration
+
+	consecutive int
+	openUntil   time.Time
+}
+
+func newBreaker(failures int, cooldown time.Duration) *breaker {
+	return &breaker{ //nolint:exhaustruct
+		failures: failures,
+		cooldown: cooldown,
+	}
+}
+
+// allow можно ли обращаться к источнику. Пробная попытка резервируется, чтобы параллельные
+// запросы не обращались к источнику, пока она не завершится.
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == 0 || b.consecutive < b.failures {
+		return true
+	}
+
+	if now.Before(b.openUntil) {
+		return false
+	}
+
+	b.openUntil = now.Add(b.cooldown)
+
+	return true
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive = 0
+}
+
+func (b *breaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive++
+
+	if b.failures != 0 && b.consecutive >= b.failures {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
@@ -0,0 +1,148 @@
+package ratesupdaterservicecomposite
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrNoAvailableProvider = errors.New("no available rates provider")
+
+type Provider interface {
+	Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error)
+	GetForDate(ctx context.Context, base string, codes []string, date time.Time) ([]entity.Rate, error)
+}
+
+// Source источник курсов и правила обращения к нему. Name записывается в курсы как их источник.
+// Timeout ограничивает каждую попытку, нулевой Timeout - без ограничения. Retries - число
+// повторов после неудачной попытки, между ними выдерживается RetryDelay.
+type Source struct {
+	Name       string
+	Provider   Provider
+	Timeout    time.Duration
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// RatesUpdaterService опрашивает источники по порядку и возвращает курсы первого ответившего.
+// Источник, который раз за разом не отвечает, на время пропускается.
+type RatesUpdaterService struct {
+	sources  []Source
+	breakers []*breaker
+}
+
+// New breakerFailures неудач подряд размыкают breaker источника на breakerCooldown,
+// нулевой breakerFailures отключает breaker.
+func New(sources []Source, breakerFailures int, breakerCooldown time.Duration) *RatesUpdaterService {
+	breakers := make([]*breaker, 0, len(sources))
+	for range sources {
+		breakers = append(breakers, newBreaker(breakerFailures, breakerCooldown))
+	}
+
+	return &RatesUpdaterService{
+		sources:  sources,
+		breakers: breakers,
+	}
+}
+
+type getFunc func(context.Context, Provider) ([]entity.Rate, error)
+
+func (s *RatesUpdaterService) Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error) {
+	ctx, span := otel.Tracer("RatesUpdaterService").Start(ctx, "Get")
+	defer span.End()
+
+	rates, err := s.get(ctx, func(ctx context.Context, provider Provider) ([]entity.Rate, error) {
+		return provider.Get(ctx, base, codes)
+	})
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.Get")
+}
+
+func (s *RatesUpdaterService) GetForDate(ctx context.Context, base string, codes []string, date time.Time,
+) ([]entity.Rate, error) {
+	ctx, span := otel.Tracer("RatesUpdaterService").Start(ctx, "GetForDate")
+	defer span.End()
+
+	rates, err := s.get(ctx, func(ctx context.Context, provider Provider) ([]entity.Rate, error) {
+		return provider.GetForDate(ctx, base, codes, date)
+	})
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.GetForDate")
+}
+
+// get возвращает ошибку последнего опрошенного источника или ErrNoAvailableProvider,
+// если все источники пропущены.
+func (s *RatesUpdaterService) get(ctx context.Context, get getFunc) ([]entity.Rate, error) {
+	err := ErrNoAvailableProvider
+
+	for i, source := range s.sources {
+		if !s.breakers[i].allow(time.Now()) {
+			logger.Infof("rates provider %s is skipped after failures", source.Name)
+
+			continue
+		}
+
+		var rates []entity.Rate
+
+		rates, err = getWithRetries(ctx, source, get)
+		if err != nil {
+			s.breakers[i].failure(time.Now())
+			logger.Errorf("rates provider %s failed: %v", source.Name, err)
+
+			// Запрос отменен вызывающим, опрашивать остальные источники незачем
+			if ctx.Err() != nil {
+				return nil, errors.Wrap(ctx.Err(), "RatesUpdaterService.get")
+			}
+
+			continue
+		}
+
+		s.breakers[i].success()
+
+		for j := range rates {
+			rates[j].SetSource(source.Name)
+		}
+
+		return rates, nil
+	}
+
+	return nil, errors.Wrap(err, "RatesUpdaterService.get")
+}
+
+func getWithRetries(ctx context.Context, source Source, get getFunc) ([]entity.Rate, error) {
+	var err error
+
+	for attempt := 0; attempt <= source.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, errors.Wrap(ctx.Err(), "getWithRetries")
+			case <-time.After(source.RetryDelay):
+			}
+		}
+
+		var rates []entity.Rate
+
+		rates, err = getWithTimeout(ctx, source, get)
+		if err == nil {
+			return rates, nil
+		}
+	}
+
+	return nil, errors.Wrap(err, "getWithRetries")
+}
+
+func getWithTimeout(ctx context.Context, source Source, get getFunc) ([]entity.Rate, error) {
+	if source.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, source.Timeout)
+		defer cancel()
+	}
+
+	return get(ctx, source.Provider)
+}
@@ -0,0 +1,219 @@
+package ratesupdaterservicecomposite_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicecomposite"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// stubProvider отвечает ошибками из errs по очереди, потом курсом USD. Если hang, ждет отмены запроса.
+// Если задан gate, курс возвращается только после его закрытия.
+type stubProvider struct {
+	mu    sync.Mutex
+	calls int
+	errs  []error
+	hang  bool
+	gate  chan struct{}
+}
+
+func (p *stubProvider) Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error) {
+	return p.GetForDate(ctx, base, codes, time.Now())
+}
+
+func (p *stubProvider) GetForDate(ctx context.Context, base string, codes []string, date time.Time,
+) ([]entity.Rate, error) {
+	p.mu.Lock()
+	call := p.calls
+	p.calls++
+	p.mu.Unlock()
+
+	if p.hang {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	if call < len(p.errs) {
+		return nil, p.errs[call]
+	}
+
+	if p.gate != nil {
+		<-p.gate
+	}
+
+	return []entity.Rate{entity.NewRate("USD", decimal.New(16, -3), date)}, nil
+}
+
+func (p *stubProvider) getCalls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.calls
+}
+
+func TestRatesUpdaterService_Failover(t *testing.T) {
+	t.Parallel()
+
+	primary := &stubProvider{errs: []error{errUnavailable, errUnavailable}}
+	fallback := &stubProvider{}
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: primary, Retries: 1},
+		{Name: "file", Provider: fallback},
+	}, 0, 0)
+
+	rates, err := service.Get(context.Background(), "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Len(t, rates, 1)
+	assert.Equal(t, "file", rates[0].GetSource())
+	assert.Equal(t, 2, primary.getCalls())
+	assert.Equal(t, 1, fallback.getCalls())
+}
+
+func TestRatesUpdaterService_Retry(t *testing.T) {
+	t.Parallel()
+
+	primary := &stubProvider{errs: []error{errUnavailable}}
+	fallback := &stubProvider{}
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: primary, Retries: 2, RetryDelay: time.Millisecond},
+		{Name: "file", Provider: fallback},
+	}, 0, 0)
+
+	date := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	rates, err := service.GetForDate(context.Background(), "RUB", []string{"USD"}, date)
+	assert.NoError(t, err)
+	assert.Equal(t, "cbr", rates[0].GetSource())
+	assert.Equal(t, date, rates[0].GetTime())
+	assert.Equal(t, 2, primary.getCalls())
+	assert.Equal(t, 0, fallback.getCalls())
+}
+
+func TestRatesUpdaterService_Timeout(t *testing.T) {
+	t.Parallel()
+
+	primary := &stubProvider{hang: true}
+	fallback := &stubProvider{}
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: primary, Timeout: 10 * time.Millisecond},
+		{Name: "file", Provider: fallback},
+	}, 0, 0)
+
+	rates, err := service.Get(context.Background(), "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, "file", rates[0].GetSource())
+}
+
+func TestRatesUpdaterService_Breaker(t *testing.T) {
+	t.Parallel()
+
+	primary := &stubProvider{errs: []error{errUnavailable, errUnavailable}}
+	fallback := &stubProvider{}
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: primary},
+		{Name: "file", Provider: fallback},
+	}, 1, 50*time.Millisecond)
+
+	ctx := context.Background()
+
+	_, err := service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, primary.getCalls())
+
+	// Breaker разомкнут, основной источник пропускается
+	_, err = service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, primary.getCalls())
+
+	// После паузы пробная попытка снова неудачна, breaker размыкается заново
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, primary.getCalls())
+
+	_, err = service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, primary.getCalls())
+
+	// Удачная пробная попытка замыкает breaker
+	time.Sleep(60 * time.Millisecond)
+
+	rates, err := service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cbr", rates[0].GetSource())
+	assert.Equal(t, 4, fallback.getCalls())
+}
+
+// Пока идет пробная попытка, breaker остается разомкнутым для остальных запросов.
+func TestRatesUpdaterService_BreakerHalfOpenSingleTrial(t *testing.T) {
+	t.Parallel()
+
+	primary := &stubProvider{errs: []error{errUnavailable}, gate: make(chan struct{})}
+	fallback := &stubProvider{}
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: primary},
+		{Name: "file", Provider: fallback},
+	}, 1, 20*time.Millisecond)
+
+	ctx := context.Background()
+
+	_, err := service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+
+	time.Sleep(30 * time.Millisecond)
+
+	trial := make(chan []entity.Rate)
+
+	go func() {
+		rates, _ := service.Get(ctx, "RUB", []string{"USD"})
+		trial <- rates
+	}()
+
+	assert.Eventually(t, func() bool { return primary.getCalls() == 2 }, time.Second, time.Millisecond)
+
+	rates, err := service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, "file", rates[0].GetSource())
+	assert.Equal(t, 2, primary.getCalls())
+
+	close(primary.gate)
+
+	rates = <-trial
+	assert.Equal(t, "cbr", rates[0].GetSource())
+
+	rates, err = service.Get(ctx, "RUB", []string{"USD"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cbr", rates[0].GetSource())
+	assert.Equal(t, 3, primary.getCalls())
+}
+
+func TestRatesUpdaterService_AllFailed(t *testing.T) {
+	t.Parallel()
+
+	service := ratesupdaterservicecomposite.New([]ratesupdaterservicecomposite.Source{
+		{Name: "cbr", Provider: &stubProvider{errs: []error{errUnavailable}}},
+	}, 1, time.Minute)
+
+	ctx := context.Background()
+
+	_, err := service.Get(ctx, "RUB", []string{"USD"})
+	assert.ErrorIs(t, err, errUnavailable)
+
+	_, err = service.Get(ctx, "RUB", []string{"USD"})
+	assert.ErrorIs(t, err, ratesupdaterservicecomposite.ErrNoAvailableProvider)
+}
@@ -14,12 +14,29 @@ import (
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 )
 
-var errUnknownCurrencyCode = errors.New("unknown code")
+var (
+	errUnknownCurrencyCode = errors.New("unknown code")
+	errUnexpectedStatus    = errors.New("unexpected status")
+)
+
+// DefaultURL адрес API exchangerate.host.
+const DefaultURL = "https://api.exchangerate.host"
+
+type RatesUpdaterService struct {
+	client *http.Client
+	url    string
+}
 
-type RatesUpdaterService struct{}
+// New url - адрес API, пустой адрес заменяется на DefaultURL.
+func New(client *http.Client, url string) *RatesUpdaterService {
+	if len(url) == 0 {
+		url = DefaultURL
+	}
 
-func New() *RatesUpdaterService {
-	return &RatesUpdaterService{}
+	return &RatesUpdaterService{
+		client: client,
+		url:    url,
+	}
 }
 
 type RatesExch struct {
@@ -27,35 +44,51 @@ type RatesExch struct {
 }
 
 func (s RatesUpdaterService) Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error) {
-	url := "https://api.exchangerate.host/latest"
-	url = fmt.Sprintf("%s?base=%s&symbols=%s", url, base, strings.Join(codes, ","))
+	rates, err := s.get(ctx, "latest", base, codes, time.Now())
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.Get")
+}
+
+// GetForDate курсы на день date. Время курсов - date.
+func (s RatesUpdaterService) GetForDate(ctx context.Context, base string, codes []string, date time.Time,
+) ([]entity.Rate, error) {
+	rates, err := s.get(ctx, date.Format("2006-01-02"), base, codes, date)
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.GetForDate")
+}
+
+func (s RatesUpdaterService) get(ctx context.Context, path, base string, codes []string, time time.Time,
+) ([]entity.Rate, error) {
+	url := fmt.Sprintf("%s/%s?base=%s&symbols=%s", s.url, path, base, strings.Join(codes, ","))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return nil, errors.Wrap(err, "RatesUpdaterService.get")
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return nil, errors.Wrap(err, "RatesUpdaterService.get")
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(errUnexpectedStatus, resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return nil, errors.Wrap(err, "RatesUpdaterService.get")
 	}
 
 	var ratesExch RatesExch
 
 	err = json.Unmarshal(body, &ratesExch)
 	if err != nil {
-		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+		return nil, errors.Wrap(err, "RatesUpdaterService.get")
 	}
 
-	time := time.Now()
-
 	rates := make([]entity.Rate, 0, len(codes))
 	rates = append(rates, entity.NewRate(base, decimal.New(1, 0), time))
 
@@ -2,12 +2,16 @@ package ratesupdaterserviceexchangerate_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterserviceexchangerate"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 )
 
 func TestRatesUpdaterServiceExch(t *testing.T) {
@@ -17,7 +21,7 @@ func TestRatesUpdaterServiceExch(t *testing.T) {
 		t.Skip("skip test with http request")
 	}
 
-	service := ratesupdaterserviceexchangerate.New()
+	service := ratesupdaterserviceexchangerate.New(http.DefaultClient, "")
 	ctx := context.Background()
 
 	base := "RUB"
@@ -46,7 +50,7 @@ func TestRatesUpdaterServiceExch_TimeUpdate(t *testing.T) {
 		t.Skip("skip test with http request")
 	}
 
-	service := ratesupdaterserviceexchangerate.New()
+	service := ratesupdaterserviceexchangerate.New(http.DefaultClient, "")
 	ctx := context.Background()
 
 	base := "RUB"
@@ -74,3 +78,63 @@ func TestRatesUpdaterServiceExch_TimeUpdate(t *testing.T) {
 		assert.Greater(t, rates2[i].GetTime(), rates[i].GetTime())
 	}
 }
+
+func TestRatesUpdaterServiceExch_GetForDate(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skip test with http request")
+	}
+
+	service := ratesupdaterserviceexchangerate.New(http.DefaultClient, "")
+	ctx := context.Background()
+
+	date := time.Date(2022, 11, 13, 0, 0, 0, 0, time.UTC)
+
+	rates, err := service.GetForDate(ctx, "RUB", []string{"USD", "EUR"}, date)
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 3)
+
+	for _, rate := range rates {
+		assert.Equal(t, date, rate.GetTime())
+	}
+}
+
+func TestRatesUpdaterServiceExch_GetForDateLocal(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2022-11-10", r.URL.Path)
+		assert.Equal(t, "RUB", r.URL.Query().Get("base"))
+		assert.Equal(t, "USD,EUR", r.URL.Query().Get("symbols"))
+
+		_, _ = w.Write([]byte(`{"rates":{"USD":0.016,"EUR":0.0155}}`))
+	}))
+	defer server.Close()
+
+	service := ratesupdaterserviceexchangerate.New(server.Client(), server.URL)
+	date := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	rates, err := service.GetForDate(context.Background(), "RUB", []string{"USD", "EUR"}, date)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Rate{
+		entity.NewRate("RUB", decimal.New(1, 0), date),
+		entity.NewRate("USD", decimal.NewFromFloat(0.016), date),
+		entity.NewRate("EUR", decimal.NewFromFloat(0.0155), date),
+	}, rates)
+}
+
+func TestRatesUpdaterServiceExch_ServerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service := ratesupdaterserviceexchangerate.New(server.Client(), server.URL)
+
+	_, err := service.Get(context.Background(), "RUB", []string{"USD"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package ratesupdaterservicefile
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+var (
+	errUnknownCurrencyCode = errors.New("unknown code")
+	errUnknownFormat       = errors.New("unknown fixture format, expected .json or .xml")
+	errBaseMismatch        = errors.New("fixture base currency differs from requested")
+)
+
+// Fixture курсы из локального файла: сколько единиц валюты стоит единица базовой валюты.
+// Rates - текущие курсы, Days - курсы на прошедшие дни в формате 2006-01-02.
+//
+// JSON:
+//
+//	{"base": "RUB", "rates": [{"code": "USD", "ratio": "0.016"}],
+//	 "days": [{"date": "2022-11-10", "rates": [{"code": "USD", "ratio": "0.0165"}]}]}
+//
+// XML:
+//
+//	<rates base="RUB">
+//	  <rate code="USD" ratio="0.016"/>
+//	  <day date="2022-11-10"><rate code="USD" ratio="0.0165"/></day>
+//	</rates>
+type Fixture struct {
+	XMLName xml.Name      `json:"-"     xml:"rates"`
+	Base    string        `json:"base"  xml:"base,attr"`
+	Rates   []FixtureRate `json:"rates" xml:"rate"`
+	Days    []FixtureDay  `json:"days"  xml:"day"`
+}
+
+type FixtureRate struct {
+	Code  string          `json:"code"  xml:"code,attr"`
+	Ratio decimal.Decimal `json:"ratio" xml:"ratio,attr"`
+}
+
+type FixtureDay struct {
+	Date  string        `json:"date"  xml:"date,attr"`
+	Rates []FixtureRate `json:"rates" xml:"rate"`
+}
+
+// RatesUpdaterService читает курсы из файла, чтобы бот работал без сети. Файл читается
+// при каждом запросе, поэтому его можно менять без перезапуска.
+type RatesUpdaterService struct {
+	path string
+}
+
+func New(path string) *RatesUpdaterService {
+	return &RatesUpdaterService{path: path}
+}
+
+func (s RatesUpdaterService) Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error) {
+	fixture, err := s.read(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "RatesUpdaterService.Get")
+	}
+
+	rates, err := newRates(base, codes, fixture.Rates, time.Now())
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.Get")
+}
+
+// GetForDate курсы последнего дня из Days не позже date, а если такого дня нет - текущие курсы.
+// Время курсов - date.
+func (s RatesUpdaterService) GetForDate(ctx context.Context, base string, codes []string, date time.Time,
+) ([]entity.Rate, error) {
+	fixture, err := s.read(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "RatesUpdaterService.GetForDate")
+	}
+
+	sort.Slice(fixture.Days, func(i, j int) bool {
+		return fixture.Days[i].Date < fixture.Days[j].Date
+	})
+
+	day := date.Format("2006-01-02")
+	fixtureRates := fixture.Rates
+
+	for _, fixtureDay := range fixture.Days {
+		if fixtureDay.Date > day {
+			break
+		}
+
+		fixtureRates = fixtureDay.Rates
+	}
+
+	rates, err := newRates(base, codes, fixtureRates, date)
+
+	return rates, errors.Wrap(err, "RatesUpdaterService.GetForDate")
+}
+
+func (s RatesUpdaterService) read(base string) (Fixture, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return Fixture{}, errors.Wrap(err, "RatesUpdaterService.read")
+	}
+
+	var fixture Fixture
+
+	switch strings.ToLower(filepath.Ext(s.path)) {
+	case ".json":
+		err = json.Unmarshal(data, &fixture)
+	case ".xml":
+		err = xml.Unmarshal(data, &fixture)
+	default:
+		err = errUnknownFormat
+	}
+
+	if err != nil {
+		return Fixture{}, errors.Wrap(err, "RatesUpdaterService.read")
+	}
+
+	if fixture.Base != base {
+		return Fixture{}, errors.Wrap(errBaseMismatch, fixture.Base)
+	}
+
+	return fixture, nil
+}
+
+func newRates(base string, codes []string, fixtureRates []FixtureRate, time time.Time) ([]entity.Rate, error) {
+	ratios := make(map[string]decimal.Decimal, len(fixtureRates))
+	for _, rate := range fixtureRates {
+		ratios[rate.Code] = rate.Ratio
+	}
+
+	rates := make([]entity.Rate, 0, len(codes))
+	rates = append(rates, entity.NewRate(base, decimal.New(1, 0), time))
+
+	for _, code := range codes {
+		ratio, ok := ratios[code]
+		if !ok {
+			return nil, errors.Wrap(errUnknownCurrencyCode, code)
+		}
+
+		rates = append(rates, entity.NewRate(code, ratio, time))
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,95 @@
+package ratesupdaterservicefile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicefile"
+)
+
+func TestRatesUpdaterServiceFile_Get(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"testdata/rates.json", "testdata/rates.xml"} {
+		path := path
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			service := ratesupdaterservicefile.New(path)
+
+			rates, err := service.Get(context.Background(), "RUB", []string{"USD", "EUR"})
+			assert.NoError(t, err)
+			assert.Len(t, rates, 3)
+
+			assert.Equal(t, "RUB", rates[0].GetCode())
+			assert.True(t, decimal.New(1, 0).Equal(rates[0].GetRatio()))
+			assert.Equal(t, "USD", rates[1].GetCode())
+			assert.True(t, decimal.New(16, -3).Equal(rates[1].GetRatio()))
+			assert.Equal(t, "EUR", rates[2].GetCode())
+			assert.True(t, decimal.New(155, -4).Equal(rates[2].GetRatio()))
+		})
+	}
+}
+
+func TestRatesUpdaterServiceFile_GetForDate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description string
+		date        time.Time
+		usdExpected decimal.Decimal
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "exact day",
+			date:        time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC),
+			usdExpected: decimal.New(165, -4),
+		},
+		{
+			description: "between days",
+			date:        time.Date(2022, 11, 5, 0, 0, 0, 0, time.UTC),
+			usdExpected: decimal.New(17, -3),
+		},
+		{
+			description: "before history",
+			date:        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			usdExpected: decimal.New(16, -3),
+		},
+	}
+
+	for _, path := range []string{"testdata/rates.json", "testdata/rates.xml"} {
+		service := ratesupdaterservicefile.New(path)
+
+		for _, scenario := range testCases {
+			scenario := scenario
+			t.Run(path+" "+scenario.description, func(t *testing.T) {
+				t.Parallel()
+
+				rates, err := service.GetForDate(context.Background(), "RUB", []string{"USD"}, scenario.date)
+				assert.NoError(t, err)
+				assert.Len(t, rates, 2)
+				assert.True(t, scenario.usdExpected.Equal(rates[1].GetRatio()), rates[1].GetRatio().String())
+				assert.Equal(t, scenario.date, rates[1].GetTime())
+			})
+		}
+	}
+}
+
+func TestRatesUpdaterServiceFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, err := ratesupdaterservicefile.New("testdata/rates.json").Get(ctx, "RUB", []string{"JPY"})
+	assert.Error(t, err)
+
+	_, err = ratesupdaterservicefile.New("testdata/rates.json").Get(ctx, "USD", []string{"EUR"})
+	assert.Error(t, err)
+
+	_, err = ratesupdaterservicefile.New("testdata/missing.json").Get(ctx, "RUB", []string{"USD"})
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -26,9 +27,17 @@ type Req struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserID   int64  `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`
-	Date     string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
-	Interval int32  `protobuf:"varint,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	UserID    int64                  `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	DateStart *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=dateStart,proto3" json:"dateStart,omitempty"`
+	DateEnd   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=dateEnd,proto3" json:"dateEnd,omitempty"`
+	// Суммировать расходы по категориям верхнего уровня
+	Rollup bool `protobuf:"varint,6,opt,name=rollup,proto3" json:"rollup,omitempty"`
+	// Интервал точек ряда расходов по времени: 1 - день, 3 - месяц, 0 - ряд не нужен
+	SeriesInterval int32 `protobuf:"varint,7,opt,name=seriesInterval,proto3" json:"seriesInterval,omitempty"`
+	// Суммы по участникам общего бюджета
+	ByMember bool `protobuf:"varint,8,opt,name=byMember,proto3" json:"byMember,omitempty"`
+	// Пересчитывать расходы по текущему курсу, а не по курсу на дату расхода
+	CurrentRate bool `protobuf:"varint,9,opt,name=currentRate,proto3" json:"currentRate,omitempty"`
 }
 
 func (x *Req) Reset() {
@@ -70,27 +79,59 @@ func (x *Req) GetUserID() int64 {
 	return 0
 }
 
-func (x *Req) GetDate() string {
+func (x *Req) GetDateStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Date
+		return x.DateStart
 	}
-	return ""
+	return nil
+}
+
+func (x *Req) GetDateEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateEnd
+	}
+	return nil
+}
+
+func (x *Req) GetRollup() bool {
+	if x != nil {
+		return x.Rollup
+	}
+	return false
 }
 
-func (x *Req) GetInterval() int32 {
+func (x *Req) GetSeriesInterval() int32 {
 	if x != nil {
-		return x.Interval
+		return x.SeriesInterval
 	}
 	return 0
 }
 
+func (x *Req) GetByMember() bool {
+	if x != nil {
+		return x.ByMember
+	}
+	return false
+}
+
+func (x *Req) GetCurrentRate() bool {
+	if x != nil {
+		return x.CurrentRate
+	}
+	return false
+}
+
 type Resp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Currency string     `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
-	Expenses []*Expense `protobuf:"bytes,2,rep,name=expenses,proto3" json:"expenses,omitempty"`
+	Currency string       `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Expenses []*Expense   `protobuf:"bytes,2,rep,name=expenses,proto3" json:"expenses,omitempty"`
+	Series   []*Point     `protobuf:"bytes,3,rep,name=series,proto3" json:"series,omitempty"`
+	Members  []*MemberSum `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	// Доходы по источникам, в расходы и ряд не входят
+	Incomes []*IncomeSum `protobuf:"bytes,5,rep,name=incomes,proto3" json:"incomes,omitempty"`
 }
 
 func (x *Resp) Reset() {
@@ -139,6 +180,27 @@ func (x *Resp) GetExpenses() []*Expense {
 	return nil
 }
 
+func (x *Resp) GetSeries() []*Point {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *Resp) GetMembers() []*MemberSum {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Resp) GetIncomes() []*IncomeSum {
+	if x != nil {
+		return x.Incomes
+	}
+	return nil
+}
+
 type Expense struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -194,33 +256,235 @@ func (x *Expense) GetSum() string {
 	return ""
 }
 
+// Расходы за интервал ряда, начинающийся с date
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Sum  string                 `protobuf:"bytes,2,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_adapter_service_report_report_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_adapter_service_report_report_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_internal_adapter_service_report_report_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Point) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Point) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+// Расходы участника общего бюджета, memberID владельца совпадает с userID запроса
+type MemberSum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MemberID int64  `protobuf:"varint,1,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	Sum      string `protobuf:"bytes,2,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *MemberSum) Reset() {
+	*x = MemberSum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_adapter_service_report_report_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemberSum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberSum) ProtoMessage() {}
+
+func (x *MemberSum) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_adapter_service_report_report_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberSum.ProtoReflect.Descriptor instead.
+func (*MemberSum) Descriptor() ([]byte, []int) {
+	return file_internal_adapter_service_report_report_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MemberSum) GetMemberID() int64 {
+	if x != nil {
+		return x.MemberID
+	}
+	return 0
+}
+
+func (x *MemberSum) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+// Доходы из источника за период отчета
+type IncomeSum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Sum    string `protobuf:"bytes,2,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *IncomeSum) Reset() {
+	*x = IncomeSum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_adapter_service_report_report_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncomeSum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeSum) ProtoMessage() {}
+
+func (x *IncomeSum) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_adapter_service_report_report_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeSum.ProtoReflect.Descriptor instead.
+func (*IncomeSum) Descriptor() ([]byte, []int) {
+	return file_internal_adapter_service_report_report_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IncomeSum) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *IncomeSum) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
 var File_internal_adapter_service_report_report_proto protoreflect.FileDescriptor
 
 var file_internal_adapter_service_report_report_proto_rawDesc = []byte{
 	0x0a, 0x2c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x64, 0x61, 0x70, 0x74,
 	0x65, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d,
-	0x0a, 0x03, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x48, 0x0a,
-	0x04, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x65,
-	0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x65, 0x6e,
-	0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x75, 0x6d,
-	0x32, 0x29, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x18, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x04,
-	0x2e, 0x52, 0x65, 0x71, 0x1a, 0x05, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x42, 0x42, 0x5a, 0x40, 0x67,
-	0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x6d,
-	0x79, 0x61, 0x73, 0x6e, 0x69, 0x6b, 0x6f, 0x76, 0x2e, 0x61, 0x6c, 0x65, 0x78, 0x61, 0x6e, 0x64,
-	0x65, 0x72, 0x2e, 0x73, 0x2f, 0x74, 0x65, 0x6c, 0x65, 0x67, 0x72, 0x61, 0x6d, 0x2d, 0x62, 0x6f,
-	0x74, 0x3b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xa7, 0x02, 0x0a, 0x03, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x38, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x61, 0x74,
+	0x65, 0x45, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x62, 0x79, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x62, 0x79, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4a, 0x04, 0x08,
+	0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xb4, 0x01, 0x0a, 0x04, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x24,
+	0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x75,
+	0x6d, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x07, 0x69, 0x6e,
+	0x63, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x49, 0x6e,
+	0x63, 0x6f, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x52, 0x07, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x73,
+	0x22, 0x37, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x22, 0x49, 0x0a, 0x05, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x73, 0x75, 0x6d, 0x22, 0x39, 0x0a, 0x09, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x75,
+	0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x44, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x22,
+	0x35, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x32, 0x29, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x04, 0x2e, 0x52, 0x65, 0x71, 0x1a, 0x05, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e,
+	0x2e, 0x64, 0x65, 0x76, 0x2f, 0x6d, 0x79, 0x61, 0x73, 0x6e, 0x69, 0x6b, 0x6f, 0x76, 0x2e, 0x61,
+	0x6c, 0x65, 0x78, 0x61, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x73, 0x2f, 0x74, 0x65, 0x6c, 0x65, 0x67,
+	0x72, 0x61, 0x6d, 0x2d, 0x62, 0x6f, 0x74, 0x3b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -235,21 +499,31 @@ func file_internal_adapter_service_report_report_proto_rawDescGZIP() []byte {
 	return file_internal_adapter_service_report_report_proto_rawDescData
 }
 
-var file_internal_adapter_service_report_report_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_adapter_service_report_report_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_internal_adapter_service_report_report_proto_goTypes = []interface{}{
-	(*Req)(nil),     // 0: Req
-	(*Resp)(nil),    // 1: Resp
-	(*Expense)(nil), // 2: Expense
+	(*Req)(nil),                   // 0: Req
+	(*Resp)(nil),                  // 1: Resp
+	(*Expense)(nil),               // 2: Expense
+	(*Point)(nil),                 // 3: Point
+	(*MemberSum)(nil),             // 4: MemberSum
+	(*IncomeSum)(nil),             // 5: IncomeSum
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_internal_adapter_service_report_report_proto_depIdxs = []int32{
-	2, // 0: Resp.expenses:type_name -> Expense
-	0, // 1: ReportService.GetReport:input_type -> Req
-	1, // 2: ReportService.GetReport:output_type -> Resp
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6, // 0: Req.dateStart:type_name -> google.protobuf.Timestamp
+	6, // 1: Req.dateEnd:type_name -> google.protobuf.Timestamp
+	2, // 2: Resp.expenses:type_name -> Expense
+	3, // 3: Resp.series:type_name -> Point
+	4, // 4: Resp.members:type_name -> MemberSum
+	5, // 5: Resp.incomes:type_name -> IncomeSum
+	6, // 6: Point.date:type_name -> google.protobuf.Timestamp
+	0, // 7: ReportService.GetReport:input_type -> Req
+	1, // 8: ReportService.GetReport:output_type -> Resp
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_internal_adapter_service_report_report_proto_init() }
@@ -294,6 +568,42 @@ func file_internal_adapter_service_report_report_proto_init() {
 				return nil
 			}
 		}
+		file_internal_adapter_service_report_report_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_adapter_service_report_report_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemberSum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_adapter_service_report_report_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncomeSum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -301,7 +611,7 @@ func file_internal_adapter_service_report_report_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_adapter_service_report_report_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel"
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ReportClient struct {
@@ -41,9 +42,13 @@ func (c *ReportClient) GetReport(ctx context.Context, req usecase.GetReportReqDT
 	defer span.End()
 
 	reqRPC := &Req{ //nolint:exhaustruct
-		UserID:   req.UserID,
-		Date:     req.Date.Format(time.RFC1123),
-		Interval: int32(req.IntervalType),
+		UserID:         req.UserID,
+		DateStart:      timestamppb.New(req.DateStart),
+		DateEnd:        timestamppb.New(req.DateEnd),
+		Rollup:         req.Rollup,
+		SeriesInterval: int32(req.SeriesInterval),
+		ByMember:       req.ByMember,
+		CurrentRate:    req.CurrentRate,
 	}
 
 	respRPC, err := c.client.GetReport(ctx, reqRPC)
@@ -60,6 +65,9 @@ func (c *ReportClient) GetReport(ctx context.Context, req usecase.GetReportReqDT
 	resp := usecase.GetReportRespDTO{
 		Currency: respRPC.Currency,
 		Expenses: make([]usecase.ExpenseReportDTO, 0, len(respRPC.Expenses)),
+		Series:   make([]usecase.SeriesPointDTO, 0, len(respRPC.Series)),
+		Members:  make([]usecase.MemberReportDTO, 0, len(respRPC.Members)),
+		Incomes:  make([]usecase.IncomeReportDTO, 0, len(respRPC.Incomes)),
 	}
 
 	for _, expense := range respRPC.Expenses {
@@ -74,5 +82,41 @@ func (c *ReportClient) GetReport(ctx context.Context, req usecase.GetReportReqDT
 		})
 	}
 
+	for _, point := range respRPC.Series {
+		sum, err := decimal.NewFromString(point.Sum)
+		if err != nil {
+			return usecase.GetReportRespDTO{}, errors.Wrap(err, "ReportClient.GetReport")
+		}
+
+		resp.Series = append(resp.Series, usecase.SeriesPointDTO{
+			Date: point.Date.AsTime().In(time.Local),
+			Sum:  sum,
+		})
+	}
+
+	for _, member := range respRPC.Members {
+		sum, err := decimal.NewFromString(member.Sum)
+		if err != nil {
+			return usecase.GetReportRespDTO{}, errors.Wrap(err, "ReportClient.GetReport")
+		}
+
+		resp.Members = append(resp.Members, usecase.MemberReportDTO{
+			MemberID: member.MemberID,
+			Sum:      sum,
+		})
+	}
+
+	for _, income := range respRPC.Incomes {
+		sum, err := decimal.NewFromString(income.Sum)
+		if err != nil {
+			return usecase.GetReportRespDTO{}, errors.Wrap(err, "ReportClient.GetReport")
+		}
+
+		resp.Incomes = append(resp.Incomes, usecase.IncomeReportDTO{
+			Source: income.Source,
+			Sum:    sum,
+		})
+	}
+
 	return resp, errors.Wrap(err, "ReportClient.GetReport")
 }
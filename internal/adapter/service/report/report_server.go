@@ -3,6 +3,7 @@ package reportservice
 import (
 	context "context"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,14 +11,29 @@ import (
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var ErrInvalidInterval = errors.New("invalid report interval")
+
+// Ограничение на число точек ряда, чтобы график оставался читаемым.
+const maxSeriesPoints = 400
+
 type ExpenseStorage interface {
 	Get(context.Context, entity.UserID, time.Time, time.Time) ([]entity.Expense, error)
 }
 
+type IncomeStorage interface {
+	Get(context.Context, entity.UserID, time.Time, time.Time) ([]entity.Income, error)
+}
+
+type CategoryStorage interface {
+	GetAll(context.Context, entity.UserID) ([]entity.Category, error)
+}
+
 type CurrencyStorage interface {
 	Get(context.Context, string) (entity.Rate, error)
+	GetRange(ctx context.Context, currency string, start, end time.Time) ([]entity.Rate, error)
 }
 
 type UserStorage interface {
@@ -26,21 +42,26 @@ type UserStorage interface {
 
 type Config interface {
 	GetBaseCurrencyCode() string
+	GetConvertAtCurrentRate() bool
 }
 
 type ReportServer struct {
 	ReportServiceServer
 
 	expenseStorage  ExpenseStorage
+	incomeStorage   IncomeStorage
+	categoryStorage CategoryStorage
 	currencyStorage CurrencyStorage
 	userStorage     UserStorage
 	config          Config
 }
 
-func NewReportServer(expenseStorage ExpenseStorage, currencyStorage CurrencyStorage,
-	userStorage UserStorage, config Config) *ReportServer {
+func NewReportServer(expenseStorage ExpenseStorage, incomeStorage IncomeStorage, categoryStorage CategoryStorage,
+	currencyStorage CurrencyStorage, userStorage UserStorage, config Config) *ReportServer {
 	return &ReportServer{ //nolint:exhaustruct
 		expenseStorage:  expenseStorage,
+		incomeStorage:   incomeStorage,
+		categoryStorage: categoryStorage,
 		currencyStorage: currencyStorage,
 		userStorage:     userStorage,
 		config:          config,
@@ -58,18 +79,25 @@ func (s *ReportServer) GetReport(ctx context.Context, req *Req) (*Resp, error) {
 
 	userID := entity.UserID(req.UserID)
 
-	date, err := time.Parse(time.RFC1123, req.Date)
-	if err != nil {
-		return nil, errors.Wrap(err, "ExpenseUsecase.GetReport")
+	if req.GetDateStart() == nil || req.GetDateEnd() == nil {
+		return nil, errors.Wrap(ErrInvalidInterval, "ReportServer.GetReport")
 	}
 
-	dateStart, dateEnd := utils.GetInterval(date, int(req.Interval))
+	dateStart, dateEnd := req.GetDateStart().AsTime(), req.GetDateEnd().AsTime()
+	if !dateStart.Before(dateEnd) {
+		return nil, errors.Wrap(ErrInvalidInterval, "ReportServer.GetReport")
+	}
 
 	expenses, err := s.expenseStorage.Get(ctx, userID, dateStart, dateEnd)
 	if err != nil {
 		return nil, errors.Wrap(err, "ExpenseUsecase.GetReport")
 	}
 
+	incomes, err := s.incomeStorage.Get(ctx, userID, dateStart, dateEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportServer.GetReport")
+	}
+
 	currency, err := s.userStorage.GetDefaultCurrency(ctx, userID)
 	if err != nil {
 		currency = s.config.GetBaseCurrencyCode()
@@ -80,25 +108,71 @@ func (s *ReportServer) GetReport(ctx context.Context, req *Req) (*Resp, error) {
 		return nil, errors.Wrap(err, "ExpenseUsecase.GetReport")
 	}
 
+	history := entity.NewRateHistory(nil, rate)
+
+	// Расходы пересчитываются по курсу на их дату, чтобы прошлые отчеты не менялись вместе с курсом
+	if !req.GetCurrentRate() && !s.config.GetConvertAtCurrentRate() && currency != s.config.GetBaseCurrencyCode() {
+		rates, err := s.currencyStorage.GetRange(ctx, currency, dateStart, dateEnd)
+		if err != nil {
+			return nil, errors.Wrap(err, "ReportServer.GetReport")
+		}
+
+		history = entity.NewRateHistory(rates, rate)
+	}
+
+	for i := range expenses {
+		expenses[i].SetPrice(expenses[i].GetPrice().Mul(history.GetRatio(expenses[i].GetDate())))
+	}
+
+	for i := range incomes {
+		incomes[i].SetAmount(incomes[i].GetAmount().Mul(history.GetRatio(incomes[i].GetDate())))
+	}
+
+	categoryOf := func(category string) string { return category }
+
+	if req.GetRollup() {
+		categories, err := s.categoryStorage.GetAll(ctx, userID)
+		if err != nil {
+			return nil, errors.Wrap(err, "ExpenseUsecase.GetReport")
+		}
+
+		roots := rootCategories(categories)
+
+		categoryOf = func(category string) string {
+			if root, ok := roots[strings.ToLower(category)]; ok {
+				return root
+			}
+
+			return category
+		}
+	}
+
 	uniq := make(map[string]int)
 	expensesReport := make([]ExpenseReportDTO, 0, len(expenses))
 
 	for _, expense := range expenses {
-		ind, ok := uniq[expense.GetCategory()]
+		category := categoryOf(expense.GetCategory())
+
+		ind, ok := uniq[category]
 
-		price := expense.GetPrice().Mul(rate.GetRatio())
+		price := expense.GetPrice()
 
 		if ok {
 			expensesReport[ind].Sum = expensesReport[ind].Sum.Add(price)
 		} else {
-			uniq[expense.GetCategory()] = len(expensesReport)
+			uniq[category] = len(expensesReport)
 			expensesReport = append(expensesReport, ExpenseReportDTO{
-				Category: expense.GetCategory(),
+				Category: category,
 				Sum:      price,
 			})
 		}
 	}
 
+	series, err := expensesSeries(expenses, dateStart, dateEnd, int(req.GetSeriesInterval()))
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportServer.GetReport")
+	}
+
 	sort.Slice(expensesReport, func(i, j int) bool {
 		return expensesReport[i].Category < expensesReport[j].Category
 	})
@@ -115,5 +189,166 @@ func (s *ReportServer) GetReport(ctx context.Context, req *Req) (*Resp, error) {
 		})
 	}
 
+	for _, point := range series {
+		resp.Series = append(resp.Series, &Point{ //nolint:exhaustruct
+			Date: timestamppb.New(point.date),
+			Sum:  point.sum.String(),
+		})
+	}
+
+	if req.GetByMember() {
+		for _, member := range membersSums(expenses, userID) {
+			resp.Members = append(resp.Members, &MemberSum{ //nolint:exhaustruct
+				MemberID: int64(member.memberID),
+				Sum:      member.sum.String(),
+			})
+		}
+	}
+
+	for _, income := range incomesSums(incomes) {
+		resp.Incomes = append(resp.Incomes, &IncomeSum{ //nolint:exhaustruct
+			Source: income.source,
+			Sum:    income.sum.String(),
+		})
+	}
+
 	return resp, nil
 }
+
+type incomeSum struct {
+	source string
+	sum    decimal.Decimal
+}
+
+// incomesSums суммирует доходы по источникам в порядке имен источников.
+func incomesSums(incomes []entity.Income) []incomeSum {
+	uniq := make(map[string]int)
+	sums := make([]incomeSum, 0)
+
+	for _, income := range incomes {
+		ind, ok := uniq[income.GetSource()]
+		if !ok {
+			ind = len(sums)
+			uniq[income.GetSource()] = ind
+			sums = append(sums, incomeSum{source: income.GetSource(), sum: decimal.Zero})
+		}
+
+		sums[ind].sum = sums[ind].sum.Add(income.GetAmount())
+	}
+
+	sort.Slice(sums, func(i, j int) bool {
+		return sums[i].source < sums[j].source
+	})
+
+	return sums
+}
+
+type memberSum struct {
+	memberID entity.UserID
+	sum      decimal.Decimal
+}
+
+// membersSums суммирует расходы по участникам бюджета ownerID, большие суммы идут первыми.
+// Расходы без участника добавлены владельцем.
+func membersSums(expenses []entity.Expense, ownerID entity.UserID) []memberSum {
+	uniq := make(map[entity.UserID]int)
+	sums := make([]memberSum, 0)
+
+	for _, expense := range expenses {
+		memberID := expense.GetMemberID()
+		if memberID == 0 {
+			memberID = ownerID
+		}
+
+		ind, ok := uniq[memberID]
+		if !ok {
+			ind = len(sums)
+			uniq[memberID] = ind
+			sums = append(sums, memberSum{memberID: memberID, sum: decimal.Zero})
+		}
+
+		sums[ind].sum = sums[ind].sum.Add(expense.GetPrice())
+	}
+
+	sort.Slice(sums, func(i, j int) bool {
+		if !sums[i].sum.Equal(sums[j].sum) {
+			return sums[i].sum.GreaterThan(sums[j].sum)
+		}
+
+		return sums[i].memberID < sums[j].memberID
+	})
+
+	return sums
+}
+
+type seriesPoint struct {
+	date time.Time
+	sum  decimal.Decimal
+}
+
+// expensesSeries разбивает [dateStart, dateEnd) на дни или месяцы и суммирует расходы
+// в каждом из них. Интервалы без расходов тоже попадают в ряд с нулевой суммой.
+// Границы считаются в локальной зоне сервиса, в ней же бот считает границы отчета.
+func expensesSeries(expenses []entity.Expense, dateStart, dateEnd time.Time, intervalType int,
+) ([]seriesPoint, error) {
+	if intervalType == 0 {
+		return nil, nil
+	}
+
+	if intervalType != utils.DayInterval && intervalType != utils.MonthInterval {
+		return nil, ErrInvalidInterval
+	}
+
+	dateStart, dateEnd = dateStart.In(time.Local), dateEnd.In(time.Local)
+
+	series := make([]seriesPoint, 0)
+
+	for date := dateStart; date.Before(dateEnd); date = utils.AddIntervals(dateStart, intervalType, len(series)) {
+		if len(series) == maxSeriesPoints {
+			return nil, ErrInvalidInterval
+		}
+
+		series = append(series, seriesPoint{date: date, sum: decimal.Zero})
+	}
+
+	for _, expense := range expenses {
+		// Первая точка, которая начинается позже расхода, следует за нужной
+		ind := sort.Search(len(series), func(i int) bool {
+			return series[i].date.After(expense.GetDate())
+		}) - 1
+		if ind < 0 {
+			continue
+		}
+
+		series[ind].sum = series[ind].sum.Add(expense.GetPrice())
+	}
+
+	return series, nil
+}
+
+// rootCategories сопоставляет имени категории имя ее предка верхнего уровня.
+func rootCategories(categories []entity.Category) map[string]string {
+	byID := make(map[entity.CategoryID]entity.Category, len(categories))
+	for _, category := range categories {
+		byID[category.GetID()] = category
+	}
+
+	roots := make(map[string]string, len(categories))
+
+	for _, category := range categories {
+		root := category
+
+		for steps := 0; root.GetParentID() != 0 && steps < len(categories); steps++ {
+			parent, ok := byID[root.GetParentID()]
+			if !ok {
+				break
+			}
+
+			root = parent
+		}
+
+		roots[category.GetName()] = root.GetName()
+	}
+
+	return roots
+}
@@ -0,0 +1,154 @@
+package accountpgsqlstorage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type AccountPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *AccountPgsqlStorage {
+	return &AccountPgsqlStorage{conn: conn}
+}
+
+// Create добавляет счет. Возвращает false, если у пользователя уже есть счет с таким именем.
+func (s *AccountPgsqlStorage) Create(ctx context.Context, userID entity.UserID, account entity.Account,
+) (entity.AccountID, bool, error) {
+	ctx, span := otel.Tracer("AccountPgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO accounts (user_id, name, currency, initial_balance) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING id`,
+		int64(userID), account.GetName(), account.GetCurrency(), account.GetInitialBalance().String()).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, errors.Wrap(err, "AccountPgsqlStorage.Create")
+	}
+
+	return entity.AccountID(id), true, nil
+}
+
+// GetByName возвращает счет без остатка. Возвращает false, если счета нет.
+func (s *AccountPgsqlStorage) GetByName(ctx context.Context, userID entity.UserID, name string,
+) (entity.Account, bool, error) {
+	ctx, span := otel.Tracer("AccountPgsqlStorage").Start(ctx, "GetByName")
+	defer span.End()
+
+	var (
+		id                int64
+		currency          string
+		initialBalanceStr string
+	)
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT id, currency, initial_balance FROM accounts WHERE user_id = $1 AND name = $2`,
+		int64(userID), name).Scan(&id, &currency, &initialBalanceStr)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Account{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Account{}, false, errors.Wrap(err, "AccountPgsqlStorage.GetByName")
+	}
+
+	initialBalance, err := decimal.NewFromString(initialBalanceStr)
+	if err != nil {
+		return entity.Account{}, false, errors.Wrap(err, "AccountPgsqlStorage.GetByName")
+	}
+
+	account := entity.NewAccount(name, currency, initialBalance)
+	account.SetID(entity.AccountID(id))
+
+	return account, true, nil
+}
+
+// GetAll возвращает счета пользователя с текущими остатками в порядке имен.
+func (s *AccountPgsqlStorage) GetAll(ctx context.Context, userID entity.UserID) ([]entity.Account, error) {
+	ctx, span := otel.Tracer("AccountPgsqlStorage").Start(ctx, "GetAll")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT a.id, a.name, a.currency, a.initial_balance,
+			a.initial_balance
+			+ COALESCE((SELECT SUM(account_amount) FROM incomes WHERE account_id = a.id), 0)
+			- COALESCE((SELECT SUM(account_amount) FROM expenses WHERE account_id = a.id), 0)
+			+ COALESCE((SELECT SUM(amount_to) FROM transfers WHERE to_account_id = a.id), 0)
+			- COALESCE((SELECT SUM(amount_from) FROM transfers WHERE from_account_id = a.id), 0)
+		FROM accounts a
+		WHERE a.user_id = $1
+		ORDER BY a.name`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "AccountPgsqlStorage.GetAll")
+	}
+
+	accounts := make([]entity.Account, 0)
+
+	var (
+		id                int64
+		name              string
+		currency          string
+		initialBalanceStr string
+		balanceStr        string
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &name, &currency, &initialBalanceStr, &balanceStr}, func() error {
+		initialBalance, err := decimal.NewFromString(initialBalanceStr)
+		if err != nil {
+			return errors.Wrap(err, "AccountPgsqlStorage.GetAll")
+		}
+
+		balance, err := decimal.NewFromString(balanceStr)
+		if err != nil {
+			return errors.Wrap(err, "AccountPgsqlStorage.GetAll")
+		}
+
+		account := entity.NewAccount(name, currency, initialBalance)
+		account.SetID(entity.AccountID(id))
+		account.SetBalance(balance)
+
+		accounts = append(accounts, account)
+
+		return nil
+	})
+
+	return accounts, errors.Wrap(err, "AccountPgsqlStorage.GetAll")
+}
+
+func (s *AccountPgsqlStorage) CreateTransfer(ctx context.Context, userID entity.UserID, transfer entity.Transfer,
+) (entity.TransferID, error) {
+	ctx, span := otel.Tracer("AccountPgsqlStorage").Start(ctx, "CreateTransfer")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO transfers (user_id, from_account_id, to_account_id, amount_from, amount_to, time)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		int64(userID), int64(transfer.GetFromAccountID()), int64(transfer.GetToAccountID()),
+		transfer.GetAmountFrom().String(), transfer.GetAmountTo().String(), transfer.GetDate()).Scan(&id)
+
+	return entity.TransferID(id), errors.Wrap(err, "AccountPgsqlStorage.CreateTransfer")
+}
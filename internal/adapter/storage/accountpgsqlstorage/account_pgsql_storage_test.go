@@ -0,0 +1,157 @@
+package accountpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/accountpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*accountpgsqlstorage.AccountPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := accountpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestAccountPgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`INSERT INTO accounts .* ON CONFLICT \(user_id, name\) DO NOTHING`).
+		WithArgs(int64(100), "карта", "RUB", "1500").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(4)))
+
+	id, created, err := storage.Create(ctx, 100, entity.NewAccount("карта", "RUB", decimal.New(1500, 0)))
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, entity.AccountID(4), id)
+}
+
+func TestAccountPgsqlStorage_CreateExisting(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`INSERT INTO accounts`).
+		WithArgs(int64(100), "карта", "RUB", "0").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, created, err := storage.Create(ctx, 100, entity.NewAccount("карта", "RUB", decimal.Zero))
+	assert.NoError(t, err)
+	assert.False(t, created)
+}
+
+func TestAccountPgsqlStorage_GetByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT id, currency, initial_balance FROM accounts WHERE user_id = \$1 AND name = \$2`).
+		WithArgs(int64(100), "наличные").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "currency", "initial_balance"}).AddRow(int64(5), "USD", "20"))
+
+	account, found, err := storage.GetByName(ctx, 100, "наличные")
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	expected := entity.NewAccount("наличные", "USD", decimal.New(20, 0))
+	expected.SetID(5)
+
+	assert.Equal(t, expected, account)
+}
+
+func TestAccountPgsqlStorage_GetByNameNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT id, currency, initial_balance FROM accounts`).
+		WithArgs(int64(100), "вклад").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, found, err := storage.GetByName(ctx, 100, "вклад")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestAccountPgsqlStorage_GetAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"id", "name", "currency", "initial_balance", "balance"}).
+		AddRow(int64(4), "карта", "RUB", "1500", "12000.5").
+		AddRow(int64(5), "наличные", "USD", "20", "5")
+
+	mock.ExpectQuery(`SELECT .* FROM accounts a\s+WHERE a.user_id = \$1`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	accounts, err := storage.GetAll(ctx, 100)
+	assert.NoError(t, err)
+
+	card := entity.NewAccount("карта", "RUB", decimal.New(1500, 0))
+	card.SetID(4)
+	card.SetBalance(decimal.New(120005, -1))
+
+	cash := entity.NewAccount("наличные", "USD", decimal.New(20, 0))
+	cash.SetID(5)
+	cash.SetBalance(decimal.New(5, 0))
+
+	assert.Equal(t, []entity.Account{card, cash}, accounts)
+}
+
+func TestAccountPgsqlStorage_CreateTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO transfers`).
+		WithArgs(int64(100), int64(4), int64(5), "9500", "100", date).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(2)))
+
+	id, err := storage.CreateTransfer(ctx, 100,
+		entity.NewTransfer(4, 5, decimal.New(9500, 0), decimal.New(100, 0), date))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TransferID(2), id)
+}
@@ -0,0 +1,84 @@
+package auditpgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type AuditPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *AuditPgsqlStorage {
+	return &AuditPgsqlStorage{conn: conn}
+}
+
+func (s *AuditPgsqlStorage) Create(ctx context.Context, record entity.AuditRecord) error {
+	ctx, span := otel.Tracer("AuditPgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO audit_log (user_id, owner_id, action, old_value, new_value, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		int64(record.GetUserID()), int64(record.GetOwnerID()), record.GetAction(), record.GetOldValue(),
+		record.GetNewValue(), record.GetCreatedAt())
+
+	return errors.Wrap(err, "AuditPgsqlStorage.Create")
+}
+
+// GetLast возвращает последнее неотмененное действие пользователя userID над данными
+// ownerID. Возвращает false, если отменять нечего.
+func (s *AuditPgsqlStorage) GetLast(ctx context.Context, userID, ownerID entity.UserID,
+) (entity.AuditRecord, bool, error) {
+	ctx, span := otel.Tracer("AuditPgsqlStorage").Start(ctx, "GetLast")
+	defer span.End()
+
+	var (
+		id        int64
+		action    string
+		oldValue  string
+		newValue  string
+		createdAt time.Time
+	)
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT id, action, old_value::text, new_value::text, created_at FROM audit_log
+		WHERE user_id = $1 AND owner_id = $2 AND undone_at IS NULL ORDER BY id DESC LIMIT 1`,
+		int64(userID), int64(ownerID)).Scan(&id, &action, &oldValue, &newValue, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.AuditRecord{}, false, nil
+	}
+
+	if err != nil {
+		return entity.AuditRecord{}, false, errors.Wrap(err, "AuditPgsqlStorage.GetLast")
+	}
+
+	record := entity.NewAuditRecord(userID, ownerID, action, oldValue, newValue, createdAt)
+	record.SetID(entity.AuditRecordID(id))
+
+	return record, true, nil
+}
+
+// MarkUndone отмечает действие отмененным, следующая отмена вернет предыдущее.
+func (s *AuditPgsqlStorage) MarkUndone(ctx context.Context, id entity.AuditRecordID, date time.Time) error {
+	ctx, span := otel.Tracer("AuditPgsqlStorage").Start(ctx, "MarkUndone")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`UPDATE audit_log SET undone_at = $2 WHERE id = $1`,
+		int64(id), date)
+
+	return errors.Wrap(err, "AuditPgsqlStorage.MarkUndone")
+}
@@ -0,0 +1,113 @@
+package auditpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/auditpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*auditpgsqlstorage.AuditPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := auditpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestAuditPgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO audit_log`).
+		WithArgs(int64(202), int64(101), entity.AuditSetCurrency, `{"currency":"RUB"}`, `{"currency":"USD"}`, date).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.Create(ctx, entity.NewAuditRecord(202, 101, entity.AuditSetCurrency,
+		`{"currency":"RUB"}`, `{"currency":"USD"}`, date))
+	assert.NoError(t, err)
+}
+
+func TestAuditPgsqlStorage_GetLast(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{"id", "action", "old_value", "new_value", "created_at"}).
+		AddRow(int64(7), entity.AuditSetCurrency, `{"currency":"RUB"}`, `{"currency":"USD"}`, date)
+
+	mock.ExpectQuery(`SELECT .* FROM audit_log WHERE user_id = \$1 AND owner_id = \$2 AND undone_at IS NULL`).
+		WithArgs(int64(202), int64(101)).
+		WillReturnRows(rows)
+
+	record, found, err := storage.GetLast(ctx, entity.UserID(202), entity.UserID(101))
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	expected := entity.NewAuditRecord(202, 101, entity.AuditSetCurrency, `{"currency":"RUB"}`, `{"currency":"USD"}`, date)
+	expected.SetID(7)
+
+	assert.Equal(t, expected, record)
+}
+
+func TestAuditPgsqlStorage_GetLast_Empty(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT .* FROM audit_log`).
+		WithArgs(int64(202), int64(202)).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, found, err := storage.GetLast(ctx, entity.UserID(202), entity.UserID(202))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestAuditPgsqlStorage_MarkUndone(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`UPDATE audit_log SET undone_at = \$2 WHERE id = \$1`).
+		WithArgs(int64(7), date).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := storage.MarkUndone(ctx, entity.AuditRecordID(7), date)
+	assert.NoError(t, err)
+}
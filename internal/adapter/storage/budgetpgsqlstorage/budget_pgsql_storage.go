@@ -0,0 +1,155 @@
+package budgetpgsqlstorage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type BudgetPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *BudgetPgsqlStorage {
+	return &BudgetPgsqlStorage{conn: conn}
+}
+
+// GetOwner возвращает владельца бюджета, в котором пользователь участник.
+func (s *BudgetPgsqlStorage) GetOwner(ctx context.Context, userID entity.UserID) (entity.UserID, bool, error) {
+	ctx, span := otel.Tracer("BudgetPgsqlStorage").Start(ctx, "GetOwner")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx, `SELECT owner_id FROM budget_members WHERE user_id = $1`, int64(userID))
+	if err != nil {
+		return 0, false, errors.Wrap(err, "BudgetPgsqlStorage.GetOwner")
+	}
+
+	var (
+		ownerID int64
+		found   bool
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&ownerID}, func() error {
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, errors.Wrap(err, "BudgetPgsqlStorage.GetOwner")
+	}
+
+	return entity.UserID(ownerID), found, nil
+}
+
+// Get возвращает бюджет владельца вместе с участниками.
+func (s *BudgetPgsqlStorage) Get(ctx context.Context, ownerID entity.UserID) (entity.Budget, bool, error) {
+	ctx, span := otel.Tracer("BudgetPgsqlStorage").Start(ctx, "Get")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT b.invite_code, m.user_id FROM budgets b
+		LEFT JOIN budget_members m ON m.owner_id = b.owner_id
+		WHERE b.owner_id = $1
+		ORDER BY m.user_id`,
+		int64(ownerID))
+	if err != nil {
+		return entity.Budget{}, false, errors.Wrap(err, "BudgetPgsqlStorage.Get")
+	}
+
+	var (
+		inviteCode string
+		memberID   *int64
+		members    []entity.UserID
+		found      bool
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&inviteCode, &memberID}, func() error {
+		found = true
+
+		if memberID != nil {
+			members = append(members, entity.UserID(*memberID))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Budget{}, false, errors.Wrap(err, "BudgetPgsqlStorage.Get")
+	}
+
+	return entity.NewBudget(ownerID, inviteCode, members), found, nil
+}
+
+// UpdateInviteCode создает бюджет или меняет код приглашения. Старый код перестает действовать.
+func (s *BudgetPgsqlStorage) UpdateInviteCode(ctx context.Context, ownerID entity.UserID, inviteCode string) error {
+	ctx, span := otel.Tracer("BudgetPgsqlStorage").Start(ctx, "UpdateInviteCode")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO budgets (owner_id, invite_code) VALUES ($1, $2)
+		ON CONFLICT (owner_id) DO UPDATE SET invite_code = $2`,
+		int64(ownerID), inviteCode)
+
+	return errors.Wrap(err, "BudgetPgsqlStorage.UpdateInviteCode")
+}
+
+// Join добавляет пользователя в бюджет по коду приглашения и возвращает владельца.
+// Участник другого бюджета переходит в новый. Вступить в свой бюджет или в бюджет
+// владельца, который сам стал участником, нельзя.
+func (s *BudgetPgsqlStorage) Join(ctx context.Context, userID entity.UserID, inviteCode string,
+) (entity.UserID, bool, error) {
+	ctx, span := otel.Tracer("BudgetPgsqlStorage").Start(ctx, "Join")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`INSERT INTO budget_members (user_id, owner_id)
+		SELECT $1, owner_id FROM budgets
+		WHERE invite_code = $2 AND owner_id <> $1
+			AND owner_id NOT IN (SELECT user_id FROM budget_members)
+		ON CONFLICT (user_id) DO UPDATE SET owner_id = EXCLUDED.owner_id
+		RETURNING owner_id`,
+		int64(userID), inviteCode)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "BudgetPgsqlStorage.Join")
+	}
+
+	var (
+		ownerID int64
+		found   bool
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&ownerID}, func() error {
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, errors.Wrap(err, "BudgetPgsqlStorage.Join")
+	}
+
+	return entity.UserID(ownerID), found, nil
+}
+
+// DeleteMember исключает участника из бюджета владельца. Возвращает false, если такого участника нет.
+func (s *BudgetPgsqlStorage) DeleteMember(ctx context.Context, ownerID, userID entity.UserID) (bool, error) {
+	ctx, span := otel.Tracer("BudgetPgsqlStorage").Start(ctx, "DeleteMember")
+	defer span.End()
+
+	tag, err := s.conn.Exec(ctx,
+		`DELETE FROM budget_members WHERE owner_id = $1 AND user_id = $2`,
+		int64(ownerID), int64(userID))
+	if err != nil {
+		return false, errors.Wrap(err, "BudgetPgsqlStorage.DeleteMember")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
@@ -0,0 +1,175 @@
+package budgetpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/budgetpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*budgetpgsqlstorage.BudgetPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := budgetpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestBudgetPgsqlStorage_GetOwner(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT owner_id FROM budget_members`).
+		WithArgs(int64(200)).
+		WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(int64(100)))
+
+	ownerID, found, err := storage.GetOwner(ctx, entity.UserID(200))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, entity.UserID(100), ownerID)
+}
+
+func TestBudgetPgsqlStorage_GetOwnerNotMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT owner_id FROM budget_members`).
+		WithArgs(int64(200)).
+		WillReturnRows(pgxmock.NewRows([]string{"owner_id"}))
+
+	_, found, err := storage.GetOwner(ctx, entity.UserID(200))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBudgetPgsqlStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	member1, member2 := int64(200), int64(300)
+
+	rows := pgxmock.NewRows([]string{"invite_code", "user_id"}).
+		AddRow("abc123", &member1).
+		AddRow("abc123", &member2)
+
+	mock.ExpectQuery(`SELECT b.invite_code, m.user_id FROM budgets b`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	budget, found, err := storage.Get(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, entity.NewBudget(100, "abc123", []entity.UserID{200, 300}), budget)
+}
+
+func TestBudgetPgsqlStorage_GetWithoutMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT b.invite_code, m.user_id FROM budgets b`).
+		WithArgs(int64(100)).
+		WillReturnRows(pgxmock.NewRows([]string{"invite_code", "user_id"}).AddRow("abc123", nil))
+
+	budget, found, err := storage.Get(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, entity.NewBudget(100, "abc123", nil), budget)
+}
+
+func TestBudgetPgsqlStorage_UpdateInviteCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`INSERT INTO budgets`).
+		WithArgs(int64(100), "abc123").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.UpdateInviteCode(ctx, entity.UserID(100), "abc123")
+	assert.NoError(t, err)
+}
+
+func TestBudgetPgsqlStorage_Join(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`INSERT INTO budget_members`).
+		WithArgs(int64(200), "abc123").
+		WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(int64(100)))
+
+	ownerID, found, err := storage.Join(ctx, entity.UserID(200), "abc123")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, entity.UserID(100), ownerID)
+}
+
+func TestBudgetPgsqlStorage_JoinUnknownCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`INSERT INTO budget_members`).
+		WithArgs(int64(200), "zzz").
+		WillReturnRows(pgxmock.NewRows([]string{"owner_id"}))
+
+	_, found, err := storage.Join(ctx, entity.UserID(200), "zzz")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBudgetPgsqlStorage_DeleteMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM budget_members`).
+		WithArgs(int64(100), int64(200)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	found, err := storage.DeleteMember(ctx, entity.UserID(100), entity.UserID(200))
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
@@ -0,0 +1,231 @@
+package categorypgsqlstorage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Begin(context.Context) (pgx.Tx, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type CategoryPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *CategoryPgsqlStorage {
+	return &CategoryPgsqlStorage{conn: conn}
+}
+
+// Create добавляет категорию. Если категория с таким именем уже есть,
+// у нее обновляется родитель и возвращается ее идентификатор.
+func (s *CategoryPgsqlStorage) Create(ctx context.Context, userID entity.UserID, category entity.Category,
+) (entity.CategoryID, error) {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO categories (user_id, name, parent_id) VALUES ($1, lower($2), NULLIF($3, 0))
+		ON CONFLICT (user_id, name) DO UPDATE SET parent_id = COALESCE(EXCLUDED.parent_id, categories.parent_id)
+		RETURNING id`,
+		int64(userID), category.GetName(), int64(category.GetParentID())).Scan(&id)
+
+	return entity.CategoryID(id), errors.Wrap(err, "CategoryPgsqlStorage.Create")
+}
+
+// GetByName ищет категорию по имени или синониму. Возвращает false, если категории нет.
+func (s *CategoryPgsqlStorage) GetByName(ctx context.Context, userID entity.UserID, name string,
+) (entity.Category, bool, error) {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "GetByName")
+	defer span.End()
+
+	var (
+		id       int64
+		realName string
+		parentID int64
+	)
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT id, name, COALESCE(parent_id, 0) FROM categories
+		WHERE user_id = $1 AND (name = lower($2) OR id = (
+			SELECT category_id FROM category_aliases WHERE user_id = $1 AND alias = lower($2)))
+		ORDER BY name = lower($2) DESC LIMIT 1`,
+		int64(userID), name).Scan(&id, &realName, &parentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Category{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Category{}, false, errors.Wrap(err, "CategoryPgsqlStorage.GetByName")
+	}
+
+	category := entity.NewCategory(realName, entity.CategoryID(parentID))
+	category.SetID(entity.CategoryID(id))
+
+	return category, true, nil
+}
+
+func (s *CategoryPgsqlStorage) GetAll(ctx context.Context, userID entity.UserID) ([]entity.Category, error) {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "GetAll")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT c.id, c.name, COALESCE(c.parent_id, 0), COALESCE(string_agg(a.alias, ',' ORDER BY a.alias), '')
+		FROM categories c LEFT JOIN category_aliases a ON a.category_id = c.id
+		WHERE c.user_id = $1
+		GROUP BY c.id
+		ORDER BY c.name`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "CategoryPgsqlStorage.GetAll")
+	}
+
+	categories := make([]entity.Category, 0, rows.CommandTag().RowsAffected())
+
+	var (
+		id       int64
+		name     string
+		parentID int64
+		aliases  string
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &name, &parentID, &aliases}, func() error {
+		category := entity.NewCategory(name, entity.CategoryID(parentID))
+		category.SetID(entity.CategoryID(id))
+
+		if len(aliases) != 0 {
+			category.SetAliases(strings.Split(aliases, ","))
+		}
+
+		categories = append(categories, category)
+
+		return nil
+	})
+
+	return categories, errors.Wrap(err, "CategoryPgsqlStorage.GetAll")
+}
+
+// Resolve возвращает имя категории по имени или синониму. Неизвестное имя возвращается как есть.
+func (s *CategoryPgsqlStorage) Resolve(ctx context.Context, userID entity.UserID, name string) (string, error) {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "Resolve")
+	defer span.End()
+
+	var resolved string
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT COALESCE(
+			(SELECT name FROM categories WHERE user_id = $1 AND name = lower($2)),
+			(SELECT c.name FROM category_aliases a JOIN categories c ON c.id = a.category_id
+				WHERE a.user_id = $1 AND a.alias = lower($2)),
+			$2)`,
+		int64(userID), name).Scan(&resolved)
+
+	return resolved, errors.Wrap(err, "CategoryPgsqlStorage.Resolve")
+}
+
+func (s *CategoryPgsqlStorage) AddAlias(ctx context.Context, userID entity.UserID, alias string,
+	categoryID entity.CategoryID,
+) error {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "AddAlias")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO category_aliases (user_id, alias, category_id) VALUES ($1, lower($2), $3)
+		ON CONFLICT (user_id, alias) DO UPDATE SET category_id = $3`,
+		int64(userID), alias, int64(categoryID))
+
+	return errors.Wrap(err, "CategoryPgsqlStorage.AddAlias")
+}
+
+// Rename переименовывает категорию вместе с расходами. Старое имя остается синонимом.
+func (s *CategoryPgsqlStorage) Rename(ctx context.Context, userID entity.UserID, categoryID entity.CategoryID,
+	name string,
+) error {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "Rename")
+	defer span.End()
+
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`UPDATE expenses e SET category = lower($3) FROM categories c
+			WHERE c.id = $2 AND c.user_id = $1 AND e.user_id = $1 AND lower(e.category) = c.name`,
+			int64(userID), int64(categoryID), name)
+		if err != nil {
+			return errors.Wrap(err, "CategoryPgsqlStorage.Rename")
+		}
+
+		_, err = tx.Exec(ctx,
+			`INSERT INTO category_aliases (user_id, alias, category_id)
+			SELECT user_id, name, id FROM categories WHERE id = $2 AND user_id = $1
+			ON CONFLICT (user_id, alias) DO NOTHING`,
+			int64(userID), int64(categoryID))
+		if err != nil {
+			return errors.Wrap(err, "CategoryPgsqlStorage.Rename")
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE categories SET name = lower($3) WHERE id = $2 AND user_id = $1`,
+			int64(userID), int64(categoryID), name)
+
+		return errors.Wrap(err, "CategoryPgsqlStorage.Rename")
+	})
+
+	return errors.Wrap(err, "CategoryPgsqlStorage.Rename")
+}
+
+// Merge переносит расходы, синонимы и подкатегории в категорию to и удаляет категорию from.
+func (s *CategoryPgsqlStorage) Merge(ctx context.Context, userID entity.UserID, from, to entity.CategoryID) error {
+	ctx, span := otel.Tracer("CategoryPgsqlStorage").Start(ctx, "Merge")
+	defer span.End()
+
+	queries := []string{
+		`UPDATE expenses e SET category = t.name FROM categories f, categories t
+		WHERE f.id = $2 AND t.id = $3 AND f.user_id = $1 AND t.user_id = $1
+			AND e.user_id = $1 AND lower(e.category) = f.name`,
+		`UPDATE category_aliases SET category_id = $3 WHERE user_id = $1 AND category_id = $2`,
+		`INSERT INTO category_aliases (user_id, alias, category_id)
+		SELECT user_id, name, $3 FROM categories WHERE id = $2 AND user_id = $1
+		ON CONFLICT (user_id, alias) DO NOTHING`,
+		`UPDATE categories SET parent_id = $3 WHERE user_id = $1 AND parent_id = $2`,
+		`DELETE FROM categories WHERE id = $2 AND user_id = $1`,
+	}
+
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		for _, query := range queries {
+			_, err := tx.Exec(ctx, query, int64(userID), int64(from), int64(to))
+			if err != nil {
+				return errors.Wrap(err, "CategoryPgsqlStorage.Merge")
+			}
+		}
+
+		return nil
+	})
+
+	return errors.Wrap(err, "CategoryPgsqlStorage.Merge")
+}
+
+func (s *CategoryPgsqlStorage) inTx(ctx context.Context, fn func(pgx.Tx) error) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "CategoryPgsqlStorage.inTx")
+	}
+
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "CategoryPgsqlStorage.inTx")
+}
@@ -0,0 +1,211 @@
+package categorypgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/categorypgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+var errInternal = errors.New("internal error")
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*categorypgsqlstorage.CategoryPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := categorypgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestCategoryPgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"id"}).
+		AddRow(int64(5))
+
+	mock.ExpectQuery(`INSERT INTO categories`).
+		WithArgs(int64(100), "такси", int64(3)).
+		WillReturnRows(rows)
+
+	id, err := storage.Create(ctx, entity.UserID(100), entity.NewCategory("такси", 3))
+	assert.NoError(t, err)
+
+	assert.Equal(t, entity.CategoryID(5), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryPgsqlStorage_GetAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"id", "name", "parent_id", "aliases"}).
+		AddRow(int64(5), "такси", int64(3), "taxi,убер").
+		AddRow(int64(3), "транспорт", int64(0), "")
+
+	mock.ExpectQuery(`SELECT c.id, c.name`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	categories, err := storage.GetAll(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+
+	taxi := entity.NewCategory("такси", 3)
+	taxi.SetID(5)
+	taxi.SetAliases([]string{"taxi", "убер"})
+
+	transport := entity.NewCategory("транспорт", 0)
+	transport.SetID(3)
+
+	assert.Equal(t, []entity.Category{taxi, transport}, categories)
+}
+
+func TestCategoryPgsqlStorage_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"name"}).
+		AddRow("продукты")
+
+	mock.ExpectQuery(`SELECT COALESCE`).
+		WithArgs(int64(100), "Еда").
+		WillReturnRows(rows)
+
+	name, err := storage.Resolve(ctx, entity.UserID(100), "Еда")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "продукты", name)
+}
+
+func TestCategoryPgsqlStorage_GetByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"id", "name", "parent_id"}).
+		AddRow(int64(5), "такси", int64(3))
+
+	mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories`).
+		WithArgs(int64(100), "Такси").
+		WillReturnRows(rows)
+
+	category, found, err := storage.GetByName(ctx, entity.UserID(100), "Такси")
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	expected := entity.NewCategory("такси", 3)
+	expected.SetID(5)
+
+	assert.Equal(t, expected, category)
+}
+
+func TestCategoryPgsqlStorage_GetByNameNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories`).
+		WithArgs(int64(100), "такси").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "parent_id"}))
+
+	_, found, err := storage.GetByName(ctx, entity.UserID(100), "такси")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestCategoryPgsqlStorage_GetByNameError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories`).
+		WithArgs(int64(100), "такси").
+		WillReturnError(errInternal)
+
+	_, found, err := storage.GetByName(ctx, entity.UserID(100), "такси")
+	assert.ErrorIs(t, err, errInternal)
+	assert.False(t, found)
+}
+
+func TestCategoryPgsqlStorage_Rename(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE expenses`).
+		WithArgs(int64(100), int64(5), "такси").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec(`INSERT INTO category_aliases`).
+		WithArgs(int64(100), int64(5)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE categories SET name`).
+		WithArgs(int64(100), int64(5), "такси").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	err := storage.Rename(ctx, entity.UserID(100), entity.CategoryID(5), "такси")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryPgsqlStorage_MergeError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE expenses`).
+		WithArgs(int64(100), int64(5), int64(3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec(`UPDATE category_aliases`).
+		WithArgs(int64(100), int64(5), int64(3)).
+		WillReturnError(errInternal)
+	mock.ExpectRollback()
+
+	err := storage.Merge(ctx, entity.UserID(100), entity.CategoryID(5), entity.CategoryID(3))
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -14,6 +14,9 @@ type CurrencyStorage interface {
 	Get(context.Context, string) (entity.Rate, error)
 	GetAll(context.Context) ([]entity.Rate, error)
 	Update(context.Context, entity.Rate) error
+	UpdateHistory(context.Context, entity.Rate) error
+	GetForDate(context.Context, string, time.Time) (entity.Rate, error)
+	GetRange(ctx context.Context, currency string, start, end time.Time) ([]entity.Rate, error)
 }
 
 type Config interface {
@@ -70,6 +73,26 @@ func (s *CurrencyCacheStorage) Update(ctx context.Context, rate entity.Rate) err
 	return errors.Wrap(err, "CurrencyCacheStorage.Update")
 }
 
+// UpdateHistory история курсов не кэшируется.
+func (s *CurrencyCacheStorage) UpdateHistory(ctx context.Context, rate entity.Rate) error {
+	err := s.storage.UpdateHistory(ctx, rate)
+
+	return errors.Wrap(err, "CurrencyCacheStorage.UpdateHistory")
+}
+
+func (s *CurrencyCacheStorage) GetForDate(ctx context.Context, currency string, date time.Time) (entity.Rate, error) {
+	rate, err := s.storage.GetForDate(ctx, currency, date)
+
+	return rate, errors.Wrap(err, "CurrencyCacheStorage.GetForDate")
+}
+
+func (s *CurrencyCacheStorage) GetRange(ctx context.Context, currency string, start, end time.Time,
+) ([]entity.Rate, error) {
+	rates, err := s.storage.GetRange(ctx, currency, start, end)
+
+	return rates, errors.Wrap(err, "CurrencyCacheStorage.GetRange")
+}
+
 func (s *CurrencyCacheStorage) addToCache(rate entity.Rate) {
 	if !s.cfg.GetCurrencyCacheEnable() {
 		return
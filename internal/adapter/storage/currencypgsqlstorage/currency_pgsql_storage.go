@@ -12,6 +12,9 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+// dateLayout формат дня в истории курсов.
+const dateLayout = "2006-01-02"
+
 type PgxIface interface {
 	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
 	QueryRow(context.Context, string, ...interface{}) pgx.Row
@@ -34,21 +37,19 @@ func (s *CurrencyPgsqlStorage) Get(ctx context.Context, currency string) (entity
 		code     string
 		ratioStr string
 		date     time.Time
+		source   string
 	)
 
 	err := s.conn.QueryRow(ctx,
-		`SELECT code, ratio, time FROM currencies WHERE code = $1`,
-		currency).Scan(&code, &ratioStr, &date)
+		`SELECT code, ratio, time, source FROM currencies WHERE code = $1`,
+		currency).Scan(&code, &ratioStr, &date, &source)
 	if err != nil {
 		return entity.Rate{}, errors.Wrap(err, "CurrencyPgsqlStorage.Get")
 	}
 
-	ratio, err := decimal.NewFromString(ratioStr)
-	if err != nil {
-		return entity.Rate{}, errors.Wrap(err, "CurrencyPgsqlStorage.Get")
-	}
+	rate, err := newRate(code, ratioStr, date, source)
 
-	return entity.NewRate(code, ratio, date), errors.Wrap(err, "CurrencyPgsqlStorage.Get")
+	return rate, errors.Wrap(err, "CurrencyPgsqlStorage.Get")
 }
 
 func (s *CurrencyPgsqlStorage) GetAll(ctx context.Context) ([]entity.Rate, error) {
@@ -56,42 +57,131 @@ func (s *CurrencyPgsqlStorage) GetAll(ctx context.Context) ([]entity.Rate, error
 	defer span.End()
 
 	rows, err := s.conn.Query(ctx,
-		`SELECT code, ratio, time FROM currencies`)
+		`SELECT code, ratio, time, source FROM currencies`)
 	if err != nil {
 		return nil, errors.Wrap(err, "CurrencyPgsqlStorage.GetAll")
 	}
 
+	rates, err := scanRates(rows)
+
+	return rates, errors.Wrap(err, "CurrencyPgsqlStorage.GetAll")
+}
+
+// Update сохраняет последний курс валюты и курс за его день в историю.
+func (s *CurrencyPgsqlStorage) Update(ctx context.Context, rate entity.Rate) error {
+	ctx, span := otel.Tracer("CurrencyPgsqlStorage").Start(ctx, "Update")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`WITH latest AS (
+			INSERT INTO currencies (code, ratio, time, source) VALUES ($1, $2, $3, $5)
+			ON CONFLICT (code) DO UPDATE
+			SET ratio = EXCLUDED.ratio, time = EXCLUDED.time, source = EXCLUDED.source
+		)
+		INSERT INTO currency_rates (code, date, ratio, source) VALUES ($1, $4, $2, $5)
+		ON CONFLICT (code, date) DO UPDATE
+		SET ratio = EXCLUDED.ratio, source = EXCLUDED.source`,
+		rate.GetCode(), rate.GetRatio().String(), rate.GetTime(), rate.GetTime().Format(dateLayout),
+		rate.GetSource())
+
+	return errors.Wrap(err, "CurrencyPgsqlStorage.Update")
+}
+
+// UpdateHistory сохраняет курс за день rate.GetTime() в историю, не трогая последний курс.
+func (s *CurrencyPgsqlStorage) UpdateHistory(ctx context.Context, rate entity.Rate) error {
+	ctx, span := otel.Tracer("CurrencyPgsqlStorage").Start(ctx, "UpdateHistory")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO currency_rates (code, date, ratio, source) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code, date) DO UPDATE
+		SET ratio = EXCLUDED.ratio, source = EXCLUDED.source`,
+		rate.GetCode(), rate.GetTime().Format(dateLayout), rate.GetRatio().String(), rate.GetSource())
+
+	return errors.Wrap(err, "CurrencyPgsqlStorage.UpdateHistory")
+}
+
+// GetForDate возвращает курс валюты за день date. Если курса за этот день нет, берется ближайший
+// более ранний, а если истории до date нет - самый ранний известный. Время курса - его день.
+func (s *CurrencyPgsqlStorage) GetForDate(ctx context.Context, currency string, date time.Time,
+) (entity.Rate, error) {
+	ctx, span := otel.Tracer("CurrencyPgsqlStorage").Start(ctx, "GetForDate")
+	defer span.End()
+
+	var (
+		code     string
+		ratioStr string
+		day      time.Time
+		source   string
+	)
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT code, ratio, date, source FROM currency_rates WHERE code = $1
+		ORDER BY date > $2::date, abs(date - $2::date) LIMIT 1`,
+		currency, date.Format(dateLayout)).Scan(&code, &ratioStr, &day, &source)
+	if err != nil {
+		return entity.Rate{}, errors.Wrap(err, "CurrencyPgsqlStorage.GetForDate")
+	}
+
+	rate, err := newRate(code, ratioStr, day, source)
+
+	return rate, errors.Wrap(err, "CurrencyPgsqlStorage.GetForDate")
+}
+
+// GetRange возвращает курсы валюты по возрастанию дня за период [start, end] вместе с последним
+// курсом до start, чтобы курс был известен для каждого дня периода.
+func (s *CurrencyPgsqlStorage) GetRange(ctx context.Context, currency string, start, end time.Time,
+) ([]entity.Rate, error) {
+	ctx, span := otel.Tracer("CurrencyPgsqlStorage").Start(ctx, "GetRange")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT code, ratio, date, source FROM currency_rates
+		WHERE code = $1 AND date <= $3::date AND date >= COALESCE(
+			(SELECT max(date) FROM currency_rates WHERE code = $1 AND date <= $2::date), $2::date)
+		ORDER BY date`,
+		currency, start.Format(dateLayout), end.Format(dateLayout))
+	if err != nil {
+		return nil, errors.Wrap(err, "CurrencyPgsqlStorage.GetRange")
+	}
+
+	rates, err := scanRates(rows)
+
+	return rates, errors.Wrap(err, "CurrencyPgsqlStorage.GetRange")
+}
+
+func scanRates(rows pgx.Rows) ([]entity.Rate, error) {
 	var (
 		code     string
 		ratioStr string
 		date     time.Time
+		source   string
 
 		rates []entity.Rate
 	)
 
-	_, err = pgx.ForEachRow(rows, []any{&code, &ratioStr, &date}, func() error {
-		ratio, err := decimal.NewFromString(ratioStr)
+	_, err := pgx.ForEachRow(rows, []any{&code, &ratioStr, &date, &source}, func() error {
+		rate, err := newRate(code, ratioStr, date, source)
 		if err != nil {
-			return errors.Wrap(err, "CurrencyPgsqlStorage.GetAll")
+			return errors.Wrap(err, "scanRates")
 		}
 
-		rates = append(rates, entity.NewRate(code, ratio, date))
+		rates = append(rates, rate)
 
 		return nil
 	})
 
-	return rates, errors.Wrap(err, "CurrencyPgsqlStorage.GetAll")
+	return rates, errors.Wrap(err, "scanRates")
 }
 
-func (s *CurrencyPgsqlStorage) Update(ctx context.Context, rate entity.Rate) error {
-	ctx, span := otel.Tracer("CurrencyPgsqlStorage").Start(ctx, "Update")
-	defer span.End()
+func newRate(code, ratioStr string, date time.Time, source string) (entity.Rate, error) {
+	ratio, err := decimal.NewFromString(ratioStr)
+	if err != nil {
+		return entity.Rate{}, errors.Wrap(err, "newRate")
+	}
 
-	_, err := s.conn.Exec(ctx,
-		`INSERT INTO currencies (code, ratio, time) VALUES ($1, $2, $3)
-		ON CONFLICT (code) DO UPDATE
-		SET ratio = EXCLUDED.ratio, time = EXCLUDED.time`,
-		rate.GetCode(), rate.GetRatio().String(), rate.GetTime())
+	rate := entity.NewRate(code, ratio, date)
+	rate.SetSource(source)
 
-	return errors.Wrap(err, "CurrencyPgsqlStorage.Update")
+	return rate, nil
 }
@@ -44,17 +44,20 @@ func TestCurrencyPgsqlStorage_Get(t *testing.T) {
 
 	date := time.Now()
 
-	rows := pgxmock.NewRows([]string{"code", "ratio", "time"}).
-		AddRow("USD", "0.016", date)
+	rows := pgxmock.NewRows([]string{"code", "ratio", "time", "source"}).
+		AddRow("USD", "0.016", date, "cbr")
 
-	mock.ExpectQuery(`SELECT code, ratio, time FROM currencies`).
+	mock.ExpectQuery(`SELECT code, ratio, time, source FROM currencies`).
 		WithArgs("USD").
 		WillReturnRows(rows)
 
 	rate, err := storage.Get(ctx, "USD")
 	assert.NoError(t, err)
 
-	assert.Equal(t, entity.NewRate("USD", decimal.New(16, -3), date), rate)
+	expected := entity.NewRate("USD", decimal.New(16, -3), date)
+	expected.SetSource("cbr")
+
+	assert.Equal(t, expected, rate)
 }
 
 func TestCurrencyPgsqlStorage_GetUnknownCurrency(t *testing.T) {
@@ -65,9 +68,9 @@ func TestCurrencyPgsqlStorage_GetUnknownCurrency(t *testing.T) {
 
 	defer teardownSuite(t)
 
-	rows := pgxmock.NewRows([]string{"code", "ratio", "time"})
+	rows := pgxmock.NewRows([]string{"code", "ratio", "time", "source"})
 
-	mock.ExpectQuery(`SELECT code, ratio, time FROM currencies`).
+	mock.ExpectQuery(`SELECT code, ratio, time, source FROM currencies`).
 		WithArgs("USD").
 		WillReturnRows(rows)
 
@@ -85,12 +88,12 @@ func TestCurrencyPgsqlStorage_GetAll(t *testing.T) {
 
 	date := time.Now()
 
-	rows := pgxmock.NewRows([]string{"code", "ratio", "time"}).
-		AddRow("RUB", "1", date).
-		AddRow("USD", "0.016", date).
-		AddRow("EUR", "0.017", date)
+	rows := pgxmock.NewRows([]string{"code", "ratio", "time", "source"}).
+		AddRow("RUB", "1", date, "").
+		AddRow("USD", "0.016", date, "").
+		AddRow("EUR", "0.017", date, "")
 
-	mock.ExpectQuery(`SELECT code, ratio, time FROM currencies`).
+	mock.ExpectQuery(`SELECT code, ratio, time, source FROM currencies`).
 		WillReturnRows(rows)
 
 	rates, err := storage.GetAll(ctx)
@@ -115,10 +118,13 @@ func TestCurrencyPgsqlStorage_Update(t *testing.T) {
 	date := time.Now()
 
 	mock.ExpectExec(`INSERT INTO currencies`).
-		WithArgs("USD", "0.016", date).
+		WithArgs("USD", "0.016", date, date.Format("2006-01-02"), "cbr").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	err := storage.Update(ctx, entity.NewRate("USD", decimal.New(16, -3), date))
+	rate := entity.NewRate("USD", decimal.New(16, -3), date)
+	rate.SetSource("cbr")
+
+	err := storage.Update(ctx, rate)
 	assert.NoError(t, err)
 }
 
@@ -133,9 +139,104 @@ func TestCurrencyPgsqlStorage_UpdateError(t *testing.T) {
 	date := time.Now()
 
 	mock.ExpectExec(`INSERT INTO currencies`).
-		WithArgs("USD", "0.016", date).
+		WithArgs("USD", "0.016", date, date.Format("2006-01-02"), "cbr").
 		WillReturnError(errInternal)
 
-	err := storage.Update(ctx, entity.NewRate("USD", decimal.New(16, -3), date))
+	rate := entity.NewRate("USD", decimal.New(16, -3), date)
+	rate.SetSource("cbr")
+
+	err := storage.Update(ctx, rate)
+	assert.Error(t, err)
+}
+
+func TestCurrencyPgsqlStorage_UpdateHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO currency_rates`).
+		WithArgs("USD", "2022-11-10", "0.016", "file").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	rate := entity.NewRate("USD", decimal.New(16, -3), date)
+	rate.SetSource("file")
+
+	err := storage.UpdateHistory(ctx, rate)
+	assert.NoError(t, err)
+}
+
+func TestCurrencyPgsqlStorage_GetForDate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	day := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{"code", "ratio", "date", "source"}).
+		AddRow("USD", "0.016", day, "")
+
+	mock.ExpectQuery(`SELECT code, ratio, date, source FROM currency_rates`).
+		WithArgs("USD", "2022-11-10").
+		WillReturnRows(rows)
+
+	rate, err := storage.GetForDate(ctx, "USD", time.Date(2022, 11, 10, 18, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.Equal(t, entity.NewRate("USD", decimal.New(16, -3), day), rate)
+}
+
+func TestCurrencyPgsqlStorage_GetForDateNoHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"code", "ratio", "date", "source"})
+
+	mock.ExpectQuery(`SELECT code, ratio, date, source FROM currency_rates`).
+		WithArgs("USD", "2022-11-10").
+		WillReturnRows(rows)
+
+	_, err := storage.GetForDate(ctx, "USD", time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC))
 	assert.Error(t, err)
 }
+
+func TestCurrencyPgsqlStorage_GetRange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	day1 := time.Date(2022, 10, 28, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2022, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{"code", "ratio", "date", "source"}).
+		AddRow("USD", "0.016", day1, "").
+		AddRow("USD", "0.0165", day2, "")
+
+	mock.ExpectQuery(`SELECT code, ratio, date, source FROM currency_rates`).
+		WithArgs("USD", "2022-11-01", "2022-11-30").
+		WillReturnRows(rows)
+
+	rates, err := storage.GetRange(ctx, "USD", time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 11, 30, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.Equal(t,
+		[]entity.Rate{
+			entity.NewRate("USD", decimal.New(16, -3), day1),
+			entity.NewRate("USD", decimal.New(165, -4), day2),
+		}, rates)
+}
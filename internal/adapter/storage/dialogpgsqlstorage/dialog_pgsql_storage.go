@@ -0,0 +1,96 @@
+package dialogpgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type DialogPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *DialogPgsqlStorage {
+	return &DialogPgsqlStorage{conn: conn}
+}
+
+// Get возвращает диалог пользователя в чате chatID, если он есть и не истек к моменту now.
+func (s *DialogPgsqlStorage) Get(ctx context.Context, chatID int64, userID entity.UserID, now time.Time,
+) (entity.Dialog, bool, error) {
+	ctx, span := otel.Tracer("DialogPgsqlStorage").Start(ctx, "Get")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT name, answers, expires_at FROM dialogs WHERE chat_id = $1 AND user_id = $2 AND expires_at > $3`,
+		chatID, int64(userID), now)
+	if err != nil {
+		return entity.Dialog{}, false, errors.Wrap(err, "DialogPgsqlStorage.Get")
+	}
+
+	var (
+		name      string
+		answers   []string
+		expiresAt time.Time
+		found     bool
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&name, &answers, &expiresAt}, func() error {
+		found = true
+
+		return nil
+	})
+	if err != nil {
+		return entity.Dialog{}, false, errors.Wrap(err, "DialogPgsqlStorage.Get")
+	}
+
+	return entity.NewDialog(name, answers, expiresAt), found, nil
+}
+
+// Update начинает диалог или сохраняет новый ответ. У пользователя в каждом чате один диалог,
+// новый заменяет старый.
+func (s *DialogPgsqlStorage) Update(ctx context.Context, chatID int64, userID entity.UserID,
+	dialog entity.Dialog,
+) error {
+	ctx, span := otel.Tracer("DialogPgsqlStorage").Start(ctx, "Update")
+	defer span.End()
+
+	answers := dialog.GetAnswers()
+	if answers == nil {
+		answers = []string{}
+	}
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO dialogs (chat_id, user_id, name, answers, expires_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (chat_id, user_id) DO UPDATE SET name = $3, answers = $4, expires_at = $5`,
+		chatID, int64(userID), dialog.GetName(), answers, dialog.GetExpiresAt())
+
+	return errors.Wrap(err, "DialogPgsqlStorage.Update")
+}
+
+// Delete завершает диалог. Возвращает false, если незаконченного диалога не было.
+// Истекший диалог не удаляется, его перезапишет следующий.
+func (s *DialogPgsqlStorage) Delete(ctx context.Context, chatID int64, userID entity.UserID, now time.Time,
+) (bool, error) {
+	ctx, span := otel.Tracer("DialogPgsqlStorage").Start(ctx, "Delete")
+	defer span.End()
+
+	tag, err := s.conn.Exec(ctx,
+		`DELETE FROM dialogs WHERE chat_id = $1 AND user_id = $2 AND expires_at > $3`,
+		chatID, int64(userID), now)
+	if err != nil {
+		return false, errors.Wrap(err, "DialogPgsqlStorage.Delete")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
@@ -0,0 +1,111 @@
+package dialogpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/dialogpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*dialogpgsqlstorage.DialogPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := dialogpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestDialogPgsqlStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(10 * time.Minute)
+
+	rows := pgxmock.NewRows([]string{"name", "answers", "expires_at"}).
+		AddRow("addExpense", []string{"такси"}, expiresAt)
+
+	mock.ExpectQuery(`SELECT name, answers, expires_at FROM dialogs`).
+		WithArgs(int64(-200), int64(100), now).
+		WillReturnRows(rows)
+
+	dialog, found, err := storage.Get(ctx, -200, entity.UserID(100), now)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, entity.NewDialog("addExpense", []string{"такси"}, expiresAt), dialog)
+}
+
+func TestDialogPgsqlStorage_GetNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT name, answers, expires_at FROM dialogs`).
+		WithArgs(int64(100), int64(100), now).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "answers", "expires_at"}))
+
+	_, found, err := storage.Get(ctx, 100, entity.UserID(100), now)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestDialogPgsqlStorage_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	expiresAt := time.Date(2026, 10, 18, 12, 10, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO dialogs`).
+		WithArgs(int64(-200), int64(100), "addExpense", []string{}, expiresAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.Update(ctx, -200, entity.UserID(100), entity.NewDialog("addExpense", nil, expiresAt))
+	assert.NoError(t, err)
+}
+
+func TestDialogPgsqlStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM dialogs`).
+		WithArgs(int64(100), int64(100), now).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	found, err := storage.Delete(ctx, 100, entity.UserID(100), now)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
@@ -26,15 +26,57 @@ func New(conn PgxIface) *ExpensePgsqlStorage {
 	return &ExpensePgsqlStorage{conn: conn}
 }
 
-func (s *ExpensePgsqlStorage) Create(ctx context.Context, userID entity.UserID, expense entity.Expense) error {
+// Create добавляет расход. Расход с уже существующим у пользователя внешним идентификатором
+// повторно не добавляется, возвращается идентификатор ранее добавленного и false.
+func (s *ExpensePgsqlStorage) Create(ctx context.Context, userID entity.UserID, expense entity.Expense,
+) (entity.ExpenseID, bool, error) {
 	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "Create")
 	defer span.End()
 
-	_, err := s.conn.Exec(ctx,
-		`INSERT INTO expenses (user_id, category, price, time) VALUES ($1, $2, $3, $4)`,
-		int64(userID), expense.GetCategory(), expense.GetPrice().String(), expense.GetDate())
+	var (
+		id      int64
+		created bool
+	)
 
-	return errors.Wrap(err, "ExpensePgsqlStorage.Create")
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO expenses (user_id, category, price, time, original_price, original_currency, external_id,
+			member_id, note, account_id, account_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, 0), NULLIF($9, ''), NULLIF($10, 0),
+			NULLIF($11::NUMERIC, 0))
+		ON CONFLICT (user_id, external_id) DO UPDATE SET external_id = EXCLUDED.external_id
+		RETURNING id, xmax = 0`,
+		int64(userID), expense.GetCategory(), expense.GetPrice().String(), expense.GetDate(),
+		expense.GetOriginalPrice().String(), expense.GetOriginalCurrency(), expense.GetExternalID(),
+		int64(expense.GetMemberID()), expense.GetNote(), int64(expense.GetAccountID()),
+		expense.GetAccountAmount().String()).Scan(&id, &created)
+
+	return entity.ExpenseID(id), created, errors.Wrap(err, "ExpensePgsqlStorage.Create")
+}
+
+// GetExternalIDs возвращает те из внешних идентификаторов, расходы с которыми уже добавлены.
+func (s *ExpensePgsqlStorage) GetExternalIDs(ctx context.Context, userID entity.UserID, externalIDs []string,
+) ([]string, error) {
+	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "GetExternalIDs")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT external_id FROM expenses WHERE user_id = $1 AND external_id = ANY($2)`,
+		int64(userID), externalIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "ExpensePgsqlStorage.GetExternalIDs")
+	}
+
+	existing := make([]string, 0)
+
+	var externalID string
+
+	_, err = pgx.ForEachRow(rows, []any{&externalID}, func() error {
+		existing = append(existing, externalID)
+
+		return nil
+	})
+
+	return existing, errors.Wrap(err, "ExpensePgsqlStorage.GetExternalIDs")
 }
 
 func (s *ExpensePgsqlStorage) Get(ctx context.Context, userID entity.UserID, dateStart time.Time, dateEnd time.Time) (
@@ -44,7 +86,8 @@ func (s *ExpensePgsqlStorage) Get(ctx context.Context, userID entity.UserID, dat
 	defer span.End()
 
 	rows, err := s.conn.Query(ctx,
-		`SELECT category, price, time FROM expenses
+		`SELECT id, category, price, time,
+			COALESCE(original_price, price), COALESCE(original_currency, ''), COALESCE(member_id, 0) FROM expenses
 		WHERE user_id = $1 AND time >= $2 AND time < $3
 		ORDER BY category`,
 		int64(userID), dateStart, dateEnd)
@@ -55,22 +98,166 @@ func (s *ExpensePgsqlStorage) Get(ctx context.Context, userID entity.UserID, dat
 	expenses := make([]entity.Expense, 0, rows.CommandTag().RowsAffected())
 
 	var (
-		category string
-		priceStr string
-		date     time.Time
+		id                  int64
+		category            string
+		priceStr            string
+		date                time.Time
+		originalPriceStr    string
+		originalCurrencyStr string
+		memberID            int64
 	)
 
-	_, err = pgx.ForEachRow(rows, []any{&category, &priceStr, &date}, func() error {
-		price, err := decimal.NewFromString(priceStr)
+	_, err = pgx.ForEachRow(rows,
+		[]any{&id, &category, &priceStr, &date, &originalPriceStr, &originalCurrencyStr, &memberID},
+		func() error {
+			expense, err := newExpense(id, category, priceStr, date, originalPriceStr, originalCurrencyStr)
+			if err != nil {
+				return errors.Wrap(err, "ExpensePgsqlStorage.Get")
+			}
 
-		if err != nil {
-			return errors.Wrap(err, "ExpensePgsqlStorage.Get")
-		}
+			expense.SetMemberID(entity.UserID(memberID))
 
-		expenses = append(expenses, entity.NewExpense(category, price, date))
+			expenses = append(expenses, expense)
 
-		return nil
-	})
+			return nil
+		})
 
 	return expenses, errors.Wrap(err, "ExpensePgsqlStorage.Get")
 }
+
+// Search полнотекстовый поиск по заметкам и категориям расходов за период. Возвращает
+// не больше limit расходов, сначала более поздние.
+func (s *ExpensePgsqlStorage) Search(ctx context.Context, userID entity.UserID, query string, dateStart time.Time,
+	dateEnd time.Time, limit int,
+) ([]entity.Expense, error) {
+	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "Search")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT id, category, price, time,
+			COALESCE(original_price, price), COALESCE(original_currency, ''), COALESCE(member_id, 0),
+			COALESCE(note, '')
+		FROM expenses
+		WHERE user_id = $1 AND time >= $2 AND time < $3 AND search @@ plainto_tsquery('russian', $4)
+		ORDER BY time DESC, id DESC LIMIT $5`,
+		int64(userID), dateStart, dateEnd, query, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "ExpensePgsqlStorage.Search")
+	}
+
+	expenses := make([]entity.Expense, 0, rows.CommandTag().RowsAffected())
+
+	var (
+		id                  int64
+		category            string
+		priceStr            string
+		date                time.Time
+		originalPriceStr    string
+		originalCurrencyStr string
+		memberID            int64
+		note                string
+	)
+
+	_, err = pgx.ForEachRow(rows,
+		[]any{&id, &category, &priceStr, &date, &originalPriceStr, &originalCurrencyStr, &memberID, &note},
+		func() error {
+			expense, err := newExpense(id, category, priceStr, date, originalPriceStr, originalCurrencyStr)
+			if err != nil {
+				return errors.Wrap(err, "ExpensePgsqlStorage.Search")
+			}
+
+			expense.SetMemberID(entity.UserID(memberID))
+			expense.SetNote(note)
+
+			expenses = append(expenses, expense)
+
+			return nil
+		})
+
+	return expenses, errors.Wrap(err, "ExpensePgsqlStorage.Search")
+}
+
+// GetByID возвращает расход пользователя. Возвращает false, если расхода нет.
+func (s *ExpensePgsqlStorage) GetByID(ctx context.Context, userID entity.UserID, expenseID entity.ExpenseID) (
+	entity.Expense, bool, error,
+) {
+	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "GetByID")
+	defer span.End()
+
+	var (
+		category            string
+		priceStr            string
+		date                time.Time
+		originalPriceStr    string
+		originalCurrencyStr string
+		memberID            int64
+	)
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT category, price, time, COALESCE(original_price, price), COALESCE(original_currency, ''),
+			COALESCE(member_id, 0)
+		FROM expenses WHERE id = $1 AND user_id = $2`,
+		int64(expenseID), int64(userID)).
+		Scan(&category, &priceStr, &date, &originalPriceStr, &originalCurrencyStr, &memberID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Expense{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Expense{}, false, errors.Wrap(err, "ExpensePgsqlStorage.GetByID")
+	}
+
+	expense, err := newExpense(int64(expenseID), category, priceStr, date, originalPriceStr, originalCurrencyStr)
+	if err != nil {
+		return entity.Expense{}, false, errors.Wrap(err, "ExpensePgsqlStorage.GetByID")
+	}
+
+	expense.SetMemberID(entity.UserID(memberID))
+
+	return expense, true, nil
+}
+
+// Update исправляет расход. Сумма в валюте счета меняется пропорционально цене.
+func (s *ExpensePgsqlStorage) Update(ctx context.Context, userID entity.UserID, expense entity.Expense) error {
+	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "Update")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`UPDATE expenses SET category = $3, price = $4, original_price = $5, original_currency = $6,
+			account_amount = account_amount * $4 / price
+		WHERE id = $1 AND user_id = $2`,
+		int64(expense.GetID()), int64(userID), expense.GetCategory(), expense.GetPrice().String(),
+		expense.GetOriginalPrice().String(), expense.GetOriginalCurrency())
+
+	return errors.Wrap(err, "ExpensePgsqlStorage.Update")
+}
+
+func (s *ExpensePgsqlStorage) Delete(ctx context.Context, userID entity.UserID, expenseID entity.ExpenseID) error {
+	ctx, span := otel.Tracer("ExpensePgsqlStorage").Start(ctx, "Delete")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`DELETE FROM expenses WHERE id = $1 AND user_id = $2`,
+		int64(expenseID), int64(userID))
+
+	return errors.Wrap(err, "ExpensePgsqlStorage.Delete")
+}
+
+func newExpense(id int64, category, priceStr string, date time.Time, originalPriceStr, originalCurrency string,
+) (entity.Expense, error) {
+	price, err := decimal.NewFromString(priceStr)
+	if err != nil {
+		return entity.Expense{}, errors.Wrap(err, "newExpense")
+	}
+
+	originalPrice, err := decimal.NewFromString(originalPriceStr)
+	if err != nil {
+		return entity.Expense{}, errors.Wrap(err, "newExpense")
+	}
+
+	expense := entity.NewExpense(category, price, date)
+	expense.SetID(entity.ExpenseID(id))
+	expense.SetOriginalPrice(originalPrice, originalCurrency)
+
+	return expense, nil
+}
@@ -44,12 +44,39 @@ func TestExpensePgsqlStorage_Create(t *testing.T) {
 
 	date := time.Now()
 
-	mock.ExpectExec(`INSERT INTO expenses \(user_id, category, price, time\)`).
-		WithArgs(int64(100), "Macbook", "150350.56", date).
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(`INSERT INTO expenses \(user_id, category, price, time, original_price, original_currency, external_id,`).
+		WithArgs(int64(100), "Macbook", "150350.56", date, "150350.56", "", "", int64(0), "", int64(0), "0").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created"}).AddRow(int64(7), true))
 
-	err := storage.Create(ctx, entity.UserID(100), entity.NewExpense("Macbook", decimal.New(15035056, -2), date))
+	expenseID, created, err := storage.Create(ctx, entity.UserID(100),
+		entity.NewExpense("Macbook", decimal.New(15035056, -2), date))
 	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, entity.ExpenseID(7), expenseID)
+}
+
+func TestExpensePgsqlStorage_CreateExternal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Now()
+
+	expense := entity.NewExpense("аренда", decimal.New(30000, 0), date)
+	expense.SetExternalID("recurring_3_1790000000")
+
+	mock.ExpectQuery(`INSERT INTO expenses .* ON CONFLICT \(user_id, external_id\)`).
+		WithArgs(int64(100), "аренда", "30000", date, "30000", "", "recurring_3_1790000000", int64(0), "",
+			int64(0), "0").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created"}).AddRow(int64(7), false))
+
+	expenseID, created, err := storage.Create(ctx, entity.UserID(100), expense)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, entity.ExpenseID(7), expenseID)
 }
 
 func TestExpensePgsqlStorage_CreateError(t *testing.T) {
@@ -62,11 +89,11 @@ func TestExpensePgsqlStorage_CreateError(t *testing.T) {
 
 	date := time.Now()
 
-	mock.ExpectExec(`INSERT INTO expenses \(user_id, category, price, time\)`).
-		WithArgs(int64(100), "Macbook", "150350.56", date).
+	mock.ExpectQuery(`INSERT INTO expenses \(user_id, category, price, time, original_price, original_currency, external_id,`).
+		WithArgs(int64(100), "Macbook", "150350.56", date, "150350.56", "", "", int64(0), "", int64(0), "0").
 		WillReturnError(errInternal)
 
-	err := storage.Create(ctx, entity.UserID(100), entity.NewExpense("Macbook", decimal.New(15035056, -2), date))
+	_, _, err := storage.Create(ctx, entity.UserID(100), entity.NewExpense("Macbook", decimal.New(15035056, -2), date))
 	assert.Error(t, err)
 }
 
@@ -81,25 +108,180 @@ func TestExpensePgsqlStorage_GetLimits(t *testing.T) {
 	dateStart := time.Now()
 	dateEnd := dateStart.AddDate(0, 1, 0)
 
-	rows := pgxmock.NewRows([]string{"category", "price", "time"}).
-		AddRow("AppStore", "400", dateStart).
-		AddRow("AppStore", "315", dateStart).
-		AddRow("AWS", "2700", dateStart.AddDate(0, 0, 1)).
-		AddRow("Sport", "980", dateStart.AddDate(0, 0, 1)).
-		AddRow("AppStore", "900", dateStart.AddDate(0, 0, 2))
+	rows := pgxmock.NewRows(
+		[]string{"id", "category", "price", "time", "original_price", "original_currency", "member_id"}).
+		AddRow(int64(1), "AppStore", "400", dateStart, "400", "", int64(0)).
+		AddRow(int64(2), "AppStore", "315", dateStart, "315", "", int64(0)).
+		AddRow(int64(3), "AWS", "2700", dateStart.AddDate(0, 0, 1), "2700", "", int64(0)).
+		AddRow(int64(4), "Sport", "980", dateStart.AddDate(0, 0, 1), "980", "", int64(200)).
+		AddRow(int64(5), "AppStore", "900", dateStart.AddDate(0, 0, 2), "900", "", int64(0))
 
-	mock.ExpectQuery(`SELECT category, price, time FROM expenses`).
+	mock.ExpectQuery(`SELECT id, category, price, time,`).
 		WithArgs(int64(100), dateStart, dateEnd).
 		WillReturnRows(rows)
 
 	expenses, err := storage.Get(ctx, entity.UserID(100), dateStart, dateEnd)
 	assert.NoError(t, err)
 
+	memberExpense := newExpense(4, "Sport", decimal.New(980, 0), dateStart.AddDate(0, 0, 1))
+	memberExpense.SetMemberID(entity.UserID(200))
+
 	assert.Equal(t, []entity.Expense{
-		entity.NewExpense("AppStore", decimal.New(400, 0), dateStart),
-		entity.NewExpense("AppStore", decimal.New(315, 0), dateStart),
-		entity.NewExpense("AWS", decimal.New(2700, 0), dateStart.AddDate(0, 0, 1)),
-		entity.NewExpense("Sport", decimal.New(980, 0), dateStart.AddDate(0, 0, 1)),
-		entity.NewExpense("AppStore", decimal.New(900, 0), dateStart.AddDate(0, 0, 2)),
+		newExpense(1, "AppStore", decimal.New(400, 0), dateStart),
+		newExpense(2, "AppStore", decimal.New(315, 0), dateStart),
+		newExpense(3, "AWS", decimal.New(2700, 0), dateStart.AddDate(0, 0, 1)),
+		memberExpense,
+		newExpense(5, "AppStore", decimal.New(900, 0), dateStart.AddDate(0, 0, 2)),
 	}, expenses)
 }
+
+func TestExpensePgsqlStorage_Search(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	dateStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	dateEnd := dateStart.AddDate(0, 1, 0)
+	date := time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{
+		"id", "category", "price", "time", "original_price", "original_currency", "member_id", "note",
+	}).AddRow(int64(4), "еда", "500", date, "500", "", int64(0), "ужин с Петей")
+
+	mock.ExpectQuery(`SELECT .* FROM expenses\s+WHERE user_id = \$1 .* search @@ plainto_tsquery\('russian', \$4\)`).
+		WithArgs(int64(100), dateStart, dateEnd, "ужин", 20).
+		WillReturnRows(rows)
+
+	expenses, err := storage.Search(ctx, entity.UserID(100), "ужин", dateStart, dateEnd, 20)
+	assert.NoError(t, err)
+
+	expected := newExpense(4, "еда", decimal.New(500, 0), date)
+	expected.SetNote("ужин с Петей")
+
+	assert.Equal(t, []entity.Expense{expected}, expenses)
+}
+
+func TestExpensePgsqlStorage_GetByID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+	defer teardownSuite(t)
+
+	date := time.Now()
+
+	rows := pgxmock.NewRows([]string{"category", "price", "time", "original_price", "original_currency", "member_id"}).
+		AddRow("AppStore", "400", date, "5", "EUR", int64(0))
+
+	mock.ExpectQuery(`SELECT category, price, time, COALESCE\(original_price, price\)`).
+		WithArgs(int64(12), int64(100)).
+		WillReturnRows(rows)
+
+	expenseExpected := newExpense(12, "AppStore", decimal.New(400, 0), date)
+	expenseExpected.SetOriginalPrice(decimal.New(5, 0), "EUR")
+
+	expense, found, err := storage.GetByID(ctx, entity.UserID(100), entity.ExpenseID(12))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, expenseExpected, expense)
+}
+
+func TestExpensePgsqlStorage_GetByIDNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT category, price, time`).
+		WithArgs(int64(12), int64(100)).
+		WillReturnRows(pgxmock.NewRows(
+			[]string{"category", "price", "time", "original_price", "original_currency", "member_id"}))
+
+	_, found, err := storage.GetByID(ctx, entity.UserID(100), entity.ExpenseID(12))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestExpensePgsqlStorage_GetByIDError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT category, price, time`).
+		WithArgs(int64(12), int64(100)).
+		WillReturnError(errInternal)
+
+	_, found, err := storage.GetByID(ctx, entity.UserID(100), entity.ExpenseID(12))
+	assert.ErrorIs(t, err, errInternal)
+	assert.False(t, found)
+}
+
+func TestExpensePgsqlStorage_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`UPDATE expenses SET category = \$3, price = \$4`).
+		WithArgs(int64(12), int64(100), "Sport", "980", "12", "USD").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	expense := newExpense(12, "Sport", decimal.New(980, 0), time.Now())
+	expense.SetOriginalPrice(decimal.New(12, 0), "USD")
+
+	err := storage.Update(ctx, entity.UserID(100), expense)
+	assert.NoError(t, err)
+}
+
+func TestExpensePgsqlStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM expenses`).
+		WithArgs(int64(12), int64(100)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	err := storage.Delete(ctx, entity.UserID(100), entity.ExpenseID(12))
+	assert.NoError(t, err)
+}
+
+func newExpense(id int64, category string, price decimal.Decimal, date time.Time) entity.Expense {
+	expense := entity.NewExpense(category, price, date)
+	expense.SetID(entity.ExpenseID(id))
+
+	return expense
+}
+
+func TestExpensePgsqlStorage_GetExternalIDs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	externalIDs := []string{"import_1", "import_2", "import_3"}
+
+	mock.ExpectQuery(`SELECT external_id FROM expenses WHERE user_id = \$1 AND external_id = ANY\(\$2\)`).
+		WithArgs(int64(100), externalIDs).
+		WillReturnRows(pgxmock.NewRows([]string{"external_id"}).AddRow("import_2"))
+
+	existing, err := storage.GetExternalIDs(ctx, entity.UserID(100), externalIDs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"import_2"}, existing)
+}
@@ -0,0 +1,206 @@
+package goalpgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// goalColumns колонки цели вместе с накопленной суммой.
+const goalColumns = `g.id, g.user_id, g.name, g.target, g.currency, g.deadline, g.created_at,
+	COALESCE((SELECT SUM(amount) FROM goal_contributions WHERE goal_id = g.id), 0)`
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type GoalPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *GoalPgsqlStorage {
+	return &GoalPgsqlStorage{conn: conn}
+}
+
+// Create добавляет цель. Возвращает false, если у пользователя уже есть цель с таким именем.
+func (s *GoalPgsqlStorage) Create(ctx context.Context, goal entity.Goal) (entity.GoalID, bool, error) {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO goals (user_id, name, target, currency, deadline, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING id`,
+		int64(goal.GetUserID()), goal.GetName(), goal.GetTarget().String(), goal.GetCurrency(), goal.GetDeadline(),
+		goal.GetCreatedAt()).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, errors.Wrap(err, "GoalPgsqlStorage.Create")
+	}
+
+	return entity.GoalID(id), true, nil
+}
+
+// Update меняет сумму и срок цели. Напоминание после изменения отправляется заново.
+func (s *GoalPgsqlStorage) Update(ctx context.Context, id entity.GoalID, target decimal.Decimal, deadline time.Time,
+) error {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "Update")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`UPDATE goals SET target = $2, deadline = $3, nudged_at = NULL WHERE id = $1`,
+		int64(id), target.String(), deadline)
+
+	return errors.Wrap(err, "GoalPgsqlStorage.Update")
+}
+
+// GetByName возвращает цель с накопленной суммой. Возвращает false, если цели нет.
+func (s *GoalPgsqlStorage) GetByName(ctx context.Context, userID entity.UserID, name string,
+) (entity.Goal, bool, error) {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "GetByName")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT `+goalColumns+` FROM goals g WHERE g.user_id = $1 AND g.name = $2`,
+		int64(userID), name)
+	if err != nil {
+		return entity.Goal{}, false, errors.Wrap(err, "GoalPgsqlStorage.GetByName")
+	}
+
+	goals, err := scanGoals(rows)
+	if err != nil || len(goals) == 0 {
+		return entity.Goal{}, false, errors.Wrap(err, "GoalPgsqlStorage.GetByName")
+	}
+
+	return goals[0], true, nil
+}
+
+// GetAll возвращает цели пользователя с накопленными суммами в порядке сроков.
+func (s *GoalPgsqlStorage) GetAll(ctx context.Context, userID entity.UserID) ([]entity.Goal, error) {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "GetAll")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT `+goalColumns+` FROM goals g WHERE g.user_id = $1 ORDER BY g.deadline, g.name`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "GoalPgsqlStorage.GetAll")
+	}
+
+	goals, err := scanGoals(rows)
+
+	return goals, errors.Wrap(err, "GoalPgsqlStorage.GetAll")
+}
+
+// GetForNudge возвращает недостигнутые цели всех пользователей со сроком после now,
+// напоминание о которых не отправлялось с момента since.
+func (s *GoalPgsqlStorage) GetForNudge(ctx context.Context, since, now time.Time) ([]entity.Goal, error) {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "GetForNudge")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT * FROM (SELECT `+goalColumns+` AS saved FROM goals g
+			WHERE (g.nudged_at IS NULL OR g.nudged_at < $1) AND g.deadline > $2) goals
+		WHERE saved < target
+		ORDER BY id`,
+		since, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "GoalPgsqlStorage.GetForNudge")
+	}
+
+	goals, err := scanGoals(rows)
+
+	return goals, errors.Wrap(err, "GoalPgsqlStorage.GetForNudge")
+}
+
+func (s *GoalPgsqlStorage) MarkNudged(ctx context.Context, id entity.GoalID, date time.Time) error {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "MarkNudged")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`UPDATE goals SET nudged_at = $2 WHERE id = $1`,
+		int64(id), date)
+
+	return errors.Wrap(err, "GoalPgsqlStorage.MarkNudged")
+}
+
+// Delete удаляет цель вместе со взносами. Возвращает false, если цели не было.
+func (s *GoalPgsqlStorage) Delete(ctx context.Context, userID entity.UserID, name string) (bool, error) {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "Delete")
+	defer span.End()
+
+	tag, err := s.conn.Exec(ctx,
+		`DELETE FROM goals WHERE user_id = $1 AND name = $2`,
+		int64(userID), name)
+	if err != nil {
+		return false, errors.Wrap(err, "GoalPgsqlStorage.Delete")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+// AddContribution добавляет взнос в валюте цели, отрицательный взнос уменьшает накопленное.
+func (s *GoalPgsqlStorage) AddContribution(ctx context.Context, id entity.GoalID, amount decimal.Decimal,
+	date time.Time,
+) error {
+	ctx, span := otel.Tracer("GoalPgsqlStorage").Start(ctx, "AddContribution")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO goal_contributions (goal_id, amount, time) VALUES ($1, $2, $3)`,
+		int64(id), amount.String(), date)
+
+	return errors.Wrap(err, "GoalPgsqlStorage.AddContribution")
+}
+
+func scanGoals(rows pgx.Rows) ([]entity.Goal, error) {
+	goals := make([]entity.Goal, 0)
+
+	var (
+		id        int64
+		userID    int64
+		name      string
+		targetStr string
+		currency  string
+		deadline  time.Time
+		createdAt time.Time
+		savedStr  string
+	)
+
+	_, err := pgx.ForEachRow(rows,
+		[]any{&id, &userID, &name, &targetStr, &currency, &deadline, &createdAt, &savedStr},
+		func() error {
+			target, err := decimal.NewFromString(targetStr)
+			if err != nil {
+				return errors.Wrap(err, "scanGoals")
+			}
+
+			saved, err := decimal.NewFromString(savedStr)
+			if err != nil {
+				return errors.Wrap(err, "scanGoals")
+			}
+
+			goal := entity.NewGoal(entity.UserID(userID), name, target, currency, deadline, createdAt)
+			goal.SetID(entity.GoalID(id))
+			goal.SetSaved(saved)
+
+			goals = append(goals, goal)
+
+			return nil
+		})
+
+	return goals, errors.Wrap(err, "scanGoals")
+}
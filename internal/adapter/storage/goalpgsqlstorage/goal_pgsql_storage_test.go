@@ -0,0 +1,201 @@
+package goalpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/goalpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+var goalColumns = []string{"id", "user_id", "name", "target", "currency", "deadline", "created_at", "saved"}
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*goalpgsqlstorage.GoalPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := goalpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestGoalPgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	deadline := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO goals .* ON CONFLICT \(user_id, name\) DO NOTHING`).
+		WithArgs(int64(100), "отпуск", "150000", "RUB", deadline, now).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(3)))
+
+	id, created, err := storage.Create(ctx, entity.NewGoal(100, "отпуск", decimal.New(150000, 0), "RUB", deadline, now))
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, entity.GoalID(3), id)
+}
+
+func TestGoalPgsqlStorage_CreateExisting(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	deadline := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO goals`).
+		WithArgs(int64(100), "отпуск", "150000", "RUB", deadline, now).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, created, err := storage.Create(ctx, entity.NewGoal(100, "отпуск", decimal.New(150000, 0), "RUB", deadline, now))
+	assert.NoError(t, err)
+	assert.False(t, created)
+}
+
+func TestGoalPgsqlStorage_GetByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	deadline := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT .* FROM goals g WHERE g.user_id = \$1 AND g.name = \$2`).
+		WithArgs(int64(100), "отпуск").
+		WillReturnRows(pgxmock.NewRows(goalColumns).
+			AddRow(int64(3), int64(100), "отпуск", "150000", "RUB", deadline, createdAt, "25000.5"))
+
+	goal, found, err := storage.GetByName(ctx, 100, "отпуск")
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	expected := entity.NewGoal(100, "отпуск", decimal.New(150000, 0), "RUB", deadline, createdAt)
+	expected.SetID(3)
+	expected.SetSaved(decimal.New(250005, -1))
+
+	assert.Equal(t, expected, goal)
+}
+
+func TestGoalPgsqlStorage_GetByNameNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectQuery(`SELECT .* FROM goals g WHERE g.user_id = \$1 AND g.name = \$2`).
+		WithArgs(int64(100), "машина").
+		WillReturnRows(pgxmock.NewRows(goalColumns))
+
+	_, found, err := storage.GetByName(ctx, 100, "машина")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestGoalPgsqlStorage_GetForNudge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT .* FROM goals g\s+WHERE \(g.nudged_at IS NULL OR g.nudged_at < \$1\)`+
+		` AND g.deadline > \$2\) goals\s+WHERE saved < target`).
+		WithArgs(since, now).
+		WillReturnRows(pgxmock.NewRows(goalColumns).
+			AddRow(int64(3), int64(100), "отпуск", "150000", "RUB", deadline, since, "0"))
+
+	goals, err := storage.GetForNudge(ctx, since, now)
+	assert.NoError(t, err)
+
+	expected := entity.NewGoal(100, "отпуск", decimal.New(150000, 0), "RUB", deadline, since)
+	expected.SetID(3)
+	expected.SetSaved(decimal.New(0, 0))
+
+	assert.Equal(t, []entity.Goal{expected}, goals)
+}
+
+func TestGoalPgsqlStorage_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	deadline := time.Date(2027, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`UPDATE goals SET target = \$2, deadline = \$3, nudged_at = NULL WHERE id = \$1`).
+		WithArgs(int64(3), "200000", deadline).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := storage.Update(ctx, 3, decimal.New(200000, 0), deadline)
+	assert.NoError(t, err)
+}
+
+func TestGoalPgsqlStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM goals WHERE user_id = \$1 AND name = \$2`).
+		WithArgs(int64(100), "отпуск").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	found, err := storage.Delete(ctx, 100, "отпуск")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestGoalPgsqlStorage_AddContribution(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO goal_contributions \(goal_id, amount, time\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs(int64(3), "-5000", date).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.AddContribution(ctx, 3, decimal.New(-5000, 0), date)
+	assert.NoError(t, err)
+}
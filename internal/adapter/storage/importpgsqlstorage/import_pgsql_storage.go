@@ -0,0 +1,123 @@
+package importpgsqlstorage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type ImportPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *ImportPgsqlStorage {
+	return &ImportPgsqlStorage{conn: conn}
+}
+
+// GetColumns возвращает колонки выписки. Если пользователь их не задавал, все номера нулевые.
+func (s *ImportPgsqlStorage) GetColumns(ctx context.Context, userID entity.UserID) (entity.ImportColumns, error) {
+	ctx, span := otel.Tracer("ImportPgsqlStorage").Start(ctx, "GetColumns")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT date_column, amount_column, description_column, currency_column
+		FROM import_columns WHERE user_id = $1`,
+		int64(userID))
+	if err != nil {
+		return entity.ImportColumns{}, errors.Wrap(err, "ImportPgsqlStorage.GetColumns")
+	}
+
+	var date, amount, description, currency int
+
+	_, err = pgx.ForEachRow(rows, []any{&date, &amount, &description, &currency}, func() error {
+		return nil
+	})
+
+	return entity.NewImportColumns(date, amount, description, currency),
+		errors.Wrap(err, "ImportPgsqlStorage.GetColumns")
+}
+
+// UpdateColumns задает колонки выписки. Нулевая колонка даты возвращает определение по заголовку.
+func (s *ImportPgsqlStorage) UpdateColumns(ctx context.Context, userID entity.UserID, columns entity.ImportColumns,
+) error {
+	ctx, span := otel.Tracer("ImportPgsqlStorage").Start(ctx, "UpdateColumns")
+	defer span.End()
+
+	if columns.GetDate() == 0 {
+		_, err := s.conn.Exec(ctx,
+			`DELETE FROM import_columns WHERE user_id = $1`,
+			int64(userID))
+
+		return errors.Wrap(err, "ImportPgsqlStorage.UpdateColumns")
+	}
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO import_columns (user_id, date_column, amount_column, description_column, currency_column)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET date_column = $2, amount_column = $3,
+			description_column = $4, currency_column = $5`,
+		int64(userID), columns.GetDate(), columns.GetAmount(), columns.GetDescription(), columns.GetCurrency())
+
+	return errors.Wrap(err, "ImportPgsqlStorage.UpdateColumns")
+}
+
+func (s *ImportPgsqlStorage) GetRules(ctx context.Context, userID entity.UserID) ([]entity.ImportRule, error) {
+	ctx, span := otel.Tracer("ImportPgsqlStorage").Start(ctx, "GetRules")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT keyword, category FROM import_rules WHERE user_id = $1 ORDER BY keyword`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "ImportPgsqlStorage.GetRules")
+	}
+
+	rules := make([]entity.ImportRule, 0)
+
+	var keyword, category string
+
+	_, err = pgx.ForEachRow(rows, []any{&keyword, &category}, func() error {
+		rules = append(rules, entity.NewImportRule(keyword, category))
+
+		return nil
+	})
+
+	return rules, errors.Wrap(err, "ImportPgsqlStorage.GetRules")
+}
+
+func (s *ImportPgsqlStorage) UpdateRule(ctx context.Context, userID entity.UserID, rule entity.ImportRule) error {
+	ctx, span := otel.Tracer("ImportPgsqlStorage").Start(ctx, "UpdateRule")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO import_rules (user_id, keyword, category) VALUES ($1, lower($2), $3)
+		ON CONFLICT (user_id, keyword) DO UPDATE SET category = $3`,
+		int64(userID), rule.GetKeyword(), rule.GetCategory())
+
+	return errors.Wrap(err, "ImportPgsqlStorage.UpdateRule")
+}
+
+// DeleteRule удаляет правило. Возвращает false, если его не было.
+func (s *ImportPgsqlStorage) DeleteRule(ctx context.Context, userID entity.UserID, keyword string) (bool, error) {
+	ctx, span := otel.Tracer("ImportPgsqlStorage").Start(ctx, "DeleteRule")
+	defer span.End()
+
+	tag, err := s.conn.Exec(ctx,
+		`DELETE FROM import_rules WHERE user_id = $1 AND keyword = lower($2)`,
+		int64(userID), keyword)
+	if err != nil {
+		return false, errors.Wrap(err, "ImportPgsqlStorage.DeleteRule")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
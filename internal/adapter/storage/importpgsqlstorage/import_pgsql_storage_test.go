@@ -0,0 +1,161 @@
+package importpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/importpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*importpgsqlstorage.ImportPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := importpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestImportPgsqlStorage_GetColumns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"date_column", "amount_column", "description_column", "currency_column"}).
+		AddRow(1, 4, 3, 0)
+
+	mock.ExpectQuery(`SELECT date_column, amount_column, description_column, currency_column`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	columns, err := storage.GetColumns(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewImportColumns(1, 4, 3, 0), columns)
+}
+
+func TestImportPgsqlStorage_GetColumnsNotSet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"date_column", "amount_column", "description_column", "currency_column"})
+
+	mock.ExpectQuery(`SELECT date_column, amount_column, description_column, currency_column`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	columns, err := storage.GetColumns(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NewImportColumns(0, 0, 0, 0), columns)
+}
+
+func TestImportPgsqlStorage_UpdateColumns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`INSERT INTO import_columns`).
+		WithArgs(int64(100), 1, 4, 3, 5).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.UpdateColumns(ctx, entity.UserID(100), entity.NewImportColumns(1, 4, 3, 5))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportPgsqlStorage_UpdateColumnsReset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM import_columns`).
+		WithArgs(int64(100)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	err := storage.UpdateColumns(ctx, entity.UserID(100), entity.NewImportColumns(0, 0, 0, 0))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportPgsqlStorage_GetRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"keyword", "category"}).
+		AddRow("пятерочка", "продукты").
+		AddRow("яндекс такси", "такси")
+
+	mock.ExpectQuery(`SELECT keyword, category FROM import_rules`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	rules, err := storage.GetRules(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ImportRule{
+		entity.NewImportRule("пятерочка", "продукты"),
+		entity.NewImportRule("яндекс такси", "такси"),
+	}, rules)
+}
+
+func TestImportPgsqlStorage_UpdateRule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`INSERT INTO import_rules`).
+		WithArgs(int64(100), "Пятерочка", "продукты").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.UpdateRule(ctx, entity.UserID(100), entity.NewImportRule("Пятерочка", "продукты"))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportPgsqlStorage_DeleteRule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM import_rules`).
+		WithArgs(int64(100), "пятерочка").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	deleted, err := storage.DeleteRule(ctx, entity.UserID(100), "пятерочка")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+}
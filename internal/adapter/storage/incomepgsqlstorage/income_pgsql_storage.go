@@ -0,0 +1,100 @@
+package incomepgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type IncomePgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *IncomePgsqlStorage {
+	return &IncomePgsqlStorage{conn: conn}
+}
+
+func (s *IncomePgsqlStorage) Create(ctx context.Context, userID entity.UserID, income entity.Income,
+) (entity.IncomeID, error) {
+	ctx, span := otel.Tracer("IncomePgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO incomes (user_id, source, amount, time, original_amount, original_currency, member_id,
+			account_id, account_amount)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, 0), NULLIF($9::NUMERIC, 0))
+		RETURNING id`,
+		int64(userID), income.GetSource(), income.GetAmount().String(), income.GetDate(),
+		income.GetOriginalAmount().String(), income.GetOriginalCurrency(), int64(income.GetMemberID()),
+		int64(income.GetAccountID()), income.GetAccountAmount().String()).Scan(&id)
+
+	return entity.IncomeID(id), errors.Wrap(err, "IncomePgsqlStorage.Create")
+}
+
+// Get возвращает доходы пользователя за период [dateStart, dateEnd).
+func (s *IncomePgsqlStorage) Get(ctx context.Context, userID entity.UserID, dateStart time.Time, dateEnd time.Time,
+) ([]entity.Income, error) {
+	ctx, span := otel.Tracer("IncomePgsqlStorage").Start(ctx, "Get")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT id, source, amount, time, original_amount, COALESCE(original_currency, ''), COALESCE(member_id, 0)
+		FROM incomes
+		WHERE user_id = $1 AND time >= $2 AND time < $3
+		ORDER BY source`,
+		int64(userID), dateStart, dateEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "IncomePgsqlStorage.Get")
+	}
+
+	incomes := make([]entity.Income, 0, rows.CommandTag().RowsAffected())
+
+	var (
+		id                int64
+		source            string
+		amountStr         string
+		date              time.Time
+		originalAmountStr string
+		originalCurrency  string
+		memberID          int64
+	)
+
+	_, err = pgx.ForEachRow(rows,
+		[]any{&id, &source, &amountStr, &date, &originalAmountStr, &originalCurrency, &memberID},
+		func() error {
+			amount, err := decimal.NewFromString(amountStr)
+			if err != nil {
+				return errors.Wrap(err, "IncomePgsqlStorage.Get")
+			}
+
+			originalAmount, err := decimal.NewFromString(originalAmountStr)
+			if err != nil {
+				return errors.Wrap(err, "IncomePgsqlStorage.Get")
+			}
+
+			income := entity.NewIncome(source, amount, date)
+			income.SetID(entity.IncomeID(id))
+			income.SetOriginalAmount(originalAmount, originalCurrency)
+			income.SetMemberID(entity.UserID(memberID))
+
+			incomes = append(incomes, income)
+
+			return nil
+		})
+
+	return incomes, errors.Wrap(err, "IncomePgsqlStorage.Get")
+}
@@ -0,0 +1,112 @@
+package incomepgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/incomepgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+var errInternal = errors.New("internal error")
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*incomepgsqlstorage.IncomePgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := incomepgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestIncomePgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	income := entity.NewIncome("зарплата", decimal.New(1000, 0), date)
+	income.SetOriginalAmount(decimal.New(10, 0), "USD")
+	income.SetAccount(2, decimal.New(10, 0))
+
+	mock.ExpectQuery(`INSERT INTO incomes`).
+		WithArgs(int64(100), "зарплата", "1000", date, "10", "USD", int64(0), int64(2), "10").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(3)))
+
+	id, err := storage.Create(ctx, entity.UserID(100), income)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.IncomeID(3), id)
+}
+
+func TestIncomePgsqlStorage_CreateError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO incomes`).
+		WithArgs(int64(100), "зарплата", "1000", date, "1000", "", int64(0), int64(0), "0").
+		WillReturnError(errInternal)
+
+	_, err := storage.Create(ctx, entity.UserID(100), entity.NewIncome("зарплата", decimal.New(1000, 0), date))
+	assert.Error(t, err)
+}
+
+func TestIncomePgsqlStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	dateStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	dateEnd := dateStart.AddDate(0, 1, 0)
+	date := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{
+		"id", "source", "amount", "time", "original_amount", "original_currency", "member_id",
+	}).
+		AddRow(int64(1), "зарплата", "100000", date, "100000", "", int64(0)).
+		AddRow(int64(2), "фриланс", "5000", date, "50", "USD", int64(200))
+
+	mock.ExpectQuery(`SELECT .* FROM incomes\s+WHERE user_id = \$1 AND time >= \$2 AND time < \$3`).
+		WithArgs(int64(100), dateStart, dateEnd).
+		WillReturnRows(rows)
+
+	incomes, err := storage.Get(ctx, entity.UserID(100), dateStart, dateEnd)
+	assert.NoError(t, err)
+
+	salary := entity.NewIncome("зарплата", decimal.New(100000, 0), date)
+	salary.SetID(1)
+
+	freelance := entity.NewIncome("фриланс", decimal.New(5000, 0), date)
+	freelance.SetID(2)
+	freelance.SetOriginalAmount(decimal.New(50, 0), "USD")
+	freelance.SetMemberID(200)
+
+	assert.Equal(t, []entity.Income{salary, freelance}, incomes)
+}
@@ -0,0 +1,128 @@
+package limitpgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type LimitPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *LimitPgsqlStorage {
+	return &LimitPgsqlStorage{conn: conn}
+}
+
+func (s *LimitPgsqlStorage) GetAll(ctx context.Context, userID entity.UserID) ([]entity.Limit, error) {
+	ctx, span := otel.Tracer("LimitPgsqlStorage").Start(ctx, "GetAll")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT category, interval_type, amount FROM limits WHERE user_id = $1
+		ORDER BY category, interval_type`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "LimitPgsqlStorage.GetAll")
+	}
+
+	limits := make([]entity.Limit, 0, rows.CommandTag().RowsAffected())
+
+	var (
+		category     string
+		intervalType int
+		amountStr    string
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&category, &intervalType, &amountStr}, func() error {
+		amount, err := decimal.NewFromString(amountStr)
+		if err != nil {
+			return errors.Wrap(err, "LimitPgsqlStorage.GetAll")
+		}
+
+		limits = append(limits, entity.NewLimit(category, intervalType, amount))
+
+		return nil
+	})
+
+	return limits, errors.Wrap(err, "LimitPgsqlStorage.GetAll")
+}
+
+// Update задает лимит. Нулевой лимит удаляется.
+func (s *LimitPgsqlStorage) Update(ctx context.Context, userID entity.UserID, limit entity.Limit) error {
+	ctx, span := otel.Tracer("LimitPgsqlStorage").Start(ctx, "Update")
+	defer span.End()
+
+	if !limit.GetAmount().IsPositive() {
+		_, err := s.conn.Exec(ctx,
+			`DELETE FROM limits WHERE user_id = $1 AND category = $2 AND interval_type = $3`,
+			int64(userID), limit.GetCategory(), limit.GetIntervalType())
+
+		return errors.Wrap(err, "LimitPgsqlStorage.Update")
+	}
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO limits (user_id, category, interval_type, amount) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, category, interval_type) DO UPDATE SET amount = $4`,
+		int64(userID), limit.GetCategory(), limit.GetIntervalType(), limit.GetAmount().String())
+
+	return errors.Wrap(err, "LimitPgsqlStorage.Update")
+}
+
+// GetNotified возвращает пороги лимита, уведомления о которых за интервал,
+// начинающийся с intervalStart, уже отправлены.
+func (s *LimitPgsqlStorage) GetNotified(ctx context.Context, userID entity.UserID, limit entity.Limit,
+	intervalStart time.Time,
+) ([]int, error) {
+	ctx, span := otel.Tracer("LimitPgsqlStorage").Start(ctx, "GetNotified")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT threshold FROM limit_notifications
+		WHERE user_id = $1 AND category = $2 AND interval_type = $3 AND interval_start = $4`,
+		int64(userID), limit.GetCategory(), limit.GetIntervalType(), intervalStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "LimitPgsqlStorage.GetNotified")
+	}
+
+	var (
+		thresholds []int
+		threshold  int
+	)
+
+	_, err = pgx.ForEachRow(rows, []any{&threshold}, func() error {
+		thresholds = append(thresholds, threshold)
+
+		return nil
+	})
+
+	return thresholds, errors.Wrap(err, "LimitPgsqlStorage.GetNotified")
+}
+
+// MarkNotified отмечает, что уведомление о пороге threshold для лимита за интервал,
+// начинающийся с intervalStart, отправлено.
+func (s *LimitPgsqlStorage) MarkNotified(ctx context.Context, userID entity.UserID, limit entity.Limit,
+	intervalStart time.Time, threshold int,
+) error {
+	ctx, span := otel.Tracer("LimitPgsqlStorage").Start(ctx, "MarkNotified")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO limit_notifications (user_id, category, interval_type, interval_start, threshold)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+		int64(userID), limit.GetCategory(), limit.GetIntervalType(), intervalStart, threshold)
+
+	return errors.Wrap(err, "LimitPgsqlStorage.MarkNotified")
+}
@@ -0,0 +1,138 @@
+package limitpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/limitpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*limitpgsqlstorage.LimitPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := limitpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestLimitPgsqlStorage_GetAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	rows := pgxmock.NewRows([]string{"category", "interval_type", "amount"}).
+		AddRow("", 1, "1000").
+		AddRow("кафе", 3, "10000")
+
+	mock.ExpectQuery(`SELECT category, interval_type, amount FROM limits`).
+		WithArgs(int64(100)).
+		WillReturnRows(rows)
+
+	limits, err := storage.GetAll(ctx, entity.UserID(100))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []entity.Limit{
+		entity.NewLimit("", 1, decimal.RequireFromString("1000")),
+		entity.NewLimit("кафе", 3, decimal.RequireFromString("10000")),
+	}, limits)
+}
+
+func TestLimitPgsqlStorage_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`INSERT INTO limits`).
+		WithArgs(int64(100), "кафе", 3, "123.45").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.Update(ctx, entity.UserID(100), entity.NewLimit("кафе", 3, decimal.New(12345, -2)))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLimitPgsqlStorage_UpdateZero(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM limits`).
+		WithArgs(int64(100), "", 1).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	err := storage.Update(ctx, entity.UserID(100), entity.NewLimit("", 1, decimal.Zero))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLimitPgsqlStorage_GetNotified(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	intervalStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	limit := entity.NewLimit("кафе", 3, decimal.New(10000, 0))
+
+	rows := pgxmock.NewRows([]string{"threshold"}).
+		AddRow(80).
+		AddRow(100)
+
+	mock.ExpectQuery(`SELECT threshold FROM limit_notifications`).
+		WithArgs(int64(100), "кафе", 3, intervalStart).
+		WillReturnRows(rows)
+
+	thresholds, err := storage.GetNotified(ctx, entity.UserID(100), limit, intervalStart)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{80, 100}, thresholds)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLimitPgsqlStorage_MarkNotified(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	intervalStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	limit := entity.NewLimit("кафе", 3, decimal.New(10000, 0))
+
+	mock.ExpectExec(`INSERT INTO limit_notifications`).
+		WithArgs(int64(100), "кафе", 3, intervalStart, 80).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := storage.MarkNotified(ctx, entity.UserID(100), limit, intervalStart, 80)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
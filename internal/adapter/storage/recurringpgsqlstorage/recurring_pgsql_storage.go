@@ -0,0 +1,141 @@
+package recurringpgsqlstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+type PgxIface interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+}
+
+type RecurringPgsqlStorage struct {
+	conn PgxIface
+}
+
+func New(conn PgxIface) *RecurringPgsqlStorage {
+	return &RecurringPgsqlStorage{conn: conn}
+}
+
+func (s *RecurringPgsqlStorage) Create(ctx context.Context, recurring entity.RecurringExpense,
+) (entity.RecurringExpenseID, error) {
+	ctx, span := otel.Tracer("RecurringPgsqlStorage").Start(ctx, "Create")
+	defer span.End()
+
+	var id int64
+
+	err := s.conn.QueryRow(ctx,
+		`INSERT INTO recurring_expenses (user_id, category, price, currency, interval_type, start_date, next_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		int64(recurring.GetUserID()), recurring.GetCategory(), recurring.GetPrice().String(), recurring.GetCurrency(),
+		recurring.GetIntervalType(), recurring.GetStartDate(), recurring.GetNextDate()).Scan(&id)
+
+	return entity.RecurringExpenseID(id), errors.Wrap(err, "RecurringPgsqlStorage.Create")
+}
+
+func (s *RecurringPgsqlStorage) GetAll(ctx context.Context, userID entity.UserID) ([]entity.RecurringExpense, error) {
+	ctx, span := otel.Tracer("RecurringPgsqlStorage").Start(ctx, "GetAll")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT id, user_id, category, price, currency, interval_type, start_date, next_date
+		FROM recurring_expenses WHERE user_id = $1 ORDER BY id`,
+		int64(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "RecurringPgsqlStorage.GetAll")
+	}
+
+	recurrings, err := scanRecurringExpenses(rows)
+
+	return recurrings, errors.Wrap(err, "RecurringPgsqlStorage.GetAll")
+}
+
+// GetDue возвращает регулярные расходы всех пользователей, дата которых наступила к date.
+func (s *RecurringPgsqlStorage) GetDue(ctx context.Context, date time.Time) ([]entity.RecurringExpense, error) {
+	ctx, span := otel.Tracer("RecurringPgsqlStorage").Start(ctx, "GetDue")
+	defer span.End()
+
+	rows, err := s.conn.Query(ctx,
+		`SELECT id, user_id, category, price, currency, interval_type, start_date, next_date
+		FROM recurring_expenses WHERE next_date <= $1 ORDER BY next_date`,
+		date)
+	if err != nil {
+		return nil, errors.Wrap(err, "RecurringPgsqlStorage.GetDue")
+	}
+
+	recurrings, err := scanRecurringExpenses(rows)
+
+	return recurrings, errors.Wrap(err, "RecurringPgsqlStorage.GetDue")
+}
+
+func (s *RecurringPgsqlStorage) UpdateNextDate(ctx context.Context, id entity.RecurringExpenseID, date time.Time,
+) error {
+	ctx, span := otel.Tracer("RecurringPgsqlStorage").Start(ctx, "UpdateNextDate")
+	defer span.End()
+
+	_, err := s.conn.Exec(ctx,
+		`UPDATE recurring_expenses SET next_date = $2 WHERE id = $1`,
+		int64(id), date)
+
+	return errors.Wrap(err, "RecurringPgsqlStorage.UpdateNextDate")
+}
+
+// Delete удаляет регулярный расход пользователя. Возвращает false, если его не было.
+func (s *RecurringPgsqlStorage) Delete(ctx context.Context, userID entity.UserID, id entity.RecurringExpenseID,
+) (bool, error) {
+	ctx, span := otel.Tracer("RecurringPgsqlStorage").Start(ctx, "Delete")
+	defer span.End()
+
+	tag, err := s.conn.Exec(ctx,
+		`DELETE FROM recurring_expenses WHERE id = $1 AND user_id = $2`,
+		int64(id), int64(userID))
+	if err != nil {
+		return false, errors.Wrap(err, "RecurringPgsqlStorage.Delete")
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+func scanRecurringExpenses(rows pgx.Rows) ([]entity.RecurringExpense, error) {
+	recurrings := make([]entity.RecurringExpense, 0)
+
+	var (
+		id           int64
+		userID       int64
+		category     string
+		priceStr     string
+		currency     string
+		intervalType int
+		startDate    time.Time
+		nextDate     time.Time
+	)
+
+	_, err := pgx.ForEachRow(rows,
+		[]any{&id, &userID, &category, &priceStr, &currency, &intervalType, &startDate, &nextDate},
+		func() error {
+			price, err := decimal.NewFromString(priceStr)
+			if err != nil {
+				return errors.Wrap(err, "scanRecurringExpenses")
+			}
+
+			recurring := entity.NewRecurringExpense(entity.UserID(userID), category, price, currency,
+				intervalType, startDate)
+			recurring.SetID(entity.RecurringExpenseID(id))
+			recurring.SetNextDate(nextDate)
+
+			recurrings = append(recurrings, recurring)
+
+			return nil
+		})
+
+	return recurrings, errors.Wrap(err, "scanRecurringExpenses")
+}
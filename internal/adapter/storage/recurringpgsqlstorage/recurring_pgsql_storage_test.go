@@ -0,0 +1,98 @@
+package recurringpgsqlstorage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/recurringpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+)
+
+func setupSuite(ctx context.Context, tb testing.TB) (
+	*recurringpgsqlstorage.RecurringPgsqlStorage, pgxmock.PgxConnIface, func(tb testing.TB),
+) {
+	tb.Helper()
+
+	mock, err := pgxmock.NewConn()
+	assert.NoError(tb, err)
+
+	storage := recurringpgsqlstorage.New(mock)
+
+	cls := func(tb testing.TB) {
+		tb.Helper()
+
+		mock.Close(ctx)
+	}
+
+	return storage, mock, cls
+}
+
+func TestRecurringPgsqlStorage_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	date := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO recurring_expenses`).
+		WithArgs(int64(100), "аренда", "30000", "RUB", 3, date, date).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(3)))
+
+	id, err := storage.Create(ctx, entity.NewRecurringExpense(100, "аренда", decimal.New(30000, 0), "RUB", 3, date))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecurringExpenseID(3), id)
+}
+
+func TestRecurringPgsqlStorage_GetDue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	now := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	startDate := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	nextDate := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{
+		"id", "user_id", "category", "price", "currency", "interval_type", "start_date", "next_date",
+	}).AddRow(int64(3), int64(100), "аренда", "30000", "RUB", 3, startDate, nextDate)
+
+	mock.ExpectQuery(`SELECT .* FROM recurring_expenses WHERE next_date <= \$1`).
+		WithArgs(now).
+		WillReturnRows(rows)
+
+	recurrings, err := storage.GetDue(ctx, now)
+	assert.NoError(t, err)
+
+	expected := entity.NewRecurringExpense(100, "аренда", decimal.RequireFromString("30000"), "RUB", 3, startDate)
+	expected.SetID(3)
+	expected.SetNextDate(nextDate)
+
+	assert.Equal(t, []entity.RecurringExpense{expected}, recurrings)
+}
+
+func TestRecurringPgsqlStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	mock.ExpectExec(`DELETE FROM recurring_expenses`).
+		WithArgs(int64(3), int64(100)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	found, err := storage.Delete(ctx, entity.UserID(100), entity.RecurringExpenseID(3))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
@@ -2,11 +2,11 @@ package userpgsqlstorage
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
-	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 	"go.opentelemetry.io/otel"
 )
@@ -53,75 +53,83 @@ func (s *UserPgsqlStorage) UpdateDefaultCurrency(ctx context.Context, userID ent
 	return errors.Wrap(err, "UpdateDefaultCurrency.UpdateDefaultCurrency")
 }
 
-func (s *UserPgsqlStorage) GetLimits(ctx context.Context, userID entity.UserID) (
-	decimal.Decimal, decimal.Decimal, decimal.Decimal, error,
-) {
-	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "GetLimits")
+// UpdateSummaryInterval задает интервал периодической сводки. Нулевой интервал отключает сводку.
+func (s *UserPgsqlStorage) UpdateSummaryInterval(ctx context.Context, userID entity.UserID, intervalType int) error {
+	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateSummaryInterval")
 	defer span.End()
 
-	var (
-		dayLimitStr   string
-		weekLimitStr  string
-		monthLimitStr string
-	)
+	_, err := s.conn.Exec(ctx,
+		`INSERT INTO users (id, summary_interval) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET summary_interval = $2`,
+		int64(userID), intervalType)
 
-	err := s.conn.QueryRow(ctx,
-		`SELECT day_limit, week_limit, month_limit FROM users WHERE id = $1`,
-		int64(userID)).Scan(&dayLimitStr, &weekLimitStr, &monthLimitStr)
-	if err != nil {
-		return decimal.Decimal{}, decimal.Decimal{}, decimal.Decimal{}, errors.Wrap(err, "UserPgsqlStorage.GetLimits")
-	}
+	return errors.Wrap(err, "UserPgsqlStorage.UpdateSummaryInterval")
+}
 
-	dayLimit, err := decimal.NewFromString(dayLimitStr)
-	if err != nil {
-		return decimal.Decimal{}, decimal.Decimal{}, decimal.Decimal{}, errors.Wrap(err, "UserPgsqlStorage.GetLimits")
-	}
+// GetSummaryRecipients возвращает пользователей со сводкой за интервал intervalType,
+// которым она не отправлялась начиная с sentBefore.
+func (s *UserPgsqlStorage) GetSummaryRecipients(ctx context.Context, intervalType int, sentBefore time.Time,
+) ([]entity.UserID, error) {
+	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "GetSummaryRecipients")
+	defer span.End()
 
-	weekLimit, err := decimal.NewFromString(weekLimitStr)
+	rows, err := s.conn.Query(ctx,
+		`SELECT id FROM users WHERE summary_interval = $1
+			AND (summary_sent_at IS NULL OR summary_sent_at < $2)`,
+		intervalType, sentBefore)
 	if err != nil {
-		return decimal.Decimal{}, decimal.Decimal{}, decimal.Decimal{}, errors.Wrap(err, "UserPgsqlStorage.GetLimits")
+		return nil, errors.Wrap(err, "UserPgsqlStorage.GetSummaryRecipients")
 	}
 
-	monthLimit, err := decimal.NewFromString(monthLimitStr)
-	if err != nil {
-		return decimal.Decimal{}, decimal.Decimal{}, decimal.Decimal{}, errors.Wrap(err, "UserPgsqlStorage.GetLimits")
-	}
+	users := make([]entity.UserID, 0)
+
+	var userID int64
+
+	_, err = pgx.ForEachRow(rows, []any{&userID}, func() error {
+		users = append(users, entity.UserID(userID))
+
+		return nil
+	})
 
-	return dayLimit, weekLimit, monthLimit, errors.Wrap(err, "UserPgsqlStorage.GetLimits")
+	return users, errors.Wrap(err, "UserPgsqlStorage.GetSummaryRecipients")
 }
 
-func (s *UserPgsqlStorage) UpdateDayLimit(ctx context.Context, userID entity.UserID, limit decimal.Decimal) error {
-	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateDayLimit")
+func (s *UserPgsqlStorage) UpdateSummarySentAt(ctx context.Context, userID entity.UserID, date time.Time) error {
+	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateSummarySentAt")
 	defer span.End()
 
 	_, err := s.conn.Exec(ctx,
-		`INSERT INTO users (id, day_limit) VALUES ($1, $2)
-			ON CONFLICT (id) DO UPDATE SET day_limit = $2`,
-		int64(userID), limit.String())
+		`UPDATE users SET summary_sent_at = $2 WHERE id = $1`,
+		int64(userID), date)
 
-	return errors.Wrap(err, "UserPgsqlStorage.UpdateDayLimit")
+	return errors.Wrap(err, "UserPgsqlStorage.UpdateSummarySentAt")
 }
 
-func (s *UserPgsqlStorage) UpdateWeekLimit(ctx context.Context, userID entity.UserID, limit decimal.Decimal) error {
-	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateWeekLimit")
+// GetLocale возвращает выбранный пользователем язык, пустой, если он не выбирался.
+func (s *UserPgsqlStorage) GetLocale(ctx context.Context, userID entity.UserID) (string, error) {
+	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "GetLocale")
 	defer span.End()
 
-	_, err := s.conn.Exec(ctx,
-		`INSERT INTO users (id, week_limit) VALUES ($1, $2)
-			ON CONFLICT (id) DO UPDATE SET week_limit = $2`,
-		int64(userID), limit.String())
+	var locale string
+
+	err := s.conn.QueryRow(ctx,
+		`SELECT COALESCE(locale, '') FROM users WHERE id = $1`,
+		int64(userID)).Scan(&locale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
 
-	return errors.Wrap(err, "UserPgsqlStorage.UpdateWeekLimit")
+	return locale, errors.Wrap(err, "UserPgsqlStorage.GetLocale")
 }
 
-func (s *UserPgsqlStorage) UpdateMonthLimit(ctx context.Context, userID entity.UserID, limit decimal.Decimal) error {
-	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateMonthLimit")
+func (s *UserPgsqlStorage) UpdateLocale(ctx context.Context, userID entity.UserID, locale string) error {
+	ctx, span := otel.Tracer("UserPgsqlStorage").Start(ctx, "UpdateLocale")
 	defer span.End()
 
 	_, err := s.conn.Exec(ctx,
-		`INSERT INTO users (id, month_limit) VALUES ($1, $2)
-			ON CONFLICT (id) DO UPDATE SET month_limit = $2`,
-		int64(userID), limit.String())
+		`INSERT INTO users (id, locale) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET locale = $2`,
+		int64(userID), locale)
 
-	return errors.Wrap(err, "UserPgsqlStorage.UpdateMonthLimit")
+	return errors.Wrap(err, "UserPgsqlStorage.UpdateLocale")
 }
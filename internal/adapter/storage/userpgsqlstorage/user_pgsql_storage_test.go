@@ -3,10 +3,11 @@ package userpgsqlstorage_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v2"
 	"github.com/pkg/errors"
-	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/userpgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
@@ -86,7 +87,7 @@ func TestUserPgsqlStorage_UpdateDefaultCurrency(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestUserPgsqlStorage_GetLimits(t *testing.T) {
+func TestUserPgsqlStorage_UpdateSummaryInterval(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
@@ -94,22 +95,39 @@ func TestUserPgsqlStorage_GetLimits(t *testing.T) {
 
 	defer teardownSuite(t)
 
-	rows := pgxmock.NewRows([]string{"day_limit", "week_limit", "month_limit"}).
-		AddRow("1000", "0", "5000")
+	mock.ExpectExec(`INSERT INTO users \(id, summary_interval\)`).
+		WithArgs(int64(100), 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	mock.ExpectQuery(`SELECT day_limit, week_limit, month_limit FROM users`).
-		WithArgs(int64(100)).
+	err := storage.UpdateSummaryInterval(ctx, entity.UserID(100), 2)
+	assert.NoError(t, err)
+}
+
+func TestUserPgsqlStorage_GetSummaryRecipients(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage, mock, teardownSuite := setupSuite(ctx, t)
+
+	defer teardownSuite(t)
+
+	sentBefore := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	rows := pgxmock.NewRows([]string{"id"}).
+		AddRow(int64(100)).
+		AddRow(int64(101))
+
+	mock.ExpectQuery(`SELECT id FROM users WHERE summary_interval`).
+		WithArgs(1, sentBefore).
 		WillReturnRows(rows)
 
-	dayLimit, weekLimit, monthLimit, err := storage.GetLimits(ctx, entity.UserID(100))
+	users, err := storage.GetSummaryRecipients(ctx, 1, sentBefore)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "1000", dayLimit.String())
-	assert.Equal(t, "0", weekLimit.String())
-	assert.Equal(t, "5000", monthLimit.String())
+	assert.Equal(t, []entity.UserID{100, 101}, users)
 }
 
-func TestUserPgsqlStorage_UpdateDayLimit(t *testing.T) {
+func TestUserPgsqlStorage_GetLocale(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
@@ -117,15 +135,16 @@ func TestUserPgsqlStorage_UpdateDayLimit(t *testing.T) {
 
 	defer teardownSuite(t)
 
-	mock.ExpectExec(`INSERT INTO users \(id, day_limit\)`).
-		WithArgs(int64(100), "123.45").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(`SELECT COALESCE\(locale, ''\) FROM users`).
+		WithArgs(int64(100)).
+		WillReturnRows(pgxmock.NewRows([]string{"locale"}).AddRow("en"))
 
-	err := storage.UpdateDayLimit(ctx, entity.UserID(100), decimal.New(12345, -2))
+	locale, err := storage.GetLocale(ctx, entity.UserID(100))
 	assert.NoError(t, err)
+	assert.Equal(t, "en", locale)
 }
 
-func TestUserPgsqlStorage_UpdateWeekLimit(t *testing.T) {
+func TestUserPgsqlStorage_GetLocaleNoUser(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
@@ -133,15 +152,16 @@ func TestUserPgsqlStorage_UpdateWeekLimit(t *testing.T) {
 
 	defer teardownSuite(t)
 
-	mock.ExpectExec(`INSERT INTO users \(id, week_limit\)`).
-		WithArgs(int64(100), "123.45").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(`SELECT COALESCE\(locale, ''\) FROM users`).
+		WithArgs(int64(100)).
+		WillReturnError(pgx.ErrNoRows)
 
-	err := storage.UpdateWeekLimit(ctx, entity.UserID(100), decimal.New(12345, -2))
+	locale, err := storage.GetLocale(ctx, entity.UserID(100))
 	assert.NoError(t, err)
+	assert.Empty(t, locale)
 }
 
-func TestUserPgsqlStorage_UpdateMonthLimit(t *testing.T) {
+func TestUserPgsqlStorage_UpdateLocale(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
@@ -149,10 +169,10 @@ func TestUserPgsqlStorage_UpdateMonthLimit(t *testing.T) {
 
 	defer teardownSuite(t)
 
-	mock.ExpectExec(`INSERT INTO users \(id, month_limit\)`).
-		WithArgs(int64(100), "123.45").
+	mock.ExpectExec(`INSERT INTO users \(id, locale\)`).
+		WithArgs(int64(100), "en").
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-	err := storage.UpdateMonthLimit(ctx, entity.UserID(100), decimal.New(12345, -2))
+	err := storage.UpdateLocale(ctx, entity.UserID(100), "en")
 	assert.NoError(t, err)
 }
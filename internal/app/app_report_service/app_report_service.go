@@ -6,9 +6,11 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	reportservice "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/report"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/categorypgsqlstorage"
 	currencycachestorage "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/currency_cache_storage" //nolint:lll
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/currencypgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/expensepgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/incomepgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/userpgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/config"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
@@ -35,8 +37,11 @@ func New(ctx context.Context, cfg *config.Config) (AppUsecase, error) {
 	currencyStorage := currencycachestorage.New(currencypgsqlstorage.New(conn), cfg)
 	userStorage := userpgsqlstorage.New(conn)
 	expenseStorage := expensepgsqlstorage.New(conn)
+	incomeStorage := incomepgsqlstorage.New(conn)
+	categoryStorage := categorypgsqlstorage.New(conn)
 
-	reportService := reportservice.NewReportServer(expenseStorage, currencyStorage, userStorage, cfg)
+	reportService := reportservice.NewReportServer(expenseStorage, incomeStorage, categoryStorage, currencyStorage,
+		userStorage, cfg)
 
 	server := grpc.NewServer()
 	reportservice.RegisterReportServiceServer(server, reportService)
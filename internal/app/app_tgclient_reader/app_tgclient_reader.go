@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	kafkawriter "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/kafka/kafka_writer"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/dialogpgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/clients/tg"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/config"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
@@ -15,12 +18,14 @@ import (
 )
 
 type AppTgClientReader struct {
-	client   Client
-	callback tg.MsgCallback
+	client           Client
+	conn             *pgx.Conn
+	callback         tg.MsgCallback
+	documentCallback tg.DocumentCallback
 }
 
 type Client interface {
-	Read(context.Context, func(context.Context, int64, time.Time, string))
+	Read(context.Context, tg.MsgCallback, tg.DocumentCallback)
 }
 
 func New(ctx context.Context, cfg *config.Config) (AppTgClientReader, error) {
@@ -33,6 +38,11 @@ func New(ctx context.Context, cfg *config.Config) (AppTgClientReader, error) {
 }
 
 func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client) (AppTgClientReader, error) {
+	conn, err := pgx.Connect(ctx, cfg.GetDatabaseURL())
+	if err != nil {
+		logger.Fatalf("pg client init failed: %v", err)
+	}
+
 	writer := kafkawriter.New(cfg.GetKafkaAddr(), usecase.ReadCmdState)
 
 	routerText := textrouter.New()
@@ -40,16 +50,57 @@ func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client)
 	routerText.Register(texthandler.NewStart())
 	routerText.Register(texthandler.NewHelp())
 	routerText.Register(texthandler.NewAbout())
+	routerText.Register(texthandler.NewGetCurrencies())
 	routerText.Register(texthandler.NewSetDefaultCurrency())
 	routerText.Register(texthandler.NewAddExpense())
+	routerText.Register(texthandler.NewUpdateExpense())
+	routerText.Register(texthandler.NewDeleteExpense())
+	routerText.Register(texthandler.NewReportPeriods())
 	routerText.Register(texthandler.NewGetReport())
 	routerText.Register(texthandler.NewSetLimit())
 	routerText.Register(texthandler.NewGetLimits())
+	routerText.Register(texthandler.NewCreateCategory())
+	routerText.Register(texthandler.NewAddCategoryAlias())
+	routerText.Register(texthandler.NewRenameCategory())
+	routerText.Register(texthandler.NewMergeCategories())
+	routerText.Register(texthandler.NewGetCategories())
+	routerText.Register(texthandler.NewSetSummary())
+	routerText.Register(texthandler.NewAddRecurringExpense())
+	routerText.Register(texthandler.NewGetRecurringExpenses())
+	routerText.Register(texthandler.NewDeleteRecurringExpense())
+	routerText.Register(texthandler.NewExport())
+	routerText.Register(texthandler.NewImportExpenses())
+	routerText.Register(texthandler.NewSetImportColumns())
+	routerText.Register(texthandler.NewAddImportRule())
+	routerText.Register(texthandler.NewDeleteImportRule())
+	routerText.Register(texthandler.NewGetImportSettings())
+	routerText.Register(texthandler.NewGetBudget())
+	routerText.Register(texthandler.NewInviteToBudget())
+	routerText.Register(texthandler.NewJoinBudget())
+	routerText.Register(texthandler.NewLeaveBudget())
+	routerText.Register(texthandler.NewRemoveBudgetMember())
+	routerText.Register(texthandler.NewLocales())
+	routerText.Register(texthandler.NewSetLocale())
+	routerText.Register(texthandler.NewUndo())
+	routerText.Register(texthandler.NewSearchExpenses())
+	routerText.Register(texthandler.NewAddIncome())
+	routerText.Register(texthandler.NewCreateAccount())
+	routerText.Register(texthandler.NewTransfer())
+	routerText.Register(texthandler.NewGetBalances())
+	routerText.Register(texthandler.NewSetGoal())
+	routerText.Register(texthandler.NewContributeGoal())
+	routerText.Register(texthandler.NewGetGoals())
+	routerText.Register(texthandler.NewDeleteGoal())
+	routerText.Register(texthandler.NewParsedExpense())
+	routerText.Register(texthandler.NewConfirmExpense())
+	routerText.Register(texthandler.NewReceiptExpense())
+	routerText.Register(texthandler.NewDialog())
+	routerText.Register(texthandler.NewCancelDialog())
 	routerText.Register(texthandler.NewUnknown())
 
-	callback := func(ctx context.Context, userID int64, date time.Time, text string) {
-		cmd := routerText.ConvertTextToCommand(ctx, userID, date, text)
+	dialogRouter := textrouter.NewDialogRouter(routerText, dialogpgsqlstorage.New(conn))
 
+	write := func(ctx context.Context, cmd usecase.Command) {
 		buf, err := json.Marshal(cmd)
 		if err != nil {
 			logger.Errorf("can not marshal command: %v", err)
@@ -61,12 +112,39 @@ func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client)
 		}
 	}
 
+	callback := func(ctx context.Context, chatID, userID int64, languageCode string, date time.Time, text string) {
+		info := messageInfo(chatID, userID, languageCode, date)
+
+		write(ctx, dialogRouter.ConvertTextToCommand(ctx, info, text))
+	}
+
+	documentCallback := func(ctx context.Context, chatID, userID int64, languageCode string, date time.Time,
+		caption, name string, data []byte,
+	) {
+		info := messageInfo(chatID, userID, languageCode, date)
+		file := usecase.FileDTO{Name: name, Data: data}
+
+		write(ctx, dialogRouter.ConvertDocumentToCommand(ctx, info, caption, file))
+	}
+
 	return AppTgClientReader{
-		client:   client,
-		callback: callback,
+		client:           client,
+		conn:             conn,
+		callback:         callback,
+		documentCallback: documentCallback,
 	}, nil
 }
 
 func (a *AppTgClientReader) Run(ctx context.Context) {
-	a.client.Read(ctx, a.callback)
+	a.client.Read(ctx, a.callback, a.documentCallback)
+
+	a.conn.Close(ctx)
+}
+
+// messageInfo язык ответа берется из настроек Telegram пользователя, если бот его
+// поддерживает. Выбранный в боте язык подставит usecase.
+func messageInfo(chatID, userID int64, languageCode string, date time.Time) usecase.MessageInfo {
+	locale, _ := i18n.ParseLocale(languageCode)
+
+	return usecase.MessageInfo{UserID: userID, ChatID: chatID, Locale: locale, Date: date}
 }
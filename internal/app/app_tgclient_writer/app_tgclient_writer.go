@@ -20,6 +20,9 @@ type AppTgClientWriter struct {
 
 type Client interface {
 	Write(context.Context, string, int64) error
+	WriteKeyboard(context.Context, string, [][]tg.Button, int64) error
+	WriteDocument(context.Context, string, []byte, int64) error
+	WritePhoto(context.Context, string, []byte, int64) error
 }
 
 func New(ctx context.Context, cfg *config.Config) (AppTgClientWriter, error) {
@@ -39,11 +42,55 @@ func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client)
 	routerText.Register(texthandler.NewStart())
 	routerText.Register(texthandler.NewHelp())
 	routerText.Register(texthandler.NewAbout())
+	routerText.Register(texthandler.NewGetCurrencies())
 	routerText.Register(texthandler.NewSetDefaultCurrency())
 	routerText.Register(texthandler.NewAddExpense())
+	routerText.Register(texthandler.NewUpdateExpense())
+	routerText.Register(texthandler.NewDeleteExpense())
+	routerText.Register(texthandler.NewReportPeriods())
 	routerText.Register(texthandler.NewGetReport())
 	routerText.Register(texthandler.NewSetLimit())
 	routerText.Register(texthandler.NewGetLimits())
+	routerText.Register(texthandler.NewCreateCategory())
+	routerText.Register(texthandler.NewAddCategoryAlias())
+	routerText.Register(texthandler.NewRenameCategory())
+	routerText.Register(texthandler.NewMergeCategories())
+	routerText.Register(texthandler.NewGetCategories())
+	routerText.Register(texthandler.NewSetSummary())
+	routerText.Register(texthandler.NewAddRecurringExpense())
+	routerText.Register(texthandler.NewGetRecurringExpenses())
+	routerText.Register(texthandler.NewDeleteRecurringExpense())
+	routerText.Register(texthandler.NewExport())
+	routerText.Register(texthandler.NewImportExpenses())
+	routerText.Register(texthandler.NewSetImportColumns())
+	routerText.Register(texthandler.NewAddImportRule())
+	routerText.Register(texthandler.NewDeleteImportRule())
+	routerText.Register(texthandler.NewGetImportSettings())
+	routerText.Register(texthandler.NewSummary())
+	routerText.Register(texthandler.NewLimitNotification())
+	routerText.Register(texthandler.NewGetBudget())
+	routerText.Register(texthandler.NewInviteToBudget())
+	routerText.Register(texthandler.NewJoinBudget())
+	routerText.Register(texthandler.NewLeaveBudget())
+	routerText.Register(texthandler.NewRemoveBudgetMember())
+	routerText.Register(texthandler.NewLocales())
+	routerText.Register(texthandler.NewSetLocale())
+	routerText.Register(texthandler.NewUndo())
+	routerText.Register(texthandler.NewSearchExpenses())
+	routerText.Register(texthandler.NewAddIncome())
+	routerText.Register(texthandler.NewCreateAccount())
+	routerText.Register(texthandler.NewTransfer())
+	routerText.Register(texthandler.NewGetBalances())
+	routerText.Register(texthandler.NewSetGoal())
+	routerText.Register(texthandler.NewContributeGoal())
+	routerText.Register(texthandler.NewGetGoals())
+	routerText.Register(texthandler.NewDeleteGoal())
+	routerText.Register(texthandler.NewGoalNudge())
+	routerText.Register(texthandler.NewParsedExpense())
+	routerText.Register(texthandler.NewConfirmExpense())
+	routerText.Register(texthandler.NewReceiptExpense())
+	routerText.Register(texthandler.NewDialog())
+	routerText.Register(texthandler.NewCancelDialog())
 	routerText.Register(texthandler.NewUnknown())
 
 	callback := func(ctx context.Context, key, value []byte) {
@@ -56,10 +103,29 @@ func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client)
 
 		text := routerText.ConvertCommandToText(ctx, &cmd)
 
-		err = client.Write(ctx, text, cmd.UserID)
+		if keyboard := routerText.ConvertCommandToKeyboard(ctx, &cmd); len(keyboard) != 0 {
+			err = client.WriteKeyboard(ctx, text, tgKeyboard(keyboard), cmd.ReplyChatID())
+		} else {
+			err = client.Write(ctx, text, cmd.ReplyChatID())
+		}
+
 		if err != nil {
 			logger.Errorf("can not write message: %v", err)
 		}
+
+		for _, file := range routerText.ConvertCommandToFiles(ctx, &cmd) {
+			err = client.WriteDocument(ctx, file.Name, file.Data, cmd.ReplyChatID())
+			if err != nil {
+				logger.Errorf("can not write document: %v", err)
+			}
+		}
+
+		for _, photo := range routerText.ConvertCommandToPhotos(ctx, &cmd) {
+			err = client.WritePhoto(ctx, photo.Name, photo.Data, cmd.ReplyChatID())
+			if err != nil {
+				logger.Errorf("can not write photo: %v", err)
+			}
+		}
 	}
 
 	return AppTgClientWriter{
@@ -71,3 +137,18 @@ func NewWithCustomClient(ctx context.Context, cfg *config.Config, client Client)
 func (a *AppTgClientWriter) Run(ctx context.Context) {
 	a.reader.Read(ctx, a.callback)
 }
+
+func tgKeyboard(keyboard [][]textrouter.Button) [][]tg.Button {
+	rows := make([][]tg.Button, 0, len(keyboard))
+
+	for _, row := range keyboard {
+		buttons := make([]tg.Button, 0, len(row))
+		for _, button := range row {
+			buttons = append(buttons, tg.Button{Text: button.Text, Data: button.Data})
+		}
+
+		rows = append(rows, buttons)
+	}
+
+	return rows
+}
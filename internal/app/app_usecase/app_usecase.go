@@ -10,19 +10,34 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	kafkanotifier "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/kafka/kafka_notifier"
 	kafkareader "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/kafka/kafka_reader"
 	kafkawriter "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/kafka/kafka_writer"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicecbr"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicecomposite"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterserviceexchangerate"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/ratesupdaterservicefile"
 	reportservice "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/service/report"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/accountpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/auditpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/budgetpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/categorypgsqlstorage"
 	currencycachestorage "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/currency_cache_storage" //nolint:lll
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/currencypgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/expensepgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/goalpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/importpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/incomepgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/limitpgsqlstorage"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/recurringpgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/storage/userpgsqlstorage"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/config"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	goalworker "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/worker/goal_worker"
 	rateupdaterworker "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/worker/rate_updater_worker"
+	recurringexpenseworker "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/worker/recurring_expense_worker" //nolint:lll
+	summaryworker "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/worker/summary_worker"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/metrics"
 	"go.opentelemetry.io/otel"
@@ -33,16 +48,22 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
+// defaultRatesTimeout ограничивает запрос к источнику курсов, если timeoutMs не задан.
+const defaultRatesTimeout = 5 * time.Second
+
+var errUnknownRatesProvider = errors.New("unknown rates provider")
+
 type worker interface {
 	Run(context.Context)
 }
 
 type IRatesUpdaterService interface {
 	Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error)
+	GetForDate(ctx context.Context, base string, codes []string, date time.Time) ([]entity.Rate, error)
 }
 
 type AppUsecase struct {
-	worker         worker
+	workers        []worker
 	conn           *pgx.Conn
 	tp             *sdktrace.TracerProvider
 	metricsServer  *http.Server
@@ -65,21 +86,43 @@ func New(ctx context.Context, cfg *config.Config) (AppUsecase, error) {
 	currencyStorage := currencycachestorage.New(currencypgsqlstorage.New(conn), cfg)
 	userStorage := userpgsqlstorage.New(conn)
 	expenseStorage := expensepgsqlstorage.New(conn)
-
-	var ratesUpdaterService IRatesUpdaterService
-
-	if cfg.GetRatesService() == "cbr" {
-		ratesUpdaterService = ratesupdaterservicecbr.New()
-	} else {
-		ratesUpdaterService = ratesupdaterserviceexchangerate.New()
+	incomeStorage := incomepgsqlstorage.New(conn)
+	accountStorage := accountpgsqlstorage.New(conn)
+	goalStorage := goalpgsqlstorage.New(conn)
+	categoryStorage := categorypgsqlstorage.New(conn)
+	limitStorage := limitpgsqlstorage.New(conn)
+	recurringStorage := recurringpgsqlstorage.New(conn)
+	importStorage := importpgsqlstorage.New(conn)
+	budgetStorage := budgetpgsqlstorage.New(conn)
+	auditStorage := auditpgsqlstorage.New(conn)
+
+	ratesUpdaterService, err := newRatesUpdaterService(cfg)
+	if err != nil {
+		logger.Fatalf("rates service init failed: %v", err)
 	}
 
 	reportClient := reportservice.NewReportClient(cfg.GetReportServiceAddr())
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage,
-		ratesUpdaterService, reportClient, cfg)
+	writer := kafkawriter.New(cfg.GetKafkaAddr(), usecase.ProcessCmdState)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, kafkanotifier.New(writer), ratesUpdaterService, reportClient, cfg)
 
-	rateUpdaterWorker := rateupdaterworker.New(expenseUsecase, cfg)
+	workers := []worker{rateupdaterworker.New(expenseUsecase, cfg)}
+
+	// Без заданной частоты проверки сводки, регулярные расходы и цели не обрабатываются
+	if cfg.GetFrequencySummaryCheckSec() > 0 {
+		workers = append(workers, summaryworker.New(expenseUsecase, cfg))
+	}
+
+	if cfg.GetFrequencyRecurringCheckSec() > 0 {
+		workers = append(workers, recurringexpenseworker.New(expenseUsecase, cfg))
+	}
+
+	if cfg.GetFrequencyGoalCheckSec() > 0 {
+		workers = append(workers, goalworker.New(expenseUsecase, cfg))
+	}
 
 	http.Handle("/metrics", promhttp.Handler())
 
@@ -92,8 +135,6 @@ func New(ctx context.Context, cfg *config.Config) (AppUsecase, error) {
 
 	reader := kafkareader.New(cfg.GetKafkaAddr(), usecase.ReadCmdState, "usecaseReader")
 
-	writer := kafkawriter.New(cfg.GetKafkaAddr(), usecase.ProcessCmdState)
-
 	middlewareMetricsUsecase := func(ctx context.Context, cmd *usecase.Command) error {
 		startTime := time.Now()
 
@@ -132,7 +173,7 @@ func New(ctx context.Context, cfg *config.Config) (AppUsecase, error) {
 	}
 
 	return AppUsecase{
-		worker:         rateUpdaterWorker,
+		workers:        workers,
 		conn:           conn,
 		tp:             tp,
 		metricsServer:  metricsServer,
@@ -158,12 +199,16 @@ func (a *AppUsecase) Run(ctx context.Context) {
 		a.reader.Read(ctx, a.readerCallback)
 	}()
 
-	wg.Add(1)
+	for _, worker := range a.workers {
+		worker := worker
 
-	go func() {
-		defer wg.Done()
-		a.worker.Run(ctx)
-	}()
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			worker.Run(ctx)
+		}()
+	}
 
 	wg.Wait()
 
@@ -186,6 +231,47 @@ func (a *AppUsecase) Run(ctx context.Context) {
 	a.reportClient.Close()
 }
 
+// newRatesUpdaterService собирает источники курсов из конфига в порядке опроса.
+func newRatesUpdaterService(cfg *config.Config) (IRatesUpdaterService, error) {
+	providers := cfg.GetRatesProviders()
+	sources := make([]ratesupdaterservicecomposite.Source, 0, len(providers))
+
+	for _, providerCfg := range providers {
+		timeout := time.Duration(providerCfg.TimeoutMs) * time.Millisecond
+		if timeout == 0 {
+			timeout = defaultRatesTimeout
+		}
+
+		client := &http.Client{Timeout: timeout} //nolint:exhaustruct
+
+		name := providerCfg.Name
+
+		var provider ratesupdaterservicecomposite.Provider
+
+		switch name {
+		case "cbr":
+			provider = ratesupdaterservicecbr.New(client, providerCfg.URL)
+		case "exchangerate":
+			provider = ratesupdaterserviceexchangerate.New(client, providerCfg.URL)
+		case "file":
+			provider = ratesupdaterservicefile.New(providerCfg.File)
+		default:
+			return nil, errors.Wrap(errUnknownRatesProvider, name)
+		}
+
+		sources = append(sources, ratesupdaterservicecomposite.Source{
+			Name:       name,
+			Provider:   provider,
+			Timeout:    timeout,
+			Retries:    providerCfg.Retries,
+			RetryDelay: time.Duration(providerCfg.RetryDelayMs) * time.Millisecond,
+		})
+	}
+
+	return ratesupdaterservicecomposite.New(sources, cfg.GetRatesBreakerFailures(),
+		time.Duration(cfg.GetRatesBreakerCooldownSec())*time.Second), nil
+}
+
 func registerJaeger(url string) (*sdktrace.TracerProvider, error) {
 	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(
 		jaeger.WithEndpoint(url)))
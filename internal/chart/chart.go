@@ -0,0 +1,97 @@
+// Package chart рисует графики отчетов в PNG.
+package chart
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+)
+
+const (
+	width    = 800
+	height   = 600
+	barWidth = 20
+)
+
+var ErrNoData = errors.New("no data for chart")
+
+// Столбцы одного ряда рисуются одним цветом, по умолчанию каждый столбец свой.
+var barStyle = chart.Style{ //nolint:exhaustruct
+	FillColor:   drawing.ColorFromHex("2bbf88"),
+	StrokeColor: drawing.ColorFromHex("2bbf88"),
+}
+
+// Value значение сектора или столбца с подписью.
+type Value struct {
+	Label string
+	Value float64
+}
+
+// Pie круговая диаграмма. Секторы с нулевым или отрицательным значением не рисуются.
+func Pie(title string, values []Value) ([]byte, error) {
+	pie := chart.PieChart{ //nolint:exhaustruct
+		Title:  title,
+		Width:  width,
+		Height: height,
+		Values: make([]chart.Value, 0, len(values)),
+	}
+
+	for _, value := range values {
+		if value.Value <= 0 {
+			continue
+		}
+
+		pie.Values = append(pie.Values, chart.Value{Label: value.Label, Value: value.Value}) //nolint:exhaustruct
+	}
+
+	if len(pie.Values) == 0 {
+		return nil, errors.Wrap(ErrNoData, "chart.Pie")
+	}
+
+	var buf bytes.Buffer
+
+	err := pie.Render(chart.PNG, &buf)
+
+	return buf.Bytes(), errors.Wrap(err, "chart.Pie")
+}
+
+// Bars столбчатая диаграмма. Ширина растет с числом столбцов, чтобы подписи не слипались.
+func Bars(title string, values []Value) ([]byte, error) {
+	bars := chart.BarChart{ //nolint:exhaustruct
+		Title:    title,
+		Width:    width,
+		Height:   height,
+		BarWidth: barWidth,
+		Bars:     make([]chart.Value, 0, len(values)),
+		Background: chart.Style{ //nolint:exhaustruct
+			Padding: chart.Box{Top: 40}, //nolint:exhaustruct,gomnd
+		},
+	}
+
+	var hasValue bool
+
+	for _, value := range values {
+		if value.Value > 0 {
+			hasValue = true
+		}
+
+		bars.Bars = append(bars.Bars, chart.Value{Label: value.Label, Value: value.Value, Style: barStyle}) //nolint:exhaustruct,lll
+	}
+
+	if !hasValue {
+		return nil, errors.Wrap(ErrNoData, "chart.Bars")
+	}
+
+	// Поля по краям и промежутки между столбцами
+	if minWidth := (len(bars.Bars) + 2) * (barWidth + barWidth/2); minWidth > bars.Width {
+		bars.Width = minWidth
+	}
+
+	var buf bytes.Buffer
+
+	err := bars.Render(chart.PNG, &buf)
+
+	return buf.Bytes(), errors.Wrap(err, "chart.Bars")
+}
@@ -0,0 +1,46 @@
+package chart_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/chart"
+)
+
+func TestPie(t *testing.T) {
+	t.Parallel()
+
+	data, err := chart.Pie("Расходы за месяц", []chart.Value{
+		{Label: "такси", Value: 300.5},
+		{Label: "продукты", Value: 1250},
+		{Label: "возврат", Value: 0},
+	})
+	assert.NoError(t, err)
+
+	_, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	_, err = chart.Pie("Расходы за месяц", []chart.Value{{Label: "такси", Value: 0}})
+	assert.ErrorIs(t, err, chart.ErrNoData)
+}
+
+func TestBars(t *testing.T) {
+	t.Parallel()
+
+	values := make([]chart.Value, 0, 60)
+	for i := 0; i < 60; i++ {
+		values = append(values, chart.Value{Label: "01", Value: float64(i % 7)})
+	}
+
+	data, err := chart.Bars("Расходы по дням", values)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Greater(t, img.Bounds().Dx(), 800)
+
+	_, err = chart.Bars("Расходы по дням", []chart.Value{{Label: "01", Value: 0}})
+	assert.ErrorIs(t, err, chart.ErrNoData)
+}
@@ -2,18 +2,46 @@ package tg
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
 	"go.opentelemetry.io/otel"
 )
 
-type MsgCallback = func(ctx context.Context, userID int64, date time.Time, text string)
+// MsgCallback получает сообщение пользователя userID из чата chatID. В личном чате
+// chatID совпадает с userID. languageCode - язык интерфейса Telegram пользователя.
+type MsgCallback = func(ctx context.Context, chatID, userID int64, languageCode string, date time.Time,
+	text string)
+
+// DocumentCallback получает присланный пользователем файл и подпись к нему.
+type DocumentCallback = func(ctx context.Context, chatID, userID int64, languageCode string, date time.Time,
+	caption, name string, data []byte)
+
+// Файл передается дальше через kafka в base64, поэтому размер ограничен
+// с запасом до лимита сообщения в 1 МБ.
+const maxDocumentSize = 512 << 10
+
+// downloadTimeout ограничивает загрузку файла, присланного пользователем.
+const downloadTimeout = 30 * time.Second
+
+// Фото приходят без имени файла, Telegram всегда пересылает их в JPEG.
+const photoFileName = "photo.jpg"
+
+// Button inline кнопка. При нажатии Data приходит в MsgCallback как текст сообщения.
+type Button struct {
+	Text string
+	Data string
+}
 
 type Client struct {
-	client *tgbotapi.BotAPI
+	client     *tgbotapi.BotAPI
+	httpClient *http.Client
 }
 
 type config interface {
@@ -27,17 +55,18 @@ func New(cfg config) (*Client, error) {
 	}
 
 	return &Client{
-		client: client,
+		client:     client,
+		httpClient: &http.Client{Timeout: downloadTimeout}, //nolint:exhaustruct
 	}, nil
 }
 
-func (c *Client) Write(ctx context.Context, text string, userID int64) error {
+func (c *Client) Write(ctx context.Context, text string, chatID int64) error {
 	_, span := otel.Tracer("tgClient").Start(ctx, "WriteMessage")
 	defer span.End()
 
-	logger.Infof("client.Write [%d][%s]", userID, text)
+	logger.Infof("client.Write [%d][%s]", chatID, text)
 
-	_, err := c.client.Send(tgbotapi.NewMessage(userID, text))
+	_, err := c.client.Send(tgbotapi.NewMessage(chatID, text))
 	if err != nil {
 		return errors.Wrap(err, "client.Write")
 	}
@@ -45,7 +74,64 @@ func (c *Client) Write(ctx context.Context, text string, userID int64) error {
 	return nil
 }
 
-func (c *Client) Read(ctx context.Context, callback MsgCallback) {
+// WriteKeyboard отправляет сообщение с inline кнопками под ним.
+func (c *Client) WriteKeyboard(ctx context.Context, text string, keyboard [][]Button, chatID int64) error {
+	_, span := otel.Tracer("tgClient").Start(ctx, "WriteKeyboard")
+	defer span.End()
+
+	logger.Infof("client.WriteKeyboard [%d][%s]", chatID, text)
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(keyboard))
+
+	for _, row := range keyboard {
+		buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(row))
+		for _, button := range row {
+			buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(button.Text, button.Data))
+		}
+
+		rows = append(rows, buttons)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	_, err := c.client.Send(msg)
+	if err != nil {
+		return errors.Wrap(err, "client.WriteKeyboard")
+	}
+
+	return nil
+}
+
+func (c *Client) WriteDocument(ctx context.Context, name string, data []byte, chatID int64) error {
+	_, span := otel.Tracer("tgClient").Start(ctx, "WriteDocument")
+	defer span.End()
+
+	logger.Infof("client.WriteDocument [%d][%s][%d bytes]", chatID, name, len(data))
+
+	_, err := c.client.Send(tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: data}))
+	if err != nil {
+		return errors.Wrap(err, "client.WriteDocument")
+	}
+
+	return nil
+}
+
+func (c *Client) WritePhoto(ctx context.Context, name string, data []byte, chatID int64) error {
+	_, span := otel.Tracer("tgClient").Start(ctx, "WritePhoto")
+	defer span.End()
+
+	logger.Infof("client.WritePhoto [%d][%s][%d bytes]", chatID, name, len(data))
+
+	_, err := c.client.Send(tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: name, Bytes: data}))
+	if err != nil {
+		return errors.Wrap(err, "client.WritePhoto")
+	}
+
+	return nil
+}
+
+func (c *Client) Read(ctx context.Context, callback MsgCallback, documentCallback DocumentCallback) {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
@@ -54,7 +140,7 @@ func (c *Client) Read(ctx context.Context, callback MsgCallback) {
 	for {
 		select {
 		case update := <-updates:
-			c.processing(ctx, update, callback)
+			c.processing(ctx, update, callback, documentCallback)
 		case <-ctx.Done():
 			c.client.StopReceivingUpdates()
 
@@ -63,15 +149,209 @@ func (c *Client) Read(ctx context.Context, callback MsgCallback) {
 	}
 }
 
-func (c *Client) processing(ctx context.Context, update tgbotapi.Update, callback MsgCallback) {
+func (c *Client) processing(ctx context.Context, update tgbotapi.Update, callback MsgCallback,
+	documentCallback DocumentCallback,
+) {
 	ctx, span := otel.Tracer("tgClient").Start(ctx, "processing")
 	defer span.End()
 
+	if update.CallbackQuery != nil {
+		c.processingCallbackQuery(ctx, update.CallbackQuery, callback)
+
+		return
+	}
+
 	if update.Message == nil {
 		return
 	}
 
-	logger.Infof("client.read: [%s][%d][%s]", update.Message.From.UserName, update.Message.From.ID, update.Message.Text)
+	message := update.Message
+	if message.From == nil || message.Chat == nil {
+		return
+	}
+
+	if message.Document != nil {
+		c.processingDocument(ctx, message, documentCallback)
+
+		return
+	}
+
+	if len(message.Photo) != 0 {
+		c.processingPhoto(ctx, message, documentCallback)
+
+		return
+	}
+
+	text, ok := addressedText(message.Text, message.Chat, c.client.Self.UserName, isReplyToBot(message, c.client.Self.ID))
+	if !ok {
+		return
+	}
+
+	logger.Infof("client.read: [%d][%s][%d][%s]", message.Chat.ID, message.From.UserName, message.From.ID, text)
+
+	callback(ctx, message.Chat.ID, message.From.ID, message.From.LanguageCode, message.Time(), text)
+}
+
+// addressedText возвращает текст сообщения без обращения к боту. В личном чате боту
+// адресованы все сообщения, в групповом - начинающиеся с @botName, команды /cmd без
+// имени бота или с ним и ответы на сообщения бота. Остальные сообщения группы
+// предназначены ее участникам и игнорируются.
+func addressedText(text string, chat *tgbotapi.Chat, botName string, replyToBot bool) (string, bool) {
+	if chat == nil || chat.IsPrivate() {
+		return text, true
+	}
+
+	mention := "@" + botName
+
+	first, rest, _ := strings.Cut(strings.TrimSpace(text), " ")
+
+	switch {
+	case strings.EqualFold(first, mention):
+		return strings.TrimSpace(rest), true
+	case strings.HasPrefix(first, "/"):
+		command, name, found := strings.Cut(first, "@")
+		if found && !strings.EqualFold(name, botName) {
+			return "", false
+		}
+
+		return strings.TrimSpace(command + " " + rest), true
+	case replyToBot:
+		return text, true
+	default:
+		return "", false
+	}
+}
+
+func isReplyToBot(message *tgbotapi.Message, botID int64) bool {
+	return message.ReplyToMessage != nil && message.ReplyToMessage.From != nil &&
+		message.ReplyToMessage.From.ID == botID
+}
+
+// processingCallbackQuery обрабатывает нажатие кнопки как сообщение с текстом из ее данных.
+// Ответ на запрос обязателен, иначе Telegram долго показывает на кнопке индикатор загрузки.
+func (c *Client) processingCallbackQuery(ctx context.Context, query *tgbotapi.CallbackQuery, callback MsgCallback) {
+	// Кнопки групповых чатов нажимают в самом чате, ответ уходит туда же
+	chatID := query.From.ID
+	if query.Message != nil && query.Message.Chat != nil {
+		chatID = query.Message.Chat.ID
+	}
+
+	logger.Infof("client.read callback: [%d][%s][%d][%s]", chatID, query.From.UserName, query.From.ID, query.Data)
+
+	_, err := c.client.Request(tgbotapi.NewCallback(query.ID, ""))
+	if err != nil {
+		logger.Errorf("can not answer callback query: %v", err)
+	}
+
+	callback(ctx, chatID, query.From.ID, query.From.LanguageCode, time.Now(), query.Data)
+}
+
+func (c *Client) processingDocument(ctx context.Context, message *tgbotapi.Message, callback DocumentCallback) {
+	document := message.Document
+
+	caption, ok := addressedText(message.Caption, message.Chat, c.client.Self.UserName,
+		isReplyToBot(message, c.client.Self.ID))
+	if !ok {
+		return
+	}
+
+	logger.Infof("client.read document: [%d][%s][%d][%s]", message.Chat.ID, message.From.UserName, message.From.ID,
+		document.FileName)
+
+	if document.FileSize > maxDocumentSize {
+		locale, _ := i18n.ParseLocale(message.From.LanguageCode)
+		text := i18n.Sprintf(locale, "Файл слишком большой, максимум %d КБ", maxDocumentSize>>10)
+
+		err := c.Write(ctx, text, message.Chat.ID)
+		if err != nil {
+			logger.Errorf("can not write message: %v", err)
+		}
+
+		return
+	}
+
+	data, err := c.download(ctx, document.FileID)
+	if err != nil {
+		logger.Errorf("can not download document: %v", err)
+
+		return
+	}
+
+	callback(ctx, message.Chat.ID, message.From.ID, message.From.LanguageCode, message.Time(), caption,
+		document.FileName, data)
+}
+
+// processingPhoto передает фото, например чека, как файл photoFileName. Telegram присылает
+// фото в нескольких размерах, берется самое крупное, которое пройдет через kafka.
+func (c *Client) processingPhoto(ctx context.Context, message *tgbotapi.Message, callback DocumentCallback) {
+	caption, ok := addressedText(message.Caption, message.Chat, c.client.Self.UserName,
+		isReplyToBot(message, c.client.Self.ID))
+	if !ok {
+		return
+	}
+
+	photo, ok := largestPhoto(message.Photo, maxDocumentSize)
+	if !ok {
+		return
+	}
+
+	logger.Infof("client.read photo: [%d][%s][%d][%dx%d]", message.Chat.ID, message.From.UserName, message.From.ID,
+		photo.Width, photo.Height)
+
+	data, err := c.download(ctx, photo.FileID)
+	if err != nil {
+		logger.Errorf("can not download photo: %v", err)
+
+		return
+	}
+
+	callback(ctx, message.Chat.ID, message.From.ID, message.From.LanguageCode, message.Time(), caption,
+		photoFileName, data)
+}
+
+// largestPhoto самый крупный размер фото, который не больше maxSize байт.
+func largestPhoto(sizes []tgbotapi.PhotoSize, maxSize int) (tgbotapi.PhotoSize, bool) {
+	var (
+		largest tgbotapi.PhotoSize
+		found   bool
+	)
+
+	for _, size := range sizes {
+		if size.FileSize > maxSize || (found && size.Width*size.Height <= largest.Width*largest.Height) {
+			continue
+		}
+
+		largest, found = size, true
+	}
+
+	return largest, found
+}
+
+func (c *Client) download(ctx context.Context, fileID string) ([]byte, error) {
+	ctx, span := otel.Tracer("tgClient").Start(ctx, "download")
+	defer span.End()
+
+	url, err := c.client.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "client.download")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "client.download")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "client.download")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("client.download: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
 
-	callback(ctx, update.Message.From.ID, update.Message.Time(), update.Message.Text)
+	return data, errors.Wrap(err, "client.download")
 }
@@ -0,0 +1,63 @@
+package tg
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressedText(t *testing.T) {
+	t.Parallel()
+
+	private := &tgbotapi.Chat{ID: 101, Type: "private"} //nolint:exhaustruct
+	group := &tgbotapi.Chat{ID: -500, Type: "group"}    //nolint:exhaustruct
+
+	tests := []struct {
+		name       string
+		text       string
+		chat       *tgbotapi.Chat
+		replyToBot bool
+		want       string
+		ok         bool
+	}{
+		{name: "private", text: "расход такси 300", chat: private, want: "расход такси 300", ok: true},
+		{name: "group mention", text: "@ExpenseBot расход такси 300", chat: group, want: "расход такси 300", ok: true},
+		{name: "group mention case", text: "@expensebot отчет неделя", chat: group, want: "отчет неделя", ok: true},
+		{name: "group command", text: "/help", chat: group, want: "/help", ok: true},
+		{name: "group command to bot", text: "/report@ExpenseBot неделя", chat: group, want: "/report неделя", ok: true},
+		{name: "group command to other bot", text: "/help@OtherBot", chat: group, want: "", ok: false},
+		{name: "group reply", text: "такси", chat: group, replyToBot: true, want: "такси", ok: true},
+		{name: "group chatter", text: "кто идет обедать?", chat: group, want: "", ok: false},
+		{name: "group other mention", text: "@someone привет", chat: group, want: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := addressedText(tt.text, tt.chat, "ExpenseBot", tt.replyToBot)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLargestPhoto(t *testing.T) {
+	t.Parallel()
+
+	sizes := []tgbotapi.PhotoSize{
+		{FileID: "small", Width: 90, Height: 160, FileSize: 2 << 10},
+		{FileID: "medium", Width: 720, Height: 1280, FileSize: 100 << 10},
+		{FileID: "large", Width: 1440, Height: 2560, FileSize: 600 << 10},
+	}
+
+	photo, ok := largestPhoto(sizes, 512<<10)
+	assert.True(t, ok)
+	assert.Equal(t, "medium", photo.FileID)
+
+	_, ok = largestPhoto(sizes, 1<<10)
+	assert.False(t, ok)
+}
@@ -20,6 +20,10 @@ type Config struct {
 	Kafka         KafkaConfig         `yaml:"kafka"`
 	Prometheus    PrometheusConfig    `yaml:"prometheus"`
 	ReportService ReportServiceConfig `yaml:"reportService"`
+	Limits        LimitsConfig        `yaml:"limits"`
+	Summary       SummaryConfig       `yaml:"summary"`
+	Recurring     RecurringConfig     `yaml:"recurring"`
+	Goals         GoalsConfig         `yaml:"goals"`
 }
 
 type LoggerConfig struct {
@@ -35,6 +39,29 @@ type RatesConfig struct {
 	Base            string   `yaml:"base"`
 	Codes           []string `yaml:"codes"`
 	FreqUpdateInSec int      `yaml:"freqUpdateInSec"`
+	// ConvertAtCurrentRate пересчитывать отчеты и лимиты по текущему курсу, а не по курсу на дату расхода
+	ConvertAtCurrentRate bool `yaml:"convertAtCurrentRate"`
+	// Providers источники курсов в порядке опроса. Если не заданы, используется только Service.
+	// В отличие от Service, неизвестное имя источника здесь - ошибка конфига
+	Providers []RatesProviderConfig `yaml:"providers"`
+	Breaker   RatesBreakerConfig    `yaml:"breaker"`
+}
+
+// RatesProviderConfig источник курсов: cbr, exchangerate или file. URL переопределяет адрес
+// сервиса, File - путь к файлу с курсами в JSON или XML для file.
+type RatesProviderConfig struct {
+	Name         string `yaml:"name"`
+	URL          string `yaml:"url"`
+	File         string `yaml:"file"`
+	TimeoutMs    int    `yaml:"timeoutMs"`
+	Retries      int    `yaml:"retries"`
+	RetryDelayMs int    `yaml:"retryDelayMs"`
+}
+
+// RatesBreakerConfig после Failures неудач подряд источник пропускается CooldownSec секунд.
+type RatesBreakerConfig struct {
+	Failures    int `yaml:"failures"`
+	CooldownSec int `yaml:"cooldownSec"`
 }
 
 type DatabaseConfig struct {
@@ -63,6 +90,23 @@ type ReportServiceConfig struct {
 	Addr string `yaml:"addr"`
 }
 
+type LimitsConfig struct {
+	// Пороги уведомлений в процентах от лимита, например [80, 100]
+	Thresholds []int `yaml:"thresholds"`
+}
+
+type SummaryConfig struct {
+	FreqCheckInSec int `yaml:"freqCheckInSec"`
+}
+
+type RecurringConfig struct {
+	FreqCheckInSec int `yaml:"freqCheckInSec"`
+}
+
+type GoalsConfig struct {
+	FreqCheckInSec int `yaml:"freqCheckInSec"`
+}
+
 func New(file string) (*Config, error) {
 	var cfg Config
 
@@ -99,10 +143,37 @@ func (c Config) GetFrequencyRateUpdateSec() int {
 	return c.Rates.FreqUpdateInSec
 }
 
+func (c Config) GetConvertAtCurrentRate() bool {
+	return c.Rates.ConvertAtCurrentRate
+}
+
 func (c Config) GetRatesService() string {
 	return c.Rates.Service
 }
 
+// GetRatesProviders без списка providers используется один сервис из service: cbr,
+// а любое другое значение, как и раньше, означает exchangerate.
+func (c Config) GetRatesProviders() []RatesProviderConfig {
+	if len(c.Rates.Providers) != 0 {
+		return c.Rates.Providers
+	}
+
+	name := "exchangerate"
+	if c.Rates.Service == "cbr" {
+		name = "cbr"
+	}
+
+	return []RatesProviderConfig{{Name: name}} //nolint:exhaustruct
+}
+
+func (c Config) GetRatesBreakerFailures() int {
+	return c.Rates.Breaker.Failures
+}
+
+func (c Config) GetRatesBreakerCooldownSec() int {
+	return c.Rates.Breaker.CooldownSec
+}
+
 func (c Config) GetDatabaseURL() string {
 	return c.Database.URL
 }
@@ -146,3 +217,19 @@ func (c Config) GetPrometheusAddr() string {
 func (c Config) GetReportServiceAddr() string {
 	return c.ReportService.Addr
 }
+
+func (c Config) GetLimitThresholds() []int {
+	return c.Limits.Thresholds
+}
+
+func (c Config) GetFrequencySummaryCheckSec() int {
+	return c.Summary.FreqCheckInSec
+}
+
+func (c Config) GetFrequencyRecurringCheckSec() int {
+	return c.Recurring.FreqCheckInSec
+}
+
+func (c Config) GetFrequencyGoalCheckSec() int {
+	return c.Goals.FreqCheckInSec
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "github.com/shopspring/decimal"
+
+type AccountID int64
+
+// Account счет пользователя со своей валютой. Остаток считается при чтении из
+// начального остатка, доходов, расходов и переводов по счету.
+type Account struct {
+	id             AccountID
+	name           string
+	currency       string
+	initialBalance decimal.Decimal
+	balance        decimal.Decimal
+}
+
+func NewAccount(name, currency string, initialBalance decimal.Decimal) Account {
+	return Account{
+		id:             0,
+		name:           name,
+		currency:       currency,
+		initialBalance: initialBalance,
+		balance:        initialBalance,
+	}
+}
+
+func (a *Account) GetID() AccountID {
+	return a.id
+}
+
+func (a *Account) SetID(id AccountID) {
+	a.id = id
+}
+
+func (a *Account) GetName() string {
+	return a.name
+}
+
+func (a *Account) GetCurrency() string {
+	return a.currency
+}
+
+func (a *Account) GetInitialBalance() decimal.Decimal {
+	return a.initialBalance
+}
+
+// GetBalance текущий остаток в валюте счета.
+func (a *Account) GetBalance() decimal.Decimal {
+	return a.balance
+}
+
+func (a *Account) SetBalance(balance decimal.Decimal) {
+	a.balance = balance
+}
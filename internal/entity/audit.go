@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+type AuditRecordID int64
+
+// Действия, которые записываются в журнал изменений и могут быть отменены.
+const (
+	AuditAddExpense  = "add_expense"
+	AuditSetLimit    = "set_limit"
+	AuditSetCurrency = "set_currency"
+)
+
+// AuditRecord запись журнала изменений. userID - кто выполнил действие, ownerID - чьи
+// данные изменены. Старое и новое значения хранятся в JSON, формат зависит от действия.
+type AuditRecord struct {
+	id        AuditRecordID
+	userID    UserID
+	ownerID   UserID
+	action    string
+	oldValue  string
+	newValue  string
+	createdAt time.Time
+}
+
+func NewAuditRecord(userID, ownerID UserID, action, oldValue, newValue string, createdAt time.Time) AuditRecord {
+	return AuditRecord{
+		id:        0,
+		userID:    userID,
+		ownerID:   ownerID,
+		action:    action,
+		oldValue:  oldValue,
+		newValue:  newValue,
+		createdAt: createdAt,
+	}
+}
+
+func (a *AuditRecord) GetID() AuditRecordID {
+	return a.id
+}
+
+func (a *AuditRecord) SetID(id AuditRecordID) {
+	a.id = id
+}
+
+func (a *AuditRecord) GetUserID() UserID {
+	return a.userID
+}
+
+func (a *AuditRecord) GetOwnerID() UserID {
+	return a.ownerID
+}
+
+func (a *AuditRecord) GetAction() string {
+	return a.action
+}
+
+func (a *AuditRecord) GetOldValue() string {
+	return a.oldValue
+}
+
+func (a *AuditRecord) GetNewValue() string {
+	return a.newValue
+}
+
+func (a *AuditRecord) GetCreatedAt() time.Time {
+	return a.createdAt
+}
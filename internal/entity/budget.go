@@ -0,0 +1,29 @@
+package entity
+
+// Budget общий бюджет. Его данные хранятся под ID владельца, участники
+// присоединяются по коду приглашения.
+type Budget struct {
+	ownerID    UserID
+	inviteCode string
+	members    []UserID
+}
+
+func NewBudget(ownerID UserID, inviteCode string, members []UserID) Budget {
+	return Budget{
+		ownerID:    ownerID,
+		inviteCode: inviteCode,
+		members:    members,
+	}
+}
+
+func (b *Budget) GetOwnerID() UserID {
+	return b.ownerID
+}
+
+func (b *Budget) GetInviteCode() string {
+	return b.inviteCode
+}
+
+func (b *Budget) GetMembers() []UserID {
+	return b.members
+}
@@ -0,0 +1,44 @@
+package entity
+
+type CategoryID int64
+
+type Category struct {
+	id       CategoryID
+	name     string
+	parentID CategoryID
+	aliases  []string
+}
+
+func NewCategory(name string, parentID CategoryID) Category {
+	return Category{
+		id:       0,
+		name:     name,
+		parentID: parentID,
+		aliases:  nil,
+	}
+}
+
+func (c *Category) GetID() CategoryID {
+	return c.id
+}
+
+func (c *Category) SetID(id CategoryID) {
+	c.id = id
+}
+
+func (c *Category) GetName() string {
+	return c.name
+}
+
+// GetParentID возвращает 0 для категории верхнего уровня.
+func (c *Category) GetParentID() CategoryID {
+	return c.parentID
+}
+
+func (c *Category) GetAliases() []string {
+	return c.aliases
+}
+
+func (c *Category) SetAliases(aliases []string) {
+	c.aliases = aliases
+}
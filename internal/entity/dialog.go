@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Dialog команда, аргументы которой бот спрашивает у пользователя по одному.
+// answers - ответы на уже заданные вопросы по порядку.
+type Dialog struct {
+	name      string
+	answers   []string
+	expiresAt time.Time
+}
+
+func NewDialog(name string, answers []string, expiresAt time.Time) Dialog {
+	return Dialog{
+		name:      name,
+		answers:   answers,
+		expiresAt: expiresAt,
+	}
+}
+
+func (d *Dialog) GetName() string {
+	return d.name
+}
+
+func (d *Dialog) GetAnswers() []string {
+	return d.answers
+}
+
+func (d *Dialog) GetExpiresAt() time.Time {
+	return d.expiresAt
+}
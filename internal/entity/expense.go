@@ -6,28 +6,117 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type ExpenseID int64
+
 type Expense struct {
-	category string
-	price    decimal.Decimal
-	date     time.Time
+	id               ExpenseID
+	category         string
+	price            decimal.Decimal
+	date             time.Time
+	originalPrice    decimal.Decimal
+	originalCurrency string
+	externalID       string
+	memberID         UserID
+	note             string
+	accountID        AccountID
+	accountAmount    decimal.Decimal
 }
 
 func NewExpense(category string, price decimal.Decimal, date time.Time) Expense {
 	return Expense{
-		category: category,
-		price:    price,
-		date:     date,
+		id:               0,
+		category:         category,
+		price:            price,
+		date:             date,
+		originalPrice:    price,
+		originalCurrency: "",
+		externalID:       "",
+		memberID:         0,
+		note:             "",
+		accountID:        0,
+		accountAmount:    decimal.Zero,
 	}
 }
 
+func (e *Expense) GetID() ExpenseID {
+	return e.id
+}
+
+func (e *Expense) SetID(id ExpenseID) {
+	e.id = id
+}
+
 func (e *Expense) GetCategory() string {
 	return e.category
 }
 
+func (e *Expense) SetCategory(category string) {
+	e.category = category
+}
+
 func (e *Expense) GetPrice() decimal.Decimal {
 	return e.price
 }
 
+func (e *Expense) SetPrice(price decimal.Decimal) {
+	e.price = price
+}
+
 func (e *Expense) GetDate() time.Time {
 	return e.date
 }
+
+func (e *Expense) GetOriginalPrice() decimal.Decimal {
+	return e.originalPrice
+}
+
+func (e *Expense) GetOriginalCurrency() string {
+	return e.originalCurrency
+}
+
+func (e *Expense) SetOriginalPrice(price decimal.Decimal, currency string) {
+	e.originalPrice = price
+	e.originalCurrency = currency
+}
+
+// GetExternalID возвращает ключ идемпотентности расхода, добавленного автоматически.
+func (e *Expense) GetExternalID() string {
+	return e.externalID
+}
+
+func (e *Expense) SetExternalID(externalID string) {
+	e.externalID = externalID
+}
+
+// GetMemberID возвращает участника общего бюджета, добавившего расход. Ноль - владелец данных.
+func (e *Expense) GetMemberID() UserID {
+	return e.memberID
+}
+
+func (e *Expense) SetMemberID(memberID UserID) {
+	e.memberID = memberID
+}
+
+// GetNote необязательная заметка к расходу.
+func (e *Expense) GetNote() string {
+	return e.note
+}
+
+func (e *Expense) SetNote(note string) {
+	e.note = note
+}
+
+// GetAccountID возвращает счет, с которого оплачен расход. Ноль - счет не указан.
+func (e *Expense) GetAccountID() AccountID {
+	return e.accountID
+}
+
+// GetAccountAmount сумма расхода в валюте счета.
+func (e *Expense) GetAccountAmount() decimal.Decimal {
+	return e.accountAmount
+}
+
+func (e *Expense) SetAccount(accountID AccountID, amount decimal.Decimal) {
+	e.accountID = accountID
+	e.accountAmount = amount
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type GoalID int64
+
+// Goal цель накоплений к дате deadline. Сумма цели и накопленное указаны в валюте
+// currency, накопленное считается при чтении из взносов.
+type Goal struct {
+	id        GoalID
+	userID    UserID
+	name      string
+	target    decimal.Decimal
+	currency  string
+	deadline  time.Time
+	createdAt time.Time
+	saved     decimal.Decimal
+}
+
+func NewGoal(userID UserID, name string, target decimal.Decimal, currency string, deadline, createdAt time.Time,
+) Goal {
+	return Goal{
+		id:        0,
+		userID:    userID,
+		name:      name,
+		target:    target,
+		currency:  currency,
+		deadline:  deadline,
+		createdAt: createdAt,
+		saved:     decimal.Zero,
+	}
+}
+
+func (g *Goal) GetID() GoalID {
+	return g.id
+}
+
+func (g *Goal) SetID(id GoalID) {
+	g.id = id
+}
+
+func (g *Goal) GetUserID() UserID {
+	return g.userID
+}
+
+func (g *Goal) GetName() string {
+	return g.name
+}
+
+func (g *Goal) GetTarget() decimal.Decimal {
+	return g.target
+}
+
+func (g *Goal) GetCurrency() string {
+	return g.currency
+}
+
+func (g *Goal) GetDeadline() time.Time {
+	return g.deadline
+}
+
+func (g *Goal) GetCreatedAt() time.Time {
+	return g.createdAt
+}
+
+// GetSaved сумма взносов в валюте цели.
+func (g *Goal) GetSaved() decimal.Decimal {
+	return g.saved
+}
+
+func (g *Goal) SetSaved(saved decimal.Decimal) {
+	g.saved = saved
+}
@@ -0,0 +1,55 @@
+package entity
+
+// ImportColumns номера колонок выписки банка, начиная с единицы. Нулевой номер - колонки нет.
+type ImportColumns struct {
+	date        int
+	amount      int
+	description int
+	currency    int
+}
+
+func NewImportColumns(date, amount, description, currency int) ImportColumns {
+	return ImportColumns{
+		date:        date,
+		amount:      amount,
+		description: description,
+		currency:    currency,
+	}
+}
+
+func (c *ImportColumns) GetDate() int {
+	return c.date
+}
+
+func (c *ImportColumns) GetAmount() int {
+	return c.amount
+}
+
+func (c *ImportColumns) GetDescription() int {
+	return c.description
+}
+
+func (c *ImportColumns) GetCurrency() int {
+	return c.currency
+}
+
+// ImportRule относит операции выписки, в описании которых есть keyword, к категории.
+type ImportRule struct {
+	keyword  string
+	category string
+}
+
+func NewImportRule(keyword, category string) ImportRule {
+	return ImportRule{
+		keyword:  keyword,
+		category: category,
+	}
+}
+
+func (r *ImportRule) GetKeyword() string {
+	return r.keyword
+}
+
+func (r *ImportRule) GetCategory() string {
+	return r.category
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type IncomeID int64
+
+// Income доход пользователя из источника source. Сумма хранится в базовой валюте.
+type Income struct {
+	id               IncomeID
+	source           string
+	amount           decimal.Decimal
+	date             time.Time
+	originalAmount   decimal.Decimal
+	originalCurrency string
+	memberID         UserID
+	accountID        AccountID
+	accountAmount    decimal.Decimal
+}
+
+func NewIncome(source string, amount decimal.Decimal, date time.Time) Income {
+	return Income{
+		id:               0,
+		source:           source,
+		amount:           amount,
+		date:             date,
+		originalAmount:   amount,
+		originalCurrency: "",
+		memberID:         0,
+		accountID:        0,
+		accountAmount:    decimal.Zero,
+	}
+}
+
+func (i *Income) GetID() IncomeID {
+	return i.id
+}
+
+func (i *Income) SetID(id IncomeID) {
+	i.id = id
+}
+
+func (i *Income) GetSource() string {
+	return i.source
+}
+
+func (i *Income) GetAmount() decimal.Decimal {
+	return i.amount
+}
+
+func (i *Income) GetDate() time.Time {
+	return i.date
+}
+
+func (i *Income) GetOriginalAmount() decimal.Decimal {
+	return i.originalAmount
+}
+
+func (i *Income) GetOriginalCurrency() string {
+	return i.originalCurrency
+}
+
+func (i *Income) SetAmount(amount decimal.Decimal) {
+	i.amount = amount
+}
+
+func (i *Income) SetOriginalAmount(amount decimal.Decimal, currency string) {
+	i.originalAmount = amount
+	i.originalCurrency = currency
+}
+
+// GetMemberID возвращает участника общего бюджета, добавившего доход. Ноль - владелец данных.
+func (i *Income) GetMemberID() UserID {
+	return i.memberID
+}
+
+func (i *Income) SetMemberID(memberID UserID) {
+	i.memberID = memberID
+}
+
+// GetAccountID возвращает счет, на который зачислен доход. Ноль - счет не указан.
+func (i *Income) GetAccountID() AccountID {
+	return i.accountID
+}
+
+// GetAccountAmount сумма дохода в валюте счета.
+func (i *Income) GetAccountAmount() decimal.Decimal {
+	return i.accountAmount
+}
+
+func (i *Income) SetAccount(accountID AccountID, amount decimal.Decimal) {
+	i.accountID = accountID
+	i.accountAmount = amount
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "github.com/shopspring/decimal"
+
+type Limit struct {
+	category     string
+	intervalType int
+	amount       decimal.Decimal
+}
+
+// NewLimit создает лимит на категорию за интервал. Пустая категория - лимит на все расходы.
+func NewLimit(category string, intervalType int, amount decimal.Decimal) Limit {
+	return Limit{
+		category:     category,
+		intervalType: intervalType,
+		amount:       amount,
+	}
+}
+
+func (l *Limit) GetCategory() string {
+	return l.category
+}
+
+func (l *Limit) GetIntervalType() int {
+	return l.intervalType
+}
+
+func (l *Limit) GetAmount() decimal.Decimal {
+	return l.amount
+}
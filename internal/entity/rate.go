@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"sort"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -10,13 +11,16 @@ type Rate struct {
 	code  string
 	ratio decimal.Decimal
 	time  time.Time
+	// source источник, от которого получен курс
+	source string
 }
 
 func NewRate(code string, ratio decimal.Decimal, time time.Time) Rate {
 	return Rate{
-		code:  code,
-		ratio: ratio,
-		time:  time,
+		code:   code,
+		ratio:  ratio,
+		time:   time,
+		source: "",
 	}
 }
 
@@ -31,3 +35,51 @@ func (r Rate) GetRatio() decimal.Decimal {
 func (r Rate) GetTime() time.Time {
 	return r.time
 }
+
+func (r Rate) GetSource() string {
+	return r.source
+}
+
+func (r *Rate) SetSource(source string) {
+	r.source = source
+}
+
+// rateDayLayout формат дня, по которому курсы сопоставляются с датами операций.
+const rateDayLayout = "2006-01-02"
+
+// RateHistory курсы одной валюты по дням. Для дня без курса берется последний известный до него,
+// для дней раньше истории - самый ранний, а без истории - fallback.
+type RateHistory struct {
+	days     []string
+	rates    []Rate
+	fallback Rate
+}
+
+// NewRateHistory rates должны быть отсортированы по возрастанию дня.
+func NewRateHistory(rates []Rate, fallback Rate) RateHistory {
+	days := make([]string, 0, len(rates))
+	for _, rate := range rates {
+		days = append(days, rate.GetTime().Format(rateDayLayout))
+	}
+
+	return RateHistory{
+		days:     days,
+		rates:    rates,
+		fallback: fallback,
+	}
+}
+
+func (h RateHistory) GetRatio(date time.Time) decimal.Decimal {
+	if len(h.rates) == 0 {
+		return h.fallback.GetRatio()
+	}
+
+	day := date.Format(rateDayLayout)
+
+	i := sort.Search(len(h.days), func(i int) bool { return h.days[i] > day }) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return h.rates[i].GetRatio()
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type RecurringExpenseID int64
+
+// RecurringExpense расписание регулярного расхода. Сумма указана в валюте currency.
+type RecurringExpense struct {
+	id           RecurringExpenseID
+	userID       UserID
+	category     string
+	price        decimal.Decimal
+	currency     string
+	intervalType int
+	startDate    time.Time
+	nextDate     time.Time
+}
+
+func NewRecurringExpense(userID UserID, category string, price decimal.Decimal, currency string,
+	intervalType int, startDate time.Time,
+) RecurringExpense {
+	return RecurringExpense{
+		id:           0,
+		userID:       userID,
+		category:     category,
+		price:        price,
+		currency:     currency,
+		intervalType: intervalType,
+		startDate:    startDate,
+		nextDate:     startDate,
+	}
+}
+
+func (r *RecurringExpense) GetID() RecurringExpenseID {
+	return r.id
+}
+
+func (r *RecurringExpense) SetID(id RecurringExpenseID) {
+	r.id = id
+}
+
+func (r *RecurringExpense) GetUserID() UserID {
+	return r.userID
+}
+
+func (r *RecurringExpense) GetCategory() string {
+	return r.category
+}
+
+func (r *RecurringExpense) GetPrice() decimal.Decimal {
+	return r.price
+}
+
+func (r *RecurringExpense) GetCurrency() string {
+	return r.currency
+}
+
+func (r *RecurringExpense) GetIntervalType() int {
+	return r.intervalType
+}
+
+func (r *RecurringExpense) GetStartDate() time.Time {
+	return r.startDate
+}
+
+func (r *RecurringExpense) GetNextDate() time.Time {
+	return r.nextDate
+}
+
+func (r *RecurringExpense) SetNextDate(date time.Time) {
+	r.nextDate = date
+}
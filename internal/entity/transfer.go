@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TransferID int64
+
+// Transfer перевод между счетами. Суммы указаны в валютах счетов списания и зачисления.
+type Transfer struct {
+	id            TransferID
+	fromAccountID AccountID
+	toAccountID   AccountID
+	amountFrom    decimal.Decimal
+	amountTo      decimal.Decimal
+	date          time.Time
+}
+
+func NewTransfer(fromAccountID, toAccountID AccountID, amountFrom, amountTo decimal.Decimal, date time.Time,
+) Transfer {
+	return Transfer{
+		id:            0,
+		fromAccountID: fromAccountID,
+		toAccountID:   toAccountID,
+		amountFrom:    amountFrom,
+		amountTo:      amountTo,
+		date:          date,
+	}
+}
+
+func (t *Transfer) GetID() TransferID {
+	return t.id
+}
+
+func (t *Transfer) SetID(id TransferID) {
+	t.id = id
+}
+
+func (t *Transfer) GetFromAccountID() AccountID {
+	return t.fromAccountID
+}
+
+func (t *Transfer) GetToAccountID() AccountID {
+	return t.toAccountID
+}
+
+func (t *Transfer) GetAmountFrom() decimal.Decimal {
+	return t.amountFrom
+}
+
+func (t *Transfer) GetAmountTo() decimal.Decimal {
+	return t.amountTo
+}
+
+func (t *Transfer) GetDate() time.Time {
+	return t.date
+}
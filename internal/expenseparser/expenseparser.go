@@ -0,0 +1,308 @@
+// Package expenseparser распознает расход в сообщении свободной формы: "кофе 250",
+// "250р такси", "потратил 1.5к на продукты вчера", "12,50€ lunch". Сумма, валюта,
+// дата и категория могут идти в любом порядке.
+package expenseparser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+// MinConfidence уверенность, с которой расход добавляется без подтверждения.
+const MinConfidence = 0.75
+
+// Больше вариантов пользователю не предлагается, такое сообщение вряд ли было расходом.
+const maxVariants = 4
+
+// Expense один из вариантов прочтения сообщения. Пустая валюта - валюта по умолчанию.
+type Expense struct {
+	Category string
+	Price    decimal.Decimal
+	Currency string
+	Date     time.Time
+}
+
+// Result варианты расхода, самый вероятный первый. Confidence - уверенность в первом
+// варианте от 0 до 1.
+type Result struct {
+	Variants   []Expense
+	Confidence float64
+}
+
+// Символы и названия валют, в том числе приклеенные к сумме.
+var currencies = map[string]string{
+	"₽": "RUB", "р": "RUB", "руб": "RUB", "рубль": "RUB", "рубля": "RUB", "рублей": "RUB", "rub": "RUB",
+	"$": "USD", "usd": "USD", "доллар": "USD", "доллара": "USD", "долларов": "USD", "dollars": "USD",
+	"€": "EUR", "eur": "EUR", "евро": "EUR", "euro": "EUR",
+	"£": "GBP", "gbp": "GBP", "фунтов": "GBP",
+	"¥": "CNY", "cny": "CNY", "юань": "CNY", "юаня": "CNY", "юаней": "CNY",
+}
+
+// Множители суммы: 1.5к - полторы тысячи.
+var multipliers = map[string]int64{
+	"к": 1000, "k": 1000, "тыс": 1000,
+}
+
+// Слова, которые не несут смысла для расхода.
+var fillers = map[string]bool{
+	"потратил": true, "потратила": true, "потратили": true, "купил": true, "купила": true,
+	"купили": true, "заплатил": true, "заплатила": true, "на": true, "за": true, "в": true,
+	"spent": true, "paid": true, "bought": true, "on": true, "for": true, "at": true,
+}
+
+// Английские относительные даты, остальные даты понимает utils.ParseDate.
+var dateAliases = map[string]string{
+	"today":     "сегодня",
+	"yesterday": "вчера",
+}
+
+var amountRe = regexp.MustCompile(`^([^\d.,]*)(\d+(?:[.,]\d+)?)([^\d.,]*)$`)
+
+// token слово сообщения и все его возможные прочтения.
+type token struct {
+	text     string
+	price    decimal.Decimal
+	currency string
+	date     time.Time
+	isPrice  bool
+	isDate   bool
+	// marked сумма с валютой или множителем, ее не спутать с датой
+	marked bool
+}
+
+// Parse разбирает сообщение относительно момента now. Сообщение, в котором нет
+// ровно одной категории и суммы хотя бы в одном прочтении, расходом не считается.
+func Parse(text string, now time.Time) (Result, bool) {
+	tokens := make([]token, 0)
+
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		field = strings.TrimRight(field, ".,!?;:")
+		if len(field) == 0 || fillers[field] {
+			continue
+		}
+
+		tokens = append(tokens, parseToken(field, now))
+	}
+
+	prices, dates, categories, currency, ok := classify(tokens)
+	if !ok {
+		return Result{}, false
+	}
+
+	return variants(prices, dates, categories, currency, now)
+}
+
+func parseToken(text string, now time.Time) token {
+	t := token{text: text}
+
+	if code, ok := currencies[text]; ok {
+		t.currency = code
+
+		return t
+	}
+
+	dateText := text
+	if alias, ok := dateAliases[text]; ok {
+		dateText = alias
+	}
+
+	t.date, t.isDate = utils.ParsePastDate(dateText, now)
+	t.price, t.currency, t.marked, t.isPrice = parseAmount(text)
+
+	return t
+}
+
+// parseAmount сумма с необязательными валютой перед или после нее и множителем:
+// 250, 250р, $5, 12,50€, 1.5к.
+func parseAmount(text string) (decimal.Decimal, string, bool, bool) {
+	match := amountRe.FindStringSubmatch(text)
+	if match == nil {
+		return decimal.Decimal{}, "", false, false
+	}
+
+	prefix, number, suffix := match[1], match[2], match[3]
+
+	price, err := decimal.NewFromString(strings.Replace(number, ",", ".", 1))
+	if err != nil || !price.IsPositive() {
+		return decimal.Decimal{}, "", false, false
+	}
+
+	var currency string
+
+	if len(prefix) != 0 {
+		code, ok := currencies[prefix]
+		if !ok {
+			return decimal.Decimal{}, "", false, false
+		}
+
+		currency = code
+	}
+
+	for unit, multiplier := range multipliers {
+		if strings.HasPrefix(suffix, unit) {
+			price, suffix = price.Mul(decimal.New(multiplier, 0)), strings.TrimPrefix(suffix, unit)
+
+			break
+		}
+	}
+
+	if len(suffix) != 0 {
+		code, ok := currencies[suffix]
+		if !ok || len(currency) != 0 {
+			return decimal.Decimal{}, "", false, false
+		}
+
+		currency = code
+	}
+
+	marked := len(prefix) != 0 || len(match[3]) != 0
+
+	return price, currency, marked, true
+}
+
+// classify делит слова на суммы, даты и категории. Число вида 15.10 может быть и
+// суммой, и датой, такие слова попадают в оба списка.
+func classify(tokens []token) ([]token, []token, []string, string, bool) {
+	prices := make([]token, 0)
+	dates := make([]token, 0)
+	categories := make([]string, 0)
+	currency := ""
+
+	setCurrency := func(code string) bool {
+		if len(code) == 0 {
+			return true
+		}
+
+		if len(currency) != 0 && currency != code {
+			return false
+		}
+
+		currency = code
+
+		return true
+	}
+
+	for _, t := range tokens {
+		switch {
+		case t.isPrice:
+			if !setCurrency(t.currency) {
+				return nil, nil, nil, "", false
+			}
+
+			prices = append(prices, t)
+
+			if t.isDate && !t.marked {
+				dates = append(dates, t)
+			}
+		case t.isDate:
+			dates = append(dates, t)
+		case len(t.currency) != 0:
+			if !setCurrency(t.currency) {
+				return nil, nil, nil, "", false
+			}
+		case isWord(t.text):
+			// Однобуквенные слова - обычно предлоги
+			if utf8.RuneCountInString(t.text) > 1 {
+				categories = append(categories, t.text)
+			}
+		default:
+			return nil, nil, nil, "", false
+		}
+	}
+
+	if len(prices) == 0 || len(categories) == 0 {
+		return nil, nil, nil, "", false
+	}
+
+	return prices, dates, categories, currency, true
+}
+
+// variants перебирает прочтения: одно из чисел - сумма, одно из оставшихся дат -
+// дата расхода, одно из слов - категория. Число, похожее на дату, при других
+// числах скорее дата, такие прочтения идут последними.
+func variants(prices, dates []token, categories []string, currency string, now time.Time,
+) (Result, bool) {
+	sort.SliceStable(prices, func(i, j int) bool {
+		return priceWeight(prices[i], len(prices)) > priceWeight(prices[j], len(prices))
+	})
+
+	expenses := make([]Expense, 0)
+	weights := make([]float64, 0)
+
+	for _, price := range prices {
+		date, ok := expenseDate(dates, price, now)
+		if !ok {
+			continue
+		}
+
+		for _, category := range categories {
+			expenses = append(expenses, Expense{
+				Category: category,
+				Price:    price.price,
+				Currency: currency,
+				Date:     date,
+			})
+			weights = append(weights, priceWeight(price, len(prices)))
+		}
+	}
+
+	if len(expenses) == 0 || len(expenses) > maxVariants {
+		return Result{}, false
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	return Result{
+		Variants:   expenses,
+		Confidence: weights[0] / total,
+	}, true
+}
+
+func priceWeight(price token, pricesCount int) float64 {
+	if pricesCount > 1 && price.isDate && !price.marked {
+		return 0.5 //nolint:gomnd
+	}
+
+	return 1
+}
+
+// expenseDate дата расхода, если сумма - price. Дат больше одной быть не может,
+// без даты расход относится к now.
+func expenseDate(dates []token, price token, now time.Time) (time.Time, bool) {
+	date, found := now, false
+
+	for _, t := range dates {
+		if t.text == price.text {
+			continue
+		}
+
+		if found {
+			return time.Time{}, false
+		}
+
+		date, found = t.date, true
+	}
+
+	return date, true
+}
+
+// isWord слово из букв, возможно через дефис.
+func isWord(text string) bool {
+	for _, r := range text {
+		if !unicode.IsLetter(r) && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
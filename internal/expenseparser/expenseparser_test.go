@@ -0,0 +1,73 @@
+package expenseparser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/expenseparser"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+
+	tests := []struct {
+		text       string
+		category   string
+		price      string
+		currency   string
+		date       time.Time
+		confidence float64
+	}{
+		{text: "кофе 250", category: "кофе", price: "250", date: now, confidence: 1},
+		{text: "250р такси", category: "такси", price: "250", currency: "RUB", date: now, confidence: 1},
+		{text: "потратил 1.5к на продукты вчера", category: "продукты", price: "1500", date: yesterday, confidence: 1},
+		{text: "12,50€ lunch", category: "lunch", price: "12.5", currency: "EUR", date: now, confidence: 1},
+		{text: "$5 coffee yesterday", category: "coffee", price: "5", currency: "USD", date: yesterday, confidence: 1},
+		{text: "такси 300 руб.", category: "такси", price: "300", currency: "RUB", date: now, confidence: 1},
+		{text: "кофе 15.10", category: "кофе", price: "15.1", date: now, confidence: 1},
+		{
+			text: "такси 15.10 250", category: "такси", price: "250", date: now.AddDate(0, 0, -3),
+			confidence: 1 / 1.5,
+		},
+		{text: "кофе с собой 250", category: "кофе", price: "250", date: now, confidence: 0.5},
+		{text: "такси 250 300", category: "такси", price: "250", date: now, confidence: 0.5},
+	}
+
+	for _, tt := range tests {
+		result, ok := expenseparser.Parse(tt.text, now)
+		if !assert.True(t, ok, tt.text) {
+			continue
+		}
+
+		expense := result.Variants[0]
+		assert.Equal(t, tt.category, expense.Category, tt.text)
+		assert.Equal(t, tt.price, expense.Price.String(), tt.text)
+		assert.Equal(t, tt.currency, expense.Currency, tt.text)
+		assert.Equal(t, tt.date, expense.Date, tt.text)
+		assert.InDelta(t, tt.confidence, result.Confidence, 0.01, tt.text)
+	}
+}
+
+func TestParse_NotExpense(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	for _, text := range []string{
+		"привет",
+		"250",
+		"/start",
+		"2кг яблок 300",
+		"такси 250$ 300€",
+		"такси 250 вчера позавчера",
+		"такси 250 20.10.2026",
+		"один два три четыре пять 100",
+	} {
+		_, ok := expenseparser.Parse(text, now)
+		assert.False(t, ok, text)
+	}
+}
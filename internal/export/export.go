@@ -0,0 +1,139 @@
+// Package export сохраняет таблицу расходов в файлы для выгрузки пользователю.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	dateLayout = "2006-01-02 15:04:05"
+	sheetName  = "Расходы"
+
+	// Excel без BOM открывает CSV в локальной кодировке и портит кириллицу
+	utf8BOM = "\xEF\xBB\xBF"
+)
+
+// Table таблица для выгрузки. Ячейки - строки, даты time.Time или суммы decimal.Decimal.
+type Table struct {
+	Header []string
+	Rows   [][]any
+}
+
+func CSV(table Table) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(utf8BOM)
+
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(table.Header)
+	if err != nil {
+		return nil, errors.Wrap(err, "export.CSV")
+	}
+
+	record := make([]string, len(table.Header))
+
+	for _, row := range table.Rows {
+		for i, cell := range row {
+			record[i] = formatCell(cell)
+		}
+
+		err := writer.Write(record)
+		if err != nil {
+			return nil, errors.Wrap(err, "export.CSV")
+		}
+	}
+
+	writer.Flush()
+
+	return buf.Bytes(), errors.Wrap(writer.Error(), "export.CSV")
+}
+
+func XLSX(table Table) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	err := file.SetSheetName(file.GetSheetName(0), sheetName)
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	writer, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	header := make([]any, 0, len(table.Header))
+	for _, title := range table.Header {
+		header = append(header, title)
+	}
+
+	err = writer.SetRow("A1", header)
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	dateStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: strPtr("yyyy-mm-dd hh:mm")}) //nolint:exhaustruct
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	for i, row := range table.Rows {
+		values := make([]any, 0, len(row))
+
+		for _, cell := range row {
+			switch value := cell.(type) {
+			case time.Time:
+				values = append(values, excelize.Cell{StyleID: dateStyle, Value: value}) //nolint:exhaustruct
+			case decimal.Decimal:
+				values = append(values, value.InexactFloat64())
+			default:
+				values = append(values, value)
+			}
+		}
+
+		axis, err := excelize.CoordinatesToCellName(1, i+2) //nolint:gomnd
+		if err != nil {
+			return nil, errors.Wrap(err, "export.XLSX")
+		}
+
+		err = writer.SetRow(axis, values)
+		if err != nil {
+			return nil, errors.Wrap(err, "export.XLSX")
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, errors.Wrap(err, "export.XLSX")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatCell(cell any) string {
+	switch value := cell.(type) {
+	case time.Time:
+		return value.Format(dateLayout)
+	case decimal.Decimal:
+		return value.StringFixed(2) //nolint:gomnd
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,54 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/export"
+)
+
+func testTable() export.Table {
+	return export.Table{
+		Header: []string{"Дата", "Категория", "Сумма"},
+		Rows: [][]any{
+			{time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC), "такси", decimal.RequireFromString("300.5")},
+			{time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "кафе, бар", decimal.RequireFromString("1250")},
+		},
+	}
+}
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	data, err := export.CSV(testTable())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "\xEF\xBB\xBF"+`Дата,Категория,Сумма
+2026-10-17 09:30:00,такси,300.50
+2026-10-18 12:00:00,"кафе, бар",1250.00
+`, string(data))
+}
+
+func TestXLSX(t *testing.T) {
+	t.Parallel()
+
+	data, err := export.XLSX(testTable())
+	assert.NoError(t, err)
+
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	defer file.Close()
+
+	rows, err := file.GetRows("Расходы", excelize.Options{RawCellValue: true}) //nolint:exhaustruct
+	assert.NoError(t, err)
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, []string{"Дата", "Категория", "Сумма"}, rows[0])
+	assert.Equal(t, "кафе, бар", rows[2][1])
+	assert.Equal(t, "1250", rows[2][2])
+}
@@ -0,0 +1,308 @@
+package i18n
+
+// english переводы на английский, ключ - русский текст.
+var english = map[string]string{
+	"help": `I understand the following commands:
+/start                               - welcome message
+/help                                - help
+/about                               - about the project
+/cancel                              - cancel the started dialog
+/undo                                - undo the last added expense, limit or currency change
+currency                             - list of currencies with buttons
+currency <currency>                  - set the default currency
+expense                              - add an expense step by step
+expense <category> <amount> <cur>    - add an expense
+  [yesterday|15.10|2026-10-15]       - optional expense date
+  [@account]                         - optional payment account, e.g. @card
+  [note]                             - optional note, e.g. dinner with Pete
+<category> <amount>                  - add an expense in free form, e.g. coffee 250 or $5 lunch
+receipt photo                        - add an expense from the receipt QR code
+edit <id> <category> <amount>        - correct an expense
+edit <id> <category>                 - change the expense category
+delete <id>                          - delete an expense
+income <source> <amount> [currency]  - add an income, e.g. income salary 100000
+  [yesterday|15.10|2026-10-15]       - optional income date
+  [@account]                         - optional account to credit
+account <name> [currency] [balance]  - add an account: card, cash, deposit
+transfer <from> <to> <amount>        - move money between accounts in the source account currency
+balance                              - account balances
+goal <name> <amount> by <date>       - add or change a savings goal, e.g. goal vacation 150000 by 2027-06-01
+  [currency]                         - optional goal currency
+goal <name> +<amount>                - put money towards a goal, -<amount> - take it back
+goal delete <name>                   - delete a goal
+goals                                - goal progress and projection by the deadline
+find <text> [period]                 - search in notes and categories
+report                               - choose the report period with buttons
+report <period>                      - report for a day, week, month or year, with income and savings
+report last <period>                 - report for the previous interval
+report <date> <date>                 - report for a date range
+report <period> total                - report by parent categories
+report <period> chart                - report with expense charts
+report <period> members              - report with totals by shared budget members
+report <period> current              - report at the current rate instead of the rate on the expense date
+category <parent/category>           - create a category
+alias <alias> <category>             - add a category alias
+rename <category> <name>             - rename a category
+merge <category> <category>          - move expenses to another category
+categories                           - list of categories
+categories <id>                      - choose a new expense category
+limit <period> <amount>              - set an expense limit
+limit <period> <amount> <category>   - set a category limit
+limits                               - list of limits
+summary <day|week|off>               - send an expense summary
+recurring <category> <amount>        - recurring expense every day, week, month or year
+  <period> [currency] [01.11]        - optional currency and start date
+recurring                            - list of recurring expenses
+cancel recurring <id>                - cancel a recurring expense
+export [period]                      - download expenses as CSV and XLSX, all by default
+import                               - bank statement CSV import settings
+import columns <date> <amount>       - statement column numbers, "auto" - by header
+  [description] [currency]           - optional columns
+import rule <words> <category>       - category for transactions with these words
+import delete rule <words>           - delete a rule
+budget                               - shared budget and its members
+budget invite                        - invite code to the shared budget
+budget join <code>                   - join a shared budget
+budget leave                         - leave the shared budget
+budget remove <id>                   - remove a member
+language                             - choose the language with buttons
+language <ru|en>                     - set the bot language
+
+In a group chat start your message with the bot's @name or reply to its
+messages. Expenses, limits and reports of a group are shared by all its members.
+
+Commands are understood in Russian too, for example расход такси 300.`,
+
+	// Общие тексты
+	"Привет. Напиши свои расходы и я запомпю их.\nВведи /help для более подробной информации": "Hi. " +
+		"Write down your expenses and I will remember them.\nType /help for more information",
+	"Я бот для учета расходов. Автор @amyasnikov. OzonTech.": "I am an expense tracking bot. " +
+		"Author @amyasnikov. OzonTech.",
+	"Не могу понять. Введи /help для более подробной информации": "I don't understand. " +
+		"Type /help for more information",
+	"Это может сделать только владелец общего бюджета": "Only the shared budget owner can do this",
+	"Язык: %s. Выберите другой:":                       "Language: %s. Choose another one:",
+	"Файл слишком большой, максимум %d КБ":             "The file is too large, maximum %d KB",
+	"Язык: %s": "Language: %s",
+
+	// Интервалы
+	"день":           "day",
+	"неделя":         "week",
+	"месяц":          "month",
+	"год":            "year",
+	"День":           "Day",
+	"Неделя":         "Week",
+	"Месяц":          "Month",
+	"Год":            "Year",
+	"каждый день":    "every day",
+	"каждую неделю":  "every week",
+	"каждый месяц":   "every month",
+	"каждый год":     "every year",
+	"за %s":          "for %s",
+	"за все время":   "for all time",
+	"с %s по %s":     "from %s to %s",
+	"Прошлая неделя": "Last week",
+	"Прошлый месяц":  "Last month",
+
+	// Диалоги
+	"Какая категория?": "Which category?",
+	"Сколько? Можно указать валюту, например 300 USD": "How much? You can specify a currency, " +
+		"for example 300 USD",
+	"Не понял ответ. %s\n/cancel - отменить": "I didn't understand the answer. %s\n/cancel - cancel",
+	"Ответьте на это сообщение":              "Reply to this message",
+	"Отменил":         "Cancelled",
+	"Нечего отменять": "Nothing to cancel",
+
+	// Расходы
+	"Добавил #%d %s - %s %s %s":                           "Added #%d %s - %s %s %s",
+	"\nВнимание! Превышен лимит: %s - %s":                 "\nAttention! Limit exceeded: %s - %s",
+	"\nВнимание! Превышен лимит по категории %s: %s - %s": "\nAttention! Category %s limit exceeded: %s - %s",
+	"\nВ валюте по умолчанию: %s %s":                      "\nIn the default currency: %s %s",
+	"Похоже на расход, но я не уверен. Выберите вариант или напишите: расход <категория> <сумма>": "Looks " +
+		"like an expense, but I'm not sure. Choose an option or write: expense <category> <amount>",
+	"Пришлите фото чека":                        "Send a photo of the receipt",
+	"Чек распознан. Какая категория у расхода?": "Receipt recognized. What is the expense category?",
+	"Не нашел на фото QR-код чека. Сфотографируйте код крупнее и ровнее": "I couldn't find a receipt QR code " +
+		"in the photo. Take a closer and straighter picture of the code",
+	"Чек на %s от %s":             "Receipt for %s on %s",
+	"Изменить категорию":          "Change category",
+	"Отменить":                    "Cancel",
+	"Изменил #%d %s - %s %s":      "Updated #%d %s - %s %s",
+	"Изменил категорию #%d на %s": "Changed the category of #%d to %s",
+	"Удалил #%d %s - %s %s":       "Deleted #%d %s - %s %s",
+	"Расход #%d не найден":        "Expense #%d not found",
+
+	// Категории
+	"Категории:\n":       "Categories:\n",
+	"Категорий пока нет": "No categories yet",
+	"Категорий пока нет, напишите: изменить %d <категория>": "No categories yet, write: " +
+		"edit %d <category>",
+	"Выберите категорию для расхода #%d:": "Choose a category for expense #%d:",
+	"Категория %s создана":                "Category %s created",
+	"Не удалось создать категорию":        "Failed to create the category",
+	"Категория %s не найдена":             "Category %s not found",
+	"Категория %s переименована в %s":     "Category %s renamed to %s",
+	"Теперь %s - это %s":                  "Now %s means %s",
+	"Расходы %s перенесены в %s":          "Expenses of %s moved to %s",
+	"Не удалось объединить %s и %s":       "Failed to merge %s and %s",
+	"остальное":                           "other",
+
+	// Валюты
+	"Валюта по умолчанию %s. Выберите новую:": "Default currency %s. Choose a new one:",
+	"Задана валюта по умолчанию %s":           "Default currency set to %s",
+
+	// Отчеты
+	"Выберите период отчета:":     "Choose the report period:",
+	"Месяц с графиком":            "Month with chart",
+	"Расходы по категориям %s:\n": "Expenses by category %s:\n",
+	"Нет расходов %s":             "No expenses %s",
+	"\nИтого - %s %s":             "\nTotal - %s %s",
+	"\n\nПо участникам:\n":        "\n\nBy members:\n",
+	"вы":                          "you",
+	"участник %d":                 "member %d",
+	"Расходы":                     "Expenses",
+	"Расходы, %s":                 "Expenses, %s",
+	"Расходы по дням":             "Expenses by day",
+	"Расходы по месяцам":          "Expenses by month",
+	"Расходы по категории %s":     "Expenses in category %s",
+
+	// Лимиты
+	"Текущие лимиты:\nДневной - %s %s\nНедельный - %s %s\nМесячный - %0s %s": "Current limits:\n" +
+		"Daily - %s %s\nWeekly - %s %s\nMonthly - %0s %s",
+	"\nПо категориям:\n":                         "\nBy category:\n",
+	"\nГодовой - %s %s":                          "\nYearly - %s %s",
+	"Установил лимит: %s - %s - %s":              "Limit set: %s - %s - %s",
+	"%s за %s достигли %d%% лимита: %s из %s %s": "%s for the %s reached %d%% of the limit: %s of %s %s",
+
+	// Сводка
+	"Буду присылать сводку расходов за прошедший день":   "I will send a summary of the past day expenses",
+	"Буду присылать сводку расходов за прошедшую неделю": "I will send a summary of the past week expenses",
+	"Сводка расходов отключена":                          "Expense summary is off",
+	"Сводка расходов за %s:\n":                           "Expense summary for %s:\n",
+	"Сводка расходов с %s по %s:\n":                      "Expense summary from %s to %s:\n",
+	"Расходов не было":                                   "There were no expenses",
+
+	// Регулярные расходы
+	"Добавил регулярный расход #%d %s - %s %s %s, начиная с %s": "Added recurring expense " +
+		"#%d %s - %s %s %s, starting %s",
+	"Не удалось добавить регулярный расход": "Failed to add the recurring expense",
+	"Регулярные расходы:\n":                 "Recurring expenses:\n",
+	"Регулярных расходов нет":               "No recurring expenses",
+	"#%d %s - %s %s %s, следующий %s":       "#%d %s - %s %s %s, next %s",
+	"Отменил регулярный расход #%d":         "Cancelled recurring expense #%d",
+	"Регулярный расход #%d не найден":       "Recurring expense #%d not found",
+
+	// Экспорт
+	"Выгрузил расходы %s: %d шт.": "Exported expenses %s: %d",
+	"Выгрузка расходов %s слишком большая (%d шт.), укажите период короче": "Export of expenses %s is too large " +
+		"(%d), choose a shorter period",
+	"Дата":      "Date",
+	"Категория": "Category",
+	"Сумма, %s": "Amount, %s",
+
+	// Импорт
+	"Пришлите CSV файл выписки банка, чтобы загрузить из нее расходы.\n": "Send a bank statement CSV file " +
+		"to import expenses from it.\n",
+	"Колонки: по заголовку": "Columns: by header",
+	"Колонки: %s":           "Columns: %s",
+	"\nПравила:\n":          "\nRules:\n",
+	"\nПравил нет, все операции попадут в категорию прочее": "\nNo rules, all transactions will go " +
+		"to the category прочее",
+	"дата %d":     "date %d",
+	"сумма %d":    "amount %d",
+	"описание %d": "description %d",
+	"валюта %d":   "currency %d",
+	"Колонки выписки будут определяться по заголовку": "Statement columns will be detected by the header",
+	"Колонки выписки сохранены: %s":                   "Statement columns saved: %s",
+	"Операции с \"%s\" в описании буду относить к категории %s": "Transactions with \"%s\" " +
+		"in the description will go to the category %s",
+	"Не удалось добавить правило": "Failed to add the rule",
+	"Удалил правило \"%s\"":       "Deleted the rule \"%s\"",
+	"Правило \"%s\" не найдено":   "Rule \"%s\" not found",
+	"Не удалось загрузить выписку %s. Укажите колонки командой " +
+		"\"импорт колонки <дата> <сумма> [описание] [валюта]\"": "Failed to import the statement %s. " +
+		"Set the columns with \"import columns <date> <amount> [description] [currency]\"",
+	"В выписке нет расходов":          "There are no expenses in the statement",
+	"Загружено расходов: %d":          "Expenses imported: %d",
+	"\nУже были загружены раньше: %d": "\nAlready imported before: %d",
+	"\nПропущено строк: %d":           "\nRows skipped: %d",
+
+	// Общий бюджет
+	"Вы участник общего бюджета пользователя %d.\nУчастники: %s\n" +
+		"бюджет выйти - вернуться к своим расходам": "You are a member of the shared budget of user %d.\n" +
+		"Members: %s\nbudget leave - return to your own expenses",
+	"В вашем бюджете нет участников.\nбюджет пригласить - получить код приглашения": "Your budget " +
+		"has no members.\nbudget invite - get an invite code",
+	"\nДействующий код: %s": "\nActive code: %s",
+	"Вы владелец общего бюджета.\nУчастники: %s\nКод приглашения: %s\n" +
+		"бюджет исключить <id> - исключить участника": "You own the shared budget.\nMembers: %s\n" +
+		"Invite code: %s\nbudget remove <id> - remove a member",
+	"Пригласить": "Invite",
+	"Выйти":      "Leave",
+	"Код приглашения: %s\nУчастник отправляет боту: бюджет вступить %s\n" +
+		"Прежний код больше не действует": "Invite code: %s\nThe member sends to the bot: budget join %s\n" +
+		"The previous code is no longer valid",
+	"Вы вступили в общий бюджет пользователя %d. Расходы, лимиты и отчеты теперь общие": "You joined " +
+		"the shared budget of user %d. Expenses, limits and reports are now shared",
+	"Код приглашения не найден":                            "Invite code not found",
+	"Вы вышли из общего бюджета":                           "You left the shared budget",
+	"Вы не состоите в общем бюджете":                       "You are not a member of a shared budget",
+	"В вашем бюджете есть участники, сначала исключите их": "Your budget has members, remove them first",
+	"Исключил участника %d":                                "Removed member %d",
+	"Участник %d не найден":                                "Member %d not found",
+
+	// Отмена действия
+	"Нет действий для отмены":                        "Nothing to undo",
+	"Отменил добавление расхода: %s - %s %s":         "Undid adding the expense: %s - %s %s",
+	"Отменил изменение лимита, лимит удален: %s":     "Undid the limit change, the limit is removed: %s",
+	"Отменил изменение лимита, вернул: %s - %s - %s": "Undid the limit change, restored: %s - %s - %s",
+	"Отменил смену валюты, валюта по умолчанию %s":   "Undid the currency change, default currency is %s",
+
+	// Заметки и поиск
+	"\nЗаметка: %s": "\nNote: %s",
+	"По запросу \"%s\" ничего не найдено %s":         "Nothing found for \"%s\" %s",
+	"Найдено по запросу \"%s\" %s:":                  "Found for \"%s\" %s:",
+	"Показаны последние, уточните запрос или период": "Showing the latest ones, refine the query or period",
+
+	// Доходы
+	"Не удалось добавить доход":       "Failed to add the income",
+	"Добавил доход #%d %s - %s %s %s": "Added income #%d %s - %s %s %s",
+	"\n\nДоходы по источникам:\n":     "\n\nIncome by source:\n",
+	"Доходы: %s\nРасходы: %s\nСбережения: %s\nНорма сбережений: %s%%": "Income: %s\nExpenses: %s\n" +
+		"Savings: %s\nSavings rate: %s%%",
+
+	// Счета
+	"\nСчет: %s":                           "\nAccount: %s",
+	"Счет %s не найден":                    "Account %s not found",
+	"Счет %s уже есть":                     "Account %s already exists",
+	"Добавил счет %s в валюте %s":          "Added account %s in %s",
+	"Перевел %s %s со счета %s на счет %s": "Transferred %s %s from %s to %s",
+	"\nЗачислено: %s %s":                   "\nCredited: %s %s",
+	"Счетов нет. Добавьте счет: счет <название> [валюта] [остаток]": "No accounts. Add one: " +
+		"account <name> [currency] [balance]",
+	"Остатки на счетах:\n": "Account balances:\n",
+
+	// Цели
+	"Цель %s уже есть в валюте %s, укажите сумму в ней": "Goal %s already exists in %s, specify the amount in it",
+	"Изменил цель %s\n":          "Updated goal %s\n",
+	"Добавил цель %s\n":          "Added goal %s\n",
+	"Цель %s не найдена":         "Goal %s not found",
+	"Отложил %s %s на цель %s\n": "Put %s %s towards goal %s\n",
+	"Снял %s %s с цели %s\n":     "Took %s %s from goal %s\n",
+	"Целей нет. Добавьте цель: цель <название> <сумма> [валюта] до <дата>": "No goals. Add one: " +
+		"goal <name> <amount> [currency] by <date>",
+	"Цели накоплений:\n":                         "Savings goals:\n",
+	"Удалил цель %s":                             "Deleted goal %s",
+	"Напоминание о целях:\n":                     "Savings goals reminder:\n",
+	"%s: %s из %s %s (%s%%), срок %s":            "%s: %s of %s %s (%s%%), due %s",
+	"\nЦель достигнута":                          "\nGoal reached",
+	"\nСрок прошел, осталось накопить %s %s":     "\nThe deadline has passed, %s %s left to save",
+	"\nЧтобы успеть, откладывайте %s %s в месяц": "\nTo make it in time, save %s %s a month",
+	"\nВзносов пока нет":                         "\nNo contributions yet",
+	"\nВ текущем темпе (%s %s в месяц) цель не будет достигнута": "\nAt the current pace (%s %s a month) " +
+		"the goal will not be reached",
+	"\nВ текущем темпе (%s %s в месяц) успеете к %s": "\nAt the current pace (%s %s a month) you will make it by %s",
+	"\nВ текущем темпе (%s %s в месяц) накопите только к %s": "\nAt the current pace (%s %s a month) " +
+		"you will only make it by %s",
+	"\n\nСуммы пересчитаны по текущему курсу": "\n\nAmounts are converted at the current rate",
+}
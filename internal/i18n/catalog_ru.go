@@ -0,0 +1,75 @@
+package i18n
+
+// russian тексты, которые хранятся под короткими ключами.
+var russian = map[string]string{
+	"help": `Я понимаю следующие команды:
+/start                               - приветственное сообщение
+/help                                - стравочная информация
+/about                               - информация о проекте
+/cancel                              - отменить начатый диалог
+/undo                                - отменить последний расход, лимит или смену валюты
+валюта                               - список валют с кнопками выбора
+валюта <валюта>                      - выбрать валюту по умолчанию
+расход                               - добавить расход по шагам
+расход <категория> <суммa> <валюта>  - добавление расходов
+  [вчера|15.10|2026-10-15]           - необязательная дата расхода
+  [@счет]                            - необязательный счет оплаты, например @карта
+  [заметка]                          - необязательная заметка, например ужин с Петей
+<категория> <сумма>                  - расход в свободной форме, например кофе 250 или 250р такси
+фото чека                            - расход по QR-коду кассового чека
+изменить <id> <категория> <сумма>    - исправить расход
+изменить <id> <категория>            - сменить категорию расхода
+удалить <id>                         - удалить расход
+доход <источник> <сумма> [валюта]    - добавить доход, например доход зарплата 100000
+  [вчера|15.10|2026-10-15]           - необязательная дата дохода
+  [@счет]                            - необязательный счет зачисления
+счет <название> [валюта] [остаток]   - добавить счет: карта, наличные, вклад
+перевод <откуда> <куда> <сумма>      - перевести между счетами в валюте счета списания
+баланс                               - остатки на счетах
+цель <название> <сумма> до <дата>    - добавить или изменить цель, например цель отпуск 150000 до 01.06.2027
+  [валюта]                           - необязательная валюта цели
+цель <название> +<сумма>             - отложить на цель, -<сумма> - снять с цели
+цель удалить <название>              - удалить цель
+цели                                 - прогресс целей и прогноз к сроку
+найти <текст> [период]               - поиск по заметкам и категориям
+отчет                                - выбрать период отчета кнопками
+отчет <период>                       - отчет за день, неделю, месяц или год, с доходами и сбережениями
+отчет прошлый <период>               - отчет за предыдущий интервал
+отчет <дата> <дата>                  - отчет за диапазон дат
+отчет <период> итого                 - отчет по родительским категориям
+отчет <период> график                - отчет с диаграммами расходов
+отчет <период> участники             - отчет с суммами по участникам общего бюджета
+отчет <период> текущий               - отчет по текущему курсу, а не по курсу на дату расхода
+категория <родитель/категория>       - создать категорию
+синоним <синоним> <категория>        - добавить синоним категории
+переименовать <категория> <имя>      - переименовать категорию
+объединить <категория> <категория>   - перенести расходы в другую категорию
+категории                            - список категорий
+категории <id>                       - выбрать новую категорию расхода
+лимит <период> <сумма>               - установить лимит расходов
+лимит <период> <сумма> <категория>   - установить лимит на категорию
+лимиты                               - список лимитов
+сводка <день|неделя|выкл>            - присылать сводку расходов
+регулярный <категория> <сумма>       - регулярный расход за день, неделю, месяц или год
+  <период> [валюта] [01.11]          - необязательные валюта и дата начала
+регулярные                           - список регулярных расходов
+отменить регулярный <id>             - отменить регулярный расход
+экспорт [период]                     - выгрузить расходы в CSV и XLSX, по умолчанию все
+импорт                               - настройки загрузки CSV выписки банка
+импорт колонки <дата> <сумма>        - номера колонок выписки, "авто" - по заголовку
+  [описание] [валюта]                - необязательные колонки
+импорт правило <слова> <категория>   - категория для операций с этими словами
+импорт удалить правило <слова>       - удалить правило
+бюджет                               - общий бюджет и его участники
+бюджет пригласить                    - код приглашения в общий бюджет
+бюджет вступить <код>                - вступить в общий бюджет
+бюджет выйти                         - выйти из общего бюджета
+бюджет исключить <id>                - исключить участника
+язык                                 - выбрать язык кнопками
+язык <ru|en>                         - выбрать язык бота
+
+В групповом чате начинайте сообщение с @имени бота или отвечайте на его
+сообщения. Расходы, лимиты и отчеты группы общие для всех ее участников.
+
+Команды понимаются и на английском, например expense taxi 300.`,
+}
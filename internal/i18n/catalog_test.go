@@ -0,0 +1,25 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Перевод формата должен принимать те же аргументы, что и исходный текст.
+func TestCatalogVerbs(t *testing.T) {
+	t.Parallel()
+
+	verbs := regexp.MustCompile(`%[0-9]*[a-z%]`)
+
+	for locale, catalog := range catalogs {
+		for key, text := range catalog {
+			if _, ok := russian[key]; ok {
+				continue
+			}
+
+			assert.Equal(t, verbs.FindAllString(key, -1), verbs.FindAllString(text, -1), "%s: %q", locale, key)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package i18n переводит тексты бота и форматирует числа и даты по языку пользователя.
+// Исходный язык - русский: русский текст служит ключом каталога, для остальных языков
+// ищется перевод, а без перевода текст остается русским.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	Russian = "ru"
+	English = "en"
+)
+
+// DefaultLocale язык пользователей, чей язык бот не поддерживает или еще не знает.
+const DefaultLocale = Russian
+
+// Переводы по языкам. Длинные тексты, например справка, хранятся под короткими
+// ключами, для них есть и русский каталог.
+var catalogs = map[string]map[string]string{
+	Russian: russian,
+	English: english,
+}
+
+// Названия языков для выбора, каждое на своем языке.
+var localeNames = map[string]string{
+	Russian: "Русский",
+	English: "English",
+}
+
+// Locales поддерживаемые языки в порядке показа пользователю.
+func Locales() []string {
+	return []string{Russian, English}
+}
+
+// LocaleName название языка на нем самом.
+func LocaleName(locale string) string {
+	return localeNames[normalize(locale)]
+}
+
+// ParseLocale возвращает поддерживаемый язык по коду, например language_code из
+// Telegram вида "en-US" или выбору пользователя.
+func ParseLocale(code string) (string, bool) {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(code)), "-")
+
+	if _, ok := catalogs[lang]; !ok {
+		return "", false
+	}
+
+	return lang, true
+}
+
+// T переводит текст на язык locale.
+func T(locale, text string) string {
+	if translated, ok := catalogs[normalize(locale)][text]; ok {
+		return translated
+	}
+
+	if translated, ok := catalogs[DefaultLocale][text]; ok {
+		return translated
+	}
+
+	return text
+}
+
+// Sprintf переводит формат на язык locale и подставляет в него аргументы.
+func Sprintf(locale, format string, args ...any) string {
+	return fmt.Sprintf(T(locale, format), args...)
+}
+
+// FormatAmount сумма с двумя знаками после запятой и разделителем разрядов:
+// 1 234,50 по-русски и 1,234.50 по-английски.
+func FormatAmount(locale string, amount decimal.Decimal) string {
+	precision := 2
+	groupLen := 3
+
+	groupSeparator, decimalSeparator := " ", ","
+	if normalize(locale) == English {
+		groupSeparator, decimalSeparator = ",", "."
+	}
+
+	text := amount.StringFixed(int32(precision))
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	groups := make([]string, 0, len(integer)/groupLen+1)
+	for len(integer) > groupLen {
+		groups = append([]string{integer[len(integer)-groupLen:]}, groups...)
+		integer = integer[:len(integer)-groupLen]
+	}
+
+	groups = append([]string{integer}, groups...)
+
+	return sign + strings.Join(groups, groupSeparator) + decimalSeparator + fraction
+}
+
+// FormatDate дата без времени: 18.10.2026 по-русски и 10/18/2026 по-английски.
+func FormatDate(locale string, date time.Time) string {
+	if normalize(locale) == English {
+		return date.Format("01/02/2006")
+	}
+
+	return date.Format("02.01.2006")
+}
+
+// FormatMonth месяц с годом: 10.2026 по-русски и 10/2026 по-английски.
+func FormatMonth(locale string, date time.Time) string {
+	if normalize(locale) == English {
+		return date.Format("01/2006")
+	}
+
+	return date.Format("01.2006")
+}
+
+// FormatDateTime дата со временем и часовым поясом.
+func FormatDateTime(locale string, date time.Time) string {
+	if normalize(locale) == English {
+		return date.Format("01/02/2006 3:04 PM MST")
+	}
+
+	return date.Format("02.01.2006 15:04 MST")
+}
+
+// normalize неизвестный или пустой язык заменяет языком по умолчанию.
+func normalize(locale string) string {
+	if _, ok := catalogs[locale]; !ok {
+		return DefaultLocale
+	}
+
+	return locale
+}
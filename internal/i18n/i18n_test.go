@@ -0,0 +1,68 @@
+package i18n_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+)
+
+func TestParseLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code string
+		want string
+		ok   bool
+	}{
+		{code: "ru", want: i18n.Russian, ok: true},
+		{code: "en-US", want: i18n.English, ok: true},
+		{code: " EN ", want: i18n.English, ok: true},
+		{code: "de", want: "", ok: false},
+		{code: "", want: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		locale, ok := i18n.ParseLocale(tt.code)
+		assert.Equal(t, tt.ok, ok, tt.code)
+		assert.Equal(t, tt.want, locale, tt.code)
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Cancelled", i18n.T(i18n.English, "Отменил"))
+	assert.Equal(t, "Отменил", i18n.T(i18n.Russian, "Отменил"))
+	// Без перевода и для неизвестного языка остается исходный текст
+	assert.Equal(t, "Отменил", i18n.T("de", "Отменил"))
+	assert.Equal(t, "нет перевода", i18n.T(i18n.English, "нет перевода"))
+	assert.Contains(t, i18n.T(i18n.Russian, "help"), "расход <категория>")
+	assert.Contains(t, i18n.T(i18n.English, "help"), "expense <category>")
+}
+
+func TestFormatAmount(t *testing.T) {
+	t.Parallel()
+
+	amount := decimal.RequireFromString("1234567.5")
+
+	assert.Equal(t, "1 234 567,50", i18n.FormatAmount(i18n.Russian, amount))
+	assert.Equal(t, "1,234,567.50", i18n.FormatAmount(i18n.English, amount))
+	assert.Equal(t, "-300,00", i18n.FormatAmount("", decimal.New(-300, 0)))
+	assert.Equal(t, "0.05", i18n.FormatAmount(i18n.English, decimal.RequireFromString("0.049")))
+}
+
+func TestFormatDate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 21, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, "18.10.2026", i18n.FormatDate(i18n.Russian, date))
+	assert.Equal(t, "10/18/2026", i18n.FormatDate(i18n.English, date))
+	assert.Equal(t, "10.2026", i18n.FormatMonth(i18n.Russian, date))
+	assert.Equal(t, "10/2026", i18n.FormatMonth(i18n.English, date))
+	assert.Equal(t, "18.10.2026 21:05 UTC", i18n.FormatDateTime(i18n.Russian, date))
+	assert.Equal(t, "10/18/2026 9:05 PM UTC", i18n.FormatDateTime(i18n.English, date))
+}
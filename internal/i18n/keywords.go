@@ -0,0 +1,141 @@
+package i18n
+
+import "strings"
+
+// Команды на других языках переводятся в русские до разбора, поэтому обработчики
+// знают только русские ключевые слова. Ключевые слова любого языка понимаются
+// независимо от выбранного языка ответов.
+
+// commandAliases команды из одного слова целиком.
+var commandAliases = map[string]string{
+	"recurring": "регулярные",
+	"undo":      "/undo",
+	"balance":   "баланс",
+	"goals":     "цели",
+}
+
+// keywordAliases первое слово команды.
+var keywordAliases = map[string]string{
+	"expense":    "расход",
+	"edit":       "изменить",
+	"delete":     "удалить",
+	"report":     "отчет",
+	"currency":   "валюта",
+	"category":   "категория",
+	"categories": "категории",
+	"alias":      "синоним",
+	"rename":     "переименовать",
+	"merge":      "объединить",
+	"limit":      "лимит",
+	"limits":     "лимиты",
+	"summary":    "сводка",
+	"recurring":  "регулярный",
+	"cancel":     "отменить",
+	"export":     "экспорт",
+	"import":     "импорт",
+	"budget":     "бюджет",
+	"language":   "язык",
+	"find":       "найти",
+	"income":     "доход",
+	"account":    "счет",
+	"transfer":   "перевод",
+	"goal":       "цель",
+}
+
+var (
+	intervalAliases = map[string]string{
+		"day":   "день",
+		"week":  "неделя",
+		"month": "месяц",
+		"year":  "год",
+	}
+	dateAliases = map[string]string{
+		"today":     "сегодня",
+		"yesterday": "вчера",
+	}
+	reportAliases = map[string]string{
+		"last":    "прошлый",
+		"total":   "итого",
+		"chart":   "график",
+		"members": "участники",
+		"current": "текущий",
+	}
+)
+
+// argumentAliases слова после первого, свои для каждой команды, чтобы не переводить
+// названия категорий и описания.
+var argumentAliases = map[string]map[string]string{
+	"расход":     dateAliases,
+	"отчет":      merge(intervalAliases, dateAliases, reportAliases),
+	"экспорт":    merge(intervalAliases, dateAliases, map[string]string{"last": "прошлый"}),
+	"лимит":      intervalAliases,
+	"сводка":     merge(intervalAliases, map[string]string{"off": "выкл"}),
+	"регулярный": merge(intervalAliases, dateAliases),
+	"отменить":   {"recurring": "регулярный"},
+	"импорт":     {"rule": "правило", "columns": "колонки", "auto": "авто", "delete": "удалить"},
+	"доход":      dateAliases,
+	"цель":       {"by": "до", "delete": "удалить"},
+	"найти":      merge(intervalAliases, dateAliases, map[string]string{"last": "прошлый"}),
+	"бюджет":     {"invite": "пригласить", "join": "вступить", "leave": "выйти", "remove": "исключить"},
+}
+
+// NormalizeCommand переводит ключевые слова команды на русский. Текст, который не
+// начинается с ключевого слова другого языка, возвращается без изменений.
+func NormalizeCommand(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return text
+	}
+
+	if len(fields) == 1 {
+		if command, ok := commandAliases[strings.ToLower(fields[0])]; ok {
+			return command
+		}
+	}
+
+	keyword, ok := keywordAliases[strings.ToLower(fields[0])]
+	if !ok {
+		return text
+	}
+
+	fields[0] = keyword
+
+	for i, field := range fields[1:] {
+		if argument, ok := argumentAliases[keyword][strings.ToLower(field)]; ok {
+			fields[i+1] = argument
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func merge(aliases ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+
+	for _, m := range aliases {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// IsCommandKeyword слово, с которого начинается какая-нибудь команда, на любом языке.
+func IsCommandKeyword(word string) bool {
+	word = strings.ToLower(word)
+
+	for alias, keyword := range keywordAliases {
+		if word == alias || word == keyword {
+			return true
+		}
+	}
+
+	for alias, command := range commandAliases {
+		if word == alias || word == command {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+)
+
+func TestNormalizeCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "expense taxi 300 USD yesterday", want: "расход taxi 300 USD вчера"},
+		{text: "Report last month chart", want: "отчет прошлый месяц график"},
+		{text: "report year current", want: "отчет год текущий"},
+		{text: "limit week 1000 food", want: "лимит неделя 1000 food"},
+		{text: "summary off", want: "сводка выкл"},
+		{text: "recurring", want: "регулярные"},
+		{text: "recurring netflix 500 month", want: "регулярный netflix 500 месяц"},
+		{text: "cancel recurring 3", want: "отменить регулярный 3"},
+		{text: "import delete rule taxi", want: "импорт удалить правило taxi"},
+		{text: "budget join ABC123", want: "бюджет вступить ABC123"},
+		{text: "undo", want: "/undo"},
+		{text: "find dinner last month", want: "найти dinner прошлый месяц"},
+		{text: "income salary 1000 yesterday", want: "доход salary 1000 вчера"},
+		{text: "transfer card cash 500", want: "перевод card cash 500"},
+		{text: "balance", want: "баланс"},
+		{text: "goal vacation 150000 by 2027-06-01", want: "цель vacation 150000 до 2027-06-01"},
+		{text: "goals", want: "цели"},
+		// Аргументы переводятся только для своей команды, категории остаются как есть
+		{text: "category week", want: "категория week"},
+		{text: "расход такси 300", want: "расход такси 300"},
+		{text: "  ", want: "  "},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, i18n.NormalizeCommand(tt.text), tt.text)
+	}
+}
+
+func TestIsCommandKeyword(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, i18n.IsCommandKeyword("лимит"))
+	assert.True(t, i18n.IsCommandKeyword("Limit"))
+	assert.True(t, i18n.IsCommandKeyword("регулярные"))
+	assert.False(t, i18n.IsCommandKeyword("кофе"))
+}
@@ -0,0 +1,92 @@
+// Package receipt распознает QR-код российского кассового чека. В коде строка вида
+// t=20261018T1215&s=1250.50&fn=...&i=...&fp=...&n=1: время покупки, сумма и
+// фискальные данные чека.
+package receipt
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg" // фото из Telegram приходят в JPEG
+	_ "image/png"  // скриншоты чеков
+	"net/url"
+	"time"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoQRCode   = errors.New("qr code not found")
+	ErrNotReceipt = errors.New("qr code is not a receipt")
+)
+
+// Receipt данные чека из QR-кода.
+type Receipt struct {
+	Date time.Time
+	Sum  decimal.Decimal
+	// FN номер фискального накопителя, FD номер документа, FP фискальный признак.
+	// Вместе они однозначно определяют чек.
+	FN string
+	FD string
+	FP string
+}
+
+// Время покупки в коде бывает с секундами и без.
+var dateLayouts = []string{"20060102T150405", "20060102T1504"}
+
+// Decode ищет на изображении QR-код чека. Время в чеке местное, оно
+// разбирается в часовом поясе loc.
+func Decode(data []byte, loc *time.Location) (Receipt, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Receipt{}, errors.Wrap(err, "receipt.Decode")
+	}
+
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return Receipt{}, errors.Wrap(err, "receipt.Decode")
+	}
+
+	hints := map[gozxing.DecodeHintType]any{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+
+	result, err := qrcode.NewQRCodeReader().Decode(bitmap, hints)
+	if err != nil {
+		return Receipt{}, errors.Wrap(ErrNoQRCode, "receipt.Decode")
+	}
+
+	return Parse(result.GetText(), loc)
+}
+
+// Parse разбирает строку из QR-кода чека.
+func Parse(text string, loc *time.Location) (Receipt, error) {
+	values, err := url.ParseQuery(text)
+	if err != nil {
+		return Receipt{}, errors.Wrap(ErrNotReceipt, "receipt.Parse")
+	}
+
+	sum, err := decimal.NewFromString(values.Get("s"))
+	if err != nil || !sum.IsPositive() {
+		return Receipt{}, errors.Wrap(ErrNotReceipt, "receipt.Parse")
+	}
+
+	for _, layout := range dateLayouts {
+		date, err := time.ParseInLocation(layout, values.Get("t"), loc)
+		if err != nil {
+			continue
+		}
+
+		return Receipt{
+			Date: date,
+			Sum:  sum,
+			FN:   values.Get("fn"),
+			FD:   values.Get("i"),
+			FP:   values.Get("fp"),
+		}, nil
+	}
+
+	return Receipt{}, errors.Wrap(ErrNotReceipt, "receipt.Parse")
+}
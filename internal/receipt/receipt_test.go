@@ -0,0 +1,85 @@
+package receipt_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/receipt"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		file string
+		date time.Time
+		sum  string
+		fn   string
+	}{
+		{
+			file: "testdata/receipt.png",
+			date: time.Date(2026, 10, 17, 19, 32, 0, 0, loc),
+			sum:  "1250.5",
+			fn:   "7380440700076549",
+		},
+		{
+			file: "testdata/receipt_photo.jpg",
+			date: time.Date(2026, 10, 16, 8, 30, 15, 0, loc),
+			sum:  "349",
+			fn:   "9960440300449301",
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.file)
+		assert.NoError(t, err)
+
+		r, err := receipt.Decode(data, loc)
+		if !assert.NoError(t, err, tt.file) {
+			continue
+		}
+
+		assert.Equal(t, tt.date, r.Date, tt.file)
+		assert.Equal(t, tt.sum, r.Sum.String(), tt.file)
+		assert.Equal(t, tt.fn, r.FN, tt.file)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/no_qr.jpg")
+	assert.NoError(t, err)
+
+	_, err = receipt.Decode(data, time.UTC)
+	assert.ErrorIs(t, err, receipt.ErrNoQRCode)
+
+	data, err = os.ReadFile("testdata/not_receipt.png")
+	assert.NoError(t, err)
+
+	_, err = receipt.Decode(data, time.UTC)
+	assert.ErrorIs(t, err, receipt.ErrNotReceipt)
+
+	_, err = receipt.Decode([]byte("not an image"), time.UTC)
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	r, err := receipt.Parse("t=20261018T1215&s=99.90&fn=1&i=2&fp=3&n=1", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 12, 15, 0, 0, time.UTC), r.Date)
+	assert.Equal(t, "99.9", r.Sum.String())
+	assert.Equal(t, "2", r.FD)
+	assert.Equal(t, "3", r.FP)
+
+	for _, text := range []string{"", "t=20261018T1215", "s=99.90", "t=18.10.2026&s=99.90", "t=20261018T1215&s=-1"} {
+		_, err := receipt.Parse(text, time.UTC)
+		assert.ErrorIs(t, err, receipt.ErrNotReceipt, text)
+	}
+}
@@ -0,0 +1,272 @@
+// Package statement разбирает CSV выписки банков в список операций.
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const utf8BOM = "\xEF\xBB\xBF"
+
+var ErrColumnsNotFound = errors.New("statement columns not found")
+
+// Форматы дат, которые встречаются в выписках российских банков.
+var dateLayouts = [...]string{
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+}
+
+// Columns номера колонок, начиная с нуля. Отрицательный номер - колонки нет.
+type Columns struct {
+	Date        int
+	Amount      int
+	Description int
+	Currency    int
+}
+
+// Row операция из выписки. Сумма расхода положительная.
+type Row struct {
+	Date        time.Time
+	Amount      decimal.Decimal
+	Description string
+	Currency    string
+}
+
+// Statement результат разбора. Skipped - строки, которые не удалось разобрать.
+type Statement struct {
+	Rows    []Row
+	Skipped int
+}
+
+// Parse разбирает выписку. Без колонок они определяются по заголовку. Если в выписке
+// есть отрицательные суммы, расходами считаются только они, остальное - поступления.
+func Parse(data []byte, columns *Columns) (Statement, error) {
+	records, err := readRecords(data)
+	if err != nil {
+		return Statement{}, errors.Wrap(err, "statement.Parse")
+	}
+
+	if len(records) == 0 {
+		return Statement{}, nil
+	}
+
+	if columns == nil {
+		detected, ok := DetectColumns(records[0])
+		if !ok {
+			return Statement{}, errors.Wrap(ErrColumnsNotFound, "statement.Parse")
+		}
+
+		columns = &detected
+		records = records[1:]
+	} else if _, ok := parseRecord(records[0], *columns); !ok {
+		// Первая строка не разбирается - это заголовок, а не пропущенная операция
+		records = records[1:]
+	}
+
+	var (
+		result   Statement
+		negative bool
+	)
+
+	rows := make([]Row, 0, len(records))
+
+	for _, record := range records {
+		row, ok := parseRecord(record, *columns)
+		if !ok {
+			result.Skipped++
+
+			continue
+		}
+
+		if row.Amount.IsNegative() {
+			negative = true
+		}
+
+		rows = append(rows, row)
+	}
+
+	result.Rows = make([]Row, 0, len(rows))
+
+	for _, row := range rows {
+		if negative {
+			if !row.Amount.IsNegative() {
+				continue
+			}
+
+			row.Amount = row.Amount.Neg()
+		}
+
+		if row.Amount.IsZero() {
+			continue
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, nil
+}
+
+// DetectColumns ищет колонки по названиям в заголовке. Дата и сумма обязательны.
+func DetectColumns(header []string) (Columns, bool) {
+	columns := Columns{Date: -1, Amount: -1, Description: -1, Currency: -1}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		switch {
+		case columns.Date < 0 && (strings.Contains(name, "дата") || strings.Contains(name, "date")):
+			columns.Date = i
+		case columns.Amount < 0 && (strings.HasPrefix(name, "сумма") || strings.HasPrefix(name, "amount")):
+			columns.Amount = i
+		case columns.Description < 0 && (strings.Contains(name, "описание") || strings.Contains(name, "назначение") ||
+			strings.Contains(name, "description")):
+			columns.Description = i
+		case columns.Currency < 0 && (strings.HasPrefix(name, "валюта") || strings.HasPrefix(name, "currency")):
+			columns.Currency = i
+		}
+	}
+
+	return columns, columns.Date >= 0 && columns.Amount >= 0
+}
+
+// Выписки часто приходят в windows-1251 и с разделителем ";".
+func readRecords(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+
+	if !utf8.Valid(data) {
+		decoded, err := charmap.Windows1251.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "readRecords")
+		}
+
+		data = decoded
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records := make([][]string, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "readRecords")
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func detectDelimiter(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	delimiter, maxCount := ',', 0
+
+	for _, candidate := range [...]rune{',', ';', '\t'} {
+		if count := bytes.Count(firstLine, []byte(string(candidate))); count > maxCount {
+			delimiter, maxCount = candidate, count
+		}
+	}
+
+	return delimiter
+}
+
+func parseRecord(record []string, columns Columns) (Row, bool) {
+	var row Row
+
+	if columns.Date >= len(record) || columns.Amount >= len(record) {
+		return row, false
+	}
+
+	date, ok := parseDate(record[columns.Date])
+	if !ok {
+		return row, false
+	}
+
+	amount, ok := parseAmount(record[columns.Amount])
+	if !ok {
+		return row, false
+	}
+
+	row.Date = date
+	row.Amount = amount
+
+	if columns.Description >= 0 && columns.Description < len(record) {
+		row.Description = strings.Join(strings.Fields(record[columns.Description]), " ")
+	}
+
+	if columns.Currency >= 0 && columns.Currency < len(record) {
+		row.Currency = parseCurrency(record[columns.Currency])
+	}
+
+	return row, true
+}
+
+func parseDate(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+
+	for _, layout := range dateLayouts {
+		date, err := time.Parse(layout, text)
+		if err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Суммы бывают вида "-1 234,56" с неразрывными пробелами между разрядами.
+func parseAmount(text string) (decimal.Decimal, bool) {
+	text = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\'':
+			return -1
+		case ',':
+			return '.'
+		case '\u2212':
+			return '-'
+		default:
+			return r
+		}
+	}, text)
+
+	amount, err := decimal.NewFromString(text)
+	if err != nil {
+		return decimal.Decimal{}, false
+	}
+
+	return amount, true
+}
+
+func parseCurrency(text string) string {
+	currency := strings.ToUpper(strings.TrimSpace(text))
+
+	switch currency {
+	case "RUR", "₽", "РУБ", "РУБ.":
+		return "RUB"
+	default:
+		return currency
+	}
+}
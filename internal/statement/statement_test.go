@@ -0,0 +1,79 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/statement"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestParse_DetectColumns(t *testing.T) {
+	t.Parallel()
+
+	data := "\xEF\xBB\xBF" + `Дата операции;Описание;Сумма операции;Валюта операции
+17.10.2026 09:30:00;"Яндекс  Такси";-300,50;RUR
+17.10.2026 12:00:00;Пополнение;5 000,00;RUB
+плохая строка;;;
+18.10.2026;Кофейня;−1 250;USD
+`
+
+	result, err := statement.Parse([]byte(data), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, statement.Statement{
+		Rows: []statement.Row{
+			{
+				Date:        time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+				Amount:      decimal.RequireFromString("300.50"),
+				Description: "Яндекс Такси",
+				Currency:    "RUB",
+			},
+			{
+				Date:        time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+				Amount:      decimal.RequireFromString("1250"),
+				Description: "Кофейня",
+				Currency:    "USD",
+			},
+		},
+		Skipped: 1,
+	}, result)
+}
+
+func TestParse_Columns(t *testing.T) {
+	t.Parallel()
+
+	// Выписка без отрицательных сумм и в windows-1251
+	data, err := charmap.Windows1251.NewEncoder().String(`2026-10-17,такси,300
+2026-10-18,кафе,"1,5"
+`)
+	assert.NoError(t, err)
+
+	result, err := statement.Parse([]byte(data), &statement.Columns{Date: 0, Amount: 2, Description: 1, Currency: -1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, statement.Statement{
+		Rows: []statement.Row{
+			{
+				Date:        time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+				Amount:      decimal.RequireFromString("300"),
+				Description: "такси",
+			},
+			{
+				Date:        time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+				Amount:      decimal.RequireFromString("1.5"),
+				Description: "кафе",
+			},
+		},
+		Skipped: 0,
+	}, result)
+}
+
+func TestParse_ColumnsNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := statement.Parse([]byte("a,b,c\n1,2,3\n"), nil)
+	assert.ErrorIs(t, err, statement.ErrColumnsNotFound)
+}
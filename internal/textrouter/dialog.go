@@ -0,0 +1,196 @@
+package textrouter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+)
+
+// Незаконченный диалог забывается, если пользователь долго не отвечает.
+const dialogTTL = 15 * time.Minute
+
+// DialogHandler обработчик команды, аргументы которой можно спросить у пользователя по одному.
+type DialogHandler interface {
+	// StartDialog проверяет, что текст начинает диалог, например это команда без аргументов.
+	StartDialog(text string) bool
+	// DialogQuestions вопросы по порядку, на каждый ожидается одно сообщение.
+	DialogQuestions() []string
+	// CheckDialogAnswer проверяет ответ на вопрос с номером step.
+	CheckDialogAnswer(step int, answer string) bool
+	// ConvertDialogToText собирает из ответов текст команды.
+	ConvertDialogToText(answers []string) string
+}
+
+// DocumentDialogHandler обработчик файла, из которого известна часть ответов диалога.
+// Например, по фото чека известны сумма и дата расхода, остается спросить категорию.
+type DocumentDialogHandler interface {
+	DialogHandler
+	// DialogAnswers ответы, известные из файла. Без ответов диалог не начинается.
+	DialogAnswers(cmd *usecase.Command) []string
+}
+
+// DialogStorage хранит диалоги по чату и пользователю: диалог, начатый в групповом чате,
+// продолжается только в нем, а расход из него попадает в бюджет чата.
+type DialogStorage interface {
+	Get(context.Context, int64, entity.UserID, time.Time) (entity.Dialog, bool, error)
+	Update(context.Context, int64, entity.UserID, entity.Dialog) error
+	Delete(context.Context, int64, entity.UserID, time.Time) (bool, error)
+}
+
+// DialogRouter хранит состояние диалогов и передает RouterText уже собранный текст команды.
+// Состояние в базе, потому что сообщения читает отдельный процесс, который может перезапуститься.
+// В групповом чате бот видит только ответы на свои сообщения, поэтому ответы на вопросы
+// диалога там должны быть ответами на вопрос.
+type DialogRouter struct {
+	router  *RouterText
+	storage DialogStorage
+}
+
+func NewDialogRouter(router *RouterText, storage DialogStorage) *DialogRouter {
+	return &DialogRouter{
+		router:  router,
+		storage: storage,
+	}
+}
+
+// ConvertTextToCommand во время диалога считает сообщение ответом на последний вопрос.
+// Команды со слешем, например /help, ответом не считаются и диалог не прерывают.
+func (r *DialogRouter) ConvertTextToCommand(ctx context.Context, info usecase.MessageInfo, text string,
+) usecase.Command {
+	if !strings.HasPrefix(strings.TrimSpace(text), "/") {
+		if cmd, ok := r.continueDialog(ctx, info, text); ok {
+			return cmd
+		}
+
+		for _, handler := range r.router.handlers {
+			dialogHandler, ok := handler.(DialogHandler)
+			if ok && dialogHandler.StartDialog(i18n.NormalizeCommand(text)) {
+				return r.updateDialog(ctx, info, handler.Name(), dialogHandler, nil)
+			}
+		}
+	}
+
+	cmd := r.router.ConvertTextToCommand(ctx, info, text)
+
+	if cmd.Name == usecase.CancelDialogCmdName {
+		found, err := r.storage.Delete(ctx, info.ReplyChatID(), entity.UserID(info.UserID), info.Date)
+		if err != nil {
+			logger.Errorf("can not delete dialog: %v", err)
+		}
+
+		cmd.CancelDialogDTO = &usecase.CancelDialogDTO{Found: found}
+	}
+
+	return cmd
+}
+
+// ConvertDocumentToCommand разбирает файл обычным образом. Если из файла известна
+// часть ответов диалога, он начинается сразу со следующего вопроса.
+func (r *DialogRouter) ConvertDocumentToCommand(ctx context.Context, info usecase.MessageInfo, caption string,
+	file usecase.FileDTO,
+) usecase.Command {
+	cmd := r.router.ConvertDocumentToCommand(ctx, info, caption, file)
+
+	for _, handler := range r.router.handlers {
+		if handler.Name() != cmd.Name {
+			continue
+		}
+
+		dialogHandler, ok := handler.(DocumentDialogHandler)
+		if !ok {
+			break
+		}
+
+		answers := dialogHandler.DialogAnswers(&cmd)
+		if len(answers) != 0 && len(answers) < len(dialogHandler.DialogQuestions()) {
+			return r.updateDialog(ctx, info, cmd.Name, dialogHandler, answers)
+		}
+
+		break
+	}
+
+	return cmd
+}
+
+func (r *DialogRouter) continueDialog(ctx context.Context, info usecase.MessageInfo, text string,
+) (usecase.Command, bool) {
+	dialog, found, err := r.storage.Get(ctx, info.ReplyChatID(), entity.UserID(info.UserID), info.Date)
+	if err != nil {
+		logger.Errorf("can not get dialog: %v", err)
+
+		return usecase.Command{}, false
+	}
+
+	if !found {
+		return usecase.Command{}, false
+	}
+
+	var dialogHandler DialogHandler
+
+	for _, handler := range r.router.handlers {
+		if handler.Name() == dialog.GetName() {
+			dialogHandler, _ = handler.(DialogHandler)
+		}
+	}
+
+	answers := dialog.GetAnswers()
+
+	// Диалог, который больше не поддерживается, просто забывается
+	if dialogHandler == nil || len(answers) >= len(dialogHandler.DialogQuestions()) {
+		r.deleteDialog(ctx, info)
+
+		return usecase.Command{}, false
+	}
+
+	answer := strings.TrimSpace(text)
+
+	if !dialogHandler.CheckDialogAnswer(len(answers), answer) {
+		return dialogCommand(info, dialogHandler.DialogQuestions()[len(answers)], true), true
+	}
+
+	answers = append(answers, answer)
+
+	if len(answers) < len(dialogHandler.DialogQuestions()) {
+		return r.updateDialog(ctx, info, dialog.GetName(), dialogHandler, answers), true
+	}
+
+	r.deleteDialog(ctx, info)
+
+	return r.router.ConvertTextToCommand(ctx, info, dialogHandler.ConvertDialogToText(answers)), true
+}
+
+// updateDialog сохраняет ответы и задает следующий вопрос.
+func (r *DialogRouter) updateDialog(ctx context.Context, info usecase.MessageInfo, name string,
+	handler DialogHandler, answers []string,
+) usecase.Command {
+	err := r.storage.Update(ctx, info.ReplyChatID(), entity.UserID(info.UserID),
+		entity.NewDialog(name, answers, info.Date.Add(dialogTTL)))
+	if err != nil {
+		logger.Errorf("can not update dialog: %v", err)
+	}
+
+	return dialogCommand(info, handler.DialogQuestions()[len(answers)], false)
+}
+
+func (r *DialogRouter) deleteDialog(ctx context.Context, info usecase.MessageInfo) {
+	_, err := r.storage.Delete(ctx, info.ReplyChatID(), entity.UserID(info.UserID), info.Date)
+	if err != nil {
+		logger.Errorf("can not delete dialog: %v", err)
+	}
+}
+
+func dialogCommand(info usecase.MessageInfo, question string, retry bool) usecase.Command {
+	return usecase.Command{
+		Name:        usecase.DialogCmdName,
+		MessageInfo: info,
+		DialogDTO: &usecase.DialogDTO{
+			Question: question,
+			Retry:    retry,
+		},
+	}
+}
@@ -0,0 +1,300 @@
+package textrouter_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/mock_textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func newDialogRouter(storage textrouter.DialogStorage) *textrouter.DialogRouter {
+	router := textrouter.New()
+	router.Register(texthandler.NewAddExpense())
+	router.Register(texthandler.NewReceiptExpense())
+	router.Register(texthandler.NewDialog())
+	router.Register(texthandler.NewCancelDialog())
+	router.Register(texthandler.NewUnknown())
+
+	return textrouter.NewDialogRouter(router, storage)
+}
+
+func TestDialogRouter_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(entity.Dialog{}, false, nil),
+		storage.EXPECT().Update(gomock.Any(), int64(101), entity.UserID(101),
+			entity.NewDialog(usecase.AddExpenseCmdName, nil, date.Add(15*time.Minute))).
+			Return(nil),
+	)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "расход")
+
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+	assert.Equal(t, &usecase.DialogDTO{Question: "Какая категория?"}, cmd.DialogDTO)
+}
+
+func TestDialogRouter_Answer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(entity.NewDialog(usecase.AddExpenseCmdName, nil, date.Add(time.Minute)), true, nil),
+		storage.EXPECT().Update(gomock.Any(), int64(101), entity.UserID(101),
+			entity.NewDialog(usecase.AddExpenseCmdName, []string{"такси"}, date.Add(15*time.Minute))).
+			Return(nil),
+	)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, " такси ")
+
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+	assert.Equal(t, "Сколько? Можно указать валюту, например 300 USD", cmd.DialogDTO.Question)
+}
+
+func TestDialogRouter_InvalidAnswer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+		Return(entity.NewDialog(usecase.AddExpenseCmdName, []string{"такси"}, date.Add(time.Minute)), true, nil)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "много")
+
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+	assert.True(t, cmd.DialogDTO.Retry)
+}
+
+func TestDialogRouter_Finish(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(entity.NewDialog(usecase.AddExpenseCmdName, []string{"такси"}, date.Add(time.Minute)), true, nil),
+		storage.EXPECT().Delete(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(true, nil),
+	)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "300 usd")
+
+	assert.Equal(t, usecase.AddExpenseCmdName, cmd.Name)
+	assert.Equal(t, &usecase.AddExpenseReqDTO{
+		UserID:   101,
+		Category: "такси",
+		Price:    decimal.New(300, 0),
+		Currency: "USD",
+		Date:     date,
+	}, cmd.AddExpenseReqDTO)
+}
+
+func TestDialogRouter_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	storage.EXPECT().Delete(gomock.Any(), int64(101), entity.UserID(101), date).
+		Return(true, nil)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "/cancel")
+
+	assert.Equal(t, usecase.CancelDialogCmdName, cmd.Name)
+	assert.Equal(t, &usecase.CancelDialogDTO{Found: true}, cmd.CancelDialogDTO)
+}
+
+func TestDialogRouter_NoDialog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+		Return(entity.Dialog{}, false, nil)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "расход такси 300")
+
+	assert.Equal(t, usecase.AddExpenseCmdName, cmd.Name)
+	assert.Nil(t, cmd.DialogDTO)
+}
+
+func TestDialogRouter_Receipt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	data, err := os.ReadFile("../receipt/testdata/receipt.png")
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	// Сумма и дата из чека - готовый первый ответ
+	storage.EXPECT().Update(gomock.Any(), int64(101), entity.UserID(101),
+		entity.NewDialog(usecase.ReceiptCmdName, []string{"1250.5 2026-10-17"}, date.Add(15*time.Minute))).
+		Return(nil)
+
+	cmd := newDialogRouter(storage).ConvertDocumentToCommand(ctx, info, "",
+		usecase.FileDTO{Name: "photo.jpg", Data: data})
+
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+	assert.Equal(t, "Чек распознан. Какая категория у расхода?", cmd.DialogDTO.Question)
+}
+
+func TestDialogRouter_ReceiptNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	info := usecase.MessageInfo{UserID: 101, Date: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+
+	data, err := os.ReadFile("../receipt/testdata/no_qr.jpg")
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	cmd := newDialogRouter(storage).ConvertDocumentToCommand(ctx, info, "",
+		usecase.FileDTO{Name: "photo.jpg", Data: data})
+
+	assert.Equal(t, usecase.ReceiptCmdName, cmd.Name)
+	assert.Equal(t, &usecase.ReceiptDTO{Found: false}, cmd.ReceiptDTO)
+}
+
+func TestDialogRouter_ReceiptCategory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	info := usecase.MessageInfo{UserID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(entity.NewDialog(usecase.ReceiptCmdName, []string{"1250.5 2026-10-17"}, date.Add(time.Minute)),
+				true, nil),
+		storage.EXPECT().Delete(gomock.Any(), int64(101), entity.UserID(101), date).Return(true, nil),
+	)
+
+	cmd := newDialogRouter(storage).ConvertTextToCommand(ctx, info, "продукты")
+
+	assert.Equal(t, usecase.AddExpenseCmdName, cmd.Name)
+	assert.Equal(t, &usecase.AddExpenseReqDTO{
+		UserID:   101,
+		Category: "продукты",
+		Price:    decimal.RequireFromString("1250.5"),
+		Date:     date.AddDate(0, 0, -1),
+	}, cmd.AddExpenseReqDTO)
+}
+
+func TestDialogRouter_GroupAndPrivate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	group := usecase.MessageInfo{UserID: 101, ChatID: -300, Date: date}
+	private := usecase.MessageInfo{UserID: 101, ChatID: 101, Date: date}
+
+	ctrl := gomock.NewController(t)
+	storage := mock_textrouter.NewMockDialogStorage(ctrl)
+
+	gomock.InOrder(
+		storage.EXPECT().Get(gomock.Any(), int64(-300), entity.UserID(101), date).
+			Return(entity.Dialog{}, false, nil),
+		storage.EXPECT().Update(gomock.Any(), int64(-300), entity.UserID(101),
+			entity.NewDialog(usecase.AddExpenseCmdName, nil, date.Add(15*time.Minute))).
+			Return(nil),
+		// В личном чате диалога нет, ответ из группы туда не переносится
+		storage.EXPECT().Get(gomock.Any(), int64(101), entity.UserID(101), date).
+			Return(entity.Dialog{}, false, nil),
+		storage.EXPECT().Get(gomock.Any(), int64(-300), entity.UserID(101), date).
+			Return(entity.NewDialog(usecase.AddExpenseCmdName, nil, date.Add(time.Minute)), true, nil),
+		storage.EXPECT().Update(gomock.Any(), int64(-300), entity.UserID(101),
+			entity.NewDialog(usecase.AddExpenseCmdName, []string{"такси"}, date.Add(15*time.Minute))).
+			Return(nil),
+		storage.EXPECT().Get(gomock.Any(), int64(-300), entity.UserID(101), date).
+			Return(entity.NewDialog(usecase.AddExpenseCmdName, []string{"такси"}, date.Add(time.Minute)), true, nil),
+		storage.EXPECT().Delete(gomock.Any(), int64(-300), entity.UserID(101), date).
+			Return(true, nil),
+	)
+
+	router := newDialogRouter(storage)
+
+	cmd := router.ConvertTextToCommand(ctx, group, "расход")
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+
+	text, err := texthandler.NewDialog().ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Какая категория?\nОтветьте на это сообщение", text)
+
+	cmd = router.ConvertTextToCommand(ctx, private, "еда")
+	assert.Equal(t, usecase.UnknownCmdName, cmd.Name)
+
+	cmd = router.ConvertTextToCommand(ctx, group, "такси")
+	assert.Equal(t, usecase.DialogCmdName, cmd.Name)
+
+	cmd = router.ConvertTextToCommand(ctx, group, "300")
+	assert.Equal(t, usecase.AddExpenseCmdName, cmd.Name)
+	assert.Equal(t, int64(-300), cmd.ChatID)
+	assert.Equal(t, "такси", cmd.AddExpenseReqDTO.Category)
+}
+
+func TestDialogRouter_PrivatePrompt(t *testing.T) {
+	t.Parallel()
+
+	cmd := usecase.Command{
+		MessageInfo: usecase.MessageInfo{UserID: 101, ChatID: 101},
+		Name:        usecase.DialogCmdName,
+		DialogDTO:   &usecase.DialogDTO{Question: "Какая категория?"},
+	}
+
+	text, err := texthandler.NewDialog().ConvertCommandToText(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Какая категория?", text)
+}
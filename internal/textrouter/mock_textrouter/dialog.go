@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/textrouter/dialog.go
+
+// Package mock_textrouter is a generated GoMock package.
+package mock_textrouter
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	usecase "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// MockDialogHandler is a mock of DialogHandler interface.
+type MockDialogHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDialogHandlerMockRecorder
+}
+
+// MockDialogHandlerMockRecorder is the mock recorder for MockDialogHandler.
+type MockDialogHandlerMockRecorder struct {
+	mock *MockDialogHandler
+}
+
+// NewMockDialogHandler creates a new mock instance.
+func NewMockDialogHandler(ctrl *gomock.Controller) *MockDialogHandler {
+	mock := &MockDialogHandler{ctrl: ctrl}
+	mock.recorder = &MockDialogHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDialogHandler) EXPECT() *MockDialogHandlerMockRecorder {
+	return m.recorder
+}
+
+// CheckDialogAnswer mocks base method.
+func (m *MockDialogHandler) CheckDialogAnswer(step int, answer string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDialogAnswer", step, answer)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckDialogAnswer indicates an expected call of CheckDialogAnswer.
+func (mr *MockDialogHandlerMockRecorder) CheckDialogAnswer(step, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDialogAnswer", reflect.TypeOf((*MockDialogHandler)(nil).CheckDialogAnswer), step, answer)
+}
+
+// ConvertDialogToText mocks base method.
+func (m *MockDialogHandler) ConvertDialogToText(answers []string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertDialogToText", answers)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ConvertDialogToText indicates an expected call of ConvertDialogToText.
+func (mr *MockDialogHandlerMockRecorder) ConvertDialogToText(answers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertDialogToText", reflect.TypeOf((*MockDialogHandler)(nil).ConvertDialogToText), answers)
+}
+
+// DialogQuestions mocks base method.
+func (m *MockDialogHandler) DialogQuestions() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialogQuestions")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DialogQuestions indicates an expected call of DialogQuestions.
+func (mr *MockDialogHandlerMockRecorder) DialogQuestions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialogQuestions", reflect.TypeOf((*MockDialogHandler)(nil).DialogQuestions))
+}
+
+// StartDialog mocks base method.
+func (m *MockDialogHandler) StartDialog(text string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartDialog", text)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// StartDialog indicates an expected call of StartDialog.
+func (mr *MockDialogHandlerMockRecorder) StartDialog(text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDialog", reflect.TypeOf((*MockDialogHandler)(nil).StartDialog), text)
+}
+
+// MockDocumentDialogHandler is a mock of DocumentDialogHandler interface.
+type MockDocumentDialogHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentDialogHandlerMockRecorder
+}
+
+// MockDocumentDialogHandlerMockRecorder is the mock recorder for MockDocumentDialogHandler.
+type MockDocumentDialogHandlerMockRecorder struct {
+	mock *MockDocumentDialogHandler
+}
+
+// NewMockDocumentDialogHandler creates a new mock instance.
+func NewMockDocumentDialogHandler(ctrl *gomock.Controller) *MockDocumentDialogHandler {
+	mock := &MockDocumentDialogHandler{ctrl: ctrl}
+	mock.recorder = &MockDocumentDialogHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentDialogHandler) EXPECT() *MockDocumentDialogHandlerMockRecorder {
+	return m.recorder
+}
+
+// CheckDialogAnswer mocks base method.
+func (m *MockDocumentDialogHandler) CheckDialogAnswer(step int, answer string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDialogAnswer", step, answer)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckDialogAnswer indicates an expected call of CheckDialogAnswer.
+func (mr *MockDocumentDialogHandlerMockRecorder) CheckDialogAnswer(step, answer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDialogAnswer", reflect.TypeOf((*MockDocumentDialogHandler)(nil).CheckDialogAnswer), step, answer)
+}
+
+// ConvertDialogToText mocks base method.
+func (m *MockDocumentDialogHandler) ConvertDialogToText(answers []string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertDialogToText", answers)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ConvertDialogToText indicates an expected call of ConvertDialogToText.
+func (mr *MockDocumentDialogHandlerMockRecorder) ConvertDialogToText(answers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertDialogToText", reflect.TypeOf((*MockDocumentDialogHandler)(nil).ConvertDialogToText), answers)
+}
+
+// DialogAnswers mocks base method.
+func (m *MockDocumentDialogHandler) DialogAnswers(cmd *usecase.Command) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialogAnswers", cmd)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DialogAnswers indicates an expected call of DialogAnswers.
+func (mr *MockDocumentDialogHandlerMockRecorder) DialogAnswers(cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialogAnswers", reflect.TypeOf((*MockDocumentDialogHandler)(nil).DialogAnswers), cmd)
+}
+
+// DialogQuestions mocks base method.
+func (m *MockDocumentDialogHandler) DialogQuestions() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialogQuestions")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DialogQuestions indicates an expected call of DialogQuestions.
+func (mr *MockDocumentDialogHandlerMockRecorder) DialogQuestions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialogQuestions", reflect.TypeOf((*MockDocumentDialogHandler)(nil).DialogQuestions))
+}
+
+// StartDialog mocks base method.
+func (m *MockDocumentDialogHandler) StartDialog(text string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartDialog", text)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// StartDialog indicates an expected call of StartDialog.
+func (mr *MockDocumentDialogHandlerMockRecorder) StartDialog(text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDialog", reflect.TypeOf((*MockDocumentDialogHandler)(nil).StartDialog), text)
+}
+
+// MockDialogStorage is a mock of DialogStorage interface.
+type MockDialogStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockDialogStorageMockRecorder
+}
+
+// MockDialogStorageMockRecorder is the mock recorder for MockDialogStorage.
+type MockDialogStorageMockRecorder struct {
+	mock *MockDialogStorage
+}
+
+// NewMockDialogStorage creates a new mock instance.
+func NewMockDialogStorage(ctrl *gomock.Controller) *MockDialogStorage {
+	mock := &MockDialogStorage{ctrl: ctrl}
+	mock.recorder = &MockDialogStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDialogStorage) EXPECT() *MockDialogStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDialogStorage) Delete(arg0 context.Context, arg1 int64, arg2 entity.UserID, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDialogStorageMockRecorder) Delete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDialogStorage)(nil).Delete), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockDialogStorage) Get(arg0 context.Context, arg1 int64, arg2 entity.UserID, arg3 time.Time) (entity.Dialog, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(entity.Dialog)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDialogStorageMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDialogStorage)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockDialogStorage) Update(arg0 context.Context, arg1 int64, arg2 entity.UserID, arg3 entity.Dialog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDialogStorageMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDialogStorage)(nil).Update), arg0, arg1, arg2, arg3)
+}
@@ -2,8 +2,8 @@ package textrouter
 
 import (
 	"context"
-	"time"
 
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
 )
@@ -14,6 +14,39 @@ type Handler interface {
 	ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error)
 }
 
+// FilesHandler обработчик, который кроме текста отправляет пользователю файлы.
+type FilesHandler interface {
+	ConvertCommandToFiles(ctx context.Context, cmd *usecase.Command) []usecase.FileDTO
+}
+
+// PhotosHandler обработчик, который кроме текста отправляет пользователю картинки.
+type PhotosHandler interface {
+	ConvertCommandToPhotos(ctx context.Context, cmd *usecase.Command) ([]usecase.FileDTO, error)
+}
+
+// Button кнопка под сообщением. Data - текст команды, которую кнопка отправит,
+// он разбирается обработчиками так же, как набранное сообщение.
+type Button struct {
+	Text string
+	Data string
+}
+
+// Ответ участнику общего бюджета на команду, доступную только владельцу.
+const forbiddenText = "Это может сделать только владелец общего бюджета"
+
+// Telegram ограничивает данные кнопки 64 байтами.
+const maxButtonDataLen = 64
+
+// KeyboardHandler обработчик, который предлагает пользователю кнопки со следующими действиями.
+type KeyboardHandler interface {
+	ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]Button
+}
+
+// DocumentHandler обработчик файлов, присланных пользователем.
+type DocumentHandler interface {
+	ConvertDocumentToCommand(ctx context.Context, file usecase.FileDTO, cmd *usecase.Command) bool
+}
+
 type RouterText struct {
 	handlers []Handler
 }
@@ -28,14 +61,11 @@ func (r *RouterText) Register(handler Handler) {
 	r.handlers = append(r.handlers, handler)
 }
 
-func (r *RouterText) ConvertTextToCommand(ctx context.Context, userID int64, date time.Time, text string,
+func (r *RouterText) ConvertTextToCommand(ctx context.Context, info usecase.MessageInfo, text string,
 ) usecase.Command {
-	cmd := usecase.Command{
-		MessageInfo: usecase.MessageInfo{
-			UserID: userID,
-			Date:   date,
-		},
-	}
+	cmd := usecase.Command{MessageInfo: info}
+
+	text = i18n.NormalizeCommand(text)
 
 	for _, handler := range r.handlers {
 		if handler.ConvertTextToCommand(ctx, text, &cmd) {
@@ -48,7 +78,30 @@ func (r *RouterText) ConvertTextToCommand(ctx context.Context, userID int64, dat
 	return cmd
 }
 
+// ConvertDocumentToCommand передает файл обработчикам файлов. Если файл никому не
+// подошел, команда разбирается по подписи к нему, как обычное сообщение.
+func (r *RouterText) ConvertDocumentToCommand(ctx context.Context, info usecase.MessageInfo, caption string,
+	file usecase.FileDTO,
+) usecase.Command {
+	cmd := usecase.Command{MessageInfo: info}
+
+	for _, handler := range r.handlers {
+		documentHandler, ok := handler.(DocumentHandler)
+		if ok && documentHandler.ConvertDocumentToCommand(ctx, file, &cmd) {
+			cmd.Name = handler.Name()
+
+			return cmd
+		}
+	}
+
+	return r.ConvertTextToCommand(ctx, info, caption)
+}
+
 func (r *RouterText) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) string {
+	if cmd.Forbidden {
+		return i18n.T(cmd.Locale, forbiddenText)
+	}
+
 	for _, handler := range r.handlers {
 		if handler.Name() == cmd.Name {
 			text, err := handler.ConvertCommandToText(ctx, cmd)
@@ -66,3 +119,97 @@ func (r *RouterText) ConvertCommandToText(ctx context.Context, cmd *usecase.Comm
 
 	return ErrInvalidCommand.Error()
 }
+
+// ConvertCommandToFiles возвращает файлы для отправки, если обработчик команды их поддерживает.
+func (r *RouterText) ConvertCommandToFiles(ctx context.Context, cmd *usecase.Command) []usecase.FileDTO {
+	if cmd.Forbidden {
+		return nil
+	}
+
+	for _, handler := range r.handlers {
+		if handler.Name() != cmd.Name {
+			continue
+		}
+
+		if filesHandler, ok := handler.(FilesHandler); ok {
+			return filesHandler.ConvertCommandToFiles(ctx, cmd)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// ConvertCommandToPhotos возвращает картинки для отправки, если обработчик команды их поддерживает.
+// Картинки дополняют текст, поэтому ошибка их отрисовки только логируется.
+func (r *RouterText) ConvertCommandToPhotos(ctx context.Context, cmd *usecase.Command) []usecase.FileDTO {
+	if cmd.Forbidden {
+		return nil
+	}
+
+	for _, handler := range r.handlers {
+		if handler.Name() != cmd.Name {
+			continue
+		}
+
+		photosHandler, ok := handler.(PhotosHandler)
+		if !ok {
+			return nil
+		}
+
+		photos, err := photosHandler.ConvertCommandToPhotos(ctx, cmd)
+		if err != nil {
+			logger.Errorf("can not convert command to photos: %v", err)
+
+			return nil
+		}
+
+		return photos
+	}
+
+	return nil
+}
+
+// ConvertCommandToKeyboard возвращает кнопки к ответу, если обработчик команды их поддерживает.
+// Кнопки со слишком длинными данными Telegram не примет, они пропускаются.
+func (r *RouterText) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]Button {
+	if cmd.Forbidden {
+		return nil
+	}
+
+	for _, handler := range r.handlers {
+		if handler.Name() != cmd.Name {
+			continue
+		}
+
+		keyboardHandler, ok := handler.(KeyboardHandler)
+		if !ok {
+			return nil
+		}
+
+		keyboard := make([][]Button, 0)
+
+		for _, row := range keyboardHandler.ConvertCommandToKeyboard(ctx, cmd) {
+			buttons := make([]Button, 0, len(row))
+
+			for _, button := range row {
+				if len(button.Data) > maxButtonDataLen {
+					logger.Errorf("button data is too long: %v", button.Data)
+
+					continue
+				}
+
+				buttons = append(buttons, button)
+			}
+
+			if len(buttons) != 0 {
+				keyboard = append(keyboard, buttons)
+			}
+		}
+
+		return keyboard
+	}
+
+	return nil
+}
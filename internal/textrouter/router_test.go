@@ -0,0 +1,41 @@
+package textrouter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestRouterText_Undo(t *testing.T) {
+	t.Parallel()
+
+	router := textrouter.New()
+	router.Register(texthandler.NewUndo())
+	router.Register(texthandler.NewDeleteRecurringExpense())
+	router.Register(texthandler.NewCancelDialog())
+	router.Register(texthandler.NewUnknown())
+
+	tests := []struct {
+		text string
+		name string
+	}{
+		{text: "/undo", name: usecase.UndoCmdName},
+		{text: "undo", name: usecase.UndoCmdName},
+		{text: "Undo", name: usecase.UndoCmdName},
+		// Отмена необратимо удаляет расход, поэтому похожие слова ее не запускают
+		{text: "cancel", name: usecase.UnknownCmdName},
+		{text: "отменить", name: usecase.UnknownCmdName},
+		{text: "cancel recurring 3", name: usecase.DeleteRecurringExpenseCmdName},
+		{text: "/cancel", name: usecase.CancelDialogCmdName},
+	}
+
+	for _, tt := range tests {
+		cmd := router.ConvertTextToCommand(context.Background(), usecase.MessageInfo{UserID: 101}, tt.text)
+
+		assert.Equal(t, tt.name, cmd.Name, tt.text)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 )
 
@@ -22,5 +23,5 @@ func (h *About) ConvertTextToCommand(ctx context.Context, text string, cmd *usec
 }
 
 func (h *About) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
-	return "Я бот для учета расходов. Автор @amyasnikov. OzonTech.", nil
+	return i18n.T(cmd.Locale, "Я бот для учета расходов. Автор @amyasnikov. OzonTech."), nil
 }
@@ -0,0 +1,187 @@
+package texthandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// accountName имя счета можно указывать как с @, так и без него.
+func accountName(text string) string {
+	if name, ok := parseAccountName(text); ok {
+		return name
+	}
+
+	return strings.ToLower(text)
+}
+
+type CreateAccount struct{}
+
+func NewCreateAccount() *CreateAccount {
+	return &CreateAccount{}
+}
+
+func (h *CreateAccount) Name() string {
+	return usecase.CreateAccountCmdName
+}
+
+// ConvertTextToCommand счет <название> [валюта] [остаток], например: счет наличные USD 200.
+func (h *CreateAccount) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCountMin := 2
+	argsCountMax := 4
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "счет" {
+		return false
+	}
+
+	var (
+		currency   string
+		balance    = decimal.Zero
+		balanceSet bool
+	)
+
+	for _, field := range fields[2:] {
+		if code, ok := parseCurrencyCode(field); ok && len(currency) == 0 {
+			currency = code
+		} else if amount, err := decimal.NewFromString(field); err == nil && !balanceSet {
+			balance, balanceSet = amount, true
+		} else {
+			return false
+		}
+	}
+
+	cmd.CreateAccountReqDTO = &usecase.CreateAccountReqDTO{
+		UserID:   cmd.UserID,
+		Name:     accountName(fields[1]),
+		Currency: currency,
+		Balance:  balance,
+	}
+
+	return true
+}
+
+func (h *CreateAccount) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.CreateAccountRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "CreateAccount.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	resp := cmd.CreateAccountRespDTO
+
+	if resp.Exists {
+		return i18n.Sprintf(locale, "Счет %s уже есть", resp.Name), nil
+	}
+
+	return i18n.Sprintf(locale, "Добавил счет %s в валюте %s", resp.Name, resp.Currency), nil
+}
+
+type Transfer struct{}
+
+func NewTransfer() *Transfer {
+	return &Transfer{}
+}
+
+func (h *Transfer) Name() string {
+	return usecase.TransferCmdName
+}
+
+// ConvertTextToCommand перевод <откуда> <куда> <сумма>, сумма в валюте счета списания.
+func (h *Transfer) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCount := 4
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "перевод" {
+		return false
+	}
+
+	from, to := accountName(fields[1]), accountName(fields[2])
+	if from == to {
+		return false
+	}
+
+	amount, err := decimal.NewFromString(fields[3])
+	if err != nil || !amount.IsPositive() {
+		return false
+	}
+
+	cmd.TransferReqDTO = &usecase.TransferReqDTO{
+		UserID: cmd.UserID,
+		From:   from,
+		To:     to,
+		Amount: amount,
+		Date:   cmd.Date,
+	}
+
+	return true
+}
+
+func (h *Transfer) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.TransferReqDTO == nil || cmd.TransferRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Transfer.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	req, resp := cmd.TransferReqDTO, cmd.TransferRespDTO
+
+	if len(resp.NotFound) != 0 {
+		return i18n.Sprintf(locale, "Счет %s не найден", resp.NotFound), nil
+	}
+
+	textOut := i18n.Sprintf(locale, "Перевел %s %s со счета %s на счет %s",
+		i18n.FormatAmount(locale, req.Amount), resp.FromCurrency, req.From, req.To)
+
+	if resp.FromCurrency != resp.ToCurrency {
+		textOut += i18n.Sprintf(locale, "\nЗачислено: %s %s", i18n.FormatAmount(locale, resp.AmountTo), resp.ToCurrency)
+	}
+
+	return textOut, nil
+}
+
+type GetBalances struct{}
+
+func NewGetBalances() *GetBalances {
+	return &GetBalances{}
+}
+
+func (h *GetBalances) Name() string {
+	return usecase.GetBalancesCmdName
+}
+
+func (h *GetBalances) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != "баланс" {
+		return false
+	}
+
+	cmd.GetBalancesReqDTO = &usecase.GetBalancesReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetBalances) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetBalancesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetBalances.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+
+	if len(cmd.GetBalancesRespDTO.Accounts) == 0 {
+		return i18n.T(locale, "Счетов нет. Добавьте счет: счет <название> [валюта] [остаток]"), nil
+	}
+
+	lines := make([]string, 0, len(cmd.GetBalancesRespDTO.Accounts))
+	for _, account := range cmd.GetBalancesRespDTO.Accounts {
+		lines = append(lines, fmt.Sprintf("%s - %s %s", account.Name,
+			i18n.FormatAmount(locale, account.Balance), account.Currency))
+	}
+
+	return i18n.T(locale, "Остатки на счетах:\n") + strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,196 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestCreateAccountConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.CreateAccount
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.CreateAccountReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "name only",
+			textInput:   "счет Карта",
+			matched:     true,
+			reqExpected: &usecase.CreateAccountReqDTO{UserID: 101, Name: "карта", Balance: decimal.Zero},
+		},
+		{
+			description: "currency and balance",
+			textInput:   "счет @наличные 200 usd",
+			matched:     true,
+			reqExpected: &usecase.CreateAccountReqDTO{
+				UserID:   101,
+				Name:     "наличные",
+				Currency: "USD",
+				Balance:  decimal.New(200, 0),
+			},
+		},
+		{
+			description: "unknown argument",
+			textInput:   "счет карта сбербанк",
+			matched:     false,
+		},
+		{
+			description: "no name",
+			textInput:   "счет",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101}}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.CreateAccountReqDTO)
+		})
+	}
+}
+
+func TestTransferConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	var handler texthandler.Transfer
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.TransferReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "transfer",
+			textInput:   "перевод карта @Наличные 5000",
+			matched:     true,
+			reqExpected: &usecase.TransferReqDTO{
+				UserID: 101,
+				From:   "карта",
+				To:     "наличные",
+				Amount: decimal.New(5000, 0),
+				Date:   date,
+			},
+		},
+		{
+			description: "same account",
+			textInput:   "перевод карта карта 5000",
+			matched:     false,
+		},
+		{
+			description: "negative amount",
+			textInput:   "перевод карта наличные -5",
+			matched:     false,
+		},
+		{
+			description: "no amount",
+			textInput:   "перевод карта наличные",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: date}}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.TransferReqDTO)
+		})
+	}
+}
+
+func TestTransferConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	req := &usecase.TransferReqDTO{From: "карта", To: "наличные", Amount: decimal.New(9500, 0)}
+
+	type testCase struct {
+		description  string
+		resp         *usecase.TransferRespDTO
+		textExpected string
+	}
+
+	testCases := [...]testCase{
+		{
+			description:  "not found",
+			resp:         &usecase.TransferRespDTO{NotFound: "наличные"},
+			textExpected: "Счет наличные не найден",
+		},
+		{
+			description:  "same currency",
+			resp:         &usecase.TransferRespDTO{FromCurrency: "RUB", AmountTo: decimal.New(9500, 0), ToCurrency: "RUB"},
+			textExpected: "Перевел 9 500,00 RUB со счета карта на счет наличные",
+		},
+		{
+			description: "conversion",
+			resp:        &usecase.TransferRespDTO{FromCurrency: "RUB", AmountTo: decimal.New(95, 0), ToCurrency: "USD"},
+			textExpected: "Перевел 9 500,00 RUB со счета карта на счет наличные\n" +
+				"Зачислено: 95,00 USD",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.Transfer
+
+			cmd := usecase.Command{TransferReqDTO: req, TransferRespDTO: scenario.resp}
+
+			textOutput, err := handler.ConvertCommandToText(context.Background(), &cmd)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.textExpected, textOutput)
+		})
+	}
+}
+
+func TestGetBalancesConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetBalances
+
+	cmd := usecase.Command{
+		GetBalancesRespDTO: &usecase.GetBalancesRespDTO{
+			Accounts: []usecase.AccountBalanceDTO{
+				{Name: "карта", Currency: "RUB", Balance: decimal.New(120005, -1)},
+				{Name: "наличные", Currency: "USD", Balance: decimal.New(-5, 0)},
+			},
+		},
+	}
+
+	textOutput, err := handler.ConvertCommandToText(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Остатки на счетах:\nкарта - 12 000,50 RUB\nналичные - -5,00 USD", textOutput)
+
+	textOutput, err = handler.ConvertCommandToText(context.Background(),
+		&usecase.Command{GetBalancesRespDTO: &usecase.GetBalancesRespDTO{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Счетов нет. Добавьте счет: счет <название> [валюта] [остаток]", textOutput)
+}
@@ -0,0 +1,63 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type AddCategoryAlias struct{}
+
+func NewAddCategoryAlias() *AddCategoryAlias {
+	return &AddCategoryAlias{}
+}
+
+func (h *AddCategoryAlias) Name() string {
+	return usecase.AddCategoryAliasCmdName
+}
+
+func (h *AddCategoryAlias) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	aliasIndex := 1
+	categoryIndex := 2
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "синоним" {
+		return false
+	}
+
+	alias, ok := parseCategoryName(fields[aliasIndex])
+	if !ok {
+		return false
+	}
+
+	category, ok := parseCategoryName(fields[categoryIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.AddCategoryAliasReqDTO = &usecase.AddCategoryAliasReqDTO{
+		UserID:   cmd.UserID,
+		Alias:    alias,
+		Category: category,
+	}
+
+	return true
+}
+
+func (h *AddCategoryAlias) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.AddCategoryAliasReqDTO == nil || cmd.AddCategoryAliasRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "AddCategoryAlias.ExecuteCommand")
+	}
+
+	if !cmd.AddCategoryAliasRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Категория %s не найдена", cmd.AddCategoryAliasReqDTO.Category), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Теперь %s - это %s", cmd.AddCategoryAliasReqDTO.Alias,
+		cmd.AddCategoryAliasRespDTO.Category), nil
+}
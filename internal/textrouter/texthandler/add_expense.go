@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+	"golang.org/x/text/currency"
 )
 
 type AddExpense struct{}
@@ -23,14 +24,16 @@ func (h *AddExpense) Name() string {
 	return usecase.AddExpenseCmdName
 }
 
+// ConvertTextToCommand после суммы допускает валюту, дату и счет в любом порядке, а за
+// ними заметку: расход еда 500 вчера @карта ужин с Петей.
 func (h *AddExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
 	categoryIndex := 1
 	priceIndex := 2
+	optionsIndex := 3
 	argsCountMin := 3
-	argsCountMax := 3
 
 	fields := strings.Fields(text)
-	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "расход" {
+	if len(fields) < argsCountMin || fields[0] != "расход" {
 		return false
 	}
 
@@ -41,11 +44,44 @@ func (h *AddExpense) ConvertTextToCommand(ctx context.Context, text string, cmd
 		return false
 	}
 
+	// Необязательные валюта, дата и счет расхода в любом порядке
+	var currency, account string
+
+	date, dateSet := cmd.Date, false
+
+	options := fields[optionsIndex:]
+
+	for len(options) != 0 {
+		field := options[0]
+
+		if parsed, ok := utils.ParsePastDate(field, cmd.Date); ok {
+			if dateSet {
+				return false
+			}
+
+			date, dateSet = parsed, true
+		} else if code, ok := parseCurrencyCode(field); ok && len(currency) == 0 {
+			currency = code
+		} else if name, ok := parseAccountName(field); ok && len(account) == 0 {
+			account = name
+		} else if isDateLike(field) {
+			// Опечатка в дате не должна превращаться в заметку
+			return false
+		} else {
+			break
+		}
+
+		options = options[1:]
+	}
+
 	cmd.AddExpenseReqDTO = &usecase.AddExpenseReqDTO{
 		UserID:   cmd.UserID,
 		Category: category,
 		Price:    price,
-		Date:     cmd.Date,
+		Currency: currency,
+		Date:     date,
+		Note:     strings.Join(options, " "),
+		Account:  account,
 	}
 
 	return true
@@ -56,27 +92,147 @@ func (h *AddExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Comm
 		return "", errors.Wrap(textrouter.ErrInvalidCommand, "AddExpense.ExecuteCommand")
 	}
 
-	precision := 2
+	locale := cmd.Locale
 
-	textOut := fmt.Sprintf("Добавил %s - %s %s %s", cmd.AddExpenseReqDTO.Category,
-		cmd.AddExpenseReqDTO.Price.StringFixed(int32(precision)), cmd.AddExpenseRespDTO.Currency,
-		cmd.AddExpenseReqDTO.Date.Format(time.RFC1123))
+	if cmd.AddExpenseRespDTO.AccountNotFound {
+		return i18n.Sprintf(locale, "Счет %s не найден", cmd.AddExpenseReqDTO.Account), nil
+	}
 
-	for _, interval := range []int{utils.DayInterval, utils.WeekInterval, utils.MonthInterval} {
-		limit, ok := cmd.AddExpenseRespDTO.Limits[interval]
-		if !ok {
-			continue
-		}
+	currency := cmd.AddExpenseReqDTO.Currency
+	if len(currency) == 0 {
+		currency = cmd.AddExpenseRespDTO.Currency
+	}
+
+	textOut := i18n.Sprintf(locale, "Добавил #%d %s - %s %s %s", cmd.AddExpenseRespDTO.ID,
+		cmd.AddExpenseReqDTO.Category, i18n.FormatAmount(locale, cmd.AddExpenseReqDTO.Price), currency,
+		i18n.FormatDateTime(locale, cmd.AddExpenseReqDTO.Date))
+
+	if len(cmd.AddExpenseReqDTO.Note) != 0 {
+		textOut += i18n.Sprintf(locale, "\nЗаметка: %s", cmd.AddExpenseReqDTO.Note)
+	}
 
-		if limit.GreaterThanOrEqual(decimal.Zero) {
+	if len(cmd.AddExpenseReqDTO.Account) != 0 {
+		textOut += i18n.Sprintf(locale, "\nСчет: %s", cmd.AddExpenseReqDTO.Account)
+	}
+
+	if currency != cmd.AddExpenseRespDTO.Currency {
+		textOut += i18n.Sprintf(locale, "\nВ валюте по умолчанию: %s %s",
+			i18n.FormatAmount(locale, cmd.AddExpenseRespDTO.Price), cmd.AddExpenseRespDTO.Currency)
+	}
+
+	for _, limit := range cmd.AddExpenseRespDTO.Limits {
+		if limit.Limit.GreaterThanOrEqual(decimal.Zero) {
 			continue
 		}
 
-		intervalStr, _ := utils.IntervalToStr(interval)
+		intervalStr := intervalToStr(locale, limit.IntervalType)
 
-		textOut += fmt.Sprintf("\nВнимание! Превышен лимит: %s - %s",
-			intervalStr, limit.Neg().StringFixed(int32(precision)))
+		if len(limit.Category) == 0 {
+			textOut += i18n.Sprintf(locale, "\nВнимание! Превышен лимит: %s - %s",
+				intervalStr, i18n.FormatAmount(locale, limit.Limit.Neg()))
+		} else {
+			textOut += i18n.Sprintf(locale, "\nВнимание! Превышен лимит по категории %s: %s - %s",
+				limit.Category, intervalStr, i18n.FormatAmount(locale, limit.Limit.Neg()))
+		}
 	}
 
 	return textOut, nil
 }
+
+// StartDialog "расход" без аргументов спрашивает категорию и сумму по очереди.
+func (h *AddExpense) StartDialog(text string) bool {
+	return strings.TrimSpace(text) == "расход"
+}
+
+func (h *AddExpense) DialogQuestions() []string {
+	return []string{"Какая категория?", "Сколько? Можно указать валюту, например 300 USD"}
+}
+
+// CheckDialogAnswer категория - одно слово, сумма - число и, возможно, код валюты.
+func (h *AddExpense) CheckDialogAnswer(step int, answer string) bool {
+	fields := strings.Fields(answer)
+
+	switch step {
+	case 0:
+		return len(fields) == 1
+	case 1:
+		argsCountMax := 2
+
+		if len(fields) == 0 || len(fields) > argsCountMax {
+			return false
+		}
+
+		if _, err := decimal.NewFromString(fields[0]); err != nil {
+			return false
+		}
+
+		if len(fields) == argsCountMax {
+			_, ok := parseCurrencyCode(fields[1])
+
+			return ok
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *AddExpense) ConvertDialogToText(answers []string) string {
+	return "расход " + strings.Join(answers, " ")
+}
+
+// ConvertCommandToKeyboard предлагает отменить только что добавленный расход или сменить ему категорию.
+func (h *AddExpense) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	if cmd.AddExpenseRespDTO == nil || cmd.AddExpenseRespDTO.ID == 0 {
+		return nil
+	}
+
+	return [][]textrouter.Button{{
+		{Text: i18n.T(cmd.Locale, "Отменить"), Data: fmt.Sprintf("удалить %d", cmd.AddExpenseRespDTO.ID)},
+		{Text: i18n.T(cmd.Locale, "Изменить категорию"), Data: fmt.Sprintf("категории %d", cmd.AddExpenseRespDTO.ID)},
+	}}
+}
+
+// Код валюты ISO 4217, например EUR или usd. Другие слова из трех латинских букв,
+// например tea или bar, могут начинать заметку, поэтому валютой не считаются.
+func parseCurrencyCode(text string) (string, bool) {
+	codeLen := 3
+
+	if len(text) != codeLen {
+		return "", false
+	}
+
+	unit, err := currency.ParseISO(strings.ToUpper(text))
+	if err != nil {
+		return "", false
+	}
+
+	return unit.String(), true
+}
+
+// parseAccountName счет указывается с @ перед именем: @карта.
+func parseAccountName(text string) (string, bool) {
+	if !strings.HasPrefix(text, "@") || len(text) == 1 {
+		return "", false
+	}
+
+	return strings.ToLower(strings.TrimPrefix(text, "@")), true
+}
+
+// isDateLike похоже на дату: только цифры и разделители даты.
+func isDateLike(text string) bool {
+	hasDigit := false
+
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r == '.' || r == '-' || r == '/':
+		default:
+			return false
+		}
+	}
+
+	return hasDigit
+}
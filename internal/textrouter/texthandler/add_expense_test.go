@@ -7,6 +7,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
@@ -16,6 +17,7 @@ func TestAddExpenseConvertTextToCommand(t *testing.T) {
 	t.Parallel()
 
 	date := time.Now()
+	msgDate := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
 
 	var handler texthandler.AddExpense
 
@@ -67,10 +69,385 @@ func TestAddExpenseConvertTextToCommand(t *testing.T) {
 			},
 		},
 		{
-			description: "invalid request",
-			textInput:   "расход категория1 1234.45678 EUR tmp",
+			description: "category + price + currency",
+			textInput:   "расход категория1 12.5 eur",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "категория1",
+					Price:    decimal.RequireFromString("12.5"),
+					Currency: "EUR",
+					Date:     date,
+				},
+			},
+		},
+		{
+			description: "unknown currency is a note",
+			textInput:   "расход категория1 12.5 евро",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "категория1",
+					Price:    decimal.RequireFromString("12.5"),
+					Date:     date,
+					Note:     "евро",
+				},
+			},
+		},
+		{
+			description: "yesterday",
+			textInput:   "расход Такси 300 вчера",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "Такси",
+					Price:    decimal.RequireFromString("300"),
+					Date:     time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			description: "day and month",
+			textInput:   "расход Такси 300 15.10",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "Такси",
+					Price:    decimal.RequireFromString("300"),
+					Date:     time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			description: "day and month in future is last year",
+			textInput:   "расход Такси 300 20.12",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "Такси",
+					Price:    decimal.RequireFromString("300"),
+					Date:     time.Date(2025, 12, 20, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			description: "iso date + currency",
+			textInput:   "расход Такси 300 2026-10-15 usd",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "Такси",
+					Price:    decimal.RequireFromString("300"),
+					Currency: "USD",
+					Date:     time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			description: "currency + date",
+			textInput:   "расход Такси 300 usd 15.10.2026",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "Такси",
+					Price:    decimal.RequireFromString("300"),
+					Currency: "USD",
+					Date:     time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			description: "invalid date",
+			textInput:   "расход Такси 300 32.10",
 			matched:     false,
 		},
+		{
+			description: "date in future",
+			textInput:   "расход Такси 300 20.10.2026",
+			matched:     false,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+		},
+		{
+			description: "no leap day",
+			textInput:   "расход Такси 300 29.02",
+			matched:     false,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+		},
+		{
+			description: "two dates",
+			textInput:   "расход Такси 300 вчера сегодня",
+			matched:     false,
+		},
+		{
+			description: "note",
+			textInput:   "расход еда 500 вчера ужин с Петей 15.10",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.New(500, 0),
+					Date:     date.AddDate(0, 0, -1),
+					Note:     "ужин с Петей 15.10",
+				},
+			},
+		},
+		{
+			description: "account",
+			textInput:   "расход еда 500 @Карта ужин",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.New(500, 0),
+					Date:     date,
+					Note:     "ужин",
+					Account:  "карта",
+				},
+			},
+		},
+		{
+			description: "currency and note",
+			textInput:   "расход категория1 1234.45678 EUR tmp",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "категория1",
+					Price:    decimal.RequireFromString("1234.45678"),
+					Currency: "EUR",
+					Date:     date,
+					Note:     "tmp",
+				},
+			},
+		},
+		{
+			description: "note starts with english word",
+			textInput:   "расход еда 500 tea with Bob",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.RequireFromString("500"),
+					Date:     date,
+					Note:     "tea with Bob",
+				},
+			},
+		},
+		{
+			description: "note starts with bar",
+			textInput:   "расход еда 500 bar",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.RequireFromString("500"),
+					Date:     date,
+					Note:     "bar",
+				},
+			},
+		},
+		{
+			description: "currency and english note",
+			textInput:   "расход еда 500 usd car wash",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.RequireFromString("500"),
+					Currency: "USD",
+					Date:     date,
+					Note:     "car wash",
+				},
+			},
+		},
+		{
+			description: "note starts with for",
+			textInput:   "расход еда 500 вчера for Bob",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   101,
+					Category: "еда",
+					Price:    decimal.RequireFromString("500"),
+					Date:     date.AddDate(0, 0, -1),
+					Note:     "for Bob",
+				},
+			},
+		},
 	}
 
 	for _, scenario := range testCases {
@@ -119,11 +496,30 @@ func TestAddExpenseConvertCommandToText(t *testing.T) {
 					Date:     date,
 				},
 				AddExpenseRespDTO: &usecase.AddExpenseRespDTO{
+					ID:       7,
 					Currency: "EUR",
 					Limits:   nil,
 				},
 			},
-			textExpected: "Добавил Category2 - 43.57 EUR Tue, 20 Sep 2022 00:00:00 UTC",
+			textExpected: "Добавил #7 Category2 - 43,57 EUR 20.09.2022 00:00 UTC",
+			errExpected:  "",
+		},
+		{
+			description: "note",
+			cmd: usecase.Command{
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   userID,
+					Category: "еда",
+					Price:    decimal.New(500, 0),
+					Date:     date,
+					Note:     "ужин с Петей",
+				},
+				AddExpenseRespDTO: &usecase.AddExpenseRespDTO{
+					ID:       9,
+					Currency: "RUB",
+				},
+			},
+			textExpected: "Добавил #9 еда - 500,00 RUB 20.09.2022 00:00 UTC\nЗаметка: ужин с Петей",
 			errExpected:  "",
 		},
 		{
@@ -136,18 +532,55 @@ func TestAddExpenseConvertCommandToText(t *testing.T) {
 					Date:     date,
 				},
 				AddExpenseRespDTO: &usecase.AddExpenseRespDTO{
+					ID:       8,
 					Currency: "USD",
-					Limits: map[int]decimal.Decimal{
-						utils.DayInterval:   decimal.New(0, 0),
-						utils.WeekInterval:  decimal.RequireFromString("-12345.678"),
-						utils.MonthInterval: decimal.RequireFromString("34.5678"),
+					Limits: []usecase.LimitDTO{
+						{IntervalType: utils.DayInterval, Limit: decimal.New(0, 0)},
+						{IntervalType: utils.WeekInterval, Limit: decimal.RequireFromString("-12345.678")},
+						{IntervalType: utils.MonthInterval, Limit: decimal.RequireFromString("34.5678")},
+						{Category: "category2", IntervalType: utils.MonthInterval, Limit: decimal.RequireFromString("-3.5")},
 					},
 				},
 			},
-			textExpected: `Добавил Category2 - 43.57 USD Tue, 20 Sep 2022 00:00:00 UTC
-Внимание! Превышен лимит: неделя - 12345.68`,
+			textExpected: `Добавил #8 Category2 - 43,57 USD 20.09.2022 00:00 UTC
+Внимание! Превышен лимит: неделя - 12 345,68
+Внимание! Превышен лимит по категории category2: месяц - 3,50`,
 			errExpected: "",
 		},
+		{
+			description: "category + price in other currency",
+			cmd: usecase.Command{
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   userID,
+					Category: "Taxi",
+					Price:    decimal.RequireFromString("12"),
+					Currency: "EUR",
+					Date:     date,
+				},
+				AddExpenseRespDTO: &usecase.AddExpenseRespDTO{
+					ID:       9,
+					Price:    decimal.RequireFromString("750.5"),
+					Currency: "RUB",
+				},
+			},
+			textExpected: `Добавил #9 Taxi - 12,00 EUR 20.09.2022 00:00 UTC
+В валюте по умолчанию: 750,50 RUB`,
+			errExpected: "",
+		},
+		{
+			description: "not added",
+			cmd: usecase.Command{
+				AddExpenseReqDTO: &usecase.AddExpenseReqDTO{
+					UserID:   userID,
+					Category: "Taxi",
+					Price:    decimal.RequireFromString("12"),
+					Currency: "KZT",
+					Date:     date,
+				},
+			},
+			textExpected: "",
+			errExpected:  "AddExpense.ExecuteCommand: internal error",
+		},
 	}
 
 	for _, scenario := range testCases {
@@ -169,3 +602,25 @@ func TestAddExpenseConvertCommandToText(t *testing.T) {
 		})
 	}
 }
+
+func TestAddExpenseConvertCommandToKeyboard(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.AddExpense
+
+	ctx := context.Background()
+
+	keyboard := handler.ConvertCommandToKeyboard(ctx, &usecase.Command{
+		AddExpenseRespDTO: &usecase.AddExpenseRespDTO{ID: 7},
+	})
+	assert.Equal(t, [][]textrouter.Button{{
+		{Text: "Отменить", Data: "удалить 7"},
+		{Text: "Изменить категорию", Data: "категории 7"},
+	}}, keyboard)
+
+	// Расход не добавлен, отменять нечего
+	keyboard = handler.ConvertCommandToKeyboard(ctx, &usecase.Command{
+		AddExpenseRespDTO: &usecase.AddExpenseRespDTO{ID: 0},
+	})
+	assert.Empty(t, keyboard)
+}
@@ -0,0 +1,53 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type AddImportRule struct{}
+
+func NewAddImportRule() *AddImportRule {
+	return &AddImportRule{}
+}
+
+func (h *AddImportRule) Name() string {
+	return usecase.AddImportRuleCmdName
+}
+
+// ConvertTextToCommand последнее слово - категория, все перед ним - ключевые слова описания.
+func (h *AddImportRule) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	keywordIndex := 2
+	argsCountMin := 4
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || fields[0] != "импорт" || fields[1] != "правило" {
+		return false
+	}
+
+	cmd.AddImportRuleReqDTO = &usecase.AddImportRuleReqDTO{
+		UserID:   cmd.UserID,
+		Keyword:  strings.ToLower(strings.Join(fields[keywordIndex:len(fields)-1], " ")),
+		Category: fields[len(fields)-1],
+	}
+
+	return true
+}
+
+func (h *AddImportRule) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.AddImportRuleReqDTO == nil || cmd.AddImportRuleRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "AddImportRule.ExecuteCommand")
+	}
+
+	if len(cmd.AddImportRuleRespDTO.Category) == 0 {
+		return i18n.T(cmd.Locale, "Не удалось добавить правило"), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Операции с \"%s\" в описании буду относить к категории %s",
+		cmd.AddImportRuleReqDTO.Keyword, cmd.AddImportRuleRespDTO.Category), nil
+}
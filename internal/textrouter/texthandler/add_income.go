@@ -0,0 +1,105 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type AddIncome struct{}
+
+func NewAddIncome() *AddIncome {
+	return &AddIncome{}
+}
+
+func (h *AddIncome) Name() string {
+	return usecase.AddIncomeCmdName
+}
+
+// ConvertTextToCommand после суммы допускает валюту, дату и счет в любом порядке:
+// доход зарплата 100000 вчера @карта.
+func (h *AddIncome) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	sourceIndex := 1
+	amountIndex := 2
+	optionsIndex := 3
+	argsCountMin := 3
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || fields[0] != "доход" {
+		return false
+	}
+
+	amount, err := decimal.NewFromString(fields[amountIndex])
+	if err != nil || !amount.IsPositive() {
+		return false
+	}
+
+	var currency, account string
+
+	date, dateSet := cmd.Date, false
+
+	for _, field := range fields[optionsIndex:] {
+		if parsed, ok := utils.ParsePastDate(field, cmd.Date); ok && !dateSet {
+			date, dateSet = parsed, true
+		} else if code, ok := parseCurrencyCode(field); ok && len(currency) == 0 {
+			currency = code
+		} else if name, ok := parseAccountName(field); ok && len(account) == 0 {
+			account = name
+		} else {
+			return false
+		}
+	}
+
+	cmd.AddIncomeReqDTO = &usecase.AddIncomeReqDTO{
+		UserID:   cmd.UserID,
+		Source:   fields[sourceIndex],
+		Amount:   amount,
+		Currency: currency,
+		Date:     date,
+		Account:  account,
+	}
+
+	return true
+}
+
+func (h *AddIncome) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.AddIncomeReqDTO == nil || cmd.AddIncomeRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "AddIncome.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+
+	if cmd.AddIncomeRespDTO.AccountNotFound {
+		return i18n.Sprintf(locale, "Счет %s не найден", cmd.AddIncomeReqDTO.Account), nil
+	}
+
+	if cmd.AddIncomeRespDTO.ID == 0 {
+		return i18n.T(locale, "Не удалось добавить доход"), nil
+	}
+
+	currency := cmd.AddIncomeReqDTO.Currency
+	if len(currency) == 0 {
+		currency = cmd.AddIncomeRespDTO.Currency
+	}
+
+	textOut := i18n.Sprintf(locale, "Добавил доход #%d %s - %s %s %s", cmd.AddIncomeRespDTO.ID,
+		strings.ToLower(cmd.AddIncomeReqDTO.Source), i18n.FormatAmount(locale, cmd.AddIncomeReqDTO.Amount), currency,
+		i18n.FormatDateTime(locale, cmd.AddIncomeReqDTO.Date))
+
+	if len(cmd.AddIncomeReqDTO.Account) != 0 {
+		textOut += i18n.Sprintf(locale, "\nСчет: %s", cmd.AddIncomeReqDTO.Account)
+	}
+
+	if currency != cmd.AddIncomeRespDTO.Currency {
+		textOut += i18n.Sprintf(locale, "\nВ валюте по умолчанию: %s %s",
+			i18n.FormatAmount(locale, cmd.AddIncomeRespDTO.Amount), cmd.AddIncomeRespDTO.Currency)
+	}
+
+	return textOut, nil
+}
@@ -0,0 +1,175 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestAddIncomeConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	var handler texthandler.AddIncome
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.AddIncomeReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "source only",
+			textInput:   "доход зарплата",
+			matched:     false,
+		},
+		{
+			description: "source + amount",
+			textInput:   "доход зарплата 100000",
+			matched:     true,
+			reqExpected: &usecase.AddIncomeReqDTO{
+				UserID: 101,
+				Source: "зарплата",
+				Amount: decimal.New(100000, 0),
+				Date:   date,
+			},
+		},
+		{
+			description: "currency and date",
+			textInput:   "доход фриланс 150.5 вчера USD",
+			matched:     true,
+			reqExpected: &usecase.AddIncomeReqDTO{
+				UserID:   101,
+				Source:   "фриланс",
+				Amount:   decimal.New(1505, -1),
+				Currency: "USD",
+				Date:     time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			description: "negative amount",
+			textInput:   "доход зарплата -100",
+			matched:     false,
+		},
+		{
+			description: "date in future",
+			textInput:   "доход зарплата 100 01.01.2027",
+			matched:     false,
+		},
+		{
+			description: "unknown option",
+			textInput:   "доход зарплата 100 премия",
+			matched:     false,
+		},
+		{
+			description: "expense",
+			textInput:   "расход еда 100",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   date,
+				},
+			}
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.AddIncomeReqDTO)
+		})
+	}
+}
+
+func TestAddIncomeConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		description  string
+		cmd          usecase.Command
+		textExpected string
+		errExpected  string
+	}
+
+	testCases := [...]testCase{
+		{
+			description:  "empty resp",
+			cmd:          usecase.Command{},
+			textExpected: "",
+			errExpected:  "AddIncome.ExecuteCommand: internal error",
+		},
+		{
+			description: "default currency",
+			cmd: usecase.Command{
+				AddIncomeReqDTO: &usecase.AddIncomeReqDTO{
+					Source: "Зарплата",
+					Amount: decimal.New(100000, 0),
+					Date:   date,
+				},
+				AddIncomeRespDTO: &usecase.AddIncomeRespDTO{
+					ID:       3,
+					Amount:   decimal.New(100000, 0),
+					Currency: "RUB",
+				},
+			},
+			textExpected: "Добавил доход #3 зарплата - 100 000,00 RUB 17.10.2026 00:00 UTC",
+			errExpected:  "",
+		},
+		{
+			description: "other currency",
+			cmd: usecase.Command{
+				AddIncomeReqDTO: &usecase.AddIncomeReqDTO{
+					Source:   "фриланс",
+					Amount:   decimal.New(100, 0),
+					Currency: "USD",
+					Date:     date,
+				},
+				AddIncomeRespDTO: &usecase.AddIncomeRespDTO{
+					ID:       4,
+					Amount:   decimal.New(9500, 0),
+					Currency: "RUB",
+				},
+			},
+			textExpected: "Добавил доход #4 фриланс - 100,00 USD 17.10.2026 00:00 UTC\n" +
+				"В валюте по умолчанию: 9 500,00 RUB",
+			errExpected: "",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.AddIncome
+
+			ctx := context.Background()
+
+			textOutput, err := handler.ConvertCommandToText(ctx, &scenario.cmd)
+			assert.Equal(t, scenario.textExpected, textOutput)
+			if len(scenario.errExpected) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, scenario.errExpected)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type AddRecurringExpense struct{}
+
+func NewAddRecurringExpense() *AddRecurringExpense {
+	return &AddRecurringExpense{}
+}
+
+func (h *AddRecurringExpense) Name() string {
+	return usecase.AddRecurringExpenseCmdName
+}
+
+func (h *AddRecurringExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	categoryIndex := 1
+	priceIndex := 2
+	optionsIndex := 3
+	argsCountMin := 4
+	argsCountMax := 6
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "регулярный" {
+		return false
+	}
+
+	category := fields[categoryIndex]
+
+	price, err := decimal.NewFromString(fields[priceIndex])
+	if err != nil || !price.IsPositive() {
+		return false
+	}
+
+	// Период обязателен, валюта и дата первого расхода - нет. Порядок любой
+	var (
+		currency     string
+		intervalType int
+	)
+
+	date, dateSet := cmd.Date, false
+
+	for _, field := range fields[optionsIndex:] {
+		if parsed, ok := utils.IntervalFromStr(field); ok && intervalType == 0 {
+			intervalType = parsed
+
+			continue
+		}
+
+		if parsed, ok := utils.ParseDate(field, cmd.Date); ok && !dateSet {
+			date, dateSet = parsed, true
+
+			continue
+		}
+
+		code, ok := parseCurrencyCode(field)
+		if !ok || len(currency) != 0 {
+			return false
+		}
+
+		currency = code
+	}
+
+	if intervalType == 0 {
+		return false
+	}
+
+	cmd.AddRecurringExpenseReqDTO = &usecase.AddRecurringExpenseReqDTO{
+		UserID:       cmd.UserID,
+		Category:     category,
+		Price:        price,
+		Currency:     currency,
+		IntervalType: intervalType,
+		DateStart:    date,
+	}
+
+	return true
+}
+
+func (h *AddRecurringExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.AddRecurringExpenseReqDTO == nil || cmd.AddRecurringExpenseRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "AddRecurringExpense.ExecuteCommand")
+	}
+
+	if cmd.AddRecurringExpenseRespDTO.ID == 0 {
+		return i18n.T(cmd.Locale, "Не удалось добавить регулярный расход"), nil
+	}
+
+	locale := cmd.Locale
+	req := cmd.AddRecurringExpenseReqDTO
+
+	return i18n.Sprintf(locale, "Добавил регулярный расход #%d %s - %s %s %s, начиная с %s",
+		cmd.AddRecurringExpenseRespDTO.ID, cmd.AddRecurringExpenseRespDTO.Category,
+		i18n.FormatAmount(locale, req.Price), cmd.AddRecurringExpenseRespDTO.Currency,
+		recurringIntervalToStr(locale, req.IntervalType), i18n.FormatDate(locale, req.DateStart)), nil
+}
+
+func recurringIntervalToStr(locale string, intervalType int) string {
+	switch intervalType {
+	case utils.DayInterval:
+		return i18n.T(locale, "каждый день")
+	case utils.WeekInterval:
+		return i18n.T(locale, "каждую неделю")
+	case utils.MonthInterval:
+		return i18n.T(locale, "каждый месяц")
+	case utils.YearInterval:
+		return i18n.T(locale, "каждый год")
+	default:
+		return ""
+	}
+}
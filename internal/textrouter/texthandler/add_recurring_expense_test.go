@@ -0,0 +1,94 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestAddRecurringExpenseConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	msgDate := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	var handler texthandler.AddRecurringExpense
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.AddRecurringExpenseReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "no interval",
+			textInput:   "регулярный аренда 30000",
+			matched:     false,
+		},
+		{
+			description: "category + price + interval",
+			textInput:   "регулярный аренда 30000 месяц",
+			matched:     true,
+			reqExpected: &usecase.AddRecurringExpenseReqDTO{
+				UserID:       101,
+				Category:     "аренда",
+				Price:        decimal.RequireFromString("30000"),
+				IntervalType: utils.MonthInterval,
+				DateStart:    msgDate,
+			},
+		},
+		{
+			description: "currency + date",
+			textInput:   "регулярный netflix 9.99 usd месяц 01.11.2026",
+			matched:     true,
+			reqExpected: &usecase.AddRecurringExpenseReqDTO{
+				UserID:       101,
+				Category:     "netflix",
+				Price:        decimal.RequireFromString("9.99"),
+				Currency:     "USD",
+				IntervalType: utils.MonthInterval,
+				DateStart:    time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			description: "two intervals",
+			textInput:   "регулярный аренда 30000 месяц неделя",
+			matched:     false,
+		},
+		{
+			description: "negative price",
+			textInput:   "регулярный аренда -30000 месяц",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			}
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+
+			if scenario.matched {
+				assert.Equal(t, scenario.reqExpected, cmd.AddRecurringExpenseReqDTO)
+			}
+		})
+	}
+}
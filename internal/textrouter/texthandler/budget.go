@@ -0,0 +1,243 @@
+package texthandler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// Команды общего бюджета начинаются со слова "бюджет", второе слово - действие.
+const budgetWord = "бюджет"
+
+// budgetArgs возвращает аргументы после "бюджет <действие>", если текст начинается с них.
+func budgetArgs(text, action string) ([]string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 || fields[0] != budgetWord || fields[1] != action {
+		return nil, false
+	}
+
+	return fields[2:], true
+}
+
+type GetBudget struct{}
+
+func NewGetBudget() *GetBudget {
+	return &GetBudget{}
+}
+
+func (h *GetBudget) Name() string {
+	return usecase.GetBudgetCmdName
+}
+
+func (h *GetBudget) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != budgetWord {
+		return false
+	}
+
+	cmd.GetBudgetReqDTO = &usecase.GetBudgetReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetBudget) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetBudgetReqDTO == nil || cmd.GetBudgetRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetBudget.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	resp := cmd.GetBudgetRespDTO
+
+	if !resp.IsOwner {
+		return i18n.Sprintf(locale, "Вы участник общего бюджета пользователя %d.\nУчастники: %s\n"+
+			"бюджет выйти - вернуться к своим расходам", resp.OwnerID, budgetMembersToStr(resp.Members)), nil
+	}
+
+	if len(resp.Members) == 0 {
+		textOut := i18n.T(locale, "В вашем бюджете нет участников.\nбюджет пригласить - получить код приглашения")
+		if len(resp.InviteCode) != 0 {
+			textOut += i18n.Sprintf(locale, "\nДействующий код: %s", resp.InviteCode)
+		}
+
+		return textOut, nil
+	}
+
+	return i18n.Sprintf(locale, "Вы владелец общего бюджета.\nУчастники: %s\nКод приглашения: %s\n"+
+		"бюджет исключить <id> - исключить участника", budgetMembersToStr(resp.Members), resp.InviteCode), nil
+}
+
+func (h *GetBudget) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	if cmd.GetBudgetRespDTO == nil {
+		return nil
+	}
+
+	if !cmd.GetBudgetRespDTO.IsOwner {
+		return [][]textrouter.Button{{{Text: i18n.T(cmd.Locale, "Выйти"), Data: budgetWord + " выйти"}}}
+	}
+
+	return [][]textrouter.Button{{{Text: i18n.T(cmd.Locale, "Пригласить"), Data: budgetWord + " пригласить"}}}
+}
+
+func budgetMembersToStr(members []int64) string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, strconv.FormatInt(member, 10))
+	}
+
+	return strings.Join(ids, ", ")
+}
+
+type InviteToBudget struct{}
+
+func NewInviteToBudget() *InviteToBudget {
+	return &InviteToBudget{}
+}
+
+func (h *InviteToBudget) Name() string {
+	return usecase.InviteToBudgetCmdName
+}
+
+func (h *InviteToBudget) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	args, ok := budgetArgs(text, "пригласить")
+	if !ok || len(args) != 0 {
+		return false
+	}
+
+	cmd.InviteToBudgetReqDTO = &usecase.InviteToBudgetReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *InviteToBudget) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.InviteToBudgetRespDTO == nil || len(cmd.InviteToBudgetRespDTO.InviteCode) == 0 {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "InviteToBudget.ExecuteCommand")
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Код приглашения: %s\nУчастник отправляет боту: бюджет вступить %s\n"+
+		"Прежний код больше не действует", cmd.InviteToBudgetRespDTO.InviteCode,
+		cmd.InviteToBudgetRespDTO.InviteCode), nil
+}
+
+type JoinBudget struct{}
+
+func NewJoinBudget() *JoinBudget {
+	return &JoinBudget{}
+}
+
+func (h *JoinBudget) Name() string {
+	return usecase.JoinBudgetCmdName
+}
+
+func (h *JoinBudget) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	args, ok := budgetArgs(text, "вступить")
+	if !ok || len(args) != 1 {
+		return false
+	}
+
+	cmd.JoinBudgetReqDTO = &usecase.JoinBudgetReqDTO{
+		UserID:     cmd.UserID,
+		InviteCode: strings.ToLower(args[0]),
+	}
+
+	return true
+}
+
+func (h *JoinBudget) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.JoinBudgetRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "JoinBudget.ExecuteCommand")
+	}
+
+	switch {
+	case cmd.JoinBudgetRespDTO.HasMembers:
+		return i18n.T(cmd.Locale, "В вашем бюджете есть участники, сначала исключите их"), nil
+	case !cmd.JoinBudgetRespDTO.Found:
+		return i18n.T(cmd.Locale, "Код приглашения не найден"), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Вы вступили в общий бюджет пользователя %d. Расходы, лимиты и отчеты теперь общие",
+		cmd.JoinBudgetRespDTO.OwnerID), nil
+}
+
+type LeaveBudget struct{}
+
+func NewLeaveBudget() *LeaveBudget {
+	return &LeaveBudget{}
+}
+
+func (h *LeaveBudget) Name() string {
+	return usecase.LeaveBudgetCmdName
+}
+
+func (h *LeaveBudget) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	args, ok := budgetArgs(text, "выйти")
+	if !ok || len(args) != 0 {
+		return false
+	}
+
+	cmd.LeaveBudgetReqDTO = &usecase.LeaveBudgetReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *LeaveBudget) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.LeaveBudgetRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "LeaveBudget.ExecuteCommand")
+	}
+
+	if !cmd.LeaveBudgetRespDTO.Found {
+		return i18n.T(cmd.Locale, "Вы не состоите в общем бюджете"), nil
+	}
+
+	return i18n.T(cmd.Locale, "Вы вышли из общего бюджета"), nil
+}
+
+type RemoveBudgetMember struct{}
+
+func NewRemoveBudgetMember() *RemoveBudgetMember {
+	return &RemoveBudgetMember{}
+}
+
+func (h *RemoveBudgetMember) Name() string {
+	return usecase.RemoveBudgetMemberCmdName
+}
+
+func (h *RemoveBudgetMember) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	args, ok := budgetArgs(text, "исключить")
+	if !ok || len(args) != 1 {
+		return false
+	}
+
+	memberID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || memberID <= 0 {
+		return false
+	}
+
+	cmd.RemoveBudgetMemberReqDTO = &usecase.RemoveBudgetMemberReqDTO{
+		UserID:   cmd.UserID,
+		MemberID: memberID,
+	}
+
+	return true
+}
+
+func (h *RemoveBudgetMember) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.RemoveBudgetMemberReqDTO == nil || cmd.RemoveBudgetMemberRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "RemoveBudgetMember.ExecuteCommand")
+	}
+
+	if !cmd.RemoveBudgetMemberRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Участник %d не найден", cmd.RemoveBudgetMemberReqDTO.MemberID), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Исключил участника %d", cmd.RemoveBudgetMemberReqDTO.MemberID), nil
+}
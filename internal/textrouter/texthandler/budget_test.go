@@ -0,0 +1,192 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestBudgetConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description string
+		handler     textrouter.Handler
+		textInput   string
+		matched     bool
+		cmdAfter    usecase.Command
+	}
+
+	info := usecase.MessageInfo{UserID: 101}
+
+	testCases := [...]testCase{
+		{
+			description: "get",
+			handler:     texthandler.NewGetBudget(),
+			textInput:   "бюджет",
+			matched:     true,
+			cmdAfter: usecase.Command{
+				MessageInfo:     info,
+				GetBudgetReqDTO: &usecase.GetBudgetReqDTO{UserID: 101},
+			},
+		},
+		{
+			description: "invite",
+			handler:     texthandler.NewInviteToBudget(),
+			textInput:   "бюджет пригласить",
+			matched:     true,
+			cmdAfter: usecase.Command{
+				MessageInfo:          info,
+				InviteToBudgetReqDTO: &usecase.InviteToBudgetReqDTO{UserID: 101},
+			},
+		},
+		{
+			description: "join",
+			handler:     texthandler.NewJoinBudget(),
+			textInput:   "бюджет вступить ABC123",
+			matched:     true,
+			cmdAfter: usecase.Command{
+				MessageInfo:      info,
+				JoinBudgetReqDTO: &usecase.JoinBudgetReqDTO{UserID: 101, InviteCode: "abc123"},
+			},
+		},
+		{
+			description: "join without code",
+			handler:     texthandler.NewJoinBudget(),
+			textInput:   "бюджет вступить",
+			matched:     false,
+			cmdAfter:    usecase.Command{MessageInfo: info},
+		},
+		{
+			description: "leave",
+			handler:     texthandler.NewLeaveBudget(),
+			textInput:   "бюджет выйти",
+			matched:     true,
+			cmdAfter: usecase.Command{
+				MessageInfo:       info,
+				LeaveBudgetReqDTO: &usecase.LeaveBudgetReqDTO{UserID: 101},
+			},
+		},
+		{
+			description: "remove member",
+			handler:     texthandler.NewRemoveBudgetMember(),
+			textInput:   "бюджет исключить 202",
+			matched:     true,
+			cmdAfter: usecase.Command{
+				MessageInfo:              info,
+				RemoveBudgetMemberReqDTO: &usecase.RemoveBudgetMemberReqDTO{UserID: 101, MemberID: 202},
+			},
+		},
+		{
+			description: "remove invalid member",
+			handler:     texthandler.NewRemoveBudgetMember(),
+			textInput:   "бюджет исключить всех",
+			matched:     false,
+			cmdAfter:    usecase.Command{MessageInfo: info},
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			cmd := usecase.Command{MessageInfo: info}
+
+			matched := scenario.handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.cmdAfter, cmd)
+		})
+	}
+}
+
+func TestGetBudgetConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetBudget
+
+	ctx := context.Background()
+
+	cmd := usecase.Command{
+		GetBudgetReqDTO: &usecase.GetBudgetReqDTO{UserID: 101},
+		GetBudgetRespDTO: &usecase.GetBudgetRespDTO{
+			OwnerID:    101,
+			IsOwner:    true,
+			InviteCode: "abc123",
+			Members:    []int64{202, 303},
+		},
+	}
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Вы владелец общего бюджета.\nУчастники: 202, 303\nКод приглашения: abc123\n"+
+		"бюджет исключить <id> - исключить участника", text)
+
+	assert.Equal(t, [][]textrouter.Button{{{Text: "Пригласить", Data: "бюджет пригласить"}}},
+		handler.ConvertCommandToKeyboard(ctx, &cmd))
+
+	cmd = usecase.Command{
+		GetBudgetReqDTO: &usecase.GetBudgetReqDTO{UserID: 202},
+		GetBudgetRespDTO: &usecase.GetBudgetRespDTO{
+			OwnerID: 101,
+			Members: []int64{202, 303},
+		},
+	}
+
+	text, err = handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Вы участник общего бюджета пользователя 101.\nУчастники: 202, 303\n"+
+		"бюджет выйти - вернуться к своим расходам", text)
+
+	assert.Equal(t, [][]textrouter.Button{{{Text: "Выйти", Data: "бюджет выйти"}}},
+		handler.ConvertCommandToKeyboard(ctx, &cmd))
+}
+
+func TestJoinBudgetConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.JoinBudget
+
+	ctx := context.Background()
+
+	testCases := map[string]usecase.JoinBudgetRespDTO{
+		"Вы вступили в общий бюджет пользователя 101. Расходы, лимиты и отчеты теперь общие": {
+			Found:   true,
+			OwnerID: 101,
+		},
+		"Код приглашения не найден":                            {},
+		"В вашем бюджете есть участники, сначала исключите их": {HasMembers: true},
+	}
+
+	for textExpected, resp := range testCases {
+		resp := resp
+
+		text, err := handler.ConvertCommandToText(ctx, &usecase.Command{JoinBudgetRespDTO: &resp})
+		assert.NoError(t, err)
+		assert.Equal(t, textExpected, text)
+	}
+}
+
+func TestForbiddenCommandToText(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	router := textrouter.New()
+	router.Register(texthandler.NewSetLimit())
+
+	cmd := usecase.Command{
+		Name:            usecase.SetLimitCmdName,
+		SetLimitReqDTO:  &usecase.SetLimitReqDTO{UserID: 202},
+		SetLimitRespDTO: &usecase.SetLimitRespDTO{},
+		Forbidden:       true,
+	}
+
+	assert.Equal(t, "Это может сделать только владелец общего бюджета", router.ConvertCommandToText(ctx, &cmd))
+}
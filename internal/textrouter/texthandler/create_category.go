@@ -0,0 +1,67 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type CreateCategory struct{}
+
+func NewCreateCategory() *CreateCategory {
+	return &CreateCategory{}
+}
+
+func (h *CreateCategory) Name() string {
+	return usecase.CreateCategoryCmdName
+}
+
+func (h *CreateCategory) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	pathIndex := 1
+	argsCount := 2
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "категория" {
+		return false
+	}
+
+	path := strings.Split(strings.ToLower(fields[pathIndex]), usecase.CategoryPathSeparator)
+	for _, name := range path {
+		if len(name) == 0 {
+			return false
+		}
+	}
+
+	cmd.CreateCategoryReqDTO = &usecase.CreateCategoryReqDTO{
+		UserID: cmd.UserID,
+		Path:   path,
+	}
+
+	return true
+}
+
+func (h *CreateCategory) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.CreateCategoryReqDTO == nil || cmd.CreateCategoryRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "CreateCategory.ExecuteCommand")
+	}
+
+	if len(cmd.CreateCategoryRespDTO.Path) == 0 {
+		return i18n.T(cmd.Locale, "Не удалось создать категорию"), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Категория %s создана", cmd.CreateCategoryRespDTO.Path), nil
+}
+
+// Имя категории без иерархии, например "продукты".
+func parseCategoryName(text string) (string, bool) {
+	name := strings.ToLower(text)
+	if len(name) == 0 || strings.Contains(name, usecase.CategoryPathSeparator) {
+		return "", false
+	}
+
+	return name, true
+}
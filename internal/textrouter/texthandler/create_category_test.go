@@ -0,0 +1,115 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestCreateCategoryConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.CreateCategory
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		cmdExpected usecase.Command
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "command only",
+			textInput:   "категория",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+		{
+			description: "top level",
+			textInput:   "категория Еда",
+			matched:     true,
+			cmdExpected: usecase.Command{
+				CreateCategoryReqDTO: &usecase.CreateCategoryReqDTO{
+					Path: []string{"еда"},
+				},
+			},
+		},
+		{
+			description: "with parent",
+			textInput:   "категория транспорт/такси",
+			matched:     true,
+			cmdExpected: usecase.Command{
+				CreateCategoryReqDTO: &usecase.CreateCategoryReqDTO{
+					Path: []string{"транспорт", "такси"},
+				},
+			},
+		},
+		{
+			description: "empty name",
+			textInput:   "категория транспорт/",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			var cmd usecase.Command
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.cmdExpected, cmd)
+		})
+	}
+}
+
+func TestCategoryCommandsConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var cmd usecase.Command
+
+	assert.True(t, texthandler.NewAddCategoryAlias().ConvertTextToCommand(ctx, "синоним Еда продукты", &cmd))
+	assert.Equal(t, &usecase.AddCategoryAliasReqDTO{Alias: "еда", Category: "продукты"}, cmd.AddCategoryAliasReqDTO)
+
+	assert.True(t, texthandler.NewRenameCategory().ConvertTextToCommand(ctx, "переименовать такси taxi", &cmd))
+	assert.Equal(t, &usecase.RenameCategoryReqDTO{Category: "такси", Name: "taxi"}, cmd.RenameCategoryReqDTO)
+
+	assert.True(t, texthandler.NewMergeCategories().ConvertTextToCommand(ctx, "объединить еда продукты", &cmd))
+	assert.Equal(t, &usecase.MergeCategoriesReqDTO{From: "еда", To: "продукты"}, cmd.MergeCategoriesReqDTO)
+
+	assert.False(t, texthandler.NewMergeCategories().ConvertTextToCommand(ctx, "объединить еда a/b", &cmd))
+}
+
+func TestGetCategoriesConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetCategories
+
+	textOutput, err := handler.ConvertCommandToText(context.Background(), &usecase.Command{
+		GetCategoriesReqDTO: &usecase.GetCategoriesReqDTO{},
+		GetCategoriesRespDTO: &usecase.GetCategoriesRespDTO{
+			Categories: []usecase.CategoryDTO{
+				{Path: "еда", Aliases: []string{"food", "продукты"}},
+				{Path: "транспорт"},
+				{Path: "транспорт/такси"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Категории:\n"+
+		"еда (food, продукты)\n"+
+		"транспорт\n"+
+		"транспорт/такси", textOutput)
+}
@@ -0,0 +1,70 @@
+package texthandler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type DeleteExpense struct{}
+
+func NewDeleteExpense() *DeleteExpense {
+	return &DeleteExpense{}
+}
+
+func (h *DeleteExpense) Name() string {
+	return usecase.DeleteExpenseCmdName
+}
+
+func (h *DeleteExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	idIndex := 1
+	argsCount := 2
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "удалить" {
+		return false
+	}
+
+	id, ok := parseExpenseID(fields[idIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.DeleteExpenseReqDTO = &usecase.DeleteExpenseReqDTO{
+		UserID: cmd.UserID,
+		ID:     id,
+	}
+
+	return true
+}
+
+func (h *DeleteExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.DeleteExpenseReqDTO == nil || cmd.DeleteExpenseRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "DeleteExpense.ExecuteCommand")
+	}
+
+	if !cmd.DeleteExpenseRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Расход #%d не найден", cmd.DeleteExpenseReqDTO.ID), nil
+	}
+
+	textOut := i18n.Sprintf(cmd.Locale, "Удалил #%d %s - %s %s", cmd.DeleteExpenseReqDTO.ID,
+		cmd.DeleteExpenseRespDTO.Category,
+		i18n.FormatAmount(cmd.Locale, cmd.DeleteExpenseRespDTO.Price), cmd.DeleteExpenseRespDTO.Currency)
+
+	return textOut, nil
+}
+
+// Идентификатор расхода можно указывать как в ответе бота: #123.
+func parseExpenseID(text string) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(text, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,137 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestDeleteExpenseConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.DeleteExpense
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		cmdExpected usecase.Command
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "command only",
+			textInput:   "удалить",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+		{
+			description: "id",
+			textInput:   "удалить 12",
+			matched:     true,
+			cmdExpected: usecase.Command{
+				DeleteExpenseReqDTO: &usecase.DeleteExpenseReqDTO{
+					ID: 12,
+				},
+			},
+		},
+		{
+			description: "id with hash",
+			textInput:   "удалить #12",
+			matched:     true,
+			cmdExpected: usecase.Command{
+				DeleteExpenseReqDTO: &usecase.DeleteExpenseReqDTO{
+					ID: 12,
+				},
+			},
+		},
+		{
+			description: "invalid id",
+			textInput:   "удалить -12",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			var cmd usecase.Command
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.EqualValues(t, scenario.matched, matched)
+			assert.EqualValues(t, scenario.cmdExpected, cmd)
+		})
+	}
+}
+
+func TestDeleteExpenseConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description  string
+		cmd          usecase.Command
+		textExpected string
+		errExpected  string
+	}
+
+	testCases := [...]testCase{
+		{
+			description:  "empty req",
+			cmd:          usecase.Command{},
+			textExpected: "",
+			errExpected:  "DeleteExpense.ExecuteCommand: internal error",
+		},
+		{
+			description: "not found",
+			cmd: usecase.Command{
+				DeleteExpenseReqDTO:  &usecase.DeleteExpenseReqDTO{ID: 12},
+				DeleteExpenseRespDTO: &usecase.DeleteExpenseRespDTO{},
+			},
+			textExpected: "Расход #12 не найден",
+			errExpected:  "",
+		},
+		{
+			description: "deleted",
+			cmd: usecase.Command{
+				DeleteExpenseReqDTO: &usecase.DeleteExpenseReqDTO{ID: 12},
+				DeleteExpenseRespDTO: &usecase.DeleteExpenseRespDTO{
+					Found:    true,
+					Category: "Netflix",
+					Price:    decimal.RequireFromString("4.499"),
+					Currency: "USD",
+				},
+			},
+			textExpected: "Удалил #12 Netflix - 4,50 USD",
+			errExpected:  "",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.DeleteExpense
+
+			ctx := context.Background()
+
+			textOutput, err := handler.ConvertCommandToText(ctx, &scenario.cmd)
+			assert.Equal(t, scenario.textExpected, textOutput)
+
+			if len(scenario.errExpected) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, scenario.errExpected)
+			}
+		})
+	}
+}
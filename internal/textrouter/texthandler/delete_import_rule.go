@@ -0,0 +1,50 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type DeleteImportRule struct{}
+
+func NewDeleteImportRule() *DeleteImportRule {
+	return &DeleteImportRule{}
+}
+
+func (h *DeleteImportRule) Name() string {
+	return usecase.DeleteImportRuleCmdName
+}
+
+func (h *DeleteImportRule) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	keywordIndex := 3
+	argsCountMin := 4
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || fields[0] != "импорт" || fields[1] != "удалить" || fields[2] != "правило" {
+		return false
+	}
+
+	cmd.DeleteImportRuleReqDTO = &usecase.DeleteImportRuleReqDTO{
+		UserID:  cmd.UserID,
+		Keyword: strings.ToLower(strings.Join(fields[keywordIndex:], " ")),
+	}
+
+	return true
+}
+
+func (h *DeleteImportRule) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.DeleteImportRuleReqDTO == nil || cmd.DeleteImportRuleRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "DeleteImportRule.ExecuteCommand")
+	}
+
+	if !cmd.DeleteImportRuleRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Правило \"%s\" не найдено", cmd.DeleteImportRuleReqDTO.Keyword), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Удалил правило \"%s\"", cmd.DeleteImportRuleReqDTO.Keyword), nil
+}
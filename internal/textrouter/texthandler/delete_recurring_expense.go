@@ -0,0 +1,55 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type DeleteRecurringExpense struct{}
+
+func NewDeleteRecurringExpense() *DeleteRecurringExpense {
+	return &DeleteRecurringExpense{}
+}
+
+func (h *DeleteRecurringExpense) Name() string {
+	return usecase.DeleteRecurringExpenseCmdName
+}
+
+func (h *DeleteRecurringExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	idIndex := 2
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "отменить" || fields[1] != "регулярный" {
+		return false
+	}
+
+	id, ok := parseExpenseID(fields[idIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.DeleteRecurringExpenseReqDTO = &usecase.DeleteRecurringExpenseReqDTO{
+		UserID: cmd.UserID,
+		ID:     id,
+	}
+
+	return true
+}
+
+func (h *DeleteRecurringExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.DeleteRecurringExpenseReqDTO == nil || cmd.DeleteRecurringExpenseRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "DeleteRecurringExpense.ExecuteCommand")
+	}
+
+	if !cmd.DeleteRecurringExpenseRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Регулярный расход #%d не найден", cmd.DeleteRecurringExpenseReqDTO.ID), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Отменил регулярный расход #%d", cmd.DeleteRecurringExpenseReqDTO.ID), nil
+}
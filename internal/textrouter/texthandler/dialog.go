@@ -0,0 +1,77 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// Dialog очередной вопрос диалога. Из текста не разбирается, вопросы задает DialogRouter.
+type Dialog struct{}
+
+func NewDialog() *Dialog {
+	return &Dialog{}
+}
+
+func (h *Dialog) Name() string {
+	return usecase.DialogCmdName
+}
+
+func (h *Dialog) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+func (h *Dialog) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.DialogDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Dialog.ExecuteCommand")
+	}
+
+	text := i18n.T(cmd.Locale, cmd.DialogDTO.Question)
+
+	if cmd.DialogDTO.Retry {
+		text = i18n.Sprintf(cmd.Locale, "Не понял ответ. %s\n/cancel - отменить", text)
+	}
+
+	// В группе бот видит только ответы на свои сообщения
+	if cmd.ReplyChatID() != cmd.UserID {
+		text += "\n" + i18n.T(cmd.Locale, "Ответьте на это сообщение")
+	}
+
+	return text, nil
+}
+
+type CancelDialog struct{}
+
+func NewCancelDialog() *CancelDialog {
+	return &CancelDialog{}
+}
+
+func (h *CancelDialog) Name() string {
+	return usecase.CancelDialogCmdName
+}
+
+func (h *CancelDialog) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != "/cancel" {
+		return false
+	}
+
+	cmd.CancelDialogDTO = &usecase.CancelDialogDTO{}
+
+	return true
+}
+
+func (h *CancelDialog) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.CancelDialogDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "CancelDialog.ExecuteCommand")
+	}
+
+	if !cmd.CancelDialogDTO.Found {
+		return i18n.T(cmd.Locale, "Нечего отменять"), nil
+	}
+
+	return i18n.T(cmd.Locale, "Отменил"), nil
+}
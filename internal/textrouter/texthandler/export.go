@@ -0,0 +1,90 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type Export struct{}
+
+func NewExport() *Export {
+	return &Export{}
+}
+
+func (h *Export) Name() string {
+	return usecase.ExportCmdName
+}
+
+// ConvertTextToCommand без периода выгружает все расходы, иначе период задается как в отчете.
+func (h *Export) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCountMax := 3
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > argsCountMax || fields[0] != "экспорт" {
+		return false
+	}
+
+	req := usecase.ExportReqDTO{
+		UserID:  cmd.UserID,
+		DateEnd: utils.TruncDate(cmd.Date).AddDate(0, 0, 1),
+	}
+
+	if len(fields) > 1 {
+		interval, ok := parseReportInterval(fields[1:], cmd.Date)
+		if !ok {
+			return false
+		}
+
+		req.DateStart, req.DateEnd, req.IntervalType = interval.DateStart, interval.DateEnd, interval.IntervalType
+	}
+
+	cmd.ExportReqDTO = &req
+
+	return true
+}
+
+func (h *Export) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.ExportReqDTO == nil || cmd.ExportRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Export.ExecuteCommand")
+	}
+
+	interval := exportIntervalToStr(cmd.Locale, cmd.ExportReqDTO, cmd.Date)
+
+	if cmd.ExportRespDTO.Count == 0 {
+		return i18n.Sprintf(cmd.Locale, "Нет расходов %s", interval), nil
+	}
+
+	if cmd.ExportRespDTO.TooLarge {
+		return i18n.Sprintf(cmd.Locale, "Выгрузка расходов %s слишком большая (%d шт.), укажите период короче",
+			interval, cmd.ExportRespDTO.Count), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Выгрузил расходы %s: %d шт.", interval, cmd.ExportRespDTO.Count), nil
+}
+
+func (h *Export) ConvertCommandToFiles(ctx context.Context, cmd *usecase.Command) []usecase.FileDTO {
+	if cmd.ExportRespDTO == nil {
+		return nil
+	}
+
+	return cmd.ExportRespDTO.Files
+}
+
+func exportIntervalToStr(locale string, req *usecase.ExportReqDTO, now time.Time) string {
+	if req.DateStart.IsZero() {
+		return i18n.T(locale, "за все время")
+	}
+
+	return reportIntervalToStr(locale, &usecase.GetReportReqDTO{
+		IntervalType: req.IntervalType,
+		DateStart:    req.DateStart,
+		DateEnd:      req.DateEnd,
+	}, now)
+}
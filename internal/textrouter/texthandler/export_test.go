@@ -0,0 +1,135 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestExportConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	msgDate := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.ExportReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "all time",
+			textInput:   "экспорт",
+			matched:     true,
+			reqExpected: &usecase.ExportReqDTO{
+				UserID:  101,
+				DateEnd: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			description: "month",
+			textInput:   "экспорт месяц",
+			matched:     true,
+			reqExpected: &usecase.ExportReqDTO{
+				UserID:       101,
+				DateStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				DateEnd:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+				IntervalType: utils.MonthInterval,
+			},
+		},
+		{
+			description: "date range",
+			textInput:   "экспорт 01.09 30.09",
+			matched:     true,
+			reqExpected: &usecase.ExportReqDTO{
+				UserID:    101,
+				DateStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+				DateEnd:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			description: "invalid interval",
+			textInput:   "экспорт век",
+			matched:     false,
+		},
+		{
+			description: "other command",
+			textInput:   "отчет месяц",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.Export
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.ExportReqDTO)
+		})
+	}
+}
+
+func TestExportConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	msgDate := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	var handler texthandler.Export
+
+	_, err := handler.ConvertCommandToText(ctx, &usecase.Command{})
+	assert.EqualError(t, err, "Export.ExecuteCommand: internal error")
+
+	files := []usecase.FileDTO{{Name: "expenses.csv", Data: []byte("data")}}
+
+	cmd := usecase.Command{
+		MessageInfo:  usecase.MessageInfo{Date: msgDate},
+		ExportReqDTO: &usecase.ExportReqDTO{DateEnd: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		ExportRespDTO: &usecase.ExportRespDTO{
+			Count: 3,
+			Files: files,
+		},
+	}
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Выгрузил расходы за все время: 3 шт.", text)
+	assert.Equal(t, files, handler.ConvertCommandToFiles(ctx, &cmd))
+
+	cmd.ExportReqDTO = &usecase.ExportReqDTO{
+		DateStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		DateEnd:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		IntervalType: utils.MonthInterval,
+	}
+	cmd.ExportRespDTO = &usecase.ExportRespDTO{}
+
+	text, err = handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Нет расходов за месяц", text)
+	assert.Empty(t, handler.ConvertCommandToFiles(ctx, &cmd))
+
+	cmd.ExportRespDTO = &usecase.ExportRespDTO{Count: 20000, TooLarge: true}
+
+	text, err = handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Выгрузка расходов за месяц слишком большая (20000 шт.), укажите период короче", text)
+	assert.Empty(t, handler.ConvertCommandToFiles(ctx, &cmd))
+}
@@ -0,0 +1,104 @@
+package texthandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type GetCategories struct{}
+
+func NewGetCategories() *GetCategories {
+	return &GetCategories{}
+}
+
+func (h *GetCategories) Name() string {
+	return usecase.GetCategoriesCmdName
+}
+
+// ConvertTextToCommand с номером расхода, "категории <id>", предлагает выбрать ему новую категорию.
+func (h *GetCategories) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCountMax := 2
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > argsCountMax || fields[0] != "категории" {
+		return false
+	}
+
+	var expenseID int64
+
+	if len(fields) == argsCountMax {
+		id, ok := parseExpenseID(fields[1])
+		if !ok {
+			return false
+		}
+
+		expenseID = id
+	}
+
+	cmd.GetCategoriesReqDTO = &usecase.GetCategoriesReqDTO{
+		UserID:    cmd.UserID,
+		ExpenseID: expenseID,
+	}
+
+	return true
+}
+
+func (h *GetCategories) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetCategoriesReqDTO == nil || cmd.GetCategoriesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetCategories.ExecuteCommand")
+	}
+
+	expenseID := cmd.GetCategoriesReqDTO.ExpenseID
+
+	if len(cmd.GetCategoriesRespDTO.Categories) == 0 {
+		if expenseID != 0 {
+			return i18n.Sprintf(cmd.Locale, "Категорий пока нет, напишите: изменить %d <категория>", expenseID), nil
+		}
+
+		return i18n.T(cmd.Locale, "Категорий пока нет"), nil
+	}
+
+	if expenseID != 0 {
+		return i18n.Sprintf(cmd.Locale, "Выберите категорию для расхода #%d:", expenseID), nil
+	}
+
+	lines := make([]string, 0, len(cmd.GetCategoriesRespDTO.Categories))
+
+	for _, category := range cmd.GetCategoriesRespDTO.Categories {
+		line := category.Path
+		if len(category.Aliases) != 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(category.Aliases, ", "))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return i18n.T(cmd.Locale, "Категории:\n") + strings.Join(lines, "\n"), nil
+}
+
+// ConvertCommandToKeyboard кнопки выбора категории для расхода. Подкатегория
+// указывается своим именем, путь показывается только на кнопке.
+func (h *GetCategories) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	if cmd.GetCategoriesReqDTO == nil || cmd.GetCategoriesRespDTO == nil || cmd.GetCategoriesReqDTO.ExpenseID == 0 {
+		return nil
+	}
+
+	keyboard := make([][]textrouter.Button, 0, len(cmd.GetCategoriesRespDTO.Categories))
+
+	for _, category := range cmd.GetCategoriesRespDTO.Categories {
+		name := category.Path[strings.LastIndex(category.Path, "/")+1:]
+
+		keyboard = append(keyboard, []textrouter.Button{{
+			Text: category.Path,
+			Data: fmt.Sprintf("изменить %d %s", cmd.GetCategoriesReqDTO.ExpenseID, name),
+		}})
+	}
+
+	return keyboard
+}
@@ -0,0 +1,98 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestGetCategoriesConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetCategories
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.GetCategoriesReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "list",
+			textInput:   "категории",
+			matched:     true,
+			reqExpected: &usecase.GetCategoriesReqDTO{UserID: 101},
+		},
+		{
+			description: "choose for expense",
+			textInput:   "категории #17",
+			matched:     true,
+			reqExpected: &usecase.GetCategoriesReqDTO{UserID: 101, ExpenseID: 17},
+		},
+		{
+			description: "invalid id",
+			textInput:   "категории такси",
+			matched:     false,
+		},
+		{
+			description: "too many args",
+			textInput:   "категории 17 18",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+				},
+			}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.GetCategoriesReqDTO)
+		})
+	}
+}
+
+func TestGetCategoriesConvertCommandToKeyboard(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetCategories
+
+	ctx := context.Background()
+
+	cmd := usecase.Command{
+		GetCategoriesReqDTO: &usecase.GetCategoriesReqDTO{UserID: 101, ExpenseID: 17},
+		GetCategoriesRespDTO: &usecase.GetCategoriesRespDTO{
+			Categories: []usecase.CategoryDTO{
+				{Path: "еда"},
+				{Path: "еда/кафе", Aliases: []string{"кофе"}},
+			},
+		},
+	}
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Выберите категорию для расхода #17:", text)
+
+	assert.Equal(t, [][]textrouter.Button{
+		{{Text: "еда", Data: "изменить 17 еда"}},
+		{{Text: "еда/кафе", Data: "изменить 17 кафе"}},
+	}, handler.ConvertCommandToKeyboard(ctx, &cmd))
+
+	// Обычный список категорий без кнопок
+	cmd.GetCategoriesReqDTO.ExpenseID = 0
+
+	assert.Empty(t, handler.ConvertCommandToKeyboard(ctx, &cmd))
+}
@@ -0,0 +1,69 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type GetCurrencies struct{}
+
+func NewGetCurrencies() *GetCurrencies {
+	return &GetCurrencies{}
+}
+
+func (h *GetCurrencies) Name() string {
+	return usecase.GetCurrenciesCmdName
+}
+
+func (h *GetCurrencies) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != "валюта" {
+		return false
+	}
+
+	cmd.GetCurrenciesReqDTO = &usecase.GetCurrenciesReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetCurrencies) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetCurrenciesReqDTO == nil || cmd.GetCurrenciesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetCurrencies.ExecuteCommand")
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Валюта по умолчанию %s. Выберите новую:", cmd.GetCurrenciesRespDTO.Current), nil
+}
+
+// ConvertCommandToKeyboard кнопки всех валют, кроме текущей, по четыре в ряд.
+func (h *GetCurrencies) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	if cmd.GetCurrenciesRespDTO == nil {
+		return nil
+	}
+
+	rowLen := 4
+
+	keyboard := make([][]textrouter.Button, 0)
+
+	for _, currency := range cmd.GetCurrenciesRespDTO.Currencies {
+		if currency == cmd.GetCurrenciesRespDTO.Current {
+			continue
+		}
+
+		if len(keyboard) == 0 || len(keyboard[len(keyboard)-1]) == rowLen {
+			keyboard = append(keyboard, make([]textrouter.Button, 0, rowLen))
+		}
+
+		keyboard[len(keyboard)-1] = append(keyboard[len(keyboard)-1], textrouter.Button{
+			Text: currency,
+			Data: "валюта " + currency,
+		})
+	}
+
+	return keyboard
+}
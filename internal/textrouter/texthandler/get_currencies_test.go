@@ -0,0 +1,43 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestGetCurrenciesConvertCommandToKeyboard(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GetCurrencies
+
+	ctx := context.Background()
+
+	cmd := usecase.Command{
+		GetCurrenciesReqDTO: &usecase.GetCurrenciesReqDTO{UserID: 101},
+		GetCurrenciesRespDTO: &usecase.GetCurrenciesRespDTO{
+			Current:    "RUB",
+			Currencies: []string{"RUB", "USD", "EUR", "CNY", "GBP", "JPY"},
+		},
+	}
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Валюта по умолчанию RUB. Выберите новую:", text)
+
+	assert.Equal(t, [][]textrouter.Button{
+		{
+			{Text: "USD", Data: "валюта USD"},
+			{Text: "EUR", Data: "валюта EUR"},
+			{Text: "CNY", Data: "валюта CNY"},
+			{Text: "GBP", Data: "валюта GBP"},
+		},
+		{
+			{Text: "JPY", Data: "валюта JPY"},
+		},
+	}, handler.ConvertCommandToKeyboard(ctx, &cmd))
+}
@@ -0,0 +1,64 @@
+package texthandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type GetImportSettings struct{}
+
+func NewGetImportSettings() *GetImportSettings {
+	return &GetImportSettings{}
+}
+
+func (h *GetImportSettings) Name() string {
+	return usecase.GetImportSettingsCmdName
+}
+
+func (h *GetImportSettings) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	fields := strings.Fields(text)
+	if len(fields) != 1 || fields[0] != "импорт" {
+		return false
+	}
+
+	cmd.GetImportSettingsReqDTO = &usecase.GetImportSettingsReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetImportSettings) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetImportSettingsReqDTO == nil || cmd.GetImportSettingsRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetImportSettings.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	resp := cmd.GetImportSettingsRespDTO
+
+	textOut := i18n.T(locale, "Пришлите CSV файл выписки банка, чтобы загрузить из нее расходы.\n")
+
+	if resp.Date == 0 {
+		textOut += i18n.T(locale, "Колонки: по заголовку")
+	} else {
+		textOut += i18n.Sprintf(locale, "Колонки: %s",
+			importColumnsToStr(locale, resp.Date, resp.Amount, resp.Description, resp.Currency))
+	}
+
+	if len(resp.Rules) == 0 {
+		return textOut + i18n.T(locale, "\nПравил нет, все операции попадут в категорию прочее"), nil
+	}
+
+	lines := make([]string, 0, len(resp.Rules))
+	for _, rule := range resp.Rules {
+		lines = append(lines, fmt.Sprintf("%s - %s", rule.Keyword, rule.Category))
+	}
+
+	return textOut + i18n.T(locale, "\nПравила:\n") + strings.Join(lines, "\n"), nil
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
@@ -39,15 +41,39 @@ func (h *GetLimits) ConvertCommandToText(ctx context.Context, cmd *usecase.Comma
 		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetLimits.ExecuteCommand")
 	}
 
-	precision := 2
+	locale := cmd.Locale
+	currency := cmd.GetLimitsRespDTO.Currency
 
-	textOut := fmt.Sprintf(`Текущие лимиты:
+	// Общие лимиты выводятся всегда, лимиты на категории - только заданные
+	general := map[int]decimal.Decimal{}
+	categoryLines := make([]string, 0, len(cmd.GetLimitsRespDTO.Limits))
+
+	for _, limit := range cmd.GetLimitsRespDTO.Limits {
+		if len(limit.Category) == 0 {
+			general[limit.IntervalType] = limit.Limit
+
+			continue
+		}
+
+		categoryLines = append(categoryLines, fmt.Sprintf("%s: %s - %s %s", limit.Category,
+			intervalToStr(locale, limit.IntervalType), i18n.FormatAmount(locale, limit.Limit), currency))
+	}
+
+	textOut := i18n.Sprintf(locale, `Текущие лимиты:
 Дневной - %s %s
 Недельный - %s %s
 Месячный - %0s %s`,
-		cmd.GetLimitsRespDTO.Limits[utils.DayInterval].StringFixed(int32(precision)), cmd.GetLimitsRespDTO.Currency,
-		cmd.GetLimitsRespDTO.Limits[utils.WeekInterval].StringFixed(int32(precision)), cmd.GetLimitsRespDTO.Currency,
-		cmd.GetLimitsRespDTO.Limits[utils.MonthInterval].StringFixed(int32(precision)), cmd.GetLimitsRespDTO.Currency)
+		i18n.FormatAmount(locale, general[utils.DayInterval]), currency,
+		i18n.FormatAmount(locale, general[utils.WeekInterval]), currency,
+		i18n.FormatAmount(locale, general[utils.MonthInterval]), currency)
+
+	if limit, ok := general[utils.YearInterval]; ok {
+		textOut += i18n.Sprintf(locale, "\nГодовой - %s %s", i18n.FormatAmount(locale, limit), currency)
+	}
+
+	if len(categoryLines) != 0 {
+		textOut += i18n.T(locale, "\nПо категориям:\n") + strings.Join(categoryLines, "\n")
+	}
 
 	return textOut, nil
 }
@@ -0,0 +1,55 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type GetRecurringExpenses struct{}
+
+func NewGetRecurringExpenses() *GetRecurringExpenses {
+	return &GetRecurringExpenses{}
+}
+
+func (h *GetRecurringExpenses) Name() string {
+	return usecase.GetRecurringExpensesCmdName
+}
+
+func (h *GetRecurringExpenses) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	fields := strings.Fields(text)
+	if len(fields) != 1 || fields[0] != "регулярные" {
+		return false
+	}
+
+	cmd.GetRecurringExpensesReqDTO = &usecase.GetRecurringExpensesReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetRecurringExpenses) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetRecurringExpensesReqDTO == nil || cmd.GetRecurringExpensesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetRecurringExpenses.ExecuteCommand")
+	}
+
+	if len(cmd.GetRecurringExpensesRespDTO.RecurringExpenses) == 0 {
+		return i18n.T(cmd.Locale, "Регулярных расходов нет"), nil
+	}
+
+	locale := cmd.Locale
+
+	lines := make([]string, 0, len(cmd.GetRecurringExpensesRespDTO.RecurringExpenses))
+	for _, recurring := range cmd.GetRecurringExpensesRespDTO.RecurringExpenses {
+		lines = append(lines, i18n.Sprintf(locale, "#%d %s - %s %s %s, следующий %s", recurring.ID,
+			recurring.Category, i18n.FormatAmount(locale, recurring.Price), recurring.Currency,
+			recurringIntervalToStr(locale, recurring.IntervalType), i18n.FormatDate(locale, recurring.NextDate)))
+	}
+
+	return i18n.T(locale, "Регулярные расходы:\n") + strings.Join(lines, "\n"), nil
+}
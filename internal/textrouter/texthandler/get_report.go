@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/chart"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
@@ -22,34 +26,59 @@ func (h *GetReport) Name() string {
 	return usecase.GetReportCmdName
 }
 
+// ConvertTextToCommand после интервала допускает слова "итого", которое сворачивает
+// подкатегории в родительские, "график", который добавляет к отчету картинки,
+// "участники", который добавляет суммы по участникам общего бюджета, и "текущий",
+// который пересчитывает расходы по текущему курсу вместо курса на дату расхода.
 func (h *GetReport) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
-	intervalIndex := 1
 	argsCountMin := 2
-	argsCountMax := 2
+	argsCountMax := 3
 
 	fields := strings.Fields(text)
-	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "отчет" {
+	if len(fields) < argsCountMin || fields[0] != "отчет" {
 		return false
 	}
 
-	var intervalType int
+	var rollup, charts, byMember, currentRate bool
 
-	if len(fields) > intervalIndex {
-		interval, ok := utils.IntervalFromStr(fields[intervalIndex])
-
-		if !ok {
-			return false
+modifiers:
+	for len(fields) > argsCountMin {
+		switch last := fields[len(fields)-1]; {
+		case last == "итого" && !rollup:
+			rollup = true
+		case last == "график" && !charts:
+			charts = true
+		case last == "участники" && !byMember:
+			byMember = true
+		case last == "текущий" && !currentRate:
+			currentRate = true
+		default:
+			break modifiers
 		}
 
-		intervalType = interval
+		fields = fields[:len(fields)-1]
 	}
 
-	cmd.GetReportReqDTO = &usecase.GetReportReqDTO{
-		UserID:       cmd.UserID,
-		Date:         cmd.Date,
-		IntervalType: intervalType,
+	if len(fields) > argsCountMax {
+		return false
 	}
 
+	req, ok := parseReportInterval(fields[1:], cmd.Date)
+	if !ok {
+		return false
+	}
+
+	req.UserID = cmd.UserID
+	req.Rollup = rollup
+	req.ByMember = byMember
+	req.CurrentRate = currentRate
+
+	if charts {
+		req.SeriesInterval = reportSeriesInterval(req)
+	}
+
+	cmd.GetReportReqDTO = &req
+
 	return true
 }
 
@@ -58,20 +87,238 @@ func (h *GetReport) ConvertCommandToText(ctx context.Context, cmd *usecase.Comma
 		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetReport.ExecuteCommand")
 	}
 
-	precision := 2
+	locale := cmd.Locale
 
-	intervalType, _ := utils.IntervalToStr(cmd.GetReportReqDTO.IntervalType)
+	textOut := i18n.Sprintf(locale, "Расходы по категориям %s:\n",
+		reportIntervalToStr(locale, cmd.GetReportReqDTO, cmd.Date))
 
-	textOut := fmt.Sprintf("Расходы по категориям за %s:\n", intervalType)
+	textOut += reportExpensesToStr(locale, cmd.GetReportRespDTO.Expenses)
 
-	lines := make([]string, 0, len(cmd.GetReportRespDTO.Expenses))
-	for _, expense := range cmd.GetReportRespDTO.Expenses {
-		lines = append(lines, fmt.Sprintf("%s - %s", expense.Category, expense.Sum.StringFixed(int32(precision))))
+	if cmd.GetReportReqDTO.ByMember && len(cmd.GetReportRespDTO.Members) != 0 {
+		textOut += i18n.T(locale, "\n\nПо участникам:\n") +
+			reportMembersToStr(locale, cmd.GetReportRespDTO.Members, cmd.UserID)
 	}
 
-	sort.Strings(lines)
+	if len(cmd.GetReportRespDTO.Incomes) != 0 {
+		textOut += i18n.T(locale, "\n\nДоходы по источникам:\n") +
+			reportIncomesToStr(locale, cmd.GetReportRespDTO.Incomes) + "\n\n" +
+			reportSavingsToStr(locale, cmd.GetReportRespDTO)
+	}
 
-	textOut += strings.Join(lines, "\n")
+	if cmd.GetReportReqDTO.CurrentRate {
+		textOut += i18n.T(locale, "\n\nСуммы пересчитаны по текущему курсу")
+	}
 
 	return textOut, nil
 }
+
+// ConvertCommandToPhotos рисует круговую диаграмму по категориям и столбцы по дням или месяцам.
+func (h *GetReport) ConvertCommandToPhotos(ctx context.Context, cmd *usecase.Command) ([]usecase.FileDTO, error) {
+	if cmd.GetReportReqDTO == nil || cmd.GetReportRespDTO == nil || cmd.GetReportReqDTO.SeriesInterval == 0 ||
+		len(cmd.GetReportRespDTO.Expenses) == 0 {
+		return nil, nil
+	}
+
+	locale := cmd.Locale
+
+	title := i18n.Sprintf(locale, "Расходы, %s", cmd.GetReportRespDTO.Currency)
+
+	pie, err := chart.Pie(title, reportPieValues(locale, cmd.GetReportRespDTO.Expenses))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetReport.ConvertCommandToPhotos")
+	}
+
+	byMonth := cmd.GetReportReqDTO.SeriesInterval == utils.MonthInterval
+
+	barsTitle := i18n.T(locale, "Расходы по дням")
+	if byMonth {
+		barsTitle = i18n.T(locale, "Расходы по месяцам")
+	}
+
+	barsValues := make([]chart.Value, 0, len(cmd.GetReportRespDTO.Series))
+	for _, point := range cmd.GetReportRespDTO.Series {
+		label := point.Date.Format("02")
+		if byMonth {
+			label = i18n.FormatMonth(locale, point.Date)
+		}
+
+		barsValues = append(barsValues, chart.Value{
+			Label: label,
+			Value: point.Sum.InexactFloat64(),
+		})
+	}
+
+	bars, err := chart.Bars(barsTitle, barsValues)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetReport.ConvertCommandToPhotos")
+	}
+
+	return []usecase.FileDTO{
+		{Name: "categories.png", Data: pie},
+		{Name: "series.png", Data: bars},
+	}, nil
+}
+
+// Мелкие категории на круговой диаграмме не различить, они собираются в один сектор.
+func reportPieValues(locale string, expenses []usecase.ExpenseReportDTO) []chart.Value {
+	maxSectors := 7
+
+	expenses = append([]usecase.ExpenseReportDTO(nil), expenses...)
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].Sum.GreaterThan(expenses[j].Sum)
+	})
+
+	values := make([]chart.Value, 0, maxSectors+1)
+	other := decimal.Zero
+
+	for i, expense := range expenses {
+		if i < maxSectors {
+			values = append(values, chart.Value{Label: expense.Category, Value: expense.Sum.InexactFloat64()})
+		} else {
+			other = other.Add(expense.Sum)
+		}
+	}
+
+	if other.IsPositive() {
+		values = append(values, chart.Value{Label: i18n.T(locale, "остальное"), Value: other.InexactFloat64()})
+	}
+
+	return values
+}
+
+// Для длинных интервалов столбцы по дням не поместятся на картинку.
+func reportSeriesInterval(req usecase.GetReportReqDTO) int {
+	maxDays := 62
+
+	if req.IntervalType == utils.YearInterval || req.DateEnd.After(req.DateStart.AddDate(0, 0, maxDays)) {
+		return utils.MonthInterval
+	}
+
+	return utils.DayInterval
+}
+
+func reportExpensesToStr(locale string, expenses []usecase.ExpenseReportDTO) string {
+	lines := make([]string, 0, len(expenses))
+	for _, expense := range expenses {
+		lines = append(lines, fmt.Sprintf("%s - %s", expense.Category, i18n.FormatAmount(locale, expense.Sum)))
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+func reportIncomesToStr(locale string, incomes []usecase.IncomeReportDTO) string {
+	lines := make([]string, 0, len(incomes))
+	for _, income := range incomes {
+		lines = append(lines, fmt.Sprintf("%s - %s", income.Source, i18n.FormatAmount(locale, income.Sum)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Норма сбережений - доля дохода, которая осталась после расходов, в процентах.
+// Без доходов она не определена, такой отчет выводится без этого блока.
+func reportSavingsToStr(locale string, resp *usecase.GetReportRespDTO) string {
+	income, expenses := decimal.Zero, decimal.Zero
+
+	for _, item := range resp.Incomes {
+		income = income.Add(item.Sum)
+	}
+
+	for _, item := range resp.Expenses {
+		expenses = expenses.Add(item.Sum)
+	}
+
+	if !income.IsPositive() {
+		return ""
+	}
+
+	savings := income.Sub(expenses)
+	rate := savings.Mul(decimal.NewFromInt(100)).Div(income).Round(0) //nolint:gomnd
+
+	return i18n.Sprintf(locale, "Доходы: %s\nРасходы: %s\nСбережения: %s\nНорма сбережений: %s%%",
+		i18n.FormatAmount(locale, income), i18n.FormatAmount(locale, expenses),
+		i18n.FormatAmount(locale, savings), rate.String())
+}
+
+// Участники перечисляются в порядке ответа сервиса, по убыванию суммы.
+func reportMembersToStr(locale string, members []usecase.MemberReportDTO, userID int64) string {
+	lines := make([]string, 0, len(members))
+
+	for _, member := range members {
+		name := i18n.Sprintf(locale, "участник %d", member.MemberID)
+		if member.MemberID == userID {
+			name = i18n.T(locale, "вы")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s - %s", name, i18n.FormatAmount(locale, member.Sum)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Интервал отчета: "день", "прошлый месяц" или две даты включительно, например "01.09.2026 30.09.2026".
+func parseReportInterval(args []string, now time.Time) (usecase.GetReportReqDTO, bool) {
+	var req usecase.GetReportReqDTO
+
+	switch {
+	case len(args) == 1:
+		intervalType, ok := utils.IntervalFromStr(args[0])
+		if !ok {
+			return req, false
+		}
+
+		req.IntervalType = intervalType
+		req.DateStart, req.DateEnd = utils.GetInterval(now, intervalType)
+	case isPrevIntervalWord(args[0]):
+		intervalType, ok := utils.IntervalFromStr(args[1])
+		if !ok {
+			return req, false
+		}
+
+		req.IntervalType = intervalType
+		req.DateStart, req.DateEnd = utils.GetPrevInterval(now, intervalType)
+	default:
+		dateStart, ok := utils.ParseDate(args[0], now)
+		if !ok {
+			return req, false
+		}
+
+		dateEnd, ok := utils.ParseDate(args[1], now)
+		if !ok || dateEnd.Before(dateStart) {
+			return req, false
+		}
+
+		req.DateStart = utils.TruncDate(dateStart)
+		req.DateEnd = utils.TruncDate(dateEnd).AddDate(0, 0, 1)
+	}
+
+	return req, true
+}
+
+func isPrevIntervalWord(word string) bool {
+	switch word {
+	case "прошлый", "прошлая", "прошлую", "прошлое":
+		return true
+	default:
+		return false
+	}
+}
+
+// Для текущего календарного интервала выводится его название, иначе границы дат.
+func reportIntervalToStr(locale string, req *usecase.GetReportReqDTO, now time.Time) string {
+	if _, ok := utils.IntervalToStr(req.IntervalType); ok && !now.Before(req.DateStart) && now.Before(req.DateEnd) {
+		return i18n.Sprintf(locale, "за %s", intervalToStr(locale, req.IntervalType))
+	}
+
+	return i18n.Sprintf(locale, "с %s по %s", i18n.FormatDate(locale, req.DateStart),
+		i18n.FormatDate(locale, req.DateEnd.AddDate(0, 0, -1)))
+}
+
+// intervalToStr название интервала на языке пользователя, например "день" или "day".
+func intervalToStr(locale string, intervalType int) string {
+	interval, _ := utils.IntervalToStr(intervalType)
+
+	return i18n.T(locale, interval)
+}
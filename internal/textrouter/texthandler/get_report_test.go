@@ -1,7 +1,9 @@
 package texthandler_test
 
 import (
+	"bytes"
 	"context"
+	"image/png"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ func TestGetReportConvertTextToCommand(t *testing.T) {
 	t.Parallel()
 
 	date := time.Now()
+	msgDate := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
 
 	var handler texthandler.GetReport
 
@@ -57,11 +60,7 @@ func TestGetReportConvertTextToCommand(t *testing.T) {
 					UserID: 101,
 					Date:   date,
 				},
-				GetReportReqDTO: &usecase.GetReportReqDTO{
-					UserID:       101,
-					Date:         date,
-					IntervalType: utils.DayInterval,
-				},
+				GetReportReqDTO: reportReq(101, date, utils.DayInterval),
 			},
 		},
 		{
@@ -79,11 +78,7 @@ func TestGetReportConvertTextToCommand(t *testing.T) {
 					UserID: 101,
 					Date:   date,
 				},
-				GetReportReqDTO: &usecase.GetReportReqDTO{
-					UserID:       101,
-					Date:         date,
-					IntervalType: utils.WeekInterval,
-				},
+				GetReportReqDTO: reportReq(101, date, utils.WeekInterval),
 			},
 		},
 		{
@@ -101,13 +96,244 @@ func TestGetReportConvertTextToCommand(t *testing.T) {
 					UserID: 101,
 					Date:   date,
 				},
+				GetReportReqDTO: reportReq(101, date, utils.MonthInterval),
+			},
+		},
+		{
+			description: "year",
+			textInput:   "отчет год",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.YearInterval,
+				},
+			},
+		},
+		{
+			description: "last month",
+			textInput:   "отчет прошлый месяц",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
 				GetReportReqDTO: &usecase.GetReportReqDTO{
 					UserID:       101,
-					Date:         date,
+					DateStart:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
 					IntervalType: utils.MonthInterval,
 				},
 			},
 		},
+		{
+			description: "last week",
+			textInput:   "отчет прошлая неделя",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.WeekInterval,
+				},
+			},
+		},
+		{
+			description: "date range",
+			textInput:   "отчет 01.09.2026 30.09.2026",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: 0,
+				},
+			},
+		},
+		{
+			description: "rollup",
+			textInput:   "отчет прошлый месяц итого",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.MonthInterval,
+					Rollup:       true,
+				},
+			},
+		},
+		{
+			description: "month chart",
+			textInput:   "отчет месяц график",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:         101,
+					DateStart:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:        time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType:   utils.MonthInterval,
+					SeriesInterval: utils.DayInterval,
+				},
+			},
+		},
+		{
+			description: "long date range chart with rollup",
+			textInput:   "отчет 01.01.2026 30.06.2026 график итого",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:         101,
+					DateStart:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:        time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType:   0,
+					Rollup:         true,
+					SeriesInterval: utils.MonthInterval,
+				},
+			},
+		},
+		{
+			description: "by member",
+			textInput:   "отчет месяц участники итого",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.MonthInterval,
+					Rollup:       true,
+					ByMember:     true,
+				},
+			},
+		},
+		{
+			description: "current rate",
+			textInput:   "отчет год текущий",
+			matched:     true,
+			cmdBefore: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+			},
+			cmdAfter: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+					Date:   msgDate,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       101,
+					DateStart:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.YearInterval,
+					CurrentRate:  true,
+				},
+			},
+		},
+		{
+			description: "chart without interval",
+			textInput:   "отчет график",
+			matched:     false,
+			cmdBefore:   usecase.Command{},
+			cmdAfter:    usecase.Command{},
+		},
+		{
+			description: "repeated chart",
+			textInput:   "отчет месяц график график",
+			matched:     false,
+			cmdBefore:   usecase.Command{},
+			cmdAfter:    usecase.Command{},
+		},
+		{
+			description: "reversed date range",
+			textInput:   "отчет 30.09.2026 01.09.2026",
+			matched:     false,
+			cmdBefore:   usecase.Command{},
+			cmdAfter:    usecase.Command{},
+		},
 		{
 			description: "invalid request",
 			textInput:   "отчет нед",
@@ -184,11 +410,11 @@ func TestGetReportConvertCommandToText(t *testing.T) {
 		{
 			description: "empty resp",
 			cmd: usecase.Command{
-				GetReportReqDTO: &usecase.GetReportReqDTO{
-					UserID:       userID,
-					Date:         date,
-					IntervalType: utils.MonthInterval,
+				MessageInfo: usecase.MessageInfo{
+					UserID: userID,
+					Date:   date,
 				},
+				GetReportReqDTO: reportReq(userID, date, utils.MonthInterval),
 			},
 			textExpected: "",
 			errExpected:  "GetReport.ExecuteCommand: internal error",
@@ -196,9 +422,41 @@ func TestGetReportConvertCommandToText(t *testing.T) {
 		{
 			description: "categories",
 			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: userID,
+					Date:   date,
+				},
+				GetReportReqDTO: reportReq(userID, date, utils.MonthInterval),
+				GetReportRespDTO: &usecase.GetReportRespDTO{
+					Currency: "RUB",
+					Expenses: []usecase.ExpenseReportDTO{
+						{
+							Category: "Catergory1",
+							Sum:      decimal.New(12, 0),
+						},
+						{
+							Category: "Catergory2",
+							Sum:      decimal.New(34567, -3),
+						},
+					},
+				},
+			},
+			textExpected: "Расходы по категориям за месяц:\n" +
+				"Catergory1 - 12,00\n" +
+				"Catergory2 - 34,57",
+			errExpected: "",
+		},
+		{
+			description: "previous month",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: userID,
+					Date:   date,
+				},
 				GetReportReqDTO: &usecase.GetReportReqDTO{
 					UserID:       userID,
-					Date:         date,
+					DateStart:    time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
 					IntervalType: utils.MonthInterval,
 				},
 				GetReportRespDTO: &usecase.GetReportRespDTO{
@@ -208,16 +466,83 @@ func TestGetReportConvertCommandToText(t *testing.T) {
 							Category: "Catergory1",
 							Sum:      decimal.New(12, 0),
 						},
+					},
+				},
+			},
+			textExpected: "Расходы по категориям с 01.08.2022 по 31.08.2022:\n" +
+				"Catergory1 - 12,00",
+			errExpected: "",
+		},
+		{
+			description: "by member",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: userID,
+					Date:   date,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       userID,
+					DateStart:    time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.MonthInterval,
+					ByMember:     true,
+				},
+				GetReportRespDTO: &usecase.GetReportRespDTO{
+					Currency: "RUB",
+					Expenses: []usecase.ExpenseReportDTO{
 						{
-							Category: "Catergory2",
-							Sum:      decimal.New(34567, -3),
+							Category: "Catergory1",
+							Sum:      decimal.New(30, 0),
 						},
 					},
+					Members: []usecase.MemberReportDTO{
+						{MemberID: 202, Sum: decimal.New(20, 0)},
+						{MemberID: userID, Sum: decimal.New(10, 0)},
+					},
 				},
 			},
 			textExpected: "Расходы по категориям за месяц:\n" +
-				"Catergory1 - 12.00\n" +
-				"Catergory2 - 34.57",
+				"Catergory1 - 30,00\n\n" +
+				"По участникам:\n" +
+				"участник 202 - 20,00\n" +
+				"вы - 10,00",
+			errExpected: "",
+		},
+		{
+			description: "with incomes",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: userID,
+					Date:   date,
+				},
+				GetReportReqDTO: &usecase.GetReportReqDTO{
+					UserID:       userID,
+					DateStart:    time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.MonthInterval,
+				},
+				GetReportRespDTO: &usecase.GetReportRespDTO{
+					Currency: "RUB",
+					Expenses: []usecase.ExpenseReportDTO{
+						{Category: "еда", Sum: decimal.New(30000, 0)},
+						{Category: "жилье", Sum: decimal.New(45000, 0)},
+					},
+					Incomes: []usecase.IncomeReportDTO{
+						{Source: "зарплата", Sum: decimal.New(90000, 0)},
+						{Source: "фриланс", Sum: decimal.New(10000, 0)},
+					},
+				},
+			},
+			textExpected: "Расходы по категориям за месяц:\n" +
+				"еда - 30 000,00\n" +
+				"жилье - 45 000,00\n\n" +
+				"Доходы по источникам:\n" +
+				"зарплата - 90 000,00\n" +
+				"фриланс - 10 000,00\n\n" +
+				"Доходы: 100 000,00\n" +
+				"Расходы: 75 000,00\n" +
+				"Сбережения: 25 000,00\n" +
+				"Норма сбережений: 25%",
 			errExpected: "",
 		},
 	}
@@ -241,3 +566,78 @@ func TestGetReportConvertCommandToText(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReportConvertCommandToPhotos(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	var handler texthandler.GetReport
+
+	cmd := usecase.Command{
+		MessageInfo: usecase.MessageInfo{
+			UserID: 101,
+			Date:   date,
+		},
+		GetReportReqDTO: &usecase.GetReportReqDTO{
+			UserID:         101,
+			DateStart:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			DateEnd:        time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC),
+			IntervalType:   0,
+			SeriesInterval: utils.DayInterval,
+		},
+		GetReportRespDTO: &usecase.GetReportRespDTO{
+			Currency: "RUB",
+			Expenses: []usecase.ExpenseReportDTO{
+				{
+					Category: "такси",
+					Sum:      decimal.New(300, 0),
+				},
+			},
+			Series: []usecase.SeriesPointDTO{
+				{
+					Date: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					Sum:  decimal.New(300, 0),
+				},
+				{
+					Date: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+					Sum:  decimal.Zero,
+				},
+			},
+		},
+	}
+
+	photos, err := handler.ConvertCommandToPhotos(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Len(t, photos, 2)
+
+	for _, photo := range photos {
+		_, err = png.Decode(bytes.NewReader(photo.Data))
+		assert.NoError(t, err)
+	}
+
+	// Без запроса графика и без расходов картинок нет
+	cmd.GetReportReqDTO.SeriesInterval = 0
+
+	photos, err = handler.ConvertCommandToPhotos(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Empty(t, photos)
+
+	cmd.GetReportReqDTO.SeriesInterval = utils.DayInterval
+	cmd.GetReportRespDTO.Expenses = nil
+
+	photos, err = handler.ConvertCommandToPhotos(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Empty(t, photos)
+}
+
+func reportReq(userID int64, date time.Time, intervalType int) *usecase.GetReportReqDTO {
+	dateStart, dateEnd := utils.GetInterval(date, intervalType)
+
+	return &usecase.GetReportReqDTO{
+		UserID:       userID,
+		DateStart:    dateStart,
+		DateEnd:      dateEnd,
+		IntervalType: intervalType,
+	}
+}
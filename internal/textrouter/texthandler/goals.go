@@ -0,0 +1,300 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type SetGoal struct{}
+
+func NewSetGoal() *SetGoal {
+	return &SetGoal{}
+}
+
+func (h *SetGoal) Name() string {
+	return usecase.SetGoalCmdName
+}
+
+// ConvertTextToCommand цель <название> <сумма> [валюта] до <дата>, например: цель отпуск 150000 до 01.06.2027.
+func (h *SetGoal) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCountMin := 5
+	argsCountMax := 6
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "цель" || fields[1] == "удалить" {
+		return false
+	}
+
+	target, err := decimal.NewFromString(fields[2])
+	if err != nil || !target.IsPositive() {
+		return false
+	}
+
+	var currency string
+
+	if len(fields) == argsCountMax {
+		code, ok := parseCurrencyCode(fields[3])
+		if !ok {
+			return false
+		}
+
+		currency = code
+	}
+
+	if fields[len(fields)-2] != "до" {
+		return false
+	}
+
+	deadline, ok := utils.ParseDate(fields[len(fields)-1], cmd.Date)
+	if !ok || !deadline.After(cmd.Date) {
+		return false
+	}
+
+	cmd.SetGoalReqDTO = &usecase.SetGoalReqDTO{
+		UserID:   cmd.UserID,
+		Name:     strings.ToLower(fields[1]),
+		Target:   target,
+		Currency: currency,
+		Deadline: deadline,
+		Date:     cmd.Date,
+	}
+
+	return true
+}
+
+func (h *SetGoal) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.SetGoalRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetGoal.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	resp := cmd.SetGoalRespDTO
+
+	if resp.CurrencyMismatch {
+		return i18n.Sprintf(locale, "Цель %s уже есть в валюте %s, укажите сумму в ней", resp.Goal.Name,
+			resp.Goal.Currency), nil
+	}
+
+	textOut := i18n.Sprintf(locale, "Изменил цель %s\n", resp.Goal.Name)
+	if resp.Created {
+		textOut = i18n.Sprintf(locale, "Добавил цель %s\n", resp.Goal.Name)
+	}
+
+	return textOut + goalToStr(locale, resp.Goal, cmd.Date), nil
+}
+
+type ContributeGoal struct{}
+
+func NewContributeGoal() *ContributeGoal {
+	return &ContributeGoal{}
+}
+
+func (h *ContributeGoal) Name() string {
+	return usecase.ContributeGoalCmdName
+}
+
+// ConvertTextToCommand цель <название> +<сумма> или -<сумма>, сумма в валюте цели.
+func (h *ContributeGoal) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "цель" {
+		return false
+	}
+
+	// Знак обязателен, чтобы взнос не путался с суммой цели
+	if !strings.HasPrefix(fields[2], "+") && !strings.HasPrefix(fields[2], "-") {
+		return false
+	}
+
+	amount, err := decimal.NewFromString(fields[2])
+	if err != nil || amount.IsZero() {
+		return false
+	}
+
+	cmd.ContributeGoalReqDTO = &usecase.ContributeGoalReqDTO{
+		UserID: cmd.UserID,
+		Name:   strings.ToLower(fields[1]),
+		Amount: amount,
+		Date:   cmd.Date,
+	}
+
+	return true
+}
+
+func (h *ContributeGoal) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.ContributeGoalReqDTO == nil || cmd.ContributeGoalRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "ContributeGoal.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	req, resp := cmd.ContributeGoalReqDTO, cmd.ContributeGoalRespDTO
+
+	if resp.NotFound {
+		return i18n.Sprintf(locale, "Цель %s не найдена", req.Name), nil
+	}
+
+	var textOut string
+	if req.Amount.IsPositive() {
+		textOut = i18n.Sprintf(locale, "Отложил %s %s на цель %s\n", i18n.FormatAmount(locale, req.Amount),
+			resp.Goal.Currency, resp.Goal.Name)
+	} else {
+		textOut = i18n.Sprintf(locale, "Снял %s %s с цели %s\n", i18n.FormatAmount(locale, req.Amount.Neg()),
+			resp.Goal.Currency, resp.Goal.Name)
+	}
+
+	return textOut + goalToStr(locale, resp.Goal, cmd.Date), nil
+}
+
+type GetGoals struct{}
+
+func NewGetGoals() *GetGoals {
+	return &GetGoals{}
+}
+
+func (h *GetGoals) Name() string {
+	return usecase.GetGoalsCmdName
+}
+
+func (h *GetGoals) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != "цели" {
+		return false
+	}
+
+	cmd.GetGoalsReqDTO = &usecase.GetGoalsReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *GetGoals) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetGoalsRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GetGoals.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+
+	if len(cmd.GetGoalsRespDTO.Goals) == 0 {
+		return i18n.T(locale, "Целей нет. Добавьте цель: цель <название> <сумма> [валюта] до <дата>"), nil
+	}
+
+	return i18n.T(locale, "Цели накоплений:\n") + goalsToStr(locale, cmd.GetGoalsRespDTO.Goals, cmd.Date), nil
+}
+
+type DeleteGoal struct{}
+
+func NewDeleteGoal() *DeleteGoal {
+	return &DeleteGoal{}
+}
+
+func (h *DeleteGoal) Name() string {
+	return usecase.DeleteGoalCmdName
+}
+
+// ConvertTextToCommand цель удалить <название>.
+func (h *DeleteGoal) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "цель" || fields[1] != "удалить" {
+		return false
+	}
+
+	cmd.DeleteGoalReqDTO = &usecase.DeleteGoalReqDTO{
+		UserID: cmd.UserID,
+		Name:   strings.ToLower(fields[2]),
+	}
+
+	return true
+}
+
+func (h *DeleteGoal) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.DeleteGoalReqDTO == nil || cmd.DeleteGoalRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "DeleteGoal.ExecuteCommand")
+	}
+
+	if !cmd.DeleteGoalRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Цель %s не найдена", cmd.DeleteGoalReqDTO.Name), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Удалил цель %s", cmd.DeleteGoalReqDTO.Name), nil
+}
+
+// GoalNudge ежемесячное напоминание о целях, которое присылает goal worker. Из текста не разбирается.
+type GoalNudge struct{}
+
+func NewGoalNudge() *GoalNudge {
+	return &GoalNudge{}
+}
+
+func (h *GoalNudge) Name() string {
+	return usecase.GoalNudgeCmdName
+}
+
+func (h *GoalNudge) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+func (h *GoalNudge) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetGoalsRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "GoalNudge.ExecuteCommand")
+	}
+
+	return i18n.T(cmd.Locale, "Напоминание о целях:\n") + goalsToStr(cmd.Locale, cmd.GetGoalsRespDTO.Goals, cmd.Date),
+		nil
+}
+
+func goalsToStr(locale string, goals []usecase.GoalDTO, now time.Time) string {
+	items := make([]string, 0, len(goals))
+	for _, goal := range goals {
+		items = append(items, goalToStr(locale, goal, now))
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// goalToStr прогресс цели и прогноз в текущем темпе.
+func goalToStr(locale string, goal usecase.GoalDTO, now time.Time) string {
+	percent := goal.Saved.Mul(decimal.NewFromInt(100)).Div(goal.Target).Round(0) //nolint:gomnd
+
+	textOut := i18n.Sprintf(locale, "%s: %s из %s %s (%s%%), срок %s", goal.Name,
+		i18n.FormatAmount(locale, goal.Saved), i18n.FormatAmount(locale, goal.Target), goal.Currency,
+		percent.String(), i18n.FormatDate(locale, goal.Deadline))
+
+	if goal.Reached {
+		return textOut + i18n.T(locale, "\nЦель достигнута")
+	}
+
+	if !goal.Deadline.After(now) {
+		return textOut + i18n.Sprintf(locale, "\nСрок прошел, осталось накопить %s %s",
+			i18n.FormatAmount(locale, goal.Target.Sub(goal.Saved)), goal.Currency)
+	}
+
+	textOut += i18n.Sprintf(locale, "\nЧтобы успеть, откладывайте %s %s в месяц",
+		i18n.FormatAmount(locale, goal.MonthlyNeeded), goal.Currency)
+
+	switch {
+	case goal.ProjectedDate.IsZero() && !goal.MonthlyPace.IsPositive():
+		textOut += i18n.T(locale, "\nВзносов пока нет")
+	case goal.ProjectedDate.IsZero():
+		textOut += i18n.Sprintf(locale, "\nВ текущем темпе (%s %s в месяц) цель не будет достигнута",
+			i18n.FormatAmount(locale, goal.MonthlyPace), goal.Currency)
+	case goal.OnTrack:
+		textOut += i18n.Sprintf(locale, "\nВ текущем темпе (%s %s в месяц) успеете к %s",
+			i18n.FormatAmount(locale, goal.MonthlyPace), goal.Currency, i18n.FormatDate(locale, goal.ProjectedDate))
+	default:
+		textOut += i18n.Sprintf(locale, "\nВ текущем темпе (%s %s в месяц) накопите только к %s",
+			i18n.FormatAmount(locale, goal.MonthlyPace), goal.Currency, i18n.FormatDate(locale, goal.ProjectedDate))
+	}
+
+	return textOut
+}
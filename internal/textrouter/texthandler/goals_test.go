@@ -0,0 +1,234 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestSetGoalConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	var handler texthandler.SetGoal
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.SetGoalReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "goal",
+			textInput:   "цель Отпуск 150000 до 01.06.2027",
+			matched:     true,
+			reqExpected: &usecase.SetGoalReqDTO{
+				UserID:   101,
+				Name:     "отпуск",
+				Target:   decimal.New(150000, 0),
+				Deadline: time.Date(2027, 6, 1, 12, 0, 0, 0, time.UTC),
+				Date:     date,
+			},
+		},
+		{
+			description: "with currency",
+			textInput:   "цель машина 20000 usd до 2028-01-01",
+			matched:     true,
+			reqExpected: &usecase.SetGoalReqDTO{
+				UserID:   101,
+				Name:     "машина",
+				Target:   decimal.New(20000, 0),
+				Currency: "USD",
+				Deadline: time.Date(2028, 1, 1, 12, 0, 0, 0, time.UTC),
+				Date:     date,
+			},
+		},
+		{
+			description: "past deadline",
+			textInput:   "цель отпуск 150000 до 01.06.2026",
+			matched:     false,
+		},
+		{
+			description: "no deadline",
+			textInput:   "цель отпуск 150000 rub",
+			matched:     false,
+		},
+		{
+			description: "contribution",
+			textInput:   "цель отпуск +5000",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: date}}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.SetGoalReqDTO)
+		})
+	}
+}
+
+func TestContributeGoalConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	var handler texthandler.ContributeGoal
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.ContributeGoalReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "contribution",
+			textInput:   "цель Отпуск +5000",
+			matched:     true,
+			reqExpected: &usecase.ContributeGoalReqDTO{
+				UserID: 101,
+				Name:   "отпуск",
+				Amount: decimal.New(5000, 0),
+				Date:   date,
+			},
+		},
+		{
+			description: "withdrawal",
+			textInput:   "цель отпуск -1000.5",
+			matched:     true,
+			reqExpected: &usecase.ContributeGoalReqDTO{
+				UserID: 101,
+				Name:   "отпуск",
+				Amount: decimal.New(-10005, -1),
+				Date:   date,
+			},
+		},
+		{
+			description: "no sign",
+			textInput:   "цель отпуск 5000",
+			matched:     false,
+		},
+		{
+			description: "zero",
+			textInput:   "цель отпуск +0",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: date}}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.ContributeGoalReqDTO)
+		})
+	}
+}
+
+func TestGetGoalsConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2027, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var handler texthandler.GetGoals
+
+	type testCase struct {
+		description  string
+		goal         usecase.GoalDTO
+		textExpected string
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "no contributions",
+			goal: usecase.GoalDTO{Name: "отпуск", Target: decimal.New(150000, 0), Saved: decimal.Zero, Currency: "RUB",
+				Deadline: deadline, MonthlyNeeded: decimal.New(18750, 0)},
+			textExpected: "отпуск: 0,00 из 150 000,00 RUB (0%), срок 01.06.2027\n" +
+				"Чтобы успеть, откладывайте 18 750,00 RUB в месяц\nВзносов пока нет",
+		},
+		{
+			description: "on track",
+			goal: usecase.GoalDTO{Name: "отпуск", Target: decimal.New(150000, 0), Saved: decimal.New(60000, 0),
+				Currency: "RUB", Deadline: deadline, OnTrack: true, MonthlyNeeded: decimal.New(11250, 0),
+				MonthlyPace: decimal.New(20000, 0), ProjectedDate: time.Date(2027, 3, 18, 12, 0, 0, 0, time.UTC)},
+			textExpected: "отпуск: 60 000,00 из 150 000,00 RUB (40%), срок 01.06.2027\n" +
+				"Чтобы успеть, откладывайте 11 250,00 RUB в месяц\n" +
+				"В текущем темпе (20 000,00 RUB в месяц) успеете к 18.03.2027",
+		},
+		{
+			description: "behind",
+			goal: usecase.GoalDTO{Name: "отпуск", Target: decimal.New(150000, 0), Saved: decimal.New(30000, 0),
+				Currency: "RUB", Deadline: deadline, MonthlyNeeded: decimal.New(15000, 0),
+				MonthlyPace: decimal.New(10000, 0), ProjectedDate: time.Date(2027, 10, 18, 12, 0, 0, 0, time.UTC)},
+			textExpected: "отпуск: 30 000,00 из 150 000,00 RUB (20%), срок 01.06.2027\n" +
+				"Чтобы успеть, откладывайте 15 000,00 RUB в месяц\n" +
+				"В текущем темпе (10 000,00 RUB в месяц) накопите только к 18.10.2027",
+		},
+		{
+			description: "reached",
+			goal: usecase.GoalDTO{Name: "отпуск", Target: decimal.New(150000, 0), Saved: decimal.New(150000, 0),
+				Currency: "RUB", Deadline: deadline, Reached: true, OnTrack: true},
+			textExpected: "отпуск: 150 000,00 из 150 000,00 RUB (100%), срок 01.06.2027\nЦель достигнута",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{
+				MessageInfo:     usecase.MessageInfo{UserID: 101, Date: date},
+				GetGoalsRespDTO: &usecase.GetGoalsRespDTO{Goals: []usecase.GoalDTO{scenario.goal}},
+			}
+
+			textOutput, err := handler.ConvertCommandToText(context.Background(), &cmd)
+			assert.NoError(t, err)
+			assert.Equal(t, "Цели накоплений:\n"+scenario.textExpected, textOutput)
+		})
+	}
+}
+
+func TestGoalNudgeConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.GoalNudge
+
+	cmd := usecase.Command{
+		MessageInfo: usecase.MessageInfo{UserID: 101, Date: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+		GetGoalsRespDTO: &usecase.GetGoalsRespDTO{Goals: []usecase.GoalDTO{{
+			Name:          "отпуск",
+			Target:        decimal.New(150000, 0),
+			Saved:         decimal.Zero,
+			Currency:      "RUB",
+			Deadline:      time.Date(2027, 6, 1, 12, 0, 0, 0, time.UTC),
+			MonthlyNeeded: decimal.New(18750, 0),
+		}}},
+	}
+
+	textOutput, err := handler.ConvertCommandToText(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Напоминание о целях:\nотпуск: 0,00 из 150 000,00 RUB (0%), срок 01.06.2027\n"+
+		"Чтобы успеть, откладывайте 18 750,00 RUB в месяц\nВзносов пока нет", textOutput)
+}
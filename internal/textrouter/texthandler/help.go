@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 )
 
@@ -21,13 +22,7 @@ func (h *Help) ConvertTextToCommand(ctx context.Context, text string, cmd *useca
 	return strings.HasPrefix(text, "/help")
 }
 
+// ConvertCommandToText справка на языке пользователя хранится в каталоге переводов.
 func (h *Help) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
-	return `Я понимаю следующие команды:
-/start                               - приветственное сообщение
-/help                                - стравочная информация
-/about                               - информация о проекте
-валюта <валюта>                      - выбрать валюту по умолчанию
-расход <категория> <суммa> <валюта>  - добавление расходов
-отчет <период>                       - отчет за интервал
-лимит <период> <сумма>               - установить бюджет`, nil
+	return i18n.T(cmd.Locale, "help"), nil
 }
@@ -0,0 +1,72 @@
+package texthandler
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// ImportExpenses загрузка выписки банка. Команда приходит файлом, а не текстом.
+type ImportExpenses struct{}
+
+func NewImportExpenses() *ImportExpenses {
+	return &ImportExpenses{}
+}
+
+func (h *ImportExpenses) Name() string {
+	return usecase.ImportExpensesCmdName
+}
+
+func (h *ImportExpenses) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+func (h *ImportExpenses) ConvertDocumentToCommand(ctx context.Context, file usecase.FileDTO, cmd *usecase.Command,
+) bool {
+	if strings.ToLower(path.Ext(file.Name)) != ".csv" {
+		return false
+	}
+
+	cmd.ImportExpensesReqDTO = &usecase.ImportExpensesReqDTO{
+		UserID:   cmd.UserID,
+		FileName: file.Name,
+		Data:     file.Data,
+	}
+
+	return true
+}
+
+func (h *ImportExpenses) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.ImportExpensesReqDTO == nil || cmd.ImportExpensesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "ImportExpenses.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	resp := cmd.ImportExpensesRespDTO
+
+	if !resp.Done {
+		return i18n.Sprintf(locale, "Не удалось загрузить выписку %s. Укажите колонки командой "+
+			"\"импорт колонки <дата> <сумма> [описание] [валюта]\"", cmd.ImportExpensesReqDTO.FileName), nil
+	}
+
+	if resp.Imported == 0 && resp.Duplicates == 0 && resp.Skipped == 0 {
+		return i18n.T(locale, "В выписке нет расходов"), nil
+	}
+
+	textOut := i18n.Sprintf(locale, "Загружено расходов: %d", resp.Imported)
+
+	if resp.Duplicates != 0 {
+		textOut += i18n.Sprintf(locale, "\nУже были загружены раньше: %d", resp.Duplicates)
+	}
+
+	if resp.Skipped != 0 {
+		textOut += i18n.Sprintf(locale, "\nПропущено строк: %d", resp.Skipped)
+	}
+
+	return textOut, nil
+}
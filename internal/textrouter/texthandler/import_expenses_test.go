@@ -0,0 +1,88 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestImportExpensesConvertDocumentToCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var handler texthandler.ImportExpenses
+
+	cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101}}
+
+	matched := handler.ConvertDocumentToCommand(ctx, usecase.FileDTO{Name: "photo.png", Data: []byte("png")}, &cmd)
+	assert.False(t, matched)
+	assert.Nil(t, cmd.ImportExpensesReqDTO)
+
+	matched = handler.ConvertDocumentToCommand(ctx, usecase.FileDTO{Name: "Выписка.CSV", Data: []byte("data")}, &cmd)
+	assert.True(t, matched)
+	assert.Equal(t, &usecase.ImportExpensesReqDTO{
+		UserID:   101,
+		FileName: "Выписка.CSV",
+		Data:     []byte("data"),
+	}, cmd.ImportExpensesReqDTO)
+
+	assert.False(t, handler.ConvertTextToCommand(ctx, "импорт", &cmd))
+}
+
+func TestImportExpensesConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description  string
+		resp         usecase.ImportExpensesRespDTO
+		textExpected string
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "not parsed",
+			resp:        usecase.ImportExpensesRespDTO{},
+			textExpected: `Не удалось загрузить выписку bank.csv. Укажите колонки командой ` +
+				`"импорт колонки <дата> <сумма> [описание] [валюта]"`,
+		},
+		{
+			description:  "empty",
+			resp:         usecase.ImportExpensesRespDTO{Done: true},
+			textExpected: "В выписке нет расходов",
+		},
+		{
+			description:  "imported",
+			resp:         usecase.ImportExpensesRespDTO{Done: true, Imported: 10},
+			textExpected: "Загружено расходов: 10",
+		},
+		{
+			description: "imported again",
+			resp:        usecase.ImportExpensesRespDTO{Done: true, Duplicates: 10, Skipped: 2},
+			textExpected: `Загружено расходов: 0
+Уже были загружены раньше: 10
+Пропущено строк: 2`,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.ImportExpenses
+
+			cmd := usecase.Command{
+				ImportExpensesReqDTO:  &usecase.ImportExpensesReqDTO{UserID: 101, FileName: "bank.csv"},
+				ImportExpensesRespDTO: &scenario.resp,
+			}
+
+			text, err := handler.ConvertCommandToText(context.Background(), &cmd)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.textExpected, text)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package texthandler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// LimitNotification уведомление о достижении порога лимита. Из текста не разбирается.
+type LimitNotification struct{}
+
+func NewLimitNotification() *LimitNotification {
+	return &LimitNotification{}
+}
+
+func (h *LimitNotification) Name() string {
+	return usecase.LimitNotificationCmdName
+}
+
+func (h *LimitNotification) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+func (h *LimitNotification) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.LimitNotificationDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "LimitNotification.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	notification := cmd.LimitNotificationDTO
+
+	subject := i18n.T(locale, "Расходы")
+	if len(notification.Category) != 0 {
+		subject = i18n.Sprintf(locale, "Расходы по категории %s", notification.Category)
+	}
+
+	return i18n.Sprintf(locale, "%s за %s достигли %d%% лимита: %s из %s %s",
+		subject, intervalToStr(locale, notification.IntervalType), notification.Threshold,
+		i18n.FormatAmount(locale, notification.Spent),
+		i18n.FormatAmount(locale, notification.Limit),
+		notification.Currency), nil
+}
@@ -0,0 +1,89 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type Locales struct{}
+
+func NewLocales() *Locales {
+	return &Locales{}
+}
+
+func (h *Locales) Name() string {
+	return usecase.LocalesCmdName
+}
+
+func (h *Locales) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return strings.TrimSpace(text) == "язык"
+}
+
+func (h *Locales) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	return i18n.Sprintf(cmd.Locale, "Язык: %s. Выберите другой:", i18n.LocaleName(cmd.Locale)), nil
+}
+
+// ConvertCommandToKeyboard кнопки всех языков, кроме текущего.
+func (h *Locales) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	current := i18n.LocaleName(cmd.Locale)
+
+	row := make([]textrouter.Button, 0, len(i18n.Locales()))
+
+	for _, locale := range i18n.Locales() {
+		if i18n.LocaleName(locale) == current {
+			continue
+		}
+
+		row = append(row, textrouter.Button{
+			Text: i18n.LocaleName(locale),
+			Data: "язык " + locale,
+		})
+	}
+
+	return [][]textrouter.Button{row}
+}
+
+type SetLocale struct{}
+
+func NewSetLocale() *SetLocale {
+	return &SetLocale{}
+}
+
+func (h *SetLocale) Name() string {
+	return usecase.SetLocaleCmdName
+}
+
+func (h *SetLocale) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	localeIndex := 1
+	argsCount := 2
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "язык" {
+		return false
+	}
+
+	locale, ok := i18n.ParseLocale(fields[localeIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.SetLocaleReqDTO = &usecase.SetLocaleReqDTO{
+		UserID: cmd.UserID,
+		Locale: locale,
+	}
+
+	return true
+}
+
+func (h *SetLocale) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.SetLocaleReqDTO == nil || cmd.SetLocaleRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetLocale.ExecuteCommand")
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Язык: %s", i18n.LocaleName(cmd.SetLocaleRespDTO.Locale)), nil
+}
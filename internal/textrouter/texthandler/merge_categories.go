@@ -0,0 +1,64 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type MergeCategories struct{}
+
+func NewMergeCategories() *MergeCategories {
+	return &MergeCategories{}
+}
+
+func (h *MergeCategories) Name() string {
+	return usecase.MergeCategoriesCmdName
+}
+
+func (h *MergeCategories) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	fromIndex := 1
+	toIndex := 2
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "объединить" {
+		return false
+	}
+
+	from, ok := parseCategoryName(fields[fromIndex])
+	if !ok {
+		return false
+	}
+
+	to, ok := parseCategoryName(fields[toIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.MergeCategoriesReqDTO = &usecase.MergeCategoriesReqDTO{
+		UserID: cmd.UserID,
+		From:   from,
+		To:     to,
+	}
+
+	return true
+}
+
+func (h *MergeCategories) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.MergeCategoriesReqDTO == nil || cmd.MergeCategoriesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "MergeCategories.ExecuteCommand")
+	}
+
+	if !cmd.MergeCategoriesRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Не удалось объединить %s и %s", cmd.MergeCategoriesReqDTO.From,
+			cmd.MergeCategoriesReqDTO.To), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Расходы %s перенесены в %s", cmd.MergeCategoriesReqDTO.From,
+		cmd.MergeCategoriesRespDTO.Category), nil
+}
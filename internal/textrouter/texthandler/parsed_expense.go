@@ -0,0 +1,142 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/expenseparser"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// Формат даты в кнопках, его понимает utils.ParseDate.
+const isoDate = "2006-01-02"
+
+// ParsedExpense расход, написанный в свободной форме, например "кофе 250" или
+// "потратил 1.5к на продукты вчера". Регистрируется после всех команд, чтобы не
+// перехватывать их. Выполняется и отображается как обычное добавление расхода.
+type ParsedExpense struct {
+	addExpense *AddExpense
+}
+
+func NewParsedExpense() *ParsedExpense {
+	return &ParsedExpense{
+		addExpense: NewAddExpense(),
+	}
+}
+
+func (h *ParsedExpense) Name() string {
+	return usecase.AddExpenseCmdName
+}
+
+func (h *ParsedExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	result, ok := parseExpense(text, cmd)
+	if !ok || result.Confidence < expenseparser.MinConfidence {
+		return false
+	}
+
+	req := parsedExpenseToReq(result.Variants[0], cmd)
+	cmd.AddExpenseReqDTO = &req
+
+	return true
+}
+
+func (h *ParsedExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	return h.addExpense.ConvertCommandToText(ctx, cmd)
+}
+
+// ConfirmExpense сообщение, похожее на расход, которое можно прочитать по-разному.
+// Вместо "не могу понять" бот предлагает кнопки с вариантами расхода.
+type ConfirmExpense struct{}
+
+func NewConfirmExpense() *ConfirmExpense {
+	return &ConfirmExpense{}
+}
+
+func (h *ConfirmExpense) Name() string {
+	return usecase.ConfirmExpenseCmdName
+}
+
+func (h *ConfirmExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	result, ok := parseExpense(text, cmd)
+	if !ok {
+		return false
+	}
+
+	variants := make([]usecase.AddExpenseReqDTO, 0, len(result.Variants))
+	for _, expense := range result.Variants {
+		variants = append(variants, parsedExpenseToReq(expense, cmd))
+	}
+
+	cmd.ConfirmExpenseDTO = &usecase.ConfirmExpenseDTO{
+		Variants: variants,
+	}
+
+	return true
+}
+
+func (h *ConfirmExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.ConfirmExpenseDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "ConfirmExpense.ExecuteCommand")
+	}
+
+	return i18n.T(cmd.Locale, "Похоже на расход, но я не уверен. Выберите вариант или напишите: "+
+		"расход <категория> <сумма>"), nil
+}
+
+// ConvertCommandToKeyboard по кнопке на вариант, кнопка отправляет точную команду добавления расхода.
+func (h *ConfirmExpense) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	if cmd.ConfirmExpenseDTO == nil {
+		return nil
+	}
+
+	locale := cmd.Locale
+
+	keyboard := make([][]textrouter.Button, 0, len(cmd.ConfirmExpenseDTO.Variants))
+
+	for _, req := range cmd.ConfirmExpenseDTO.Variants {
+		text := []string{req.Category, i18n.FormatAmount(locale, req.Price)}
+		data := []string{"расход", req.Category, req.Price.String()}
+
+		if len(req.Currency) != 0 {
+			text = append(text, req.Currency)
+			data = append(data, req.Currency)
+		}
+
+		// Дата нужна, только если расход не сегодняшний
+		if date := req.Date.Format(isoDate); date != cmd.Date.Format(isoDate) {
+			text = append(text, i18n.FormatDate(locale, req.Date))
+			data = append(data, date)
+		}
+
+		keyboard = append(keyboard, []textrouter.Button{{
+			Text: strings.Join(text, " "),
+			Data: strings.Join(data, " "),
+		}})
+	}
+
+	return keyboard
+}
+
+// parseExpense сообщения, начинающиеся с ключевого слова команды, - это ошибки в
+// команде, а не расходы.
+func parseExpense(text string, cmd *usecase.Command) (expenseparser.Result, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || i18n.IsCommandKeyword(fields[0]) {
+		return expenseparser.Result{}, false
+	}
+
+	return expenseparser.Parse(text, cmd.Date)
+}
+
+func parsedExpenseToReq(expense expenseparser.Expense, cmd *usecase.Command) usecase.AddExpenseReqDTO {
+	return usecase.AddExpenseReqDTO{
+		UserID:   cmd.UserID,
+		Category: expense.Category,
+		Price:    expense.Price,
+		Currency: expense.Currency,
+		Date:     expense.Date,
+	}
+}
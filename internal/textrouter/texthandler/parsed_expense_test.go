@@ -0,0 +1,88 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestParsedExpenseConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		text string
+		req  *usecase.AddExpenseReqDTO
+	}{
+		{
+			name: "category and price",
+			text: "кофе 250",
+			req:  &usecase.AddExpenseReqDTO{UserID: 101, Category: "кофе", Price: decimal.New(250, 0), Date: date},
+		},
+		{
+			name: "currency symbol and date",
+			text: "потратил 2к₽ на продукты вчера",
+			req: &usecase.AddExpenseReqDTO{
+				UserID: 101, Category: "продукты", Price: decimal.New(2000, 0), Currency: "RUB",
+				Date: date.AddDate(0, 0, -1),
+			},
+		},
+		{
+			name: "ambiguous",
+			text: "кофе с собой 250",
+		},
+		{
+			name: "command with mistake",
+			text: "лимит 5000",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := texthandler.NewParsedExpense()
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: date}}
+
+			ok := handler.ConvertTextToCommand(context.Background(), tt.text, &cmd)
+			assert.Equal(t, tt.req != nil, ok)
+			assert.Equal(t, tt.req, cmd.AddExpenseReqDTO)
+		})
+	}
+}
+
+func TestConfirmExpense(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.ConfirmExpense
+
+	ctx := context.Background()
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: date}}
+
+	ok := handler.ConvertTextToCommand(ctx, "такси 15.10 250 usd", &cmd)
+	assert.True(t, ok)
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Похоже на расход, но я не уверен. Выберите вариант или напишите: "+
+		"расход <категория> <сумма>", text)
+
+	// Число, похожее на дату, скорее дата
+	assert.Equal(t, [][]textrouter.Button{
+		{{Text: "такси 250,00 USD 15.10.2026", Data: "расход такси 250 USD 2026-10-15"}},
+		{{Text: "такси 15,10 USD", Data: "расход такси 15.1 USD"}},
+	}, handler.ConvertCommandToKeyboard(ctx, &cmd))
+}
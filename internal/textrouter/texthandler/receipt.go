@@ -0,0 +1,89 @@
+package texthandler
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/receipt"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// ReceiptExpense расход по фото кассового чека. Сумма и дата берутся из QR-кода, а
+// категорию бот спрашивает в диалоге.
+type ReceiptExpense struct{}
+
+func NewReceiptExpense() *ReceiptExpense {
+	return &ReceiptExpense{}
+}
+
+func (h *ReceiptExpense) Name() string {
+	return usecase.ReceiptCmdName
+}
+
+func (h *ReceiptExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+// ConvertDocumentToCommand принимает фото и картинки. Время в чеке местное, как и
+// время сообщения.
+func (h *ReceiptExpense) ConvertDocumentToCommand(ctx context.Context, file usecase.FileDTO, cmd *usecase.Command,
+) bool {
+	switch strings.ToLower(path.Ext(file.Name)) {
+	case ".jpg", ".jpeg", ".png":
+	default:
+		return false
+	}
+
+	r, err := receipt.Decode(file.Data, cmd.Date.Location())
+
+	cmd.ReceiptDTO = &usecase.ReceiptDTO{
+		Found: err == nil,
+		Price: r.Sum,
+		Date:  r.Date,
+	}
+
+	return true
+}
+
+func (h *ReceiptExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.ReceiptDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "ReceiptExpense.ExecuteCommand")
+	}
+
+	if !cmd.ReceiptDTO.Found {
+		return i18n.T(cmd.Locale, "Не нашел на фото QR-код чека. Сфотографируйте код крупнее и ровнее"), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Чек на %s от %s", i18n.FormatAmount(cmd.Locale, cmd.ReceiptDTO.Price),
+		i18n.FormatDate(cmd.Locale, cmd.ReceiptDTO.Date)), nil
+}
+
+// StartDialog диалог начинается только с фото, первый ответ - сумма и дата из чека.
+func (h *ReceiptExpense) StartDialog(text string) bool {
+	return false
+}
+
+func (h *ReceiptExpense) DialogQuestions() []string {
+	return []string{"Пришлите фото чека", "Чек распознан. Какая категория у расхода?"}
+}
+
+func (h *ReceiptExpense) CheckDialogAnswer(step int, answer string) bool {
+	return step == 1 && len(strings.Fields(answer)) == 1
+}
+
+func (h *ReceiptExpense) ConvertDialogToText(answers []string) string {
+	return "расход " + answers[1] + " " + answers[0]
+}
+
+// DialogAnswers сумма и дата чека в виде, который понимает команда "расход".
+func (h *ReceiptExpense) DialogAnswers(cmd *usecase.Command) []string {
+	if cmd.ReceiptDTO == nil || !cmd.ReceiptDTO.Found {
+		return nil
+	}
+
+	return []string{cmd.ReceiptDTO.Price.String() + " " + cmd.ReceiptDTO.Date.Format(isoDate)}
+}
@@ -0,0 +1,31 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestReceiptExpense(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.ReceiptExpense
+
+	ctx := context.Background()
+
+	cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101, Date: time.Now()}}
+
+	// Не картинки достаются другим обработчикам, например импорту выписки
+	assert.False(t, handler.ConvertDocumentToCommand(ctx, usecase.FileDTO{Name: "bank.csv"}, &cmd))
+
+	assert.True(t, handler.ConvertDocumentToCommand(ctx, usecase.FileDTO{Name: "IMG_1.JPG", Data: []byte("x")}, &cmd))
+	assert.Nil(t, handler.DialogAnswers(&cmd))
+
+	text, err := handler.ConvertCommandToText(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "Не нашел на фото QR-код чека. Сфотографируйте код крупнее и ровнее", text)
+}
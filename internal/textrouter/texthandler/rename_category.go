@@ -0,0 +1,63 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type RenameCategory struct{}
+
+func NewRenameCategory() *RenameCategory {
+	return &RenameCategory{}
+}
+
+func (h *RenameCategory) Name() string {
+	return usecase.RenameCategoryCmdName
+}
+
+func (h *RenameCategory) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	categoryIndex := 1
+	nameIndex := 2
+	argsCount := 3
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "переименовать" {
+		return false
+	}
+
+	category, ok := parseCategoryName(fields[categoryIndex])
+	if !ok {
+		return false
+	}
+
+	name, ok := parseCategoryName(fields[nameIndex])
+	if !ok {
+		return false
+	}
+
+	cmd.RenameCategoryReqDTO = &usecase.RenameCategoryReqDTO{
+		UserID:   cmd.UserID,
+		Category: category,
+		Name:     name,
+	}
+
+	return true
+}
+
+func (h *RenameCategory) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.RenameCategoryReqDTO == nil || cmd.RenameCategoryRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "RenameCategory.ExecuteCommand")
+	}
+
+	if !cmd.RenameCategoryRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Категория %s не найдена", cmd.RenameCategoryReqDTO.Category), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Категория %s переименована в %s", cmd.RenameCategoryReqDTO.Category,
+		cmd.RenameCategoryReqDTO.Name), nil
+}
@@ -0,0 +1,49 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// ReportPeriods отвечает на "отчет" без периода кнопками с готовыми отчетами.
+type ReportPeriods struct{}
+
+func NewReportPeriods() *ReportPeriods {
+	return &ReportPeriods{}
+}
+
+func (h *ReportPeriods) Name() string {
+	return usecase.ReportPeriodsCmdName
+}
+
+func (h *ReportPeriods) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return strings.TrimSpace(text) == "отчет"
+}
+
+func (h *ReportPeriods) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	return i18n.T(cmd.Locale, "Выберите период отчета:"), nil
+}
+
+func (h *ReportPeriods) ConvertCommandToKeyboard(ctx context.Context, cmd *usecase.Command) [][]textrouter.Button {
+	locale := cmd.Locale
+
+	return [][]textrouter.Button{
+		{
+			{Text: i18n.T(locale, "День"), Data: "отчет день"},
+			{Text: i18n.T(locale, "Неделя"), Data: "отчет неделя"},
+			{Text: i18n.T(locale, "Месяц"), Data: "отчет месяц"},
+			{Text: i18n.T(locale, "Год"), Data: "отчет год"},
+		},
+		{
+			{Text: i18n.T(locale, "Прошлая неделя"), Data: "отчет прошлая неделя"},
+			{Text: i18n.T(locale, "Прошлый месяц"), Data: "отчет прошлый месяц"},
+		},
+		{
+			{Text: i18n.T(locale, "Месяц с графиком"), Data: "отчет месяц график"},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package texthandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type SearchExpenses struct{}
+
+func NewSearchExpenses() *SearchExpenses {
+	return &SearchExpenses{}
+}
+
+func (h *SearchExpenses) Name() string {
+	return usecase.SearchExpensesCmdName
+}
+
+// ConvertTextToCommand после текста допускает период как в отчете: найти такси месяц,
+// найти ужин 01.09 30.09. Без периода поиск идет за все время.
+func (h *SearchExpenses) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCountMin := 2
+	intervalArgsMax := 2
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || fields[0] != "найти" {
+		return false
+	}
+
+	words := fields[1:]
+
+	req := usecase.SearchExpensesReqDTO{
+		UserID:  cmd.UserID,
+		DateEnd: utils.TruncDate(cmd.Date).AddDate(0, 0, 1),
+	}
+
+	// Сначала пробуется более длинный период, в запросе должно остаться хотя бы одно слово
+	for count := intervalArgsMax; count > 0; count-- {
+		if len(words) <= count {
+			continue
+		}
+
+		interval, ok := parseReportInterval(words[len(words)-count:], cmd.Date)
+		if !ok {
+			continue
+		}
+
+		req.DateStart, req.DateEnd, req.IntervalType = interval.DateStart, interval.DateEnd, interval.IntervalType
+		words = words[:len(words)-count]
+
+		break
+	}
+
+	req.Query = strings.Join(words, " ")
+
+	cmd.SearchExpensesReqDTO = &req
+
+	return true
+}
+
+func (h *SearchExpenses) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.SearchExpensesReqDTO == nil || cmd.SearchExpensesRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SearchExpenses.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+	req, resp := cmd.SearchExpensesReqDTO, cmd.SearchExpensesRespDTO
+
+	interval := exportIntervalToStr(locale, &usecase.ExportReqDTO{
+		UserID:       req.UserID,
+		DateStart:    req.DateStart,
+		DateEnd:      req.DateEnd,
+		IntervalType: req.IntervalType,
+	}, cmd.Date)
+
+	if len(resp.Expenses) == 0 {
+		return i18n.Sprintf(locale, "По запросу \"%s\" ничего не найдено %s", req.Query, interval), nil
+	}
+
+	lines := make([]string, 0, len(resp.Expenses)+2) //nolint:gomnd
+
+	lines = append(lines, i18n.Sprintf(locale, "Найдено по запросу \"%s\" %s:", req.Query, interval))
+
+	for _, expense := range resp.Expenses {
+		line := fmt.Sprintf("#%d %s %s - %s %s", expense.ID, i18n.FormatDate(locale, expense.Date),
+			expense.Category, i18n.FormatAmount(locale, expense.Price), resp.Currency)
+
+		if len(expense.Note) != 0 {
+			line += " - " + expense.Note
+		}
+
+		lines = append(lines, line)
+	}
+
+	if resp.HasMore {
+		lines = append(lines, i18n.T(locale, "Показаны последние, уточните запрос или период"))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
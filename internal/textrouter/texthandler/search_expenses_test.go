@@ -0,0 +1,189 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestSearchExpensesConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.SearchExpenses
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.SearchExpensesReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "command only",
+			textInput:   "найти",
+			matched:     false,
+			reqExpected: nil,
+		},
+		{
+			description: "all time",
+			textInput:   "найти ужин с Петей",
+			matched:     true,
+			reqExpected: &usecase.SearchExpensesReqDTO{
+				UserID:  101,
+				Query:   "ужин с Петей",
+				DateEnd: tomorrow,
+			},
+		},
+		{
+			description: "interval",
+			textInput:   "найти такси месяц",
+			matched:     true,
+			reqExpected: &usecase.SearchExpensesReqDTO{
+				UserID:       101,
+				Query:        "такси",
+				DateStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				DateEnd:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+				IntervalType: utils.MonthInterval,
+			},
+		},
+		{
+			description: "date range",
+			textInput:   "найти ужин 01.09 30.09",
+			matched:     true,
+			reqExpected: &usecase.SearchExpensesReqDTO{
+				UserID:    101,
+				Query:     "ужин",
+				DateStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+				DateEnd:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			description: "interval word only is a query",
+			textInput:   "найти месяц",
+			matched:     true,
+			reqExpected: &usecase.SearchExpensesReqDTO{
+				UserID:  101,
+				Query:   "месяц",
+				DateEnd: tomorrow,
+			},
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{UserID: 101, Date: now},
+			}
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.SearchExpensesReqDTO)
+		})
+	}
+}
+
+func TestSearchExpensesConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		description  string
+		cmd          usecase.Command
+		textExpected string
+		errExpected  string
+	}
+
+	testCases := [...]testCase{
+		{
+			description:  "empty req",
+			cmd:          usecase.Command{},
+			textExpected: "",
+			errExpected:  "SearchExpenses.ExecuteCommand: internal error",
+		},
+		{
+			description: "nothing found",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{Date: now},
+				SearchExpensesReqDTO: &usecase.SearchExpensesReqDTO{
+					Query:   "ужин",
+					DateEnd: now,
+				},
+				SearchExpensesRespDTO: &usecase.SearchExpensesRespDTO{Currency: "RUB"},
+			},
+			textExpected: "По запросу \"ужин\" ничего не найдено за все время",
+			errExpected:  "",
+		},
+		{
+			description: "found",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{Date: now},
+				SearchExpensesReqDTO: &usecase.SearchExpensesReqDTO{
+					Query:        "ужин",
+					DateStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					DateEnd:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+					IntervalType: utils.MonthInterval,
+				},
+				SearchExpensesRespDTO: &usecase.SearchExpensesRespDTO{
+					Currency: "RUB",
+					Expenses: []usecase.FoundExpenseDTO{
+						{
+							ID:       4,
+							Category: "еда",
+							Note:     "ужин с Петей",
+							Price:    decimal.New(500, 0),
+							Date:     time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC),
+						},
+						{
+							ID:       2,
+							Category: "ужин",
+							Price:    decimal.New(1200, 0),
+							Date:     time.Date(2026, 10, 3, 20, 0, 0, 0, time.UTC),
+						},
+					},
+					HasMore: true,
+				},
+			},
+			textExpected: `Найдено по запросу "ужин" за месяц:
+#4 15.10.2026 еда - 500,00 RUB - ужин с Петей
+#2 03.10.2026 ужин - 1 200,00 RUB
+Показаны последние, уточните запрос или период`,
+			errExpected: "",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.SearchExpenses
+
+			ctx := context.Background()
+
+			textOutput, err := handler.ConvertCommandToText(ctx, &scenario.cmd)
+			assert.Equal(t, scenario.textExpected, textOutput)
+
+			if len(scenario.errExpected) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, scenario.errExpected)
+			}
+		})
+	}
+}
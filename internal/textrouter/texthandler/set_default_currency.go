@@ -2,10 +2,10 @@ package texthandler
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 )
@@ -44,7 +44,7 @@ func (h *SetDefaultCurrency) ConvertCommandToText(ctx context.Context, cmd *usec
 		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetDefaultCurrency.ExecuteCommand")
 	}
 
-	textOut := fmt.Sprintf("Задана валюта по умолчанию %s", cmd.SetDefaultCurrencyReqDTO.Currency)
+	textOut := i18n.Sprintf(cmd.Locale, "Задана валюта по умолчанию %s", cmd.SetDefaultCurrencyReqDTO.Currency)
 
 	return textOut, nil
 }
@@ -0,0 +1,90 @@
+package texthandler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type SetImportColumns struct{}
+
+func NewSetImportColumns() *SetImportColumns {
+	return &SetImportColumns{}
+}
+
+func (h *SetImportColumns) Name() string {
+	return usecase.SetImportColumnsCmdName
+}
+
+// ConvertTextToCommand номера колонок начинаются с единицы, "авто" - определять колонки по заголовку.
+func (h *SetImportColumns) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	columnsIndex := 2
+	argsCountMin := 3
+	argsCountMax := 6
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "импорт" || fields[1] != "колонки" {
+		return false
+	}
+
+	req := usecase.SetImportColumnsReqDTO{UserID: cmd.UserID}
+
+	if len(fields) == argsCountMin && fields[columnsIndex] == "авто" {
+		cmd.SetImportColumnsReqDTO = &req
+
+		return true
+	}
+
+	if len(fields) == argsCountMin {
+		return false
+	}
+
+	columns := make([]int, argsCountMax-columnsIndex)
+
+	for i, field := range fields[columnsIndex:] {
+		column, err := strconv.Atoi(field)
+		if err != nil || column <= 0 {
+			return false
+		}
+
+		columns[i] = column
+	}
+
+	req.Date, req.Amount, req.Description, req.Currency = columns[0], columns[1], columns[2], columns[3]
+	cmd.SetImportColumnsReqDTO = &req
+
+	return true
+}
+
+func (h *SetImportColumns) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.SetImportColumnsReqDTO == nil || cmd.SetImportColumnsRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetImportColumns.ExecuteCommand")
+	}
+
+	if cmd.SetImportColumnsReqDTO.Date == 0 {
+		return i18n.T(cmd.Locale, "Колонки выписки будут определяться по заголовку"), nil
+	}
+
+	return i18n.Sprintf(cmd.Locale, "Колонки выписки сохранены: %s", importColumnsToStr(cmd.Locale,
+		cmd.SetImportColumnsReqDTO.Date, cmd.SetImportColumnsReqDTO.Amount, cmd.SetImportColumnsReqDTO.Description,
+		cmd.SetImportColumnsReqDTO.Currency)), nil
+}
+
+func importColumnsToStr(locale string, date, amount, description, currency int) string {
+	columns := []string{i18n.Sprintf(locale, "дата %d", date), i18n.Sprintf(locale, "сумма %d", amount)}
+
+	if description != 0 {
+		columns = append(columns, i18n.Sprintf(locale, "описание %d", description))
+	}
+
+	if currency != 0 {
+		columns = append(columns, i18n.Sprintf(locale, "валюта %d", currency))
+	}
+
+	return strings.Join(columns, ", ")
+}
@@ -0,0 +1,89 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestSetImportColumnsConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.SetImportColumnsReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "auto",
+			textInput:   "импорт колонки авто",
+			matched:     true,
+			reqExpected: &usecase.SetImportColumnsReqDTO{UserID: 101},
+		},
+		{
+			description: "date + amount",
+			textInput:   "импорт колонки 1 3",
+			matched:     true,
+			reqExpected: &usecase.SetImportColumnsReqDTO{UserID: 101, Date: 1, Amount: 3},
+		},
+		{
+			description: "all columns",
+			textInput:   "импорт колонки 1 4 3 5",
+			matched:     true,
+			reqExpected: &usecase.SetImportColumnsReqDTO{UserID: 101, Date: 1, Amount: 4, Description: 3, Currency: 5},
+		},
+		{
+			description: "only date",
+			textInput:   "импорт колонки 1",
+			matched:     false,
+		},
+		{
+			description: "zero column",
+			textInput:   "импорт колонки 0 1",
+			matched:     false,
+		},
+		{
+			description: "too many columns",
+			textInput:   "импорт колонки 1 2 3 4 5",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.SetImportColumns
+
+			cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101}}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.SetImportColumnsReqDTO)
+		})
+	}
+}
+
+func TestAddImportRuleConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.AddImportRule
+
+	cmd := usecase.Command{MessageInfo: usecase.MessageInfo{UserID: 101}}
+
+	assert.False(t, handler.ConvertTextToCommand(context.Background(), "импорт правило такси", &cmd))
+
+	assert.True(t, handler.ConvertTextToCommand(context.Background(), "импорт правило Яндекс Такси такси", &cmd))
+	assert.Equal(t, &usecase.AddImportRuleReqDTO{
+		UserID:   101,
+		Keyword:  "яндекс такси",
+		Category: "такси",
+	}, cmd.AddImportRuleReqDTO)
+}
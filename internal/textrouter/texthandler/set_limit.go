@@ -2,11 +2,11 @@ package texthandler
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
@@ -25,8 +25,9 @@ func (h *SetLimit) Name() string {
 func (h *SetLimit) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
 	intervalIndex := 1
 	limitIndex := 2
+	categoryIndex := 3
 	argsCountMin := 3
-	argsCountMax := 3
+	argsCountMax := 4
 
 	fields := strings.Fields(text)
 	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "лимит" {
@@ -43,8 +44,18 @@ func (h *SetLimit) ConvertTextToCommand(ctx context.Context, text string, cmd *u
 		return false
 	}
 
+	var category string
+
+	if len(fields) > categoryIndex {
+		category, ok = parseCategoryName(fields[categoryIndex])
+		if !ok {
+			return false
+		}
+	}
+
 	cmd.SetLimitReqDTO = &usecase.SetLimitReqDTO{
 		UserID:       cmd.UserID,
+		Category:     category,
 		Limit:        limit,
 		IntervalType: intervalType,
 	}
@@ -57,14 +68,14 @@ func (h *SetLimit) ConvertCommandToText(ctx context.Context, cmd *usecase.Comman
 		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetLimit.ExecuteCommand")
 	}
 
-	precision := 2
-
-	intervalType, _ := utils.IntervalToStr(cmd.SetLimitReqDTO.IntervalType)
-
-	textOut := fmt.Sprintf("Установил лимит: %s - %s - %s",
-		intervalType,
-		cmd.SetLimitReqDTO.Limit.StringFixed(int32(precision)),
+	textOut := i18n.Sprintf(cmd.Locale, "Установил лимит: %s - %s - %s",
+		intervalToStr(cmd.Locale, cmd.SetLimitReqDTO.IntervalType),
+		i18n.FormatAmount(cmd.Locale, cmd.SetLimitReqDTO.Limit),
 		cmd.SetLimitRespDTO.Currency)
 
+	if len(cmd.SetLimitRespDTO.Category) != 0 {
+		textOut += " - " + cmd.SetLimitRespDTO.Category
+	}
+
 	return textOut, nil
 }
@@ -0,0 +1,66 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+type SetSummary struct{}
+
+func NewSetSummary() *SetSummary {
+	return &SetSummary{}
+}
+
+func (h *SetSummary) Name() string {
+	return usecase.SetSummaryCmdName
+}
+
+func (h *SetSummary) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	argsCount := 2
+
+	fields := strings.Fields(text)
+	if len(fields) != argsCount || fields[0] != "сводка" {
+		return false
+	}
+
+	var intervalType int
+
+	switch fields[1] {
+	case "день":
+		intervalType = utils.DayInterval
+	case "неделя":
+		intervalType = utils.WeekInterval
+	case "выкл":
+		intervalType = 0
+	default:
+		return false
+	}
+
+	cmd.SetSummaryReqDTO = &usecase.SetSummaryReqDTO{
+		UserID:       cmd.UserID,
+		IntervalType: intervalType,
+	}
+
+	return true
+}
+
+func (h *SetSummary) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.SetSummaryReqDTO == nil || cmd.SetSummaryRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "SetSummary.ExecuteCommand")
+	}
+
+	switch cmd.SetSummaryReqDTO.IntervalType {
+	case utils.DayInterval:
+		return i18n.T(cmd.Locale, "Буду присылать сводку расходов за прошедший день"), nil
+	case utils.WeekInterval:
+		return i18n.T(cmd.Locale, "Буду присылать сводку расходов за прошедшую неделю"), nil
+	default:
+		return i18n.T(cmd.Locale, "Сводка расходов отключена"), nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 )
 
@@ -22,6 +23,6 @@ func (h *Start) ConvertTextToCommand(ctx context.Context, text string, cmd *usec
 }
 
 func (h *Start) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
-	return `Привет. Напиши свои расходы и я запомпю их.
-Введи /help для более подробной информации`, nil
+	return i18n.T(cmd.Locale, `Привет. Напиши свои расходы и я запомпю их.
+Введи /help для более подробной информации`), nil
 }
@@ -0,0 +1,61 @@
+package texthandler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+// Summary сводка расходов, которую присылает summary worker. Из текста не разбирается.
+type Summary struct{}
+
+func NewSummary() *Summary {
+	return &Summary{}
+}
+
+func (h *Summary) Name() string {
+	return usecase.SummaryCmdName
+}
+
+func (h *Summary) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	return false
+}
+
+func (h *Summary) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.GetReportReqDTO == nil || cmd.GetReportRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Summary.ExecuteCommand")
+	}
+
+	locale := cmd.Locale
+
+	req := cmd.GetReportReqDTO
+	resp := cmd.GetReportRespDTO
+
+	var textOut string
+
+	dateLast := req.DateEnd.AddDate(0, 0, -1)
+	if dateLast.Equal(req.DateStart) {
+		textOut = i18n.Sprintf(locale, "Сводка расходов за %s:\n", i18n.FormatDate(locale, req.DateStart))
+	} else {
+		textOut = i18n.Sprintf(locale, "Сводка расходов с %s по %s:\n", i18n.FormatDate(locale, req.DateStart),
+			i18n.FormatDate(locale, dateLast))
+	}
+
+	if len(resp.Expenses) == 0 {
+		return textOut + i18n.T(locale, "Расходов не было"), nil
+	}
+
+	total := decimal.Zero
+	for _, expense := range resp.Expenses {
+		total = total.Add(expense.Sum)
+	}
+
+	textOut += reportExpensesToStr(locale, resp.Expenses)
+	textOut += i18n.Sprintf(locale, "\nИтого - %s %s", i18n.FormatAmount(locale, total), resp.Currency)
+
+	return textOut, nil
+}
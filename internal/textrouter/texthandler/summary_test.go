@@ -0,0 +1,81 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestSummaryConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var handler texthandler.Summary
+
+	dateStart := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	textOutput, err := handler.ConvertCommandToText(ctx, &usecase.Command{
+		GetReportReqDTO: &usecase.GetReportReqDTO{
+			UserID:       101,
+			DateStart:    dateStart,
+			DateEnd:      dateStart.AddDate(0, 0, 7),
+			IntervalType: utils.WeekInterval,
+		},
+		GetReportRespDTO: &usecase.GetReportRespDTO{
+			Currency: "RUB",
+			Expenses: []usecase.ExpenseReportDTO{
+				{Category: "такси", Sum: decimal.RequireFromString("300")},
+				{Category: "еда", Sum: decimal.RequireFromString("1250.5")},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `Сводка расходов с 12.10.2026 по 18.10.2026:
+еда - 1 250,50
+такси - 300,00
+Итого - 1 550,50 RUB`, textOutput)
+
+	textOutput, err = handler.ConvertCommandToText(ctx, &usecase.Command{
+		GetReportReqDTO: &usecase.GetReportReqDTO{
+			UserID:       101,
+			DateStart:    dateStart,
+			DateEnd:      dateStart.AddDate(0, 0, 1),
+			IntervalType: utils.DayInterval,
+		},
+		GetReportRespDTO: &usecase.GetReportRespDTO{Currency: "RUB"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `Сводка расходов за 12.10.2026:
+Расходов не было`, textOutput)
+}
+
+func TestLimitNotificationConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var handler texthandler.LimitNotification
+
+	textOutput, err := handler.ConvertCommandToText(ctx, &usecase.Command{
+		LimitNotificationDTO: &usecase.LimitNotificationDTO{
+			Category:     "кафе",
+			IntervalType: utils.MonthInterval,
+			Threshold:    80,
+			Spent:        decimal.RequireFromString("8100"),
+			Limit:        decimal.RequireFromString("10000"),
+			Currency:     "RUB",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Расходы по категории кафе за месяц достигли 80% лимита: 8 100,00 из 10 000,00 RUB", textOutput)
+
+	_, err = handler.ConvertCommandToText(ctx, &usecase.Command{})
+	assert.EqualError(t, err, "LimitNotification.ExecuteCommand: internal error")
+}
@@ -0,0 +1,75 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type Undo struct{}
+
+func NewUndo() *Undo {
+	return &Undo{}
+}
+
+func (h *Undo) Name() string {
+	return usecase.UndoCmdName
+}
+
+// ConvertTextToCommand отмена последнего действия, только /undo: действие необратимо,
+// поэтому слова вроде "отменить" или "cancel" его не запускают.
+func (h *Undo) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	if strings.TrimSpace(text) != "/undo" {
+		return false
+	}
+
+	cmd.UndoReqDTO = &usecase.UndoReqDTO{
+		UserID: cmd.UserID,
+	}
+
+	return true
+}
+
+func (h *Undo) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.UndoRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Undo.ExecuteCommand")
+	}
+
+	resp := cmd.UndoRespDTO
+
+	if !resp.Found {
+		return i18n.T(cmd.Locale, "Нет действий для отмены"), nil
+	}
+
+	var textOut string
+
+	switch resp.Action {
+	case entity.AuditAddExpense:
+		textOut = i18n.Sprintf(cmd.Locale, "Отменил добавление расхода: %s - %s %s", resp.Category,
+			i18n.FormatAmount(cmd.Locale, resp.Amount), resp.Currency)
+	case entity.AuditSetLimit:
+		if resp.Amount.IsZero() {
+			textOut = i18n.Sprintf(cmd.Locale, "Отменил изменение лимита, лимит удален: %s",
+				intervalToStr(cmd.Locale, resp.IntervalType))
+		} else {
+			textOut = i18n.Sprintf(cmd.Locale, "Отменил изменение лимита, вернул: %s - %s - %s",
+				intervalToStr(cmd.Locale, resp.IntervalType), i18n.FormatAmount(cmd.Locale, resp.Amount),
+				resp.Currency)
+		}
+
+		if len(resp.Category) != 0 {
+			textOut += " - " + resp.Category
+		}
+	case entity.AuditSetCurrency:
+		textOut = i18n.Sprintf(cmd.Locale, "Отменил смену валюты, валюта по умолчанию %s", resp.Currency)
+	default:
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "Undo.ExecuteCommand")
+	}
+
+	return textOut, nil
+}
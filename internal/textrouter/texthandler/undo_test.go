@@ -0,0 +1,167 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestUndoConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.Undo
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		cmdExpected usecase.Command
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "slash command",
+			textInput:   "/undo",
+			matched:     true,
+			cmdExpected: usecase.Command{
+				UndoReqDTO: &usecase.UndoReqDTO{},
+			},
+		},
+		{
+			description: "keyword",
+			textInput:   "отменить",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+		{
+			description: "cancel recurring",
+			textInput:   "отменить регулярный 3",
+			matched:     false,
+			cmdExpected: usecase.Command{},
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			var cmd usecase.Command
+
+			matched := handler.ConvertTextToCommand(ctx, scenario.textInput, &cmd)
+			assert.EqualValues(t, scenario.matched, matched)
+			assert.EqualValues(t, scenario.cmdExpected, cmd)
+		})
+	}
+}
+
+func TestUndoConvertCommandToText(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		description  string
+		cmd          usecase.Command
+		textExpected string
+		errExpected  string
+	}
+
+	testCases := [...]testCase{
+		{
+			description:  "empty resp",
+			cmd:          usecase.Command{},
+			textExpected: "",
+			errExpected:  "Undo.ExecuteCommand: internal error",
+		},
+		{
+			description: "nothing to undo",
+			cmd: usecase.Command{
+				UndoRespDTO: &usecase.UndoRespDTO{},
+			},
+			textExpected: "Нет действий для отмены",
+			errExpected:  "",
+		},
+		{
+			description: "expense",
+			cmd: usecase.Command{
+				UndoRespDTO: &usecase.UndoRespDTO{
+					Found:    true,
+					Action:   entity.AuditAddExpense,
+					Category: "такси",
+					Amount:   decimal.New(300, 0),
+					Currency: "RUB",
+				},
+			},
+			textExpected: "Отменил добавление расхода: такси - 300,00 RUB",
+			errExpected:  "",
+		},
+		{
+			description: "limit restored",
+			cmd: usecase.Command{
+				UndoRespDTO: &usecase.UndoRespDTO{
+					Found:        true,
+					Action:       entity.AuditSetLimit,
+					Category:     "кафе",
+					IntervalType: utils.MonthInterval,
+					Amount:       decimal.New(5000, 0),
+					Currency:     "RUB",
+				},
+			},
+			textExpected: "Отменил изменение лимита, вернул: месяц - 5 000,00 - RUB - кафе",
+			errExpected:  "",
+		},
+		{
+			description: "limit removed",
+			cmd: usecase.Command{
+				UndoRespDTO: &usecase.UndoRespDTO{
+					Found:        true,
+					Action:       entity.AuditSetLimit,
+					IntervalType: utils.WeekInterval,
+					Currency:     "RUB",
+				},
+			},
+			textExpected: "Отменил изменение лимита, лимит удален: неделя",
+			errExpected:  "",
+		},
+		{
+			description: "currency in english",
+			cmd: usecase.Command{
+				MessageInfo: usecase.MessageInfo{Locale: "en"},
+				UndoRespDTO: &usecase.UndoRespDTO{
+					Found:    true,
+					Action:   entity.AuditSetCurrency,
+					Currency: "RUB",
+				},
+			},
+			textExpected: "Undid the currency change, default currency is RUB",
+			errExpected:  "",
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			var handler texthandler.Undo
+
+			ctx := context.Background()
+
+			textOutput, err := handler.ConvertCommandToText(ctx, &scenario.cmd)
+			assert.Equal(t, scenario.textExpected, textOutput)
+
+			if len(scenario.errExpected) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, scenario.errExpected)
+			}
+		})
+	}
+}
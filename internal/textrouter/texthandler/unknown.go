@@ -3,6 +3,7 @@ package texthandler
 import (
 	"context"
 
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
 )
 
@@ -21,5 +22,5 @@ func (h *Unknown) ConvertTextToCommand(ctx context.Context, text string, cmd *us
 }
 
 func (h *Unknown) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
-	return "Не могу понять. Введи /help для более подробной информации", nil
+	return i18n.T(cmd.Locale, "Не могу понять. Введи /help для более подробной информации"), nil
 }
@@ -0,0 +1,82 @@
+package texthandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+type UpdateExpense struct{}
+
+func NewUpdateExpense() *UpdateExpense {
+	return &UpdateExpense{}
+}
+
+func (h *UpdateExpense) Name() string {
+	return usecase.UpdateExpenseCmdName
+}
+
+// ConvertTextToCommand без суммы, "изменить <id> <категория>", меняет только категорию.
+func (h *UpdateExpense) ConvertTextToCommand(ctx context.Context, text string, cmd *usecase.Command) bool {
+	idIndex := 1
+	categoryIndex := 2
+	priceIndex := 3
+	argsCountMin := 3
+	argsCountMax := 4
+
+	fields := strings.Fields(text)
+	if len(fields) < argsCountMin || len(fields) > argsCountMax || fields[0] != "изменить" {
+		return false
+	}
+
+	id, ok := parseExpenseID(fields[idIndex])
+	if !ok {
+		return false
+	}
+
+	req := usecase.UpdateExpenseReqDTO{
+		UserID:    cmd.UserID,
+		ID:        id,
+		Category:  fields[categoryIndex],
+		KeepPrice: true,
+	}
+
+	if len(fields) == argsCountMax {
+		price, err := decimal.NewFromString(fields[priceIndex])
+		if err != nil {
+			return false
+		}
+
+		req.Price, req.KeepPrice = price, false
+	}
+
+	cmd.UpdateExpenseReqDTO = &req
+
+	return true
+}
+
+func (h *UpdateExpense) ConvertCommandToText(ctx context.Context, cmd *usecase.Command) (string, error) {
+	if cmd.UpdateExpenseReqDTO == nil || cmd.UpdateExpenseRespDTO == nil {
+		return "", errors.Wrap(textrouter.ErrInvalidCommand, "UpdateExpense.ExecuteCommand")
+	}
+
+	if !cmd.UpdateExpenseRespDTO.Found {
+		return i18n.Sprintf(cmd.Locale, "Расход #%d не найден", cmd.UpdateExpenseReqDTO.ID), nil
+	}
+
+	if cmd.UpdateExpenseReqDTO.KeepPrice {
+		return i18n.Sprintf(cmd.Locale, "Изменил категорию #%d на %s", cmd.UpdateExpenseReqDTO.ID,
+			cmd.UpdateExpenseReqDTO.Category), nil
+	}
+
+	textOut := i18n.Sprintf(cmd.Locale, "Изменил #%d %s - %s %s", cmd.UpdateExpenseReqDTO.ID,
+		cmd.UpdateExpenseReqDTO.Category,
+		i18n.FormatAmount(cmd.Locale, cmd.UpdateExpenseReqDTO.Price), cmd.UpdateExpenseRespDTO.Currency)
+
+	return textOut, nil
+}
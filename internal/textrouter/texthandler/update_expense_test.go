@@ -0,0 +1,89 @@
+package texthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/textrouter/texthandler"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+)
+
+func TestUpdateExpenseConvertTextToCommand(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.UpdateExpense
+
+	type testCase struct {
+		description string
+		textInput   string
+		matched     bool
+		reqExpected *usecase.UpdateExpenseReqDTO
+	}
+
+	testCases := [...]testCase{
+		{
+			description: "category and price",
+			textInput:   "изменить 17 такси 350.5",
+			matched:     true,
+			reqExpected: &usecase.UpdateExpenseReqDTO{
+				UserID:   101,
+				ID:       17,
+				Category: "такси",
+				Price:    decimal.RequireFromString("350.5"),
+			},
+		},
+		{
+			description: "category only",
+			textInput:   "изменить #17 такси",
+			matched:     true,
+			reqExpected: &usecase.UpdateExpenseReqDTO{
+				UserID:    101,
+				ID:        17,
+				Category:  "такси",
+				KeepPrice: true,
+			},
+		},
+		{
+			description: "invalid price",
+			textInput:   "изменить 17 такси много",
+			matched:     false,
+		},
+		{
+			description: "id only",
+			textInput:   "изменить 17",
+			matched:     false,
+		},
+	}
+
+	for _, scenario := range testCases {
+		scenario := scenario
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := usecase.Command{
+				MessageInfo: usecase.MessageInfo{
+					UserID: 101,
+				},
+			}
+
+			matched := handler.ConvertTextToCommand(context.Background(), scenario.textInput, &cmd)
+			assert.Equal(t, scenario.matched, matched)
+			assert.Equal(t, scenario.reqExpected, cmd.UpdateExpenseReqDTO)
+		})
+	}
+}
+
+func TestUpdateExpenseConvertCommandToText_KeepPrice(t *testing.T) {
+	t.Parallel()
+
+	var handler texthandler.UpdateExpense
+
+	text, err := handler.ConvertCommandToText(context.Background(), &usecase.Command{
+		UpdateExpenseReqDTO:  &usecase.UpdateExpenseReqDTO{ID: 17, Category: "такси", KeepPrice: true},
+		UpdateExpenseRespDTO: &usecase.UpdateExpenseRespDTO{Found: true, Currency: "RUB"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Изменил категорию #17 на такси", text)
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrSameAccount = errors.New("transfer to the same account")
+
+func (uc *ExpenseUsecase) CreateAccount(ctx context.Context, req CreateAccountReqDTO) (CreateAccountRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "CreateAccount")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return CreateAccountRespDTO{}, errors.Wrap(err, "ExpenseUsecase.CreateAccount")
+	}
+
+	currency := req.Currency
+	if len(currency) == 0 {
+		currency = uc.getCurrencyForUser(ctx, userID)
+	} else if !uc.isSupportedCurrencyCode(currency) {
+		return CreateAccountRespDTO{}, errors.New("currency is unsupported")
+	}
+
+	account := entity.NewAccount(strings.ToLower(req.Name), currency, req.Balance)
+
+	_, created, err := uc.accountStorage.Create(ctx, userID, account)
+	if err != nil {
+		return CreateAccountRespDTO{}, errors.Wrap(err, "ExpenseUsecase.CreateAccount")
+	}
+
+	resp := CreateAccountRespDTO{
+		Exists:   !created,
+		Name:     account.GetName(),
+		Currency: currency,
+	}
+
+	return resp, nil
+}
+
+// Transfer переводит деньги между счетами. Если валюты счетов различаются, сумма
+// пересчитывается по текущему курсу.
+func (uc *ExpenseUsecase) Transfer(ctx context.Context, req TransferReqDTO) (TransferRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "Transfer")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+	}
+
+	accounts := make([]entity.Account, 0, 2) //nolint:gomnd
+
+	for _, name := range []string{req.From, req.To} {
+		account, found, err := uc.accountStorage.GetByName(ctx, userID, strings.ToLower(name))
+		if err != nil {
+			return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+		}
+
+		if !found {
+			return TransferRespDTO{NotFound: name}, nil //nolint:exhaustruct
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	from, to := accounts[0], accounts[1]
+	if from.GetID() == to.GetID() {
+		return TransferRespDTO{}, errors.Wrap(ErrSameAccount, "ExpenseUsecase.Transfer")
+	}
+
+	err = uc.tryUpdateRates(ctx, false)
+	if err != nil {
+		return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+	}
+
+	fromRate, err := uc.currencyStorage.Get(ctx, from.GetCurrency())
+	if err != nil {
+		return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+	}
+
+	amountTo, err := uc.accountAmount(ctx, to, req.Amount, from.GetCurrency(), req.Amount.Div(fromRate.GetRatio()))
+	if err != nil {
+		return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+	}
+
+	transfer := entity.NewTransfer(from.GetID(), to.GetID(), req.Amount, amountTo, req.Date)
+
+	_, err = uc.accountStorage.CreateTransfer(ctx, userID, transfer)
+	if err != nil {
+		return TransferRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Transfer")
+	}
+
+	resp := TransferRespDTO{
+		NotFound:     "",
+		FromCurrency: from.GetCurrency(),
+		AmountTo:     amountTo,
+		ToCurrency:   to.GetCurrency(),
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) GetBalances(ctx context.Context, req GetBalancesReqDTO) (GetBalancesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetBalances")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetBalancesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetBalances")
+	}
+
+	accounts, err := uc.accountStorage.GetAll(ctx, userID)
+	if err != nil {
+		return GetBalancesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetBalances")
+	}
+
+	resp := GetBalancesRespDTO{
+		Accounts: make([]AccountBalanceDTO, 0, len(accounts)),
+	}
+
+	for _, account := range accounts {
+		resp.Accounts = append(resp.Accounts, AccountBalanceDTO{
+			Name:     account.GetName(),
+			Currency: account.GetCurrency(),
+			Balance:  account.GetBalance(),
+		})
+	}
+
+	return resp, nil
+}
+
+// accountAmount переводит сумму amount в валюте currency в валюту счета. base - та же
+// сумма в базовой валюте, через нее идет пересчет между разными валютами.
+func (uc *ExpenseUsecase) accountAmount(ctx context.Context, account entity.Account, amount decimal.Decimal,
+	currency string, base decimal.Decimal,
+) (decimal.Decimal, error) {
+	if account.GetCurrency() == currency {
+		return amount, nil
+	}
+
+	rate, err := uc.currencyStorage.Get(ctx, account.GetCurrency())
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "ExpenseUsecase.accountAmount")
+	}
+
+	return base.Mul(rate.GetRatio()), nil
+}
@@ -0,0 +1,274 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func newAccount(id entity.AccountID, name, currency string) entity.Account {
+	account := entity.NewAccount(name, currency, decimal.Zero)
+	account.SetID(id)
+
+	return account
+}
+
+func TestCreateAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	accountStorage.EXPECT().Create(gomock.Any(), entity.UserID(202),
+		entity.NewAccount("карта", "RUB", decimal.New(1500, 0))).Return(entity.AccountID(4), true, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.CreateAccount(ctx, usecase.CreateAccountReqDTO{
+		UserID:  202,
+		Name:    "Карта",
+		Balance: decimal.New(1500, 0),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.CreateAccountRespDTO{Exists: false, Name: "карта", Currency: "RUB"}, resp)
+}
+
+func TestTransfer_Conversion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+		Return(entity.NewRate("USD", decimal.New(1, -2), time.Now()), nil)
+	accountStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "карта").
+		Return(newAccount(4, "карта", "RUB"), true, nil)
+	accountStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "наличные").
+		Return(newAccount(5, "наличные", "USD"), true, nil)
+	accountStorage.EXPECT().CreateTransfer(gomock.Any(), entity.UserID(202), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, transfer entity.Transfer) (entity.TransferID, error) {
+			assert.Equal(t, entity.AccountID(4), transfer.GetFromAccountID())
+			assert.Equal(t, entity.AccountID(5), transfer.GetToAccountID())
+			assert.True(t, decimal.New(9500, 0).Equal(transfer.GetAmountFrom()))
+			assert.True(t, decimal.New(95, 0).Equal(transfer.GetAmountTo()))
+			assert.Equal(t, date, transfer.GetDate())
+
+			return 1, nil
+		})
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Transfer(ctx, usecase.TransferReqDTO{
+		UserID: 202,
+		From:   "карта",
+		To:     "наличные",
+		Amount: decimal.New(9500, 0),
+		Date:   date,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", resp.NotFound)
+	assert.Equal(t, "RUB", resp.FromCurrency)
+	assert.Equal(t, "USD", resp.ToCurrency)
+	assert.True(t, decimal.New(95, 0).Equal(resp.AmountTo))
+}
+
+func TestTransfer_AccountNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	accountStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "карта").
+		Return(newAccount(4, "карта", "RUB"), true, nil)
+	accountStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "вклад").
+		Return(entity.Account{}, false, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Transfer(ctx, usecase.TransferReqDTO{
+		UserID: 202,
+		From:   "карта",
+		To:     "вклад",
+		Amount: decimal.New(100, 0),
+		Date:   time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "вклад", resp.NotFound)
+}
+
+func TestAddExpense_Account(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+		Return(entity.NewRate("USD", decimal.New(1, -2), time.Now()), nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	accountStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "наличные").
+		Return(newAccount(5, "наличные", "USD"), true, nil)
+	expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) (entity.ExpenseID, bool, error) {
+			assert.Equal(t, entity.AccountID(5), expense.GetAccountID())
+			assert.True(t, decimal.New(5, 0).Equal(expense.GetAccountAmount()))
+
+			return 9, true, nil
+		})
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "еда",
+		Price:    decimal.New(500, 0),
+		Date:     time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC),
+		Account:  "Наличные",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), resp.ID)
+}
+
+func TestGetBalances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	card := newAccount(4, "карта", "RUB")
+	card.SetBalance(decimal.New(12000, 0))
+
+	accountStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return([]entity.Account{card}, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.GetBalances(ctx, usecase.GetBalancesReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.GetBalancesRespDTO{
+		Accounts: []usecase.AccountBalanceDTO{{Name: "карта", Currency: "RUB", Balance: decimal.New(12000, 0)}},
+	}, resp)
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// Общий бюджет объединяет личные данные пользователей, поэтому команды бюджета не
+// зависят от чата, из которого пришли. Данные группового чата общие и без бюджета.
+
+// ErrForbidden действие доступно только владельцу общего бюджета.
+var ErrForbidden = errors.New("only budget owner can do this")
+
+// Длина кода приглашения в байтах, в тексте он вдвое длиннее.
+const inviteCodeLen = 5
+
+func (uc *ExpenseUsecase) GetBudget(ctx context.Context, req GetBudgetReqDTO) (GetBudgetRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetBudget")
+	defer span.End()
+
+	ownerID, isMember, err := uc.personalBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetBudget")
+	}
+
+	budget, _, err := uc.budgetStorage.Get(ctx, ownerID)
+	if err != nil {
+		return GetBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetBudget")
+	}
+
+	resp := GetBudgetRespDTO{
+		OwnerID: int64(ownerID),
+		IsOwner: !isMember,
+		Members: make([]int64, 0, len(budget.GetMembers())),
+	}
+
+	// Код приглашения знает только владелец
+	if !isMember {
+		resp.InviteCode = budget.GetInviteCode()
+	}
+
+	for _, memberID := range budget.GetMembers() {
+		resp.Members = append(resp.Members, int64(memberID))
+	}
+
+	return resp, nil
+}
+
+// InviteToBudget выдает новый код приглашения, старый перестает действовать.
+func (uc *ExpenseUsecase) InviteToBudget(ctx context.Context, req InviteToBudgetReqDTO,
+) (InviteToBudgetRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "InviteToBudget")
+	defer span.End()
+
+	ownerID, err := uc.personalOwnerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return InviteToBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.InviteToBudget")
+	}
+
+	code := make([]byte, inviteCodeLen)
+
+	_, err = rand.Read(code)
+	if err != nil {
+		return InviteToBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.InviteToBudget")
+	}
+
+	inviteCode := hex.EncodeToString(code)
+
+	err = uc.budgetStorage.UpdateInviteCode(ctx, ownerID, inviteCode)
+	if err != nil {
+		return InviteToBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.InviteToBudget")
+	}
+
+	return InviteToBudgetRespDTO{InviteCode: inviteCode}, nil
+}
+
+// JoinBudget добавляет пользователя в бюджет по коду приглашения. Владелец бюджета
+// с участниками вступить в другой не может, иначе участники останутся без бюджета.
+func (uc *ExpenseUsecase) JoinBudget(ctx context.Context, req JoinBudgetReqDTO) (JoinBudgetRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "JoinBudget")
+	defer span.End()
+
+	userID := entity.UserID(req.UserID)
+
+	budget, _, err := uc.budgetStorage.Get(ctx, userID)
+	if err != nil {
+		return JoinBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.JoinBudget")
+	}
+
+	if len(budget.GetMembers()) != 0 {
+		return JoinBudgetRespDTO{HasMembers: true}, nil
+	}
+
+	ownerID, found, err := uc.budgetStorage.Join(ctx, userID, req.InviteCode)
+	if err != nil {
+		return JoinBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.JoinBudget")
+	}
+
+	resp := JoinBudgetRespDTO{
+		Found:   found,
+		OwnerID: int64(ownerID),
+	}
+
+	return resp, nil
+}
+
+// LeaveBudget выводит участника из общего бюджета, он снова работает со своими данными.
+func (uc *ExpenseUsecase) LeaveBudget(ctx context.Context, req LeaveBudgetReqDTO) (LeaveBudgetRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "LeaveBudget")
+	defer span.End()
+
+	ownerID, isMember, err := uc.personalBudgetUserID(ctx, req.UserID)
+	if err != nil || !isMember {
+		return LeaveBudgetRespDTO{}, errors.Wrap(err, "ExpenseUsecase.LeaveBudget")
+	}
+
+	found, err := uc.budgetStorage.DeleteMember(ctx, ownerID, entity.UserID(req.UserID))
+
+	return LeaveBudgetRespDTO{Found: found}, errors.Wrap(err, "ExpenseUsecase.LeaveBudget")
+}
+
+// RemoveBudgetMember исключает участника. Его расходы остаются в общем бюджете.
+func (uc *ExpenseUsecase) RemoveBudgetMember(ctx context.Context, req RemoveBudgetMemberReqDTO,
+) (RemoveBudgetMemberRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "RemoveBudgetMember")
+	defer span.End()
+
+	ownerID, err := uc.personalOwnerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return RemoveBudgetMemberRespDTO{}, errors.Wrap(err, "ExpenseUsecase.RemoveBudgetMember")
+	}
+
+	found, err := uc.budgetStorage.DeleteMember(ctx, ownerID, entity.UserID(req.MemberID))
+
+	return RemoveBudgetMemberRespDTO{Found: found}, errors.Wrap(err, "ExpenseUsecase.RemoveBudgetMember")
+}
+
+// budgetUserID возвращает ID, под которым хранятся данные пользователя: ID группового
+// чата, из которого пришла команда, ID владельца общего бюджета, если пользователь в
+// нем участник, иначе его собственный. Второе значение - данные не принадлежат пользователю.
+func (uc *ExpenseUsecase) budgetUserID(ctx context.Context, userID int64) (entity.UserID, bool, error) {
+	if chatID, ok := groupChatID(ctx, userID); ok {
+		return entity.UserID(chatID), true, nil
+	}
+
+	return uc.personalBudgetUserID(ctx, userID)
+}
+
+// personalBudgetUserID то же, что budgetUserID, без учета группового чата.
+func (uc *ExpenseUsecase) personalBudgetUserID(ctx context.Context, userID int64) (entity.UserID, bool, error) {
+	ownerID, isMember, err := uc.budgetStorage.GetOwner(ctx, entity.UserID(userID))
+	if err != nil {
+		return 0, false, errors.Wrap(err, "ExpenseUsecase.personalBudgetUserID")
+	}
+
+	if !isMember {
+		return entity.UserID(userID), false, nil
+	}
+
+	return ownerID, true, nil
+}
+
+// ownerBudgetUserID то же, что budgetUserID, для действий, которые меняют настройки
+// бюджета. Участнику общего бюджета они запрещены, в групповом чате доступны всем.
+func (uc *ExpenseUsecase) ownerBudgetUserID(ctx context.Context, userID int64) (entity.UserID, error) {
+	if chatID, ok := groupChatID(ctx, userID); ok {
+		return entity.UserID(chatID), nil
+	}
+
+	return uc.personalOwnerBudgetUserID(ctx, userID)
+}
+
+// personalOwnerBudgetUserID то же, что ownerBudgetUserID, без учета группового чата.
+func (uc *ExpenseUsecase) personalOwnerBudgetUserID(ctx context.Context, userID int64) (entity.UserID, error) {
+	budgetUserID, isMember, err := uc.personalBudgetUserID(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "ExpenseUsecase.personalOwnerBudgetUserID")
+	}
+
+	if isMember {
+		return 0, ErrForbidden
+	}
+
+	return budgetUserID, nil
+}
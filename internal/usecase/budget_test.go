@@ -0,0 +1,342 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func TestAddExpense_BudgetMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), entity.UserID(202)).Return(entity.UserID(101), true, nil)
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("RUB", nil)
+	// Расход сохраняется в бюджет владельца с отметкой участника
+	expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(101), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) (entity.ExpenseID, bool, error) {
+			assert.Equal(t, entity.UserID(202), expense.GetMemberID())
+
+			return 17, true, nil
+		})
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(101)).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "такси",
+		Price:    decimal.New(300, 0),
+		Date:     date,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(17), resp.ID)
+}
+
+func TestSetLimit_BudgetMemberForbidden(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), entity.UserID(202)).Return(entity.UserID(101), true, nil)
+
+	userStorage.EXPECT().GetLocale(gomock.Any(), entity.UserID(202)).Return("", nil).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	facade := usecase.New(expenseUsecase)
+
+	cmd := usecase.Command{
+		MessageInfo: usecase.MessageInfo{UserID: 202, Date: time.Now()},
+		Name:        usecase.SetLimitCmdName,
+		SetLimitReqDTO: &usecase.SetLimitReqDTO{
+			UserID:       202,
+			Limit:        decimal.New(1000, 0),
+			IntervalType: 1,
+		},
+	}
+
+	err := facade.ExecuteCommand(ctx, &cmd)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	assert.True(t, cmd.Forbidden)
+}
+
+func TestDeleteExpense_BudgetMemberOtherExpense(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	// Расход добавил владелец, участник удалить его не может
+	expense := entity.NewExpense("такси", decimal.New(300, 0), time.Now())
+	expense.SetID(7)
+
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), entity.UserID(202)).Return(entity.UserID(101), true, nil)
+	expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(101), entity.ExpenseID(7)).Return(expense, true, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.DeleteExpense(ctx, usecase.DeleteExpenseReqDTO{UserID: 202, ID: 7})
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestGetReport_BudgetMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	req := usecase.GetReportReqDTO{
+		UserID:    202,
+		DateStart: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		DateEnd:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		ByMember:  true,
+	}
+
+	budgetReq := req
+	budgetReq.UserID = 101
+
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), entity.UserID(202)).Return(entity.UserID(101), true, nil)
+	reportClient.EXPECT().GetReport(gomock.Any(), budgetReq).Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.GetReport(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "RUB", resp.Currency)
+}
+
+func TestGetBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	budget := entity.NewBudget(101, "abc123", []entity.UserID{202, 303})
+
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID entity.UserID) (entity.UserID, bool, error) {
+			return 101, userID != 101, nil
+		}).Times(2)
+	budgetStorage.EXPECT().Get(gomock.Any(), entity.UserID(101)).Return(budget, true, nil).Times(2)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.GetBudget(ctx, usecase.GetBudgetReqDTO{UserID: 101})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.GetBudgetRespDTO{
+		OwnerID:    101,
+		IsOwner:    true,
+		InviteCode: "abc123",
+		Members:    []int64{202, 303},
+	}, resp)
+
+	// Участник не видит код приглашения
+	resp, err = expenseUsecase.GetBudget(ctx, usecase.GetBudgetReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.GetBudgetRespDTO{
+		OwnerID: 101,
+		IsOwner: false,
+		Members: []int64{202, 303},
+	}, resp)
+}
+
+func TestJoinBudget_OwnerWithMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	budgetStorage.EXPECT().Get(gomock.Any(), entity.UserID(101)).
+		Return(entity.NewBudget(101, "abc123", []entity.UserID{202}), true, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.JoinBudget(ctx, usecase.JoinBudgetReqDTO{UserID: 101, InviteCode: "def456"})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.JoinBudgetRespDTO{HasMembers: true}, resp)
+}
+
+func TestInviteToBudget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	var inviteCode string
+
+	budgetStorage.EXPECT().UpdateInviteCode(gomock.Any(), entity.UserID(101), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, code string) error {
+			inviteCode = code
+
+			return nil
+		})
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.InviteToBudget(ctx, usecase.InviteToBudgetReqDTO{UserID: 101})
+	assert.NoError(t, err)
+	assert.Len(t, resp.InviteCode, 10)
+	assert.Equal(t, inviteCode, resp.InviteCode)
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// CategoryPathSeparator разделяет родительскую и дочернюю категории: "транспорт/такси".
+const CategoryPathSeparator = "/"
+
+var ErrCategoryCycle = errors.New("category can not be moved into its subcategory")
+
+func (uc *ExpenseUsecase) CreateCategory(ctx context.Context, req CreateCategoryReqDTO) (CreateCategoryRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "CreateCategory")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return CreateCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.CreateCategory")
+	}
+
+	categories, err := uc.categoryStorage.GetAll(ctx, userID)
+	if err != nil {
+		return CreateCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.CreateCategory")
+	}
+
+	tree := newCategoryTree(categories)
+
+	var parentID entity.CategoryID
+
+	for _, name := range req.Path {
+		name = strings.ToLower(name)
+
+		if category, ok := tree.byName[name]; ok && tree.isAncestor(category.GetID(), parentID) {
+			return CreateCategoryRespDTO{}, errors.Wrap(ErrCategoryCycle, "ExpenseUsecase.CreateCategory")
+		}
+
+		category := entity.NewCategory(name, parentID)
+
+		id, err := uc.categoryStorage.Create(ctx, userID, category)
+		if err != nil {
+			return CreateCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.CreateCategory")
+		}
+
+		if existing, ok := tree.byName[name]; ok && parentID == 0 {
+			category = entity.NewCategory(name, existing.GetParentID())
+		}
+
+		category.SetID(id)
+		tree.add(category)
+
+		parentID = id
+	}
+
+	uc.deleteAllReportsFromCache(int64(userID))
+
+	return CreateCategoryRespDTO{Path: tree.path(parentID)}, nil
+}
+
+func (uc *ExpenseUsecase) AddCategoryAlias(ctx context.Context, req AddCategoryAliasReqDTO,
+) (AddCategoryAliasRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddCategoryAlias")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return AddCategoryAliasRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddCategoryAlias")
+	}
+
+	category, found, err := uc.categoryStorage.GetByName(ctx, userID, req.Category)
+	if err != nil {
+		return AddCategoryAliasRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddCategoryAlias")
+	}
+
+	if !found {
+		return AddCategoryAliasRespDTO{Found: false}, nil //nolint:exhaustruct
+	}
+
+	err = uc.categoryStorage.AddAlias(ctx, userID, req.Alias, category.GetID())
+	if err != nil {
+		return AddCategoryAliasRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddCategoryAlias")
+	}
+
+	resp := AddCategoryAliasRespDTO{
+		Found:    true,
+		Category: category.GetName(),
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) RenameCategory(ctx context.Context, req RenameCategoryReqDTO) (RenameCategoryRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "RenameCategory")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return RenameCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.RenameCategory")
+	}
+
+	category, found, err := uc.categoryStorage.GetByName(ctx, userID, req.Category)
+	if err != nil {
+		return RenameCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.RenameCategory")
+	}
+
+	if !found {
+		return RenameCategoryRespDTO{Found: false}, nil //nolint:exhaustruct
+	}
+
+	err = uc.categoryStorage.Rename(ctx, userID, category.GetID(), req.Name)
+	if err != nil {
+		return RenameCategoryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.RenameCategory")
+	}
+
+	uc.deleteAllReportsFromCache(int64(userID))
+
+	return RenameCategoryRespDTO{Found: true}, nil
+}
+
+func (uc *ExpenseUsecase) MergeCategories(ctx context.Context, req MergeCategoriesReqDTO,
+) (MergeCategoriesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "MergeCategories")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return MergeCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.MergeCategories")
+	}
+
+	from, fromFound, err := uc.categoryStorage.GetByName(ctx, userID, req.From)
+	if err != nil {
+		return MergeCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.MergeCategories")
+	}
+
+	to, toFound, err := uc.categoryStorage.GetByName(ctx, userID, req.To)
+	if err != nil {
+		return MergeCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.MergeCategories")
+	}
+
+	if !fromFound || !toFound {
+		return MergeCategoriesRespDTO{Found: false}, nil //nolint:exhaustruct
+	}
+
+	if from.GetID() == to.GetID() {
+		return MergeCategoriesRespDTO{Found: true, Category: to.GetName()}, nil
+	}
+
+	categories, err := uc.categoryStorage.GetAll(ctx, userID)
+	if err != nil {
+		return MergeCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.MergeCategories")
+	}
+
+	// Подкатегории переходят к новой категории, поэтому она не может быть потомком старой
+	if newCategoryTree(categories).isAncestor(from.GetID(), to.GetID()) {
+		return MergeCategoriesRespDTO{}, errors.Wrap(ErrCategoryCycle, "ExpenseUsecase.MergeCategories")
+	}
+
+	err = uc.categoryStorage.Merge(ctx, userID, from.GetID(), to.GetID())
+	if err != nil {
+		return MergeCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.MergeCategories")
+	}
+
+	uc.deleteAllReportsFromCache(int64(userID))
+
+	resp := MergeCategoriesRespDTO{
+		Found:    true,
+		Category: to.GetName(),
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) GetCategories(ctx context.Context, req GetCategoriesReqDTO) (GetCategoriesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetCategories")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetCategories")
+	}
+
+	categories, err := uc.categoryStorage.GetAll(ctx, userID)
+	if err != nil {
+		return GetCategoriesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetCategories")
+	}
+
+	tree := newCategoryTree(categories)
+
+	resp := GetCategoriesRespDTO{
+		Categories: make([]CategoryDTO, 0, len(categories)),
+	}
+
+	for _, category := range categories {
+		resp.Categories = append(resp.Categories, CategoryDTO{
+			Path:    tree.path(category.GetID()),
+			Aliases: category.GetAliases(),
+		})
+	}
+
+	sort.Slice(resp.Categories, func(i, j int) bool {
+		return resp.Categories[i].Path < resp.Categories[j].Path
+	})
+
+	return resp, nil
+}
+
+// ---- helpers
+
+type categoryTree struct {
+	byID   map[entity.CategoryID]entity.Category
+	byName map[string]entity.Category
+}
+
+func newCategoryTree(categories []entity.Category) categoryTree {
+	tree := categoryTree{
+		byID:   make(map[entity.CategoryID]entity.Category, len(categories)),
+		byName: make(map[string]entity.Category, len(categories)),
+	}
+
+	for _, category := range categories {
+		tree.add(category)
+	}
+
+	return tree
+}
+
+func (t categoryTree) add(category entity.Category) {
+	t.byID[category.GetID()] = category
+	t.byName[category.GetName()] = category
+}
+
+// isAncestor проверяет, что категория ancestorID совпадает с id или является ее предком.
+func (t categoryTree) isAncestor(ancestorID, id entity.CategoryID) bool {
+	for steps := 0; id != 0 && steps <= len(t.byID); steps++ {
+		if id == ancestorID {
+			return true
+		}
+
+		category := t.byID[id]
+		id = category.GetParentID()
+	}
+
+	return false
+}
+
+// contains проверяет, что категория name совпадает с parent или вложена в нее.
+func (t categoryTree) contains(parent, name string) bool {
+	parent, name = strings.ToLower(parent), strings.ToLower(name)
+	if parent == name {
+		return true
+	}
+
+	parentCategory, ok := t.byName[parent]
+	if !ok {
+		return false
+	}
+
+	category, ok := t.byName[name]
+
+	return ok && t.isAncestor(parentCategory.GetID(), category.GetID())
+}
+
+func (t categoryTree) path(id entity.CategoryID) string {
+	var names []string
+
+	for steps := 0; id != 0 && steps <= len(t.byID); steps++ {
+		category, ok := t.byID[id]
+		if !ok {
+			break
+		}
+
+		names = append([]string{category.GetName()}, names...)
+		id = category.GetParentID()
+	}
+
+	return strings.Join(names, CategoryPathSeparator)
+}
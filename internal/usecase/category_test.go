@@ -0,0 +1,233 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func newCategory(id entity.CategoryID, name string, parentID entity.CategoryID) entity.Category {
+	category := entity.NewCategory(name, parentID)
+	category.SetID(id)
+
+	return category
+}
+
+func newCategoryUsecase(t *testing.T) (*usecase.ExpenseUsecase, *mock_usecase.MockICategoryStorage) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	return expenseUsecase, categoryStorage
+}
+
+func TestCreateCategory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	gomock.InOrder(
+		categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Category{newCategory(3, "транспорт", 0)}, nil),
+		categoryStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), entity.NewCategory("транспорт", 0)).
+			Return(entity.CategoryID(3), nil),
+		categoryStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), entity.NewCategory("такси", 3)).
+			Return(entity.CategoryID(5), nil),
+	)
+
+	resp, err := expenseUsecase.CreateCategory(ctx, usecase.CreateCategoryReqDTO{
+		UserID: 202,
+		Path:   []string{"Транспорт", "Такси"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.CreateCategoryRespDTO{Path: "транспорт/такси"}, resp)
+}
+
+func TestCreateCategory_Cycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	gomock.InOrder(
+		categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Category{newCategory(3, "транспорт", 0), newCategory(5, "такси", 3)}, nil),
+		categoryStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), entity.NewCategory("такси", 0)).
+			Return(entity.CategoryID(5), nil),
+	)
+
+	_, err := expenseUsecase.CreateCategory(ctx, usecase.CreateCategoryReqDTO{
+		UserID: 202,
+		Path:   []string{"такси", "транспорт"},
+	})
+	assert.ErrorIs(t, err, usecase.ErrCategoryCycle)
+}
+
+func TestMergeCategories(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	gomock.InOrder(
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "еда").
+			Return(newCategory(7, "еда", 0), true, nil),
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "продукты").
+			Return(newCategory(8, "продукты", 0), true, nil),
+		categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Category{newCategory(7, "еда", 0), newCategory(8, "продукты", 0)}, nil),
+		categoryStorage.EXPECT().Merge(gomock.Any(), entity.UserID(202), entity.CategoryID(7), entity.CategoryID(8)).
+			Return(nil),
+	)
+
+	resp, err := expenseUsecase.MergeCategories(ctx, usecase.MergeCategoriesReqDTO{
+		UserID: 202,
+		From:   "еда",
+		To:     "продукты",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.MergeCategoriesRespDTO{Found: true, Category: "продукты"}, resp)
+}
+
+func TestMergeCategories_IntoSubcategory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	gomock.InOrder(
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "транспорт").
+			Return(newCategory(3, "транспорт", 0), true, nil),
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "такси").
+			Return(newCategory(5, "такси", 3), true, nil),
+		categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Category{newCategory(3, "транспорт", 0), newCategory(5, "такси", 3)}, nil),
+	)
+
+	_, err := expenseUsecase.MergeCategories(ctx, usecase.MergeCategoriesReqDTO{
+		UserID: 202,
+		From:   "транспорт",
+		To:     "такси",
+	})
+	assert.ErrorIs(t, err, usecase.ErrCategoryCycle)
+}
+
+func TestMergeCategories_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	gomock.InOrder(
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "еда").
+			Return(newCategory(7, "еда", 0), true, nil),
+		categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "продукты").
+			Return(entity.Category{}, false, nil),
+	)
+
+	resp, err := expenseUsecase.MergeCategories(ctx, usecase.MergeCategoriesReqDTO{
+		UserID: 202,
+		From:   "еда",
+		To:     "продукты",
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.Found)
+}
+
+func TestRenameCategory_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "еда").
+		Return(entity.Category{}, false, nil)
+
+	resp, err := expenseUsecase.RenameCategory(ctx, usecase.RenameCategoryReqDTO{
+		UserID:   202,
+		Category: "еда",
+		Name:     "продукты",
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.Found)
+}
+
+// Ошибка хранилища не выдается за отсутствие категории.
+func TestAddCategoryAlias_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	categoryStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "еда").
+		Return(entity.Category{}, false, errUnknown)
+
+	_, err := expenseUsecase.AddCategoryAlias(ctx, usecase.AddCategoryAliasReqDTO{
+		UserID:   202,
+		Category: "еда",
+		Alias:    "жратва",
+	})
+	assert.ErrorIs(t, err, errUnknown)
+}
+
+func TestGetCategories(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, categoryStorage := newCategoryUsecase(t)
+
+	taxi := newCategory(5, "такси", 3)
+	taxi.SetAliases([]string{"taxi"})
+
+	categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+		Return([]entity.Category{taxi, newCategory(3, "транспорт", 0), newCategory(7, "еда", 0)}, nil)
+
+	resp, err := expenseUsecase.GetCategories(ctx, usecase.GetCategoriesReqDTO{UserID: 202})
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.GetCategoriesRespDTO{
+		Categories: []usecase.CategoryDTO{
+			{Path: "еда"},
+			{Path: "транспорт"},
+			{Path: "транспорт/такси", Aliases: []string{"taxi"}},
+		},
+	}, resp)
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import "context"
+
+type chatIDKey struct{}
+
+// WithChatID сохраняет в контексте чат, из которого пришла команда.
+func WithChatID(ctx context.Context, chatID int64) context.Context {
+	return context.WithValue(ctx, chatIDKey{}, chatID)
+}
+
+func chatIDFromContext(ctx context.Context) int64 {
+	chatID, _ := ctx.Value(chatIDKey{}).(int64)
+
+	return chatID
+}
+
+// groupChatID возвращает групповой чат, из которого пришла команда. ID личного чата
+// в Telegram совпадает с ID пользователя, а команды без чата приходят от воркеров.
+func groupChatID(ctx context.Context, userID int64) (int64, bool) {
+	chatID := chatIDFromContext(ctx)
+
+	return chatID, chatID != 0 && chatID != userID
+}
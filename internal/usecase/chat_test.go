@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func TestAddExpense_GroupChat(t *testing.T) {
+	t.Parallel()
+
+	// Групповой чат в Telegram имеет отрицательный ID
+	ctx := usecase.WithChatID(context.Background(), -500)
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(-500)).Return("RUB", nil)
+	// Расход сохраняется в данные чата с отметкой автора, личный бюджет автора не важен
+	expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(-500), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) (entity.ExpenseID, bool, error) {
+			assert.Equal(t, entity.UserID(202), expense.GetMemberID())
+
+			return 17, true, nil
+		})
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(-500)).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "такси",
+		Price:    decimal.New(300, 0),
+		Date:     date,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(17), resp.ID)
+}
+
+func TestSetLimit_GroupChat(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(-500)).Return("RUB", nil)
+	// Настройки чата меняет любой его участник, даже участник чужого общего бюджета
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(-500)).Return(nil, nil)
+	limitStorage.EXPECT().Update(gomock.Any(), entity.UserID(-500), gomock.Any()).Return(nil)
+
+	userStorage.EXPECT().GetLocale(gomock.Any(), entity.UserID(202)).Return("", nil).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	facade := usecase.New(expenseUsecase)
+
+	cmd := usecase.Command{
+		MessageInfo: usecase.MessageInfo{UserID: 202, ChatID: -500, Date: time.Now()},
+		Name:        usecase.SetLimitCmdName,
+		SetLimitReqDTO: &usecase.SetLimitReqDTO{
+			UserID:       202,
+			Limit:        decimal.New(1000, 0),
+			IntervalType: 1,
+		},
+	}
+
+	err := facade.ExecuteCommand(ctx, &cmd)
+	assert.NoError(t, err)
+	assert.False(t, cmd.Forbidden)
+}
+
+func TestMessageInfo_ReplyChatID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(101), usecase.MessageInfo{UserID: 101}.ReplyChatID())
+	assert.Equal(t, int64(-500), usecase.MessageInfo{UserID: 101, ChatID: -500}.ReplyChatID())
+}
@@ -4,13 +4,56 @@ const (
 	ReadCmdState    = "read"
 	ProcessCmdState = "process"
 
-	StartCmdName       = "start"
-	HelpCmdName        = "help"
-	AboutCmdName       = "about"
-	SetCurrencyCmdName = "setCurrency"
-	AddExpenseCmdName  = "addExpense"
-	GetReportCmdName   = "getReport"
-	SetLimitCmdName    = "setLimit"
-	GetLimitsCmdName   = "getLimits"
-	UnknownCmdName     = "unknown"
+	StartCmdName                  = "start"
+	HelpCmdName                   = "help"
+	AboutCmdName                  = "about"
+	SetCurrencyCmdName            = "setCurrency"
+	GetCurrenciesCmdName          = "getCurrencies"
+	AddExpenseCmdName             = "addExpense"
+	GetReportCmdName              = "getReport"
+	ReportPeriodsCmdName          = "reportPeriods"
+	SetLimitCmdName               = "setLimit"
+	GetLimitsCmdName              = "getLimits"
+	DeleteExpenseCmdName          = "deleteExpense"
+	UpdateExpenseCmdName          = "updateExpense"
+	CreateCategoryCmdName         = "createCategory"
+	AddCategoryAliasCmdName       = "addCategoryAlias"
+	RenameCategoryCmdName         = "renameCategory"
+	MergeCategoriesCmdName        = "mergeCategories"
+	GetCategoriesCmdName          = "getCategories"
+	SetSummaryCmdName             = "setSummary"
+	SummaryCmdName                = "summary"
+	LimitNotificationCmdName      = "limitNotification"
+	AddRecurringExpenseCmdName    = "addRecurringExpense"
+	GetRecurringExpensesCmdName   = "getRecurringExpenses"
+	DeleteRecurringExpenseCmdName = "deleteRecurringExpense"
+	ExportCmdName                 = "export"
+	SetImportColumnsCmdName       = "setImportColumns"
+	AddImportRuleCmdName          = "addImportRule"
+	DeleteImportRuleCmdName       = "deleteImportRule"
+	GetImportSettingsCmdName      = "getImportSettings"
+	ImportExpensesCmdName         = "importExpenses"
+	DialogCmdName                 = "dialog"
+	CancelDialogCmdName           = "cancelDialog"
+	GetBudgetCmdName              = "getBudget"
+	InviteToBudgetCmdName         = "inviteToBudget"
+	JoinBudgetCmdName             = "joinBudget"
+	LeaveBudgetCmdName            = "leaveBudget"
+	RemoveBudgetMemberCmdName     = "removeBudgetMember"
+	LocalesCmdName                = "locales"
+	SetLocaleCmdName              = "setLocale"
+	ConfirmExpenseCmdName         = "confirmExpense"
+	ReceiptCmdName                = "receipt"
+	UndoCmdName                   = "undo"
+	SearchExpensesCmdName         = "searchExpenses"
+	AddIncomeCmdName              = "addIncome"
+	CreateAccountCmdName          = "createAccount"
+	TransferCmdName               = "transfer"
+	GetBalancesCmdName            = "getBalances"
+	SetGoalCmdName                = "setGoal"
+	ContributeGoalCmdName         = "contributeGoal"
+	GetGoalsCmdName               = "getGoals"
+	DeleteGoalCmdName             = "deleteGoal"
+	GoalNudgeCmdName              = "goalNudge"
+	UnknownCmdName                = "unknown"
 )
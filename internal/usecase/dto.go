@@ -8,22 +8,110 @@ import (
 
 type MessageInfo struct {
 	UserID int64     `json:"user_id,omitempty"`
+	ChatID int64     `json:"chat_id,omitempty"`
 	Date   time.Time `json:"date,omitempty"`
+	// Locale язык ответа. Клиент берет его из настроек Telegram, выбранный пользователем
+	// язык подставляется при выполнении команды.
+	Locale string `json:"locale,omitempty"`
+}
+
+// ReplyChatID возвращает чат для ответа. Без чата ответ отправляется в личный чат пользователя.
+func (m MessageInfo) ReplyChatID() int64 {
+	if m.ChatID != 0 {
+		return m.ChatID
+	}
+
+	return m.UserID
 }
 
 type Command struct {
 	MessageInfo
-	Name                      string                     `json:"name"`
-	SetDefaultCurrencyReqDTO  *SetDefaultCurrencyReqDTO  `json:"set_default_currency_req_dto,omitempty"`
-	SetDefaultCurrencyRespDTO *SetDefaultCurrencyRespDTO `json:"set_default_currency_resp_dto,omitempty"`
-	AddExpenseReqDTO          *AddExpenseReqDTO          `json:"add_expense_req_dto,omitempty"`
-	AddExpenseRespDTO         *AddExpenseRespDTO         `json:"add_expense_resp_dto,omitempty"`
-	GetReportReqDTO           *GetReportReqDTO           `json:"get_report_req_dto,omitempty"`
-	GetReportRespDTO          *GetReportRespDTO          `json:"get_report_resp_dto,omitempty"`
-	SetLimitReqDTO            *SetLimitReqDTO            `json:"set_limit_req_dto,omitempty"`
-	SetLimitRespDTO           *SetLimitRespDTO           `json:"set_limit_resp_dto,omitempty"`
-	GetLimitsReqDTO           *GetLimitsReqDTO           `json:"get_limits_req_dto,omitempty"`
-	GetLimitsRespDTO          *GetLimitsRespDTO          `json:"get_limits_resp_dto,omitempty"`
+	Name                          string                         `json:"name"`
+	SetDefaultCurrencyReqDTO      *SetDefaultCurrencyReqDTO      `json:"set_default_currency_req_dto,omitempty"`
+	SetDefaultCurrencyRespDTO     *SetDefaultCurrencyRespDTO     `json:"set_default_currency_resp_dto,omitempty"`
+	GetCurrenciesReqDTO           *GetCurrenciesReqDTO           `json:"get_currencies_req_dto,omitempty"`
+	GetCurrenciesRespDTO          *GetCurrenciesRespDTO          `json:"get_currencies_resp_dto,omitempty"`
+	AddExpenseReqDTO              *AddExpenseReqDTO              `json:"add_expense_req_dto,omitempty"`
+	AddExpenseRespDTO             *AddExpenseRespDTO             `json:"add_expense_resp_dto,omitempty"`
+	GetReportReqDTO               *GetReportReqDTO               `json:"get_report_req_dto,omitempty"`
+	GetReportRespDTO              *GetReportRespDTO              `json:"get_report_resp_dto,omitempty"`
+	SetLimitReqDTO                *SetLimitReqDTO                `json:"set_limit_req_dto,omitempty"`
+	SetLimitRespDTO               *SetLimitRespDTO               `json:"set_limit_resp_dto,omitempty"`
+	GetLimitsReqDTO               *GetLimitsReqDTO               `json:"get_limits_req_dto,omitempty"`
+	GetLimitsRespDTO              *GetLimitsRespDTO              `json:"get_limits_resp_dto,omitempty"`
+	DeleteExpenseReqDTO           *DeleteExpenseReqDTO           `json:"delete_expense_req_dto,omitempty"`
+	DeleteExpenseRespDTO          *DeleteExpenseRespDTO          `json:"delete_expense_resp_dto,omitempty"`
+	UpdateExpenseReqDTO           *UpdateExpenseReqDTO           `json:"update_expense_req_dto,omitempty"`
+	UpdateExpenseRespDTO          *UpdateExpenseRespDTO          `json:"update_expense_resp_dto,omitempty"`
+	CreateCategoryReqDTO          *CreateCategoryReqDTO          `json:"create_category_req_dto,omitempty"`
+	CreateCategoryRespDTO         *CreateCategoryRespDTO         `json:"create_category_resp_dto,omitempty"`
+	AddCategoryAliasReqDTO        *AddCategoryAliasReqDTO        `json:"add_category_alias_req_dto,omitempty"`
+	AddCategoryAliasRespDTO       *AddCategoryAliasRespDTO       `json:"add_category_alias_resp_dto,omitempty"`
+	RenameCategoryReqDTO          *RenameCategoryReqDTO          `json:"rename_category_req_dto,omitempty"`
+	RenameCategoryRespDTO         *RenameCategoryRespDTO         `json:"rename_category_resp_dto,omitempty"`
+	MergeCategoriesReqDTO         *MergeCategoriesReqDTO         `json:"merge_categories_req_dto,omitempty"`
+	MergeCategoriesRespDTO        *MergeCategoriesRespDTO        `json:"merge_categories_resp_dto,omitempty"`
+	GetCategoriesReqDTO           *GetCategoriesReqDTO           `json:"get_categories_req_dto,omitempty"`
+	GetCategoriesRespDTO          *GetCategoriesRespDTO          `json:"get_categories_resp_dto,omitempty"`
+	SetSummaryReqDTO              *SetSummaryReqDTO              `json:"set_summary_req_dto,omitempty"`
+	SetSummaryRespDTO             *SetSummaryRespDTO             `json:"set_summary_resp_dto,omitempty"`
+	LimitNotificationDTO          *LimitNotificationDTO          `json:"limit_notification_dto,omitempty"`
+	DialogDTO                     *DialogDTO                     `json:"dialog_dto,omitempty"`
+	CancelDialogDTO               *CancelDialogDTO               `json:"cancel_dialog_dto,omitempty"`
+	AddRecurringExpenseReqDTO     *AddRecurringExpenseReqDTO     `json:"add_recurring_expense_req_dto,omitempty"`
+	AddRecurringExpenseRespDTO    *AddRecurringExpenseRespDTO    `json:"add_recurring_expense_resp_dto,omitempty"`
+	GetRecurringExpensesReqDTO    *GetRecurringExpensesReqDTO    `json:"get_recurring_expenses_req_dto,omitempty"`
+	GetRecurringExpensesRespDTO   *GetRecurringExpensesRespDTO   `json:"get_recurring_expenses_resp_dto,omitempty"`
+	DeleteRecurringExpenseReqDTO  *DeleteRecurringExpenseReqDTO  `json:"delete_recurring_expense_req_dto,omitempty"`
+	DeleteRecurringExpenseRespDTO *DeleteRecurringExpenseRespDTO `json:"delete_recurring_expense_resp_dto,omitempty"`
+	ExportReqDTO                  *ExportReqDTO                  `json:"export_req_dto,omitempty"`
+	ExportRespDTO                 *ExportRespDTO                 `json:"export_resp_dto,omitempty"`
+	SetImportColumnsReqDTO        *SetImportColumnsReqDTO        `json:"set_import_columns_req_dto,omitempty"`
+	SetImportColumnsRespDTO       *SetImportColumnsRespDTO       `json:"set_import_columns_resp_dto,omitempty"`
+	AddImportRuleReqDTO           *AddImportRuleReqDTO           `json:"add_import_rule_req_dto,omitempty"`
+	AddImportRuleRespDTO          *AddImportRuleRespDTO          `json:"add_import_rule_resp_dto,omitempty"`
+	DeleteImportRuleReqDTO        *DeleteImportRuleReqDTO        `json:"delete_import_rule_req_dto,omitempty"`
+	DeleteImportRuleRespDTO       *DeleteImportRuleRespDTO       `json:"delete_import_rule_resp_dto,omitempty"`
+	GetImportSettingsReqDTO       *GetImportSettingsReqDTO       `json:"get_import_settings_req_dto,omitempty"`
+	GetImportSettingsRespDTO      *GetImportSettingsRespDTO      `json:"get_import_settings_resp_dto,omitempty"`
+	ImportExpensesReqDTO          *ImportExpensesReqDTO          `json:"import_expenses_req_dto,omitempty"`
+	ImportExpensesRespDTO         *ImportExpensesRespDTO         `json:"import_expenses_resp_dto,omitempty"`
+	GetBudgetReqDTO               *GetBudgetReqDTO               `json:"get_budget_req_dto,omitempty"`
+	GetBudgetRespDTO              *GetBudgetRespDTO              `json:"get_budget_resp_dto,omitempty"`
+	InviteToBudgetReqDTO          *InviteToBudgetReqDTO          `json:"invite_to_budget_req_dto,omitempty"`
+	InviteToBudgetRespDTO         *InviteToBudgetRespDTO         `json:"invite_to_budget_resp_dto,omitempty"`
+	JoinBudgetReqDTO              *JoinBudgetReqDTO              `json:"join_budget_req_dto,omitempty"`
+	JoinBudgetRespDTO             *JoinBudgetRespDTO             `json:"join_budget_resp_dto,omitempty"`
+	LeaveBudgetReqDTO             *LeaveBudgetReqDTO             `json:"leave_budget_req_dto,omitempty"`
+	LeaveBudgetRespDTO            *LeaveBudgetRespDTO            `json:"leave_budget_resp_dto,omitempty"`
+	RemoveBudgetMemberReqDTO      *RemoveBudgetMemberReqDTO      `json:"remove_budget_member_req_dto,omitempty"`
+	RemoveBudgetMemberRespDTO     *RemoveBudgetMemberRespDTO     `json:"remove_budget_member_resp_dto,omitempty"`
+	SetLocaleReqDTO               *SetLocaleReqDTO               `json:"set_locale_req_dto,omitempty"`
+	SetLocaleRespDTO              *SetLocaleRespDTO              `json:"set_locale_resp_dto,omitempty"`
+	ConfirmExpenseDTO             *ConfirmExpenseDTO             `json:"confirm_expense_dto,omitempty"`
+	ReceiptDTO                    *ReceiptDTO                    `json:"receipt_dto,omitempty"`
+	UndoReqDTO                    *UndoReqDTO                    `json:"undo_req_dto,omitempty"`
+	UndoRespDTO                   *UndoRespDTO                   `json:"undo_resp_dto,omitempty"`
+	SearchExpensesReqDTO          *SearchExpensesReqDTO          `json:"search_expenses_req_dto,omitempty"`
+	SearchExpensesRespDTO         *SearchExpensesRespDTO         `json:"search_expenses_resp_dto,omitempty"`
+	AddIncomeReqDTO               *AddIncomeReqDTO               `json:"add_income_req_dto,omitempty"`
+	AddIncomeRespDTO              *AddIncomeRespDTO              `json:"add_income_resp_dto,omitempty"`
+	CreateAccountReqDTO           *CreateAccountReqDTO           `json:"create_account_req_dto,omitempty"`
+	CreateAccountRespDTO          *CreateAccountRespDTO          `json:"create_account_resp_dto,omitempty"`
+	TransferReqDTO                *TransferReqDTO                `json:"transfer_req_dto,omitempty"`
+	TransferRespDTO               *TransferRespDTO               `json:"transfer_resp_dto,omitempty"`
+	GetBalancesReqDTO             *GetBalancesReqDTO             `json:"get_balances_req_dto,omitempty"`
+	GetBalancesRespDTO            *GetBalancesRespDTO            `json:"get_balances_resp_dto,omitempty"`
+	SetGoalReqDTO                 *SetGoalReqDTO                 `json:"set_goal_req_dto,omitempty"`
+	SetGoalRespDTO                *SetGoalRespDTO                `json:"set_goal_resp_dto,omitempty"`
+	ContributeGoalReqDTO          *ContributeGoalReqDTO          `json:"contribute_goal_req_dto,omitempty"`
+	ContributeGoalRespDTO         *ContributeGoalRespDTO         `json:"contribute_goal_resp_dto,omitempty"`
+	GetGoalsReqDTO                *GetGoalsReqDTO                `json:"get_goals_req_dto,omitempty"`
+	GetGoalsRespDTO               *GetGoalsRespDTO               `json:"get_goals_resp_dto,omitempty"`
+	DeleteGoalReqDTO              *DeleteGoalReqDTO              `json:"delete_goal_req_dto,omitempty"`
+	DeleteGoalRespDTO             *DeleteGoalRespDTO             `json:"delete_goal_resp_dto,omitempty"`
+	// Forbidden команду может выполнить только владелец общего бюджета.
+	Forbidden bool `json:"forbidden,omitempty"`
 }
 
 type CommandAddExpense struct {
@@ -39,27 +127,108 @@ type SetDefaultCurrencyReqDTO struct {
 type SetDefaultCurrencyRespDTO struct {
 }
 
+type GetCurrenciesReqDTO struct {
+	UserID int64
+}
+
+// GetCurrenciesRespDTO поддерживаемые валюты, базовая идет первой.
+type GetCurrenciesRespDTO struct {
+	Current    string
+	Currencies []string
+}
+
+// AddExpenseReqDTO расход. ExternalID задается для расходов, добавленных автоматически,
+// повторный запрос с тем же ExternalID не добавляет расход второй раз.
 type AddExpenseReqDTO struct {
-	UserID   int64
+	UserID     int64
+	Category   string
+	Price      decimal.Decimal
+	Currency   string
+	Date       time.Time
+	ExternalID string
+	// Note необязательная заметка к расходу.
+	Note string
+	// Account необязательное имя счета, с которого оплачен расход.
+	Account string
+}
+
+// AddExpenseRespDTO добавленный расход. AccountNotFound - указанного счета нет, расход не добавлен.
+// Duplicate - расход с таким ExternalID уже был добавлен, ID указывает на него.
+type AddExpenseRespDTO struct {
+	ID              int64
+	Price           decimal.Decimal
+	Limits          []LimitDTO
+	Currency        string
+	AccountNotFound bool
+	Duplicate       bool
+}
+
+type DeleteExpenseReqDTO struct {
+	UserID int64
+	ID     int64
+}
+
+type DeleteExpenseRespDTO struct {
+	Found    bool
 	Category string
 	Price    decimal.Decimal
-	Date     time.Time
+	Currency string
 }
 
-type AddExpenseRespDTO struct {
-	Limits   map[int]decimal.Decimal
+// UpdateExpenseReqDTO исправление расхода. С KeepPrice меняется только категория.
+type UpdateExpenseReqDTO struct {
+	UserID    int64
+	ID        int64
+	Category  string
+	Price     decimal.Decimal
+	KeepPrice bool
+}
+
+type UpdateExpenseRespDTO struct {
+	Found    bool
 	Currency string
 }
 
+// GetReportReqDTO отчет за полуинтервал [DateStart, DateEnd). IntervalType равен нулю
+// для произвольного диапазона дат. Ненулевой SeriesInterval запрашивает еще и ряд
+// расходов по дням или месяцам для графика. ByMember добавляет суммы по участникам
+// общего бюджета. CurrentRate пересчитывает расходы по текущему курсу, а не по курсу
+// на дату расхода.
 type GetReportReqDTO struct {
-	UserID       int64
-	Date         time.Time
-	IntervalType int
+	UserID         int64
+	DateStart      time.Time
+	DateEnd        time.Time
+	IntervalType   int
+	Rollup         bool
+	SeriesInterval int
+	ByMember       bool
+	CurrentRate    bool
 }
 
 type GetReportRespDTO struct {
 	Currency string
 	Expenses []ExpenseReportDTO
+	Series   []SeriesPointDTO
+	Members  []MemberReportDTO
+	Incomes  []IncomeReportDTO
+}
+
+// IncomeReportDTO доходы из источника за период отчета.
+type IncomeReportDTO struct {
+	Source string
+	Sum    decimal.Decimal
+}
+
+// MemberReportDTO расходы участника общего бюджета.
+type MemberReportDTO struct {
+	MemberID int64
+	Sum      decimal.Decimal
+}
+
+// SeriesPointDTO расходы за день или месяц, начинающийся с Date.
+type SeriesPointDTO struct {
+	Date time.Time
+	Sum  decimal.Decimal
 }
 
 type UpdateCurrencyReqDTO struct {
@@ -69,11 +238,13 @@ type UpdateCurrencyReqDTO struct {
 
 type SetLimitReqDTO struct {
 	UserID       int64
+	Category     string
 	Limit        decimal.Decimal
 	IntervalType int
 }
 
 type SetLimitRespDTO struct {
+	Category string
 	Currency string
 }
 
@@ -82,13 +253,483 @@ type GetLimitsReqDTO struct {
 }
 
 type GetLimitsRespDTO struct {
-	Limits   map[int]decimal.Decimal
+	Limits   []LimitDTO
+	Currency string
+}
+
+// LimitDTO лимит на категорию за интервал. Пустая категория - лимит на все расходы.
+// В ответе на добавление расхода Limit - остаток, отрицательный при превышении.
+type LimitDTO struct {
+	Category     string
+	IntervalType int
+	Limit        decimal.Decimal
+}
+
+// DialogDTO очередной вопрос диалога. Retry - предыдущий ответ не подошел, вопрос задан повторно.
+type DialogDTO struct {
+	Question string
+	Retry    bool
+}
+
+// CancelDialogDTO отмена диалога. Found - был ли незаконченный диалог.
+type CancelDialogDTO struct {
+	Found bool
+}
+
+// LimitNotificationDTO уведомление о том, что расходы за интервал достигли Threshold процентов лимита.
+type LimitNotificationDTO struct {
+	Category     string
+	IntervalType int
+	Threshold    int
+	Spent        decimal.Decimal
+	Limit        decimal.Decimal
+	Currency     string
+}
+
+// SetSummaryReqDTO подписка на сводку расходов. Нулевой IntervalType отключает сводку.
+type SetSummaryReqDTO struct {
+	UserID       int64
+	IntervalType int
+}
+
+type SetSummaryRespDTO struct{}
+
+type AddRecurringExpenseReqDTO struct {
+	UserID       int64
+	Category     string
+	Price        decimal.Decimal
+	Currency     string
+	IntervalType int
+	DateStart    time.Time
+}
+
+type AddRecurringExpenseRespDTO struct {
+	ID       int64
+	Category string
 	Currency string
 }
 
+type GetRecurringExpensesReqDTO struct {
+	UserID int64
+}
+
+type GetRecurringExpensesRespDTO struct {
+	RecurringExpenses []RecurringExpenseDTO
+}
+
+type RecurringExpenseDTO struct {
+	ID           int64
+	Category     string
+	Price        decimal.Decimal
+	Currency     string
+	IntervalType int
+	NextDate     time.Time
+}
+
+type DeleteRecurringExpenseReqDTO struct {
+	UserID int64
+	ID     int64
+}
+
+type DeleteRecurringExpenseRespDTO struct {
+	Found bool
+}
+
+// ExportReqDTO выгрузка расходов за полуинтервал [DateStart, DateEnd). Нулевая
+// DateStart - выгрузка с самого первого расхода.
+type ExportReqDTO struct {
+	UserID       int64
+	DateStart    time.Time
+	DateEnd      time.Time
+	IntervalType int
+}
+
+// ExportRespDTO выгрузка. TooLarge - файлы не помещаются в сообщение и не отправляются.
+type ExportRespDTO struct {
+	Count    int
+	TooLarge bool
+	Files    []FileDTO
+}
+
+// FileDTO файл, который отправляется пользователю документом. Передается через
+// kafka вместе с командой, поэтому размер ограничен размером сообщения.
+type FileDTO struct {
+	Name string
+	Data []byte
+}
+
+// SetImportColumnsReqDTO номера колонок выписки, начиная с единицы. Нулевые Date и Amount
+// возвращают определение колонок по заголовку, нулевые Description и Currency - колонки нет.
+type SetImportColumnsReqDTO struct {
+	UserID      int64
+	Date        int
+	Amount      int
+	Description int
+	Currency    int
+}
+
+type SetImportColumnsRespDTO struct{}
+
+type AddImportRuleReqDTO struct {
+	UserID   int64
+	Keyword  string
+	Category string
+}
+
+type AddImportRuleRespDTO struct {
+	Category string
+}
+
+type DeleteImportRuleReqDTO struct {
+	UserID  int64
+	Keyword string
+}
+
+type DeleteImportRuleRespDTO struct {
+	Found bool
+}
+
+type GetImportSettingsReqDTO struct {
+	UserID int64
+}
+
+// GetImportSettingsRespDTO настройки импорта. Нулевая колонка Date - колонки определяются по заголовку.
+type GetImportSettingsRespDTO struct {
+	Date        int
+	Amount      int
+	Description int
+	Currency    int
+	Rules       []ImportRuleDTO
+}
+
+type ImportRuleDTO struct {
+	Keyword  string
+	Category string
+}
+
+type ImportExpensesReqDTO struct {
+	UserID   int64
+	FileName string
+	Data     []byte
+}
+
+// ImportExpensesRespDTO итог импорта. Done ложно, если файл не удалось разобрать.
+// Duplicates - операции, загруженные ранее, Skipped - строки, которые не удалось разобрать.
+type ImportExpensesRespDTO struct {
+	Done       bool
+	Imported   int
+	Duplicates int
+	Skipped    int
+}
+
 // ----
 
 type ExpenseReportDTO struct {
 	Category string
 	Sum      decimal.Decimal
 }
+
+type CreateCategoryReqDTO struct {
+	UserID int64
+	Path   []string
+}
+
+type CreateCategoryRespDTO struct {
+	Path string
+}
+
+type AddCategoryAliasReqDTO struct {
+	UserID   int64
+	Alias    string
+	Category string
+}
+
+type AddCategoryAliasRespDTO struct {
+	Found    bool
+	Category string
+}
+
+type RenameCategoryReqDTO struct {
+	UserID   int64
+	Category string
+	Name     string
+}
+
+type RenameCategoryRespDTO struct {
+	Found bool
+}
+
+type MergeCategoriesReqDTO struct {
+	UserID int64
+	From   string
+	To     string
+}
+
+type MergeCategoriesRespDTO struct {
+	Found    bool
+	Category string
+}
+
+// GetCategoriesReqDTO список категорий. Ненулевой ExpenseID означает, что из списка
+// выбирается новая категория для этого расхода.
+type GetCategoriesReqDTO struct {
+	UserID    int64
+	ExpenseID int64
+}
+
+type GetCategoriesRespDTO struct {
+	Categories []CategoryDTO
+}
+
+type CategoryDTO struct {
+	Path    string
+	Aliases []string
+}
+
+type GetBudgetReqDTO struct {
+	UserID int64
+}
+
+// GetBudgetRespDTO общий бюджет пользователя. Без бюджета OwnerID - сам пользователь,
+// участников нет. Код приглашения возвращается только владельцу.
+type GetBudgetRespDTO struct {
+	OwnerID    int64
+	IsOwner    bool
+	InviteCode string
+	Members    []int64
+}
+
+type InviteToBudgetReqDTO struct {
+	UserID int64
+}
+
+type InviteToBudgetRespDTO struct {
+	InviteCode string
+}
+
+type JoinBudgetReqDTO struct {
+	UserID     int64
+	InviteCode string
+}
+
+// JoinBudgetRespDTO вступление в бюджет. Found - код действителен, HasMembers - у
+// пользователя свой бюджет с участниками, и вступить в другой он не может.
+type JoinBudgetRespDTO struct {
+	Found      bool
+	HasMembers bool
+	OwnerID    int64
+}
+
+type LeaveBudgetReqDTO struct {
+	UserID int64
+}
+
+type LeaveBudgetRespDTO struct {
+	Found bool
+}
+
+type RemoveBudgetMemberReqDTO struct {
+	UserID   int64
+	MemberID int64
+}
+
+type RemoveBudgetMemberRespDTO struct {
+	Found bool
+}
+
+type SetLocaleReqDTO struct {
+	UserID int64
+	Locale string
+}
+
+type SetLocaleRespDTO struct {
+	Locale string
+}
+
+// ConfirmExpenseDTO варианты прочтения сообщения свободной формы, из которых
+// пользователь выбирает нужный расход.
+type ConfirmExpenseDTO struct {
+	Variants []AddExpenseReqDTO
+}
+
+// ReceiptDTO расход по фото чека. Found - на фото нашелся QR-код чека с суммой и датой.
+type ReceiptDTO struct {
+	Found bool
+	Price decimal.Decimal
+	Date  time.Time
+}
+
+type UndoReqDTO struct {
+	UserID int64
+}
+
+// UndoRespDTO отмененное действие. Found - было что отменять. Amount - сумма расхода или
+// восстановленный лимит, нулевой, если лимита не было. Currency - валюта суммы, а при
+// отмене смены валюты - восстановленная валюта.
+type UndoRespDTO struct {
+	Found        bool
+	Action       string
+	Category     string
+	IntervalType int
+	Amount       decimal.Decimal
+	Currency     string
+}
+
+// SearchExpensesReqDTO поиск по заметкам и категориям. Нулевая DateStart - с начала учета.
+type SearchExpensesReqDTO struct {
+	UserID       int64
+	Query        string
+	DateStart    time.Time
+	DateEnd      time.Time
+	IntervalType int
+}
+
+// SearchExpensesRespDTO найденные расходы, сначала более поздние. HasMore - найдено
+// больше, чем показано.
+type SearchExpensesRespDTO struct {
+	Currency string
+	Expenses []FoundExpenseDTO
+	HasMore  bool
+}
+
+type FoundExpenseDTO struct {
+	ID       int64
+	Category string
+	Note     string
+	Price    decimal.Decimal
+	Date     time.Time
+}
+
+// AddIncomeReqDTO доход из источника Source. Currency - валюта суммы, если отличается
+// от валюты пользователя.
+type AddIncomeReqDTO struct {
+	UserID   int64
+	Source   string
+	Amount   decimal.Decimal
+	Currency string
+	Date     time.Time
+	// Account необязательное имя счета зачисления.
+	Account string
+}
+
+// AddIncomeRespDTO добавленный доход. AccountNotFound - указанного счета нет, доход не добавлен.
+type AddIncomeRespDTO struct {
+	ID              int64
+	Amount          decimal.Decimal
+	Currency        string
+	AccountNotFound bool
+}
+
+// CreateAccountReqDTO новый счет. Пустая Currency - валюта пользователя, Balance - начальный остаток.
+type CreateAccountReqDTO struct {
+	UserID   int64
+	Name     string
+	Currency string
+	Balance  decimal.Decimal
+}
+
+// CreateAccountRespDTO добавленный счет. Exists - счет с таким именем уже есть.
+type CreateAccountRespDTO struct {
+	Exists   bool
+	Name     string
+	Currency string
+}
+
+// TransferReqDTO перевод Amount в валюте счета From на счет To.
+type TransferReqDTO struct {
+	UserID int64
+	From   string
+	To     string
+	Amount decimal.Decimal
+	Date   time.Time
+}
+
+// TransferRespDTO выполненный перевод. NotFound - имя ненайденного счета, перевод не выполнен.
+type TransferRespDTO struct {
+	NotFound     string
+	FromCurrency string
+	AmountTo     decimal.Decimal
+	ToCurrency   string
+}
+
+type GetBalancesReqDTO struct {
+	UserID int64
+}
+
+type GetBalancesRespDTO struct {
+	Accounts []AccountBalanceDTO
+}
+
+// AccountBalanceDTO остаток счета в его валюте.
+type AccountBalanceDTO struct {
+	Name     string
+	Currency string
+	Balance  decimal.Decimal
+}
+
+// SetGoalReqDTO новая цель или новые сумма и срок существующей. Пустая Currency - валюта
+// пользователя для новой цели и валюта цели для существующей.
+type SetGoalReqDTO struct {
+	UserID   int64
+	Name     string
+	Target   decimal.Decimal
+	Currency string
+	Deadline time.Time
+	Date     time.Time
+}
+
+// SetGoalRespDTO цель после изменения. CurrencyMismatch - валюта существующей цели
+// отличается от указанной, цель не изменена.
+type SetGoalRespDTO struct {
+	Created          bool
+	CurrencyMismatch bool
+	Goal             GoalDTO
+}
+
+// ContributeGoalReqDTO взнос Amount в валюте цели, отрицательный - снятие с накоплений.
+type ContributeGoalReqDTO struct {
+	UserID int64
+	Name   string
+	Amount decimal.Decimal
+	Date   time.Time
+}
+
+// ContributeGoalRespDTO цель после взноса. NotFound - цели нет, взнос не добавлен.
+type ContributeGoalRespDTO struct {
+	NotFound bool
+	Goal     GoalDTO
+}
+
+type GetGoalsReqDTO struct {
+	UserID int64
+}
+
+// GetGoalsRespDTO цели пользователя. Его же получают напоминания о целях.
+type GetGoalsRespDTO struct {
+	Goals []GoalDTO
+}
+
+type DeleteGoalReqDTO struct {
+	UserID int64
+	Name   string
+}
+
+type DeleteGoalRespDTO struct {
+	Found bool
+}
+
+// GoalDTO прогресс цели в ее валюте. MonthlyNeeded - сколько откладывать в месяц, чтобы
+// успеть к сроку, MonthlyPace - сколько откладывается в месяц сейчас. ProjectedDate - когда
+// цель будет достигнута в текущем темпе, нулевая, если накоплений пока нет.
+type GoalDTO struct {
+	Name          string
+	Target        decimal.Decimal
+	Saved         decimal.Decimal
+	Currency      string
+	Deadline      time.Time
+	Reached       bool
+	OnTrack       bool
+	MonthlyNeeded decimal.Decimal
+	MonthlyPace   decimal.Decimal
+	ProjectedDate time.Time
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	lrucache "gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/adapter/cache/lru"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
 	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
 	"go.opentelemetry.io/otel"
 )
 
@@ -18,50 +20,156 @@ type ICurrencyStorage interface {
 	Get(context.Context, string) (entity.Rate, error)
 	GetAll(context.Context) ([]entity.Rate, error)
 	Update(context.Context, entity.Rate) error
+	UpdateHistory(context.Context, entity.Rate) error
+	GetForDate(context.Context, string, time.Time) (entity.Rate, error)
+	GetRange(ctx context.Context, currency string, start, end time.Time) ([]entity.Rate, error)
 }
 
 type IUserStorage interface {
 	GetDefaultCurrency(context.Context, entity.UserID) (string, error)
 	UpdateDefaultCurrency(context.Context, entity.UserID, string) error
-	GetLimits(context.Context, entity.UserID) (decimal.Decimal, decimal.Decimal, decimal.Decimal, error)
-	UpdateDayLimit(context.Context, entity.UserID, decimal.Decimal) error
-	UpdateWeekLimit(context.Context, entity.UserID, decimal.Decimal) error
-	UpdateMonthLimit(context.Context, entity.UserID, decimal.Decimal) error
+	UpdateSummaryInterval(context.Context, entity.UserID, int) error
+	GetSummaryRecipients(context.Context, int, time.Time) ([]entity.UserID, error)
+	UpdateSummarySentAt(context.Context, entity.UserID, time.Time) error
+	GetLocale(context.Context, entity.UserID) (string, error)
+	UpdateLocale(context.Context, entity.UserID, string) error
 }
 
 type IExpenseStorage interface {
-	Create(context.Context, entity.UserID, entity.Expense) error
+	Create(context.Context, entity.UserID, entity.Expense) (entity.ExpenseID, bool, error)
+	GetExternalIDs(context.Context, entity.UserID, []string) ([]string, error)
 	Get(context.Context, entity.UserID, time.Time, time.Time) ([]entity.Expense, error)
+	Search(context.Context, entity.UserID, string, time.Time, time.Time, int) ([]entity.Expense, error)
+	GetByID(context.Context, entity.UserID, entity.ExpenseID) (entity.Expense, bool, error)
+	Update(context.Context, entity.UserID, entity.Expense) error
+	Delete(context.Context, entity.UserID, entity.ExpenseID) error
+}
+
+// IIncomeStorage доходы хранятся отдельно от расходов и не учитываются в лимитах.
+type IIncomeStorage interface {
+	Create(context.Context, entity.UserID, entity.Income) (entity.IncomeID, error)
+}
+
+// IAccountStorage счета пользователя и переводы между ними.
+type IAccountStorage interface {
+	Create(context.Context, entity.UserID, entity.Account) (entity.AccountID, bool, error)
+	GetByName(context.Context, entity.UserID, string) (entity.Account, bool, error)
+	GetAll(context.Context, entity.UserID) ([]entity.Account, error)
+	CreateTransfer(context.Context, entity.UserID, entity.Transfer) (entity.TransferID, error)
+}
+
+// IGoalStorage цели накоплений и взносы в них.
+type IGoalStorage interface {
+	Create(context.Context, entity.Goal) (entity.GoalID, bool, error)
+	Update(context.Context, entity.GoalID, decimal.Decimal, time.Time) error
+	GetByName(context.Context, entity.UserID, string) (entity.Goal, bool, error)
+	GetAll(context.Context, entity.UserID) ([]entity.Goal, error)
+	GetForNudge(context.Context, time.Time, time.Time) ([]entity.Goal, error)
+	MarkNudged(context.Context, entity.GoalID, time.Time) error
+	Delete(context.Context, entity.UserID, string) (bool, error)
+	AddContribution(context.Context, entity.GoalID, decimal.Decimal, time.Time) error
+}
+
+type ILimitStorage interface {
+	GetAll(context.Context, entity.UserID) ([]entity.Limit, error)
+	Update(context.Context, entity.UserID, entity.Limit) error
+	GetNotified(context.Context, entity.UserID, entity.Limit, time.Time) ([]int, error)
+	MarkNotified(context.Context, entity.UserID, entity.Limit, time.Time, int) error
+}
+
+type IRecurringExpenseStorage interface {
+	Create(context.Context, entity.RecurringExpense) (entity.RecurringExpenseID, error)
+	GetAll(context.Context, entity.UserID) ([]entity.RecurringExpense, error)
+	GetDue(context.Context, time.Time) ([]entity.RecurringExpense, error)
+	UpdateNextDate(context.Context, entity.RecurringExpenseID, time.Time) error
+	Delete(context.Context, entity.UserID, entity.RecurringExpenseID) (bool, error)
+}
+
+type IImportStorage interface {
+	GetColumns(context.Context, entity.UserID) (entity.ImportColumns, error)
+	UpdateColumns(context.Context, entity.UserID, entity.ImportColumns) error
+	GetRules(context.Context, entity.UserID) ([]entity.ImportRule, error)
+	UpdateRule(context.Context, entity.UserID, entity.ImportRule) error
+	DeleteRule(context.Context, entity.UserID, string) (bool, error)
+}
+
+type ICategoryStorage interface {
+	Create(context.Context, entity.UserID, entity.Category) (entity.CategoryID, error)
+	GetByName(context.Context, entity.UserID, string) (entity.Category, bool, error)
+	GetAll(context.Context, entity.UserID) ([]entity.Category, error)
+	Resolve(context.Context, entity.UserID, string) (string, error)
+	AddAlias(context.Context, entity.UserID, string, entity.CategoryID) error
+	Rename(context.Context, entity.UserID, entity.CategoryID, string) error
+	Merge(context.Context, entity.UserID, entity.CategoryID, entity.CategoryID) error
+}
+
+// IBudgetStorage общие бюджеты. Данные бюджета хранятся под ID владельца.
+type IBudgetStorage interface {
+	GetOwner(context.Context, entity.UserID) (entity.UserID, bool, error)
+	Get(context.Context, entity.UserID) (entity.Budget, bool, error)
+	UpdateInviteCode(context.Context, entity.UserID, string) error
+	Join(context.Context, entity.UserID, string) (entity.UserID, bool, error)
+	DeleteMember(context.Context, entity.UserID, entity.UserID) (bool, error)
+}
+
+// IAuditStorage журнал изменений, по которому отменяется последнее действие пользователя.
+type IAuditStorage interface {
+	Create(context.Context, entity.AuditRecord) error
+	GetLast(context.Context, entity.UserID, entity.UserID) (entity.AuditRecord, bool, error)
+	MarkUndone(context.Context, entity.AuditRecordID, time.Time) error
 }
 
 type IRatesUpdaterService interface {
 	Get(ctx context.Context, base string, codes []string) ([]entity.Rate, error)
+	GetForDate(ctx context.Context, base string, codes []string, date time.Time) ([]entity.Rate, error)
 }
 
 type GetReportClient interface {
 	GetReport(ctx context.Context, req GetReportReqDTO) (GetReportRespDTO, error)
 }
 
+// INotifier доставляет пользователю сообщение, не являющееся ответом на его команду.
+type INotifier interface {
+	Notify(context.Context, Command) error
+}
+
 type IConfig interface {
 	GetBaseCurrencyCode() string
 	GetCurrencyCodes() []string
 	GetFrequencyRateUpdateSec() int
+	GetConvertAtCurrentRate() bool
 	GetReportCacheEnable() bool
 	GetReportCacheSize() int
 	GetReportCacheTTL() int
+	GetLimitThresholds() []int
 }
 
 type ExpenseUsecase struct {
 	currencyStorage     ICurrencyStorage
 	userStorage         IUserStorage
 	expenseStorage      IExpenseStorage
+	incomeStorage       IIncomeStorage
+	accountStorage      IAccountStorage
+	goalStorage         IGoalStorage
+	categoryStorage     ICategoryStorage
+	limitStorage        ILimitStorage
+	recurringStorage    IRecurringExpenseStorage
+	importStorage       IImportStorage
+	budgetStorage       IBudgetStorage
+	auditStorage        IAuditStorage
+	notifier            INotifier
 	ratesUpdaterService IRatesUpdaterService
 	getReportClient     GetReportClient
 	config              IConfig
 	cache               *lrucache.LRUCache
+	cacheGenMu          *sync.Mutex
+	cacheGen            map[int64]int64
 }
 
 func NewExpenseUsecase(currencyStorage ICurrencyStorage, userStorage IUserStorage, expenseStorage IExpenseStorage,
+	incomeStorage IIncomeStorage, accountStorage IAccountStorage, goalStorage IGoalStorage,
+	categoryStorage ICategoryStorage, limitStorage ILimitStorage, recurringStorage IRecurringExpenseStorage,
+	importStorage IImportStorage, budgetStorage IBudgetStorage, auditStorage IAuditStorage, notifier INotifier,
 	ratesUpdaterService IRatesUpdaterService, getReportClient GetReportClient, config IConfig,
 ) *ExpenseUsecase {
 	var cache *lrucache.LRUCache
@@ -73,10 +181,22 @@ func NewExpenseUsecase(currencyStorage ICurrencyStorage, userStorage IUserStorag
 		currencyStorage:     currencyStorage,
 		userStorage:         userStorage,
 		expenseStorage:      expenseStorage,
+		incomeStorage:       incomeStorage,
+		accountStorage:      accountStorage,
+		goalStorage:         goalStorage,
+		categoryStorage:     categoryStorage,
+		limitStorage:        limitStorage,
+		recurringStorage:    recurringStorage,
+		importStorage:       importStorage,
+		budgetStorage:       budgetStorage,
+		auditStorage:        auditStorage,
+		notifier:            notifier,
 		ratesUpdaterService: ratesUpdaterService,
 		getReportClient:     getReportClient,
 		config:              config,
 		cache:               cache,
+		cacheGenMu:          &sync.Mutex{},
+		cacheGen:            make(map[int64]int64),
 	}
 }
 
@@ -85,23 +205,64 @@ func (uc *ExpenseUsecase) SetDefaultCurrency(ctx context.Context, req SetDefault
 	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetDefaultCurrency")
 	defer span.End()
 
-	userID := entity.UserID(req.UserID)
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return SetDefaultCurrencyRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetDefaultCurrency")
+	}
 
 	ok := uc.isSupportedCurrencyCode(req.Currency)
 	if !ok {
 		return SetDefaultCurrencyRespDTO{}, errors.New("currency is unsupported")
 	}
 
-	err := uc.userStorage.UpdateDefaultCurrency(ctx, userID, req.Currency)
+	oldCurrency := uc.getCurrencyForUser(ctx, userID)
+
+	err = uc.userStorage.UpdateDefaultCurrency(ctx, userID, req.Currency)
+	if err != nil {
+		return SetDefaultCurrencyRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetDefaultCurrency")
+	}
+
+	// Отчеты строятся в валюте пользователя, а ее нет в ключе кеша
+	uc.deleteAllReportsFromCache(int64(userID))
+
+	uc.audit(ctx, req.UserID, userID, entity.AuditSetCurrency,
+		auditCurrency{Currency: oldCurrency}, auditCurrency{Currency: req.Currency})
+
+	return SetDefaultCurrencyRespDTO{}, nil
+}
+
+func (uc *ExpenseUsecase) GetCurrencies(ctx context.Context, req GetCurrenciesReqDTO,
+) (GetCurrenciesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetCurrencies")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetCurrenciesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetCurrencies")
+	}
+
+	resp := GetCurrenciesRespDTO{
+		Current:    uc.getCurrencyForUser(ctx, userID),
+		Currencies: []string{uc.config.GetBaseCurrencyCode()},
+	}
+
+	resp.Currencies = append(resp.Currencies, uc.config.GetCurrencyCodes()...)
 
-	return SetDefaultCurrencyRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetDefaultCurrency")
+	return resp, nil
 }
 
 func (uc *ExpenseUsecase) SetLimit(ctx context.Context, req SetLimitReqDTO) (SetLimitRespDTO, error) {
 	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetLimit")
 	defer span.End()
 
-	userID := entity.UserID(req.UserID)
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return SetLimitRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLimit")
+	}
+
+	if _, ok := utils.IntervalToStr(req.IntervalType); !ok {
+		return SetLimitRespDTO{}, errors.New("unknown intervalType")
+	}
 
 	currency := uc.getCurrencyForUser(ctx, userID)
 
@@ -110,33 +271,46 @@ func (uc *ExpenseUsecase) SetLimit(ctx context.Context, req SetLimitReqDTO) (Set
 		return SetLimitRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLimit")
 	}
 
-	req.Limit = req.Limit.Div(rate.GetRatio())
+	category := req.Category
+	if len(category) != 0 {
+		category, err = uc.categoryStorage.Resolve(ctx, userID, category)
+		if err != nil {
+			return SetLimitRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLimit")
+		}
+	}
 
-	switch req.IntervalType {
-	case utils.DayInterval:
-		err = uc.userStorage.UpdateDayLimit(ctx, userID, req.Limit)
-	case utils.WeekInterval:
-		err = uc.userStorage.UpdateWeekLimit(ctx, userID, req.Limit)
-	case utils.MonthInterval:
-		err = uc.userStorage.UpdateMonthLimit(ctx, userID, req.Limit)
-	default:
-		return SetLimitRespDTO{}, errors.New("unknown intervalType")
+	limit := entity.NewLimit(strings.ToLower(category), req.IntervalType, req.Limit.Div(rate.GetRatio()))
+
+	oldLimit, err := uc.getLimit(ctx, userID, limit.GetCategory(), limit.GetIntervalType())
+	if err != nil {
+		return SetLimitRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLimit")
+	}
+
+	err = uc.limitStorage.Update(ctx, userID, limit)
+	if err != nil {
+		return SetLimitRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLimit")
 	}
 
+	uc.audit(ctx, req.UserID, userID, entity.AuditSetLimit, newAuditLimit(oldLimit), newAuditLimit(limit))
+
 	resp := SetLimitRespDTO{
+		Category: limit.GetCategory(),
 		Currency: currency,
 	}
 
-	return resp, errors.Wrap(err, "ExpenseUsecase.SetDefaultCurrency")
+	return resp, nil
 }
 
 func (uc *ExpenseUsecase) GetLimits(ctx context.Context, req GetLimitsReqDTO) (GetLimitsRespDTO, error) {
 	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetLimits")
 	defer span.End()
 
-	userID := entity.UserID(req.UserID)
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetLimitsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetLimits")
+	}
 
-	dayLimit, weekLimit, monthLimit, err := uc.userStorage.GetLimits(ctx, userID)
+	limits, err := uc.limitStorage.GetAll(ctx, userID)
 	if err != nil {
 		return GetLimitsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetLimits")
 	}
@@ -150,25 +324,30 @@ func (uc *ExpenseUsecase) GetLimits(ctx context.Context, req GetLimitsReqDTO) (G
 
 	resp := GetLimitsRespDTO{
 		Currency: currency,
-		Limits: map[int]decimal.Decimal{
-			utils.DayInterval:   dayLimit.Mul(rate.GetRatio()),
-			utils.WeekInterval:  weekLimit.Mul(rate.GetRatio()),
-			utils.MonthInterval: monthLimit.Mul(rate.GetRatio()),
-		},
+		Limits:   make([]LimitDTO, 0, len(limits)),
 	}
 
-	return resp, errors.Wrap(err, "ExpenseUsecase.GetLimits")
+	for _, limit := range limits {
+		resp.Limits = append(resp.Limits, LimitDTO{
+			Category:     limit.GetCategory(),
+			IntervalType: limit.GetIntervalType(),
+			Limit:        limit.GetAmount().Mul(rate.GetRatio()),
+		})
+	}
+
+	return resp, nil
 }
 
 func (uc *ExpenseUsecase) AddExpense(ctx context.Context, req AddExpenseReqDTO) (AddExpenseRespDTO, error) {
 	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddExpense")
 	defer span.End()
 
-	uc.deleteReportFromCache(req)
-
-	userID := entity.UserID(req.UserID)
+	userID, isMember, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+	}
 
-	err := uc.tryUpdateRates(ctx, false)
+	err = uc.tryUpdateRates(ctx, false)
 	if err != nil {
 		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
 	}
@@ -180,79 +359,331 @@ func (uc *ExpenseUsecase) AddExpense(ctx context.Context, req AddExpenseReqDTO)
 		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
 	}
 
-	expense := entity.NewExpense(req.Category, req.Price.Div(rate.GetRatio()), req.Date)
+	// Расход может быть указан в валюте, отличной от валюты пользователя
+	expenseCurrency, expenseRate := currency, rate
+
+	if len(req.Currency) != 0 && req.Currency != currency {
+		if !uc.isSupportedCurrencyCode(req.Currency) {
+			return AddExpenseRespDTO{}, errors.New("currency is unsupported")
+		}
+
+		expenseCurrency = req.Currency
+	}
+
+	// Расход задним числом переводится в базовую валюту по курсу на его дату
+	if expenseCurrency != currency || uc.isPastRate(currency, req.Date) {
+		expenseRate, err = uc.getRateForDate(ctx, expenseCurrency, req.Date)
+		if err != nil {
+			return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		}
+	}
 
-	err = uc.expenseStorage.Create(ctx, userID, expense)
+	category, err := uc.categoryStorage.Resolve(ctx, userID, req.Category)
 	if err != nil {
 		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
 	}
 
-	limits, err := uc.checkLimits(ctx, userID, req.Date)
+	expense := entity.NewExpense(category, req.Price.Div(expenseRate.GetRatio()), req.Date)
+	expense.SetOriginalPrice(req.Price, expenseCurrency)
+	expense.SetExternalID(req.ExternalID)
+	expense.SetNote(req.Note)
+
+	if len(req.Account) != 0 {
+		account, found, err := uc.accountStorage.GetByName(ctx, userID, strings.ToLower(req.Account))
+		if err != nil {
+			return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		}
+
+		if !found {
+			return AddExpenseRespDTO{AccountNotFound: true}, nil //nolint:exhaustruct
+		}
 
-	for i := range limits {
-		limits[i] = limits[i].Mul(rate.GetRatio())
+		amount, err := uc.accountAmount(ctx, account, req.Price, expenseCurrency, expense.GetPrice())
+		if err != nil {
+			return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		}
+
+		expense.SetAccount(account.GetID(), amount)
+	}
+
+	if isMember {
+		expense.SetMemberID(entity.UserID(req.UserID))
+	}
+
+	expenseID, created, err := uc.expenseStorage.Create(ctx, userID, expense)
+	if err != nil {
+		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
 	}
 
+	// Повтор после сбоя: расход уже добавлен, аудит, лимиты и уведомления уже отработали
+	if !created {
+		return AddExpenseRespDTO{ID: int64(expenseID), Currency: currency, Duplicate: true}, nil //nolint:exhaustruct
+	}
+
+	// Расход может быть задним числом, поэтому отчеты и лимиты считаются по его дате
+	uc.deleteReportFromCache(int64(userID), expense.GetDate())
+
+	expense.SetID(expenseID)
+	uc.audit(ctx, req.UserID, userID, entity.AuditAddExpense, nil, newAuditExpense(expense))
+
+	priceRatio, err := uc.getRatioForReport(ctx, rate, expense.GetDate())
+	if err != nil {
+		return AddExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+	}
+
+	// Расход уже добавлен, поэтому ошибка проверки лимитов не должна выглядеть как ошибка добавления
+	usages, err := uc.checkLimits(ctx, userID, expense.GetCategory(), expense.GetDate(), rate)
+	if err != nil {
+		logger.Errorf("can not check limits for user %d: %v", userID, err)
+	}
+
+	limits := make([]LimitDTO, 0, len(usages))
+
+	for _, usage := range usages {
+		limits = append(limits, LimitDTO{
+			Category:     usage.limit.GetCategory(),
+			IntervalType: usage.limit.GetIntervalType(),
+			Limit:        usage.limit.GetAmount().Sub(usage.spent).Mul(rate.GetRatio()),
+		})
+	}
+
+	uc.notifyLimitThresholds(ctx, userID, req.UserID, expense.GetDate(), usages, rate, currency)
+
 	resp := AddExpenseRespDTO{
-		Currency: currency,
-		Limits:   limits,
+		ID:              int64(expenseID),
+		Price:           expense.GetPrice().Mul(priceRatio),
+		Currency:        currency,
+		Limits:          limits,
+		AccountNotFound: false,
+		Duplicate:       false,
 	}
 
-	return resp, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+	return resp, nil
 }
 
-func (uc *ExpenseUsecase) checkLimits(ctx context.Context, userID entity.UserID, date time.Time,
-) (map[int]decimal.Decimal, error) {
-	limits := make(map[int]decimal.Decimal, 1+1+1)
+func (uc *ExpenseUsecase) DeleteExpense(ctx context.Context, req DeleteExpenseReqDTO) (DeleteExpenseRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "DeleteExpense")
+	defer span.End()
 
-	dayLimit, weekLimit, monthLimit, err := uc.userStorage.GetLimits(ctx, userID)
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
 	if err != nil {
-		return nil, errors.Wrap(err, "ExpenseUsecase.checkLimits")
+		return DeleteExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteExpense")
 	}
 
-	checkLimit := func(intervalType int, limit decimal.Decimal) error {
-		if limit.LessThanOrEqual(decimal.Zero) {
-			return nil
-		}
+	expense, found, err := uc.expenseStorage.GetByID(ctx, userID, entity.ExpenseID(req.ID))
+	if err != nil {
+		return DeleteExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteExpense")
+	}
+
+	if !found {
+		return DeleteExpenseRespDTO{Found: false}, nil //nolint:exhaustruct
+	}
 
-		dateStart, dateEnd := utils.GetInterval(date, intervalType)
+	err = checkExpenseAuthor(req.UserID, userID, expense)
+	if err != nil {
+		return DeleteExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteExpense")
+	}
 
-		expenses, err := uc.expenseStorage.Get(ctx, userID, dateStart, dateEnd)
-		if err != nil {
-			return errors.Wrap(err, "ExpenseUsecase.AddExpense")
-		}
+	err = uc.expenseStorage.Delete(ctx, userID, expense.GetID())
+	if err != nil {
+		return DeleteExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteExpense")
+	}
 
-		for _, expense := range expenses {
-			limit = limit.Sub(expense.GetPrice())
-		}
+	uc.deleteReportFromCache(int64(userID), expense.GetDate())
 
-		limits[intervalType] = limit
+	currency := uc.getCurrencyForUser(ctx, userID)
 
-		return nil
+	// Сумма показывается по курсу на дату расхода, как в отчетах
+	rate, err := uc.getRateForDate(ctx, currency, expense.GetDate())
+	if err != nil {
+		return DeleteExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteExpense")
+	}
+
+	resp := DeleteExpenseRespDTO{
+		Found:    true,
+		Category: expense.GetCategory(),
+		Price:    expense.GetPrice().Mul(rate.GetRatio()),
+		Currency: currency,
 	}
 
-	err = checkLimit(utils.DayInterval, dayLimit)
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) UpdateExpense(ctx context.Context, req UpdateExpenseReqDTO) (UpdateExpenseRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "UpdateExpense")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
+	}
+
+	expense, found, err := uc.expenseStorage.GetByID(ctx, userID, entity.ExpenseID(req.ID))
+	if err != nil {
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
+	}
+
+	if !found {
+		return UpdateExpenseRespDTO{Found: false}, nil //nolint:exhaustruct
+	}
+
+	err = checkExpenseAuthor(req.UserID, userID, expense)
 	if err != nil {
-		return nil, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
 	}
 
-	err = checkLimit(utils.WeekInterval, weekLimit)
+	err = uc.tryUpdateRates(ctx, false)
 	if err != nil {
-		return nil, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
 	}
 
-	err = checkLimit(utils.MonthInterval, monthLimit)
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	// Новая сумма переводится в базовую валюту по курсу на дату расхода, как при добавлении
+	rate, err := uc.getRateForDate(ctx, currency, expense.GetDate())
 	if err != nil {
-		return nil, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
+	}
+
+	category, err := uc.categoryStorage.Resolve(ctx, userID, req.Category)
+	if err != nil {
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
+	}
+
+	expense.SetCategory(category)
+
+	if !req.KeepPrice {
+		expense.SetPrice(req.Price.Div(rate.GetRatio()))
+		expense.SetOriginalPrice(req.Price, currency)
+	}
+
+	err = uc.expenseStorage.Update(ctx, userID, expense)
+	if err != nil {
+		return UpdateExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.UpdateExpense")
+	}
+
+	uc.deleteReportFromCache(int64(userID), expense.GetDate())
+
+	resp := UpdateExpenseRespDTO{
+		Found:    true,
+		Currency: currency,
+	}
+
+	return resp, nil
+}
+
+// checkExpenseAuthor разрешает участнику общего бюджета менять только свои расходы.
+// Владелец может менять любые.
+func checkExpenseAuthor(userID int64, budgetUserID entity.UserID, expense entity.Expense) error {
+	if entity.UserID(userID) == budgetUserID || expense.GetMemberID() == entity.UserID(userID) {
+		return nil
 	}
 
-	return limits, errors.Wrap(err, "ExpenseUsecase.AddExpense")
+	return ErrForbidden
+}
+
+// limitUsage сумма расходов за интервал лимита.
+type limitUsage struct {
+	limit entity.Limit
+	spent decimal.Decimal
+}
+
+// checkLimits возвращает расходы по каждому лимиту, который затрагивает расход в категории category.
+// Лимит на категорию учитывает и расходы в ее подкатегориях. Лимиты хранятся в базовой валюте и
+// сравниваются в валюте пользователя: лимит по текущему курсу rate, расходы - по курсу на их дату.
+func (uc *ExpenseUsecase) checkLimits(ctx context.Context, userID entity.UserID, category string, date time.Time,
+	rate entity.Rate,
+) ([]limitUsage, error) {
+	limits, err := uc.limitStorage.GetAll(ctx, userID)
+	if err != nil || len(limits) == 0 {
+		return nil, errors.Wrap(err, "ExpenseUsecase.checkLimits")
+	}
+
+	var tree categoryTree
+
+	for _, limit := range limits {
+		if len(limit.GetCategory()) != 0 {
+			categories, err := uc.categoryStorage.GetAll(ctx, userID)
+			if err != nil {
+				return nil, errors.Wrap(err, "ExpenseUsecase.checkLimits")
+			}
+
+			tree = newCategoryTree(categories)
+
+			break
+		}
+	}
+
+	inLimit := func(limit entity.Limit, category string) bool {
+		return len(limit.GetCategory()) == 0 || tree.contains(limit.GetCategory(), category)
+	}
+
+	expensesByInterval := make(map[int][]entity.Expense)
+	historyByInterval := make(map[int]entity.RateHistory)
+	result := make([]limitUsage, 0, len(limits))
+
+	for _, limit := range limits {
+		if !inLimit(limit, category) {
+			continue
+		}
+
+		intervalType := limit.GetIntervalType()
+
+		expenses, ok := expensesByInterval[intervalType]
+		if !ok {
+			dateStart, dateEnd := utils.GetInterval(date, intervalType)
+
+			expenses, err = uc.expenseStorage.Get(ctx, userID, dateStart, dateEnd)
+			if err != nil {
+				return nil, errors.Wrap(err, "ExpenseUsecase.checkLimits")
+			}
+
+			expensesByInterval[intervalType] = expenses
+
+			historyByInterval[intervalType], err = uc.getRateHistory(ctx, rate, dateStart, dateEnd)
+			if err != nil {
+				return nil, errors.Wrap(err, "ExpenseUsecase.checkLimits")
+			}
+		}
+
+		history := historyByInterval[intervalType]
+		spent := decimal.Zero
+
+		for _, expense := range expenses {
+			if !inLimit(limit, expense.GetCategory()) {
+				continue
+			}
+
+			// Расход приводится к базовой валюте по текущему курсу, чтобы сравнить его с лимитом
+			price := expense.GetPrice()
+			if ratio := history.GetRatio(expense.GetDate()); !ratio.Equal(rate.GetRatio()) {
+				price = price.Mul(ratio).Div(rate.GetRatio())
+			}
+
+			spent = spent.Add(price)
+		}
+
+		result = append(result, limitUsage{
+			limit: limit,
+			spent: spent,
+		})
+	}
+
+	return result, nil
 }
 
 func (uc *ExpenseUsecase) GetReport(ctx context.Context, req GetReportReqDTO) (GetReportRespDTO, error) {
 	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetReport")
 	defer span.End()
 
+	// Отчет строится по всему бюджету, кеш у участников общий
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetReportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetReport")
+	}
+
+	req.UserID = int64(userID)
+
 	if report, ok := uc.getReportFromCache(req); ok {
 		return report, nil
 	}
@@ -303,6 +734,74 @@ func (uc *ExpenseUsecase) needUpdateRates(ctx context.Context) bool {
 	return duration > float64(uc.config.GetFrequencyRateUpdateSec())
 }
 
+// getRateForDate курс валюты на день date. Для прошлых дней курс берется из истории, а если
+// за этот день его нет, история дополняется сервисом курсов. Если сервис недоступен, используется
+// ближайший известный курс.
+func (uc *ExpenseUsecase) getRateForDate(ctx context.Context, code string, date time.Time) (entity.Rate, error) {
+	if code == uc.config.GetBaseCurrencyCode() || !isPastDay(date, time.Now()) {
+		rate, err := uc.currencyStorage.Get(ctx, code)
+
+		return rate, errors.Wrap(err, "ExpenseUsecase.getRateForDate")
+	}
+
+	rate, err := uc.currencyStorage.GetForDate(ctx, code, date)
+	if err == nil && isSameDay(rate.GetTime(), date) {
+		return rate, nil
+	}
+
+	rates, errService := uc.ratesUpdaterService.GetForDate(ctx, uc.config.GetBaseCurrencyCode(),
+		uc.config.GetCurrencyCodes(), date)
+	if errService != nil {
+		logger.Errorf("can not get rates for %s: %v", date.Format(dayLayout), errService)
+
+		return rate, errors.Wrap(err, "ExpenseUsecase.getRateForDate")
+	}
+
+	for _, dayRate := range rates {
+		err := uc.currencyStorage.UpdateHistory(ctx, dayRate)
+		if err != nil {
+			return entity.Rate{}, errors.Wrap(err, "ExpenseUsecase.getRateForDate")
+		}
+
+		if dayRate.GetCode() == code {
+			rate = dayRate
+		}
+	}
+
+	return rate, nil
+}
+
+// getRatioForReport курс валюты rate на день date так, как его считают отчеты.
+func (uc *ExpenseUsecase) getRatioForReport(ctx context.Context, rate entity.Rate, date time.Time,
+) (decimal.Decimal, error) {
+	if !isPastDay(date, time.Now()) {
+		return rate.GetRatio(), nil
+	}
+
+	history, err := uc.getRateHistory(ctx, rate, date, date)
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, "ExpenseUsecase.getRatioForReport")
+	}
+
+	return history.GetRatio(date), nil
+}
+
+// getRateHistory курсы валюты за период для пересчета расходов по курсу на их дату.
+// Если пересчет по текущему курсу включен в конфиге или валюта базовая, вся история - текущий курс.
+func (uc *ExpenseUsecase) getRateHistory(ctx context.Context, rate entity.Rate, start, end time.Time,
+) (entity.RateHistory, error) {
+	if rate.GetCode() == uc.config.GetBaseCurrencyCode() || uc.config.GetConvertAtCurrentRate() {
+		return entity.NewRateHistory(nil, rate), nil
+	}
+
+	rates, err := uc.currencyStorage.GetRange(ctx, rate.GetCode(), start, end)
+	if err != nil {
+		return entity.RateHistory{}, errors.Wrap(err, "ExpenseUsecase.getRateHistory")
+	}
+
+	return entity.NewRateHistory(rates, rate), nil
+}
+
 func (uc *ExpenseUsecase) getCurrencyForUser(ctx context.Context, userID entity.UserID) string {
 	currency, err := uc.userStorage.GetDefaultCurrency(ctx, userID)
 	if err == nil {
@@ -328,12 +827,62 @@ func (uc *ExpenseUsecase) isSupportedCurrencyCode(currency string) bool {
 	return false
 }
 
+// dayLayout формат дня, по которому сопоставляются курсы и даты операций.
+const dayLayout = "2006-01-02"
+
+// isPastRate нужен ли для валюты курс за прошедший день. Курс базовой валюты всегда 1.
+func (uc *ExpenseUsecase) isPastRate(code string, date time.Time) bool {
+	return isPastDay(date, time.Now()) && code != uc.config.GetBaseCurrencyCode()
+}
+
+func isSameDay(a, b time.Time) bool {
+	return a.Format(dayLayout) == b.Format(dayLayout)
+}
+
+func isPastDay(date, now time.Time) bool {
+	return date.Format(dayLayout) < now.Format(dayLayout)
+}
+
+func reportCacheKey(userID, gen int64, req GetReportReqDTO) string {
+	return fmt.Sprintf("%d_%d_%d_%d_%t_%t_%t", userID, gen, req.DateStart.Unix(), req.DateEnd.Unix(), req.Rollup,
+		req.ByMember, req.CurrentRate)
+}
+
+func (uc *ExpenseUsecase) getReportCacheGen(userID int64) int64 {
+	uc.cacheGenMu.Lock()
+	defer uc.cacheGenMu.Unlock()
+
+	return uc.cacheGen[userID]
+}
+
+// Изменение справочника категорий затрагивает все отчеты пользователя. Вместо
+// поиска ключей меняется поколение, и старые отчеты вытесняются из кеша сами.
+func (uc *ExpenseUsecase) deleteAllReportsFromCache(userID int64) {
+	uc.cacheGenMu.Lock()
+	defer uc.cacheGenMu.Unlock()
+
+	uc.cacheGen[userID]++
+}
+
+// Кешируются только отчеты за календарные интервалы, произвольные диапазоны
+// дат сбросить при добавлении расхода не получится. Отчеты для графиков
+// запрашиваются редко и не кешируются.
+func isReportCacheable(req GetReportReqDTO) bool {
+	if req.IntervalType == 0 || req.SeriesInterval != 0 {
+		return false
+	}
+
+	dateStart, dateEnd := utils.GetInterval(req.DateStart, req.IntervalType)
+
+	return dateStart.Equal(req.DateStart) && dateEnd.Equal(req.DateEnd)
+}
+
 func (uc *ExpenseUsecase) addReportToCache(req GetReportReqDTO, resp GetReportRespDTO) {
-	if !uc.config.GetReportCacheEnable() {
+	if !uc.config.GetReportCacheEnable() || !isReportCacheable(req) {
 		return
 	}
 
-	key := fmt.Sprintf("%d_%d_%d", req.UserID, utils.TruncDate(req.Date).Unix(), req.IntervalType)
+	key := reportCacheKey(req.UserID, uc.getReportCacheGen(req.UserID), req)
 
 	uc.cache.Add(time.Now(), key, resp, uc.config.GetReportCacheTTL())
 }
@@ -341,11 +890,11 @@ func (uc *ExpenseUsecase) addReportToCache(req GetReportReqDTO, resp GetReportRe
 func (uc *ExpenseUsecase) getReportFromCache(req GetReportReqDTO) (GetReportRespDTO, bool) {
 	var resp GetReportRespDTO
 
-	if !uc.config.GetReportCacheEnable() {
+	if !uc.config.GetReportCacheEnable() || !isReportCacheable(req) {
 		return resp, false
 	}
 
-	key := fmt.Sprintf("%d_%d_%d", req.UserID, utils.TruncDate(req.Date).Unix(), req.IntervalType)
+	key := reportCacheKey(req.UserID, uc.getReportCacheGen(req.UserID), req)
 
 	val, ok := uc.cache.Get(time.Now(), key)
 	if !ok {
@@ -360,17 +909,27 @@ func (uc *ExpenseUsecase) getReportFromCache(req GetReportReqDTO) (GetReportResp
 	return resp, ok
 }
 
-func (uc *ExpenseUsecase) deleteReportFromCache(req AddExpenseReqDTO) {
+// Отчет кешируется по границам интервала, поэтому сбрасываются отчеты за все
+// интервалы, которые содержат дату расхода.
+func (uc *ExpenseUsecase) deleteReportFromCache(userID int64, date time.Time) {
 	if !uc.config.GetReportCacheEnable() {
 		return
 	}
 
-	uc.cache.Delete(time.Now(), fmt.Sprintf("%d_%d_%d",
-		req.UserID, utils.TruncDate(req.Date).Unix(), utils.DayInterval))
+	gen := uc.getReportCacheGen(userID)
 
-	uc.cache.Delete(time.Now(), fmt.Sprintf("%d_%d_%d",
-		req.UserID, utils.TruncDate(req.Date).Unix(), utils.WeekInterval))
+	for _, intervalType := range []int{utils.DayInterval, utils.WeekInterval, utils.MonthInterval, utils.YearInterval} {
+		req := GetReportReqDTO{UserID: userID, IntervalType: intervalType} //nolint:exhaustruct
+		req.DateStart, req.DateEnd = utils.GetInterval(date, intervalType)
 
-	uc.cache.Delete(time.Now(), fmt.Sprintf("%d_%d_%d",
-		req.UserID, utils.TruncDate(req.Date).Unix(), utils.MonthInterval))
+		for _, rollup := range []bool{false, true} {
+			for _, byMember := range []bool{false, true} {
+				for _, currentRate := range []bool{false, true} {
+					req.Rollup, req.ByMember, req.CurrentRate = rollup, byMember, currentRate
+
+					uc.cache.Delete(time.Now(), reportCacheKey(userID, gen, req))
+				}
+			}
+		}
+	}
 }
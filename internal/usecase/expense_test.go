@@ -21,6 +21,36 @@ var timeHelper = func(year, month, day int) time.Time { //nolint:gochecknoglobal
 	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
 }
 
+// Категории без справочника сохраняются как есть.
+func newCategoryStorageMock(ctrl *gomock.Controller) *mock_usecase.MockICategoryStorage {
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+
+	categoryStorage.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, name string) (string, error) {
+			return name, nil
+		}).AnyTimes()
+
+	return categoryStorage
+}
+
+// Пользователь не состоит в общем бюджете.
+func newBudgetStorageMock(ctrl *gomock.Controller) *mock_usecase.MockIBudgetStorage {
+	budgetStorage := mock_usecase.NewMockIBudgetStorage(ctrl)
+
+	budgetStorage.EXPECT().GetOwner(gomock.Any(), gomock.Any()).Return(entity.UserID(0), false, nil).AnyTimes()
+
+	return budgetStorage
+}
+
+// Журнал изменений принимает любые записи.
+func newAuditStorageMock(ctrl *gomock.Controller) *mock_usecase.MockIAuditStorage {
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+
+	auditStorage.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return auditStorage
+}
+
 func TestExpenseSetDefaultCurrency_CurrencyEqBaseCode(t *testing.T) {
 	t.Parallel()
 
@@ -30,6 +60,16 @@ func TestExpenseSetDefaultCurrency_CurrencyEqBaseCode(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -37,11 +77,13 @@ func TestExpenseSetDefaultCurrency_CurrencyEqBaseCode(t *testing.T) {
 	gomock.InOrder(
 		config.EXPECT().GetReportCacheEnable().Return(false),
 		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(201)).Return("RUB", nil),
 		userStorage.EXPECT().UpdateDefaultCurrency(gomock.Any(), entity.UserID(201), "RUB").Return(nil),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.SetDefaultCurrencyReqDTO{
 		UserID:   201,
@@ -61,6 +103,16 @@ func TestExpenseSetDefaultCurrency_CurrencyInCodes(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -69,11 +121,13 @@ func TestExpenseSetDefaultCurrency_CurrencyInCodes(t *testing.T) {
 		config.EXPECT().GetReportCacheEnable().Return(false),
 		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
 		config.EXPECT().GetCurrencyCodes().Return([]string{"CNY", "EUR", "USD", "JPY"}),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(201)).Return("RUB", nil),
 		userStorage.EXPECT().UpdateDefaultCurrency(gomock.Any(), entity.UserID(201), "USD").Return(nil),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.SetDefaultCurrencyReqDTO{
 		UserID:   201,
@@ -93,6 +147,16 @@ func TestExpenseSetDefaultCurrency_UnknownCurrency(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -103,8 +167,9 @@ func TestExpenseSetDefaultCurrency_UnknownCurrency(t *testing.T) {
 		config.EXPECT().GetCurrencyCodes().Return([]string{"CNY", "EUR", "USD", "JPY"}),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.SetDefaultCurrencyReqDTO{
 		UserID:   201,
@@ -125,6 +190,16 @@ func TestExpenseSetDefaultCurrency_DBError(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -132,11 +207,13 @@ func TestExpenseSetDefaultCurrency_DBError(t *testing.T) {
 	gomock.InOrder(
 		config.EXPECT().GetReportCacheEnable().Return(false),
 		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(201)).Return("RUB", nil),
 		userStorage.EXPECT().UpdateDefaultCurrency(gomock.Any(), entity.UserID(201), "RUB").Return(errUnknown),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.SetDefaultCurrencyReqDTO{
 		UserID:   201,
@@ -148,6 +225,49 @@ func TestExpenseSetDefaultCurrency_DBError(t *testing.T) {
 	assert.EqualError(t, err, "ExpenseUsecase.SetDefaultCurrency: unknown error")
 }
 
+func TestGetCurrencies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+		Return("USD", nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.GetCurrencies(ctx, usecase.GetCurrenciesReqDTO{UserID: 202})
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, usecase.GetCurrenciesRespDTO{
+		Current:    "USD",
+		Currencies: []string{"RUB", "USD", "EUR"},
+	}, resp)
+}
+
 func TestUpdateCurrency(t *testing.T) {
 	t.Parallel()
 
@@ -157,6 +277,16 @@ func TestUpdateCurrency(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -178,8 +308,9 @@ func TestUpdateCurrency(t *testing.T) {
 		).Return(nil),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	err := expenseUsecase.UpdateCurrency(ctx)
 	assert.NoError(t, err)
@@ -194,6 +325,16 @@ func TestUpdateCurrency_SrvError(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -205,8 +346,9 @@ func TestUpdateCurrency_SrvError(t *testing.T) {
 		ratesUpdaterService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errUnknown),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	err := expenseUsecase.UpdateCurrency(ctx)
 	assert.Error(t, err)
@@ -224,6 +366,16 @@ func TestAddExpense(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -242,20 +394,43 @@ func TestAddExpense(t *testing.T) {
 			Return("EUR", nil),
 		currencyStorage.EXPECT().Get(gomock.Any(), "EUR").
 			Return(entity.NewRate("EUR", decimal.New(16, -3), time.Now()), nil),
+		// Расход задним числом: в базовую валюту по курсу на его дату
+		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "EUR", time1).
+			Return(entity.NewRate("EUR", decimal.New(16, -3), time1), nil),
 		expenseStorage.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
-			Return(nil),
-		userStorage.EXPECT().GetLimits(gomock.Any(), gomock.Any()).
-			Return(decimal.New(10, 0), decimal.New(0, 0), decimal.New(50, 0), nil),
+			Return(entity.ExpenseID(17), true, nil),
+		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		config.EXPECT().GetConvertAtCurrentRate().Return(false),
+		currencyStorage.EXPECT().GetRange(gomock.Any(), "EUR", time1, time1).
+			Return([]entity.Rate{entity.NewRate("EUR", decimal.New(16, -3), time1)}, nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Limit{
+				entity.NewLimit("", utils.DayInterval, decimal.New(10, 0)),
+				entity.NewLimit("", utils.MonthInterval, decimal.New(50, 0)),
+			}, nil),
 		expenseStorage.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil, nil),
+		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		config.EXPECT().GetConvertAtCurrentRate().Return(false),
+		currencyStorage.EXPECT().GetRange(gomock.Any(), "EUR", gomock.Any(), gomock.Any()).
+			Return(nil, nil),
 		expenseStorage.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return([]entity.Expense{
 				entity.NewExpense("Category1", decimal.New(2, 0), time1),
 			}, nil),
+		config.EXPECT().GetBaseCurrencyCode().Return("RUB"),
+		config.EXPECT().GetConvertAtCurrentRate().Return(false),
+		// Расход месяца сделан по курсу 0.02, по текущему курсу 0.016 он весит больше
+		currencyStorage.EXPECT().GetRange(gomock.Any(), "EUR", gomock.Any(), gomock.Any()).
+			Return([]entity.Rate{entity.NewRate("EUR", decimal.New(2, -2), time1)}, nil),
+		config.EXPECT().GetLimitThresholds().Return(nil),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.AddExpenseReqDTO{
 		UserID:   202,
@@ -268,14 +443,650 @@ func TestAddExpense(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.EqualValues(t, usecase.AddExpenseRespDTO{
+		ID:       17,
+		Price:    decimal.RequireFromString("10.0000000000000000000"),
 		Currency: "EUR",
-		Limits: map[int]decimal.Decimal{
-			utils.DayInterval:   decimal.New(160, -3),
-			utils.MonthInterval: decimal.New(768, -3),
+		Limits: []usecase.LimitDTO{
+			{IntervalType: utils.DayInterval, Limit: decimal.New(160, -3)},
+			{IntervalType: utils.MonthInterval, Limit: decimal.RequireFromString("0.7600000000000000000")},
 		},
 	}, resp)
 }
 
+func TestAddExpense_OtherCurrency(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+	config.EXPECT().GetConvertAtCurrentRate().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("USD", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+			Return(entity.NewRate("USD", decimal.New(2, -2), time.Now()), nil),
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "EUR", time1).
+			Return(entity.NewRate("EUR", decimal.New(1, -2), time1), nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense,
+			) (entity.ExpenseID, bool, error) {
+				assert.True(t, decimal.New(500, 0).Equal(expense.GetPrice()))
+				assert.True(t, decimal.New(5, 0).Equal(expense.GetOriginalPrice()))
+				assert.Equal(t, "EUR", expense.GetOriginalCurrency())
+
+				return entity.ExpenseID(18), true, nil
+			}),
+		currencyStorage.EXPECT().GetRange(gomock.Any(), "USD", time1, time1).
+			Return([]entity.Rate{entity.NewRate("USD", decimal.New(2, -2), time1)}, nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return(nil, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "Taxi",
+		Price:    decimal.New(5, 0),
+		Currency: "EUR",
+		Date:     time1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(18), resp.ID)
+	assert.Equal(t, "USD", resp.Currency)
+	assert.True(t, decimal.New(10, 0).Equal(resp.Price))
+}
+
+func TestAddExpense_BackfillRates(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).Times(2)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+
+	dayRates := []entity.Rate{
+		entity.NewRate("RUB", decimal.New(1, 0), time1),
+		entity.NewRate("USD", decimal.New(2, -2), time1),
+		entity.NewRate("EUR", decimal.New(19, -3), time1),
+	}
+
+	gomock.InOrder(
+		// В истории есть только курс за несколько дней до расхода
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "USD", time1).
+			Return(entity.NewRate("USD", decimal.New(25, -3), time1.AddDate(0, 0, -3)), nil),
+		ratesUpdaterService.EXPECT().GetForDate(gomock.Any(), "RUB", []string{"USD", "EUR"}, time1).
+			Return(dayRates, nil),
+		currencyStorage.EXPECT().UpdateHistory(gomock.Any(), dayRates[0]).Return(nil),
+		currencyStorage.EXPECT().UpdateHistory(gomock.Any(), dayRates[1]).Return(nil),
+		currencyStorage.EXPECT().UpdateHistory(gomock.Any(), dayRates[2]).Return(nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense,
+			) (entity.ExpenseID, bool, error) {
+				assert.True(t, decimal.New(250, 0).Equal(expense.GetPrice()))
+
+				return entity.ExpenseID(18), true, nil
+			}),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return(nil, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "Taxi",
+		Price:    decimal.New(5, 0),
+		Currency: "USD",
+		Date:     time1,
+	})
+	assert.NoError(t, err)
+	assert.True(t, decimal.New(250, 0).Equal(resp.Price))
+}
+
+func TestAddExpense_RatesServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).Times(2)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+
+	gomock.InOrder(
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "USD", time1).
+			Return(entity.NewRate("USD", decimal.New(25, -3), time1.AddDate(0, 0, -3)), nil),
+		ratesUpdaterService.EXPECT().GetForDate(gomock.Any(), "RUB", []string{"USD", "EUR"}, time1).
+			Return(nil, errUnknown),
+		// Используется ближайший известный курс
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense,
+			) (entity.ExpenseID, bool, error) {
+				assert.True(t, decimal.New(200, 0).Equal(expense.GetPrice()))
+
+				return entity.ExpenseID(18), true, nil
+			}),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return(nil, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "Taxi",
+		Price:    decimal.New(5, 0),
+		Currency: "USD",
+		Date:     time1,
+	})
+	assert.NoError(t, err)
+}
+
+func TestAddExpense_UnsupportedCurrency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	gomock.InOrder(
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("USD", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+			Return(entity.NewRate("USD", decimal.New(2, -2), time.Now()), nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "Taxi",
+		Price:    decimal.New(5, 0),
+		Currency: "KZT",
+		Date:     timeHelper(2022, 11, 10),
+	})
+	assert.EqualError(t, err, "currency is unsupported")
+}
+
+func TestDeleteExpense(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	expense := entity.NewExpense("Netflix", decimal.New(500, 0), time1)
+	expense.SetID(17)
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(expense, true, nil),
+		expenseStorage.EXPECT().Delete(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("EUR", nil),
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "EUR", time1).
+			Return(entity.NewRate("EUR", decimal.New(16, -3), time1), nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.DeleteExpense(ctx, usecase.DeleteExpenseReqDTO{
+		UserID: 202,
+		ID:     17,
+	})
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, usecase.DeleteExpenseRespDTO{
+		Found:    true,
+		Category: "Netflix",
+		Price:    decimal.New(8000, -3),
+		Currency: "EUR",
+	}, resp)
+}
+
+func TestDeleteExpense_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(entity.Expense{}, false, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.DeleteExpense(ctx, usecase.DeleteExpenseReqDTO{
+		UserID: 202,
+		ID:     17,
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.Found)
+}
+
+func TestDeleteExpense_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(entity.Expense{}, false, errUnknown),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.DeleteExpense(ctx, usecase.DeleteExpenseReqDTO{
+		UserID: 202,
+		ID:     17,
+	})
+	assert.EqualError(t, err, "ExpenseUsecase.DeleteExpense: unknown error")
+	assert.False(t, resp.Found)
+}
+
+func TestUpdateExpense(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	expense := entity.NewExpense("Netflix", decimal.New(500, 0), time1)
+	expense.SetID(17)
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(expense, true, nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("EUR", nil),
+		// Расход прошлого года, сумма переводится по курсу на его дату, а не по текущему
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "EUR", time1).
+			Return(entity.NewRate("EUR", decimal.New(2, -2), time1), nil),
+		expenseStorage.EXPECT().Update(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) error {
+				assert.Equal(t, entity.ExpenseID(17), expense.GetID())
+				assert.Equal(t, "Spotify", expense.GetCategory())
+				assert.True(t, decimal.New(200, 0).Equal(expense.GetPrice()), expense.GetPrice().String())
+				assert.Equal(t, time1, expense.GetDate())
+
+				return nil
+			}),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.UpdateExpense(ctx, usecase.UpdateExpenseReqDTO{
+		UserID:   202,
+		ID:       17,
+		Category: "Spotify",
+		Price:    decimal.New(4, 0),
+	})
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, usecase.UpdateExpenseRespDTO{
+		Found:    true,
+		Currency: "EUR",
+	}, resp)
+}
+
+func TestUpdateExpense_KeepPrice(t *testing.T) {
+	t.Parallel()
+
+	time1 := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	expense := entity.NewExpense("Netflix", decimal.New(500, 0), time1)
+	expense.SetID(17)
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(expense, true, nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("EUR", nil),
+		currencyStorage.EXPECT().GetForDate(gomock.Any(), "EUR", time1).
+			Return(entity.NewRate("EUR", decimal.New(16, -3), time1), nil),
+		expenseStorage.EXPECT().Update(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) error {
+				assert.Equal(t, "Spotify", expense.GetCategory())
+				assert.True(t, decimal.New(500, 0).Equal(expense.GetPrice()))
+
+				return nil
+			}),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.UpdateExpense(ctx, usecase.UpdateExpenseReqDTO{
+		UserID:    202,
+		ID:        17,
+		Category:  "Spotify",
+		KeepPrice: true,
+	})
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, usecase.UpdateExpenseRespDTO{
+		Found:    true,
+		Currency: "EUR",
+	}, resp)
+}
+
+func TestUpdateExpense_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(entity.Expense{}, false, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.UpdateExpense(ctx, usecase.UpdateExpenseReqDTO{
+		UserID:   202,
+		ID:       17,
+		Category: "Spotify",
+		Price:    decimal.New(4, 0),
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.Found)
+}
+
+func TestUpdateExpense_StorageError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+			Return(entity.Expense{}, false, errUnknown),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.UpdateExpense(ctx, usecase.UpdateExpenseReqDTO{
+		UserID:   202,
+		ID:       17,
+		Category: "Spotify",
+		Price:    decimal.New(4, 0),
+	})
+	assert.EqualError(t, err, "ExpenseUsecase.UpdateExpense: unknown error")
+	assert.False(t, resp.Found)
+}
+
 func TestGetReport(t *testing.T) {
 	t.Parallel()
 
@@ -285,6 +1096,16 @@ func TestGetReport(t *testing.T) {
 	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
 	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
 	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
 	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
 	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
 	config := mock_usecase.NewMockIConfig(ctrl)
@@ -294,7 +1115,8 @@ func TestGetReport(t *testing.T) {
 	gomock.InOrder(
 		reportClient.EXPECT().GetReport(gomock.Any(), usecase.GetReportReqDTO{
 			UserID:       202,
-			Date:         timeHelper(2022, 10, 1),
+			DateStart:    timeHelper(2022, 9, 26),
+			DateEnd:      timeHelper(2022, 10, 3),
 			IntervalType: utils.WeekInterval,
 		}).Return(usecase.GetReportRespDTO{
 			Currency: "EUR",
@@ -311,12 +1133,14 @@ func TestGetReport(t *testing.T) {
 		}, nil),
 	)
 
-	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage,
-		expenseStorage, ratesUpdaterService, reportClient, config)
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
 
 	req := usecase.GetReportReqDTO{
 		UserID:       202,
-		Date:         timeHelper(2022, 10, 1),
+		DateStart:    timeHelper(2022, 9, 26),
+		DateEnd:      timeHelper(2022, 10, 3),
 		IntervalType: utils.WeekInterval,
 	}
 
@@ -337,3 +1161,90 @@ func TestGetReport(t *testing.T) {
 		},
 	}, resp)
 }
+
+func TestGetReport_Cache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(true).AnyTimes()
+	config.EXPECT().GetReportCacheSize().Return(10).AnyTimes()
+	config.EXPECT().GetReportCacheTTL().Return(3600).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	weekReq := usecase.GetReportReqDTO{
+		UserID:       202,
+		DateStart:    timeHelper(2022, 9, 26),
+		DateEnd:      timeHelper(2022, 10, 3),
+		IntervalType: utils.WeekInterval,
+	}
+
+	rangeReq := usecase.GetReportReqDTO{
+		UserID:    202,
+		DateStart: timeHelper(2022, 9, 27),
+		DateEnd:   timeHelper(2022, 9, 29),
+	}
+
+	gomock.InOrder(
+		reportClient.EXPECT().GetReport(gomock.Any(), weekReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil),
+		reportClient.EXPECT().GetReport(gomock.Any(), rangeReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil).Times(2),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("RUB", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			Return(entity.ExpenseID(1), true, nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return(nil, nil),
+		reportClient.EXPECT().GetReport(gomock.Any(), weekReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	// Отчет за неделю кешируется, за произвольный диапазон - нет
+	for i := 0; i < 2; i++ {
+		_, err := expenseUsecase.GetReport(ctx, weekReq)
+		assert.NoError(t, err)
+
+		_, err = expenseUsecase.GetReport(ctx, rangeReq)
+		assert.NoError(t, err)
+	}
+
+	// Расход задним числом сбрасывает кеш недели, в которую он попал
+	_, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "Taxi",
+		Price:    decimal.New(300, 0),
+		Date:     timeHelper(2022, 9, 28),
+	})
+	assert.NoError(t, err)
+
+	_, err = expenseUsecase.GetReport(ctx, weekReq)
+	assert.NoError(t, err)
+}
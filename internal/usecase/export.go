@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/export"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"go.opentelemetry.io/otel"
+)
+
+// maxExportSize ограничивает суммарный размер файлов выгрузки. Файлы передаются через kafka
+// в base64 внутри команды, а сообщение kafka по умолчанию не больше 1 МБ.
+const maxExportSize = 512 * 1024
+
+// Export выгружает расходы за период в CSV и XLSX. Сумма выводится в базовой
+// валюте, в которой хранится, и в валюте пользователя.
+func (uc *ExpenseUsecase) Export(ctx context.Context, req ExportReqDTO) (ExportRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "Export")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return ExportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Export")
+	}
+
+	expenses, err := uc.expenseStorage.Get(ctx, userID, req.DateStart, req.DateEnd)
+	if err != nil {
+		return ExportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Export")
+	}
+
+	if len(expenses) == 0 {
+		return ExportRespDTO{}, nil
+	}
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	rate, err := uc.currencyStorage.Get(ctx, currency)
+	if err != nil {
+		return ExportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Export")
+	}
+
+	// Значения в файле не зависят от языка, чтобы их читали программы, переводятся только заголовки
+	locale := localeFromContext(ctx)
+
+	// Хранилище сортирует расходы по категориям, в выгрузке они идут по времени
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].GetDate().Before(expenses[j].GetDate())
+	})
+
+	table := export.Table{
+		Header: []string{
+			i18n.T(locale, "Дата"), i18n.T(locale, "Категория"),
+			i18n.Sprintf(locale, "Сумма, %s", uc.config.GetBaseCurrencyCode()), i18n.Sprintf(locale, "Сумма, %s", currency),
+		},
+		Rows: make([][]any, 0, len(expenses)),
+	}
+
+	for _, expense := range expenses {
+		table.Rows = append(table.Rows, []any{
+			expense.GetDate(),
+			expense.GetCategory(),
+			expense.GetPrice(),
+			expense.GetPrice().Mul(rate.GetRatio()),
+		})
+	}
+
+	csvData, err := export.CSV(table)
+	if err != nil {
+		return ExportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Export")
+	}
+
+	xlsxData, err := export.XLSX(table)
+	if err != nil {
+		return ExportRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Export")
+	}
+
+	if len(csvData)+len(xlsxData) > maxExportSize {
+		return ExportRespDTO{Count: len(expenses), TooLarge: true}, nil //nolint:exhaustruct
+	}
+
+	name := exportFileName(req)
+
+	resp := ExportRespDTO{
+		Count:    len(expenses),
+		TooLarge: false,
+		Files: []FileDTO{
+			{Name: name + ".csv", Data: csvData},
+			{Name: name + ".xlsx", Data: xlsxData},
+		},
+	}
+
+	return resp, nil
+}
+
+func exportFileName(req ExportReqDTO) string {
+	dateLayout := "2006-01-02"
+
+	if req.DateStart.IsZero() {
+		return "expenses"
+	}
+
+	return "expenses_" + req.DateStart.Format(dateLayout) + "_" + req.DateEnd.AddDate(0, 0, -1).Format(dateLayout)
+}
@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	usd := entity.NewRate("USD", decimal.RequireFromString("0.01"), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "USD").Return(usd, nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("USD", nil)
+
+	dateStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	dateEnd := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(101), dateStart, dateEnd).Return([]entity.Expense{
+		entity.NewExpense("такси", decimal.New(300, 0), time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)),
+		entity.NewExpense("кафе", decimal.New(1250, 0), time.Date(2026, 10, 2, 20, 0, 0, 0, time.UTC)),
+	}, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Export(ctx, usecase.ExportReqDTO{
+		UserID:    101,
+		DateStart: dateStart,
+		DateEnd:   dateEnd,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.Count)
+	assert.Len(t, resp.Files, 2)
+
+	assert.Equal(t, "expenses_2026-10-01_2026-10-31.csv", resp.Files[0].Name)
+	assert.Equal(t, "\xEF\xBB\xBF"+`Дата,Категория,"Сумма, RUB","Сумма, USD"
+2026-10-02 20:00:00,кафе,1250.00,12.50
+2026-10-17 09:30:00,такси,300.00,3.00
+`, string(resp.Files[0].Data))
+
+	assert.Equal(t, "expenses_2026-10-01_2026-10-31.xlsx", resp.Files[1].Name)
+	assert.True(t, bytes.HasPrefix(resp.Files[1].Data, []byte("PK")))
+}
+
+func TestExport_Empty(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(101), time.Time{}, gomock.Any()).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Export(ctx, usecase.ExportReqDTO{
+		UserID:  101,
+		DateEnd: time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.ExportRespDTO{}, resp)
+}
+
+func TestExport_TooLarge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("RUB", nil)
+
+	date := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+
+	// Двадцать тысяч расходов дают больше мегабайта CSV
+	expenses := make([]entity.Expense, 0, 20000)
+	for i := 0; i < 20000; i++ {
+		expenses = append(expenses, entity.NewExpense("продукты", decimal.New(int64(i), -2), date))
+	}
+
+	expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(101), time.Time{}, gomock.Any()).Return(expenses, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Export(ctx, usecase.ExportReqDTO{
+		UserID:  101,
+		DateEnd: time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.TooLarge)
+	assert.Equal(t, 20000, resp.Count)
+	assert.Empty(t, resp.Files)
+}
@@ -25,10 +25,15 @@ func forward[Req, Resp any](ctx context.Context, usecase func(context.Context, R
 		return errors.New("internal error")
 	}
 
+	// Без ответа обработчик сообщает об ошибке, а не показывает пустой ответ
 	r, err := usecase(ctx, *req)
+	if err != nil {
+		return err
+	}
+
 	*resp = &r
 
-	return err
+	return nil
 }
 
 func (f *FacadeUsecase) ExecuteCommand(ctx context.Context, cmd *Command) error {
@@ -37,9 +42,37 @@ func (f *FacadeUsecase) ExecuteCommand(ctx context.Context, cmd *Command) error
 	ctx, span := otel.Tracer("FacadeUsecase").Start(ctx, "ExecuteCommand")
 	defer span.End()
 
+	// Данные группового чата общие для его участников
+	ctx = WithChatID(ctx, cmd.ChatID)
+
+	// Выбранный пользователем язык важнее языка из настроек Telegram
+	if locale := f.expenseUsecase.userLocale(ctx, cmd.UserID); len(locale) != 0 {
+		cmd.Locale = locale
+	}
+
+	ctx = WithLocale(ctx, cmd.Locale)
+
+	err := f.executeCommand(ctx, cmd)
+
+	// Ответ на смену языка уже на новом языке
+	if err == nil && cmd.SetLocaleRespDTO != nil {
+		cmd.Locale = cmd.SetLocaleRespDTO.Locale
+	}
+
+	// Участнику общего бюджета объясняется, почему команда не выполнена
+	if errors.Is(err, ErrForbidden) {
+		cmd.Forbidden = true
+	}
+
+	return err
+}
+
+func (f *FacadeUsecase) executeCommand(ctx context.Context, cmd *Command) error {
 	switch cmd.Name {
 	case SetCurrencyCmdName:
 		return forward(ctx, f.expenseUsecase.SetDefaultCurrency, cmd.SetDefaultCurrencyReqDTO, &cmd.SetDefaultCurrencyRespDTO)
+	case GetCurrenciesCmdName:
+		return forward(ctx, f.expenseUsecase.GetCurrencies, cmd.GetCurrenciesReqDTO, &cmd.GetCurrenciesRespDTO)
 	case AddExpenseCmdName:
 		return forward(ctx, f.expenseUsecase.AddExpense, cmd.AddExpenseReqDTO, &cmd.AddExpenseRespDTO)
 	case GetReportCmdName:
@@ -48,6 +81,83 @@ func (f *FacadeUsecase) ExecuteCommand(ctx context.Context, cmd *Command) error
 		return forward(ctx, f.expenseUsecase.SetLimit, cmd.SetLimitReqDTO, &cmd.SetLimitRespDTO)
 	case GetLimitsCmdName:
 		return forward(ctx, f.expenseUsecase.GetLimits, cmd.GetLimitsReqDTO, &cmd.GetLimitsRespDTO)
+	case DeleteExpenseCmdName:
+		return forward(ctx, f.expenseUsecase.DeleteExpense, cmd.DeleteExpenseReqDTO, &cmd.DeleteExpenseRespDTO)
+	case UpdateExpenseCmdName:
+		return forward(ctx, f.expenseUsecase.UpdateExpense, cmd.UpdateExpenseReqDTO, &cmd.UpdateExpenseRespDTO)
+	case CreateCategoryCmdName:
+		return forward(ctx, f.expenseUsecase.CreateCategory, cmd.CreateCategoryReqDTO, &cmd.CreateCategoryRespDTO)
+	case AddCategoryAliasCmdName:
+		return forward(ctx, f.expenseUsecase.AddCategoryAlias, cmd.AddCategoryAliasReqDTO, &cmd.AddCategoryAliasRespDTO)
+	case RenameCategoryCmdName:
+		return forward(ctx, f.expenseUsecase.RenameCategory, cmd.RenameCategoryReqDTO, &cmd.RenameCategoryRespDTO)
+	case MergeCategoriesCmdName:
+		return forward(ctx, f.expenseUsecase.MergeCategories, cmd.MergeCategoriesReqDTO, &cmd.MergeCategoriesRespDTO)
+	case GetCategoriesCmdName:
+		return forward(ctx, f.expenseUsecase.GetCategories, cmd.GetCategoriesReqDTO, &cmd.GetCategoriesRespDTO)
+	case SetSummaryCmdName:
+		return forward(ctx, f.expenseUsecase.SetSummary, cmd.SetSummaryReqDTO, &cmd.SetSummaryRespDTO)
+	case AddRecurringExpenseCmdName:
+		return forward(ctx, f.expenseUsecase.AddRecurringExpense, cmd.AddRecurringExpenseReqDTO,
+			&cmd.AddRecurringExpenseRespDTO)
+	case GetRecurringExpensesCmdName:
+		return forward(ctx, f.expenseUsecase.GetRecurringExpenses, cmd.GetRecurringExpensesReqDTO,
+			&cmd.GetRecurringExpensesRespDTO)
+	case DeleteRecurringExpenseCmdName:
+		return forward(ctx, f.expenseUsecase.DeleteRecurringExpense, cmd.DeleteRecurringExpenseReqDTO,
+			&cmd.DeleteRecurringExpenseRespDTO)
+	case ExportCmdName:
+		return forward(ctx, f.expenseUsecase.Export, cmd.ExportReqDTO, &cmd.ExportRespDTO)
+	case SetImportColumnsCmdName:
+		return forward(ctx, f.expenseUsecase.SetImportColumns, cmd.SetImportColumnsReqDTO, &cmd.SetImportColumnsRespDTO)
+	case AddImportRuleCmdName:
+		return forward(ctx, f.expenseUsecase.AddImportRule, cmd.AddImportRuleReqDTO, &cmd.AddImportRuleRespDTO)
+	case DeleteImportRuleCmdName:
+		return forward(ctx, f.expenseUsecase.DeleteImportRule, cmd.DeleteImportRuleReqDTO, &cmd.DeleteImportRuleRespDTO)
+	case GetImportSettingsCmdName:
+		return forward(ctx, f.expenseUsecase.GetImportSettings, cmd.GetImportSettingsReqDTO,
+			&cmd.GetImportSettingsRespDTO)
+	case ImportExpensesCmdName:
+		return forward(ctx, f.expenseUsecase.ImportExpenses, cmd.ImportExpensesReqDTO, &cmd.ImportExpensesRespDTO)
+	case GetBudgetCmdName:
+		return forward(ctx, f.expenseUsecase.GetBudget, cmd.GetBudgetReqDTO, &cmd.GetBudgetRespDTO)
+	case InviteToBudgetCmdName:
+		return forward(ctx, f.expenseUsecase.InviteToBudget, cmd.InviteToBudgetReqDTO, &cmd.InviteToBudgetRespDTO)
+	case JoinBudgetCmdName:
+		return forward(ctx, f.expenseUsecase.JoinBudget, cmd.JoinBudgetReqDTO, &cmd.JoinBudgetRespDTO)
+	case LeaveBudgetCmdName:
+		return forward(ctx, f.expenseUsecase.LeaveBudget, cmd.LeaveBudgetReqDTO, &cmd.LeaveBudgetRespDTO)
+	case RemoveBudgetMemberCmdName:
+		return forward(ctx, f.expenseUsecase.RemoveBudgetMember, cmd.RemoveBudgetMemberReqDTO,
+			&cmd.RemoveBudgetMemberRespDTO)
+	case SetLocaleCmdName:
+		return forward(ctx, f.expenseUsecase.SetLocale, cmd.SetLocaleReqDTO, &cmd.SetLocaleRespDTO)
+	case UndoCmdName:
+		return forward(ctx, f.expenseUsecase.Undo, cmd.UndoReqDTO, &cmd.UndoRespDTO)
+	case SearchExpensesCmdName:
+		return forward(ctx, f.expenseUsecase.SearchExpenses, cmd.SearchExpensesReqDTO, &cmd.SearchExpensesRespDTO)
+	case AddIncomeCmdName:
+		return forward(ctx, f.expenseUsecase.AddIncome, cmd.AddIncomeReqDTO, &cmd.AddIncomeRespDTO)
+	case CreateAccountCmdName:
+		return forward(ctx, f.expenseUsecase.CreateAccount, cmd.CreateAccountReqDTO, &cmd.CreateAccountRespDTO)
+	case TransferCmdName:
+		return forward(ctx, f.expenseUsecase.Transfer, cmd.TransferReqDTO, &cmd.TransferRespDTO)
+	case GetBalancesCmdName:
+		return forward(ctx, f.expenseUsecase.GetBalances, cmd.GetBalancesReqDTO, &cmd.GetBalancesRespDTO)
+	case SetGoalCmdName:
+		return forward(ctx, f.expenseUsecase.SetGoal, cmd.SetGoalReqDTO, &cmd.SetGoalRespDTO)
+	case ContributeGoalCmdName:
+		return forward(ctx, f.expenseUsecase.ContributeGoal, cmd.ContributeGoalReqDTO, &cmd.ContributeGoalRespDTO)
+	case GetGoalsCmdName:
+		return forward(ctx, f.expenseUsecase.GetGoals, cmd.GetGoalsReqDTO, &cmd.GetGoalsRespDTO)
+	case DeleteGoalCmdName:
+		return forward(ctx, f.expenseUsecase.DeleteGoal, cmd.DeleteGoalReqDTO, &cmd.DeleteGoalRespDTO)
+	case ReportPeriodsCmdName:
+	case LocalesCmdName:
+	case ConfirmExpenseCmdName:
+	case ReceiptCmdName:
+	case DialogCmdName:
+	case CancelDialogCmdName:
 	case StartCmdName:
 	case HelpCmdName:
 	case AboutCmdName:
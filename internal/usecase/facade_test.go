@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func newFacadeUsecase(t *testing.T) (*usecase.FacadeUsecase, *mock_usecase.MockIExpenseStorage) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	userStorage.EXPECT().GetLocale(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	return usecase.New(expenseUsecase), expenseStorage
+}
+
+func TestExecuteCommand_NotFound(t *testing.T) {
+	t.Parallel()
+
+	facade, expenseStorage := newFacadeUsecase(t)
+
+	expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+		Return(entity.Expense{}, false, nil)
+
+	cmd := usecase.Command{
+		MessageInfo:         usecase.MessageInfo{UserID: 202},
+		Name:                usecase.DeleteExpenseCmdName,
+		DeleteExpenseReqDTO: &usecase.DeleteExpenseReqDTO{UserID: 202, ID: 17},
+	}
+
+	err := facade.ExecuteCommand(context.Background(), &cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.DeleteExpenseRespDTO{Found: false}, cmd.DeleteExpenseRespDTO)
+}
+
+// Ошибка хранилища не превращается в ответ "не найден", ответа нет совсем.
+func TestExecuteCommand_Error(t *testing.T) {
+	t.Parallel()
+
+	facade, expenseStorage := newFacadeUsecase(t)
+
+	expenseStorage.EXPECT().GetByID(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).
+		Return(entity.Expense{}, false, errUnknown)
+
+	cmd := usecase.Command{
+		MessageInfo:         usecase.MessageInfo{UserID: 202},
+		Name:                usecase.DeleteExpenseCmdName,
+		DeleteExpenseReqDTO: &usecase.DeleteExpenseReqDTO{UserID: 202, ID: 17},
+	}
+
+	err := facade.ExecuteCommand(context.Background(), &cmd)
+	assert.ErrorIs(t, err, errUnknown)
+	assert.Nil(t, cmd.DeleteExpenseRespDTO)
+}
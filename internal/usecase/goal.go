@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrGoalDeadlinePassed = errors.New("goal deadline must be in the future")
+
+const (
+	daysInMonth = 30
+	// projectionMaxDays дальше прогноз не строится, темп считается недостаточным.
+	projectionMaxDays = 100 * 365
+)
+
+// SetGoal добавляет цель или меняет сумму и срок существующей, накопленное сохраняется.
+func (uc *ExpenseUsecase) SetGoal(ctx context.Context, req SetGoalReqDTO) (SetGoalRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetGoal")
+	defer span.End()
+
+	if !req.Deadline.After(req.Date) {
+		return SetGoalRespDTO{}, errors.Wrap(ErrGoalDeadlinePassed, "ExpenseUsecase.SetGoal")
+	}
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return SetGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetGoal")
+	}
+
+	name := strings.ToLower(req.Name)
+
+	goal, found, err := uc.goalStorage.GetByName(ctx, userID, name)
+	if err != nil {
+		return SetGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetGoal")
+	}
+
+	if found {
+		// Взносы хранятся в валюте цели, поэтому валюта цели не меняется
+		if len(req.Currency) != 0 && req.Currency != goal.GetCurrency() {
+			return SetGoalRespDTO{CurrencyMismatch: true, Goal: goalProgress(goal, req.Date)}, nil //nolint:exhaustruct
+		}
+
+		err = uc.goalStorage.Update(ctx, goal.GetID(), req.Target, req.Deadline)
+		if err != nil {
+			return SetGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetGoal")
+		}
+
+		updated := entity.NewGoal(userID, name, req.Target, goal.GetCurrency(), req.Deadline, goal.GetCreatedAt())
+		updated.SetID(goal.GetID())
+		updated.SetSaved(goal.GetSaved())
+
+		return SetGoalRespDTO{Created: false, CurrencyMismatch: false, Goal: goalProgress(updated, req.Date)}, nil
+	}
+
+	currency := req.Currency
+	if len(currency) == 0 {
+		currency = uc.getCurrencyForUser(ctx, userID)
+	} else if !uc.isSupportedCurrencyCode(currency) {
+		return SetGoalRespDTO{}, errors.New("currency is unsupported")
+	}
+
+	goal = entity.NewGoal(userID, name, req.Target, currency, req.Deadline, req.Date)
+
+	id, created, err := uc.goalStorage.Create(ctx, goal)
+	if err != nil {
+		return SetGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetGoal")
+	}
+
+	if !created {
+		return SetGoalRespDTO{}, errors.New("goal was created concurrently")
+	}
+
+	goal.SetID(id)
+
+	return SetGoalRespDTO{Created: true, CurrencyMismatch: false, Goal: goalProgress(goal, req.Date)}, nil
+}
+
+// ContributeGoal добавляет взнос в цель. Взносы доступны и участникам общего бюджета.
+func (uc *ExpenseUsecase) ContributeGoal(ctx context.Context, req ContributeGoalReqDTO,
+) (ContributeGoalRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "ContributeGoal")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return ContributeGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ContributeGoal")
+	}
+
+	goal, found, err := uc.goalStorage.GetByName(ctx, userID, strings.ToLower(req.Name))
+	if err != nil {
+		return ContributeGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ContributeGoal")
+	}
+
+	if !found {
+		return ContributeGoalRespDTO{NotFound: true}, nil //nolint:exhaustruct
+	}
+
+	err = uc.goalStorage.AddContribution(ctx, goal.GetID(), req.Amount, req.Date)
+	if err != nil {
+		return ContributeGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ContributeGoal")
+	}
+
+	goal.SetSaved(goal.GetSaved().Add(req.Amount))
+
+	return ContributeGoalRespDTO{NotFound: false, Goal: goalProgress(goal, req.Date)}, nil
+}
+
+func (uc *ExpenseUsecase) GetGoals(ctx context.Context, req GetGoalsReqDTO) (GetGoalsRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetGoals")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetGoalsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetGoals")
+	}
+
+	goals, err := uc.goalStorage.GetAll(ctx, userID)
+	if err != nil {
+		return GetGoalsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetGoals")
+	}
+
+	now := time.Now()
+
+	resp := GetGoalsRespDTO{
+		Goals: make([]GoalDTO, 0, len(goals)),
+	}
+
+	for _, goal := range goals {
+		resp.Goals = append(resp.Goals, goalProgress(goal, now))
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) DeleteGoal(ctx context.Context, req DeleteGoalReqDTO) (DeleteGoalRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "DeleteGoal")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return DeleteGoalRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteGoal")
+	}
+
+	found, err := uc.goalStorage.Delete(ctx, userID, strings.ToLower(req.Name))
+
+	return DeleteGoalRespDTO{Found: found}, errors.Wrap(err, "ExpenseUsecase.DeleteGoal")
+}
+
+// SendGoalNudges раз в месяц напоминает о недостигнутых целях, сколько нужно откладывать
+// в месяц, чтобы успеть к сроку. Цели одного пользователя попадают в одно сообщение.
+// Ошибка отправки одному пользователю не мешает остальным.
+func (uc *ExpenseUsecase) SendGoalNudges(ctx context.Context) error {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SendGoalNudges")
+	defer span.End()
+
+	now := time.Now()
+	monthStart, _ := utils.GetInterval(now, utils.MonthInterval)
+
+	goals, err := uc.goalStorage.GetForNudge(ctx, monthStart, now)
+	if err != nil {
+		return errors.Wrap(err, "ExpenseUsecase.SendGoalNudges")
+	}
+
+	userIDs := make([]entity.UserID, 0)
+	userGoals := make(map[entity.UserID][]entity.Goal)
+
+	for _, goal := range goals {
+		if _, ok := userGoals[goal.GetUserID()]; !ok {
+			userIDs = append(userIDs, goal.GetUserID())
+		}
+
+		userGoals[goal.GetUserID()] = append(userGoals[goal.GetUserID()], goal)
+	}
+
+	for _, userID := range userIDs {
+		err := uc.sendGoalNudge(ctx, userID, userGoals[userID], now)
+		if err != nil {
+			logger.Errorf("can not send goal nudge to user %d: %v", userID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *ExpenseUsecase) sendGoalNudge(ctx context.Context, userID entity.UserID, goals []entity.Goal,
+	now time.Time,
+) error {
+	resp := GetGoalsRespDTO{
+		Goals: make([]GoalDTO, 0, len(goals)),
+	}
+
+	for _, goal := range goals {
+		resp.Goals = append(resp.Goals, goalProgress(goal, now))
+	}
+
+	err := uc.notifier.Notify(ctx, Command{
+		MessageInfo: MessageInfo{
+			UserID: int64(userID),
+			Date:   now,
+			Locale: uc.userLocale(ctx, int64(userID)),
+		},
+		Name:            GoalNudgeCmdName,
+		GetGoalsRespDTO: &resp,
+	})
+	if err != nil {
+		return errors.Wrap(err, "ExpenseUsecase.sendGoalNudge")
+	}
+
+	for _, goal := range goals {
+		err := uc.goalStorage.MarkNudged(ctx, goal.GetID(), now)
+		if err != nil {
+			return errors.Wrap(err, "ExpenseUsecase.sendGoalNudge")
+		}
+	}
+
+	return nil
+}
+
+// goalProgress считает прогресс цели на момент now. Темп - накопленное, деленное на число
+// месяцев с создания цели, но не меньше одного, чтобы первый взнос не завышал темп.
+func goalProgress(goal entity.Goal, now time.Time) GoalDTO {
+	progress := GoalDTO{
+		Name:          goal.GetName(),
+		Target:        goal.GetTarget(),
+		Saved:         goal.GetSaved(),
+		Currency:      goal.GetCurrency(),
+		Deadline:      goal.GetDeadline(),
+		Reached:       false,
+		OnTrack:       false,
+		MonthlyNeeded: decimal.Zero,
+		MonthlyPace:   decimal.Zero,
+		ProjectedDate: time.Time{},
+	}
+
+	remaining := goal.GetTarget().Sub(goal.GetSaved())
+	if !remaining.IsPositive() {
+		progress.Reached, progress.OnTrack = true, true
+
+		return progress
+	}
+
+	progress.MonthlyNeeded = remaining.Div(decimal.NewFromInt(int64(monthsUntil(now, goal.GetDeadline()))))
+
+	months := decimal.NewFromFloat(now.Sub(goal.GetCreatedAt()).Hours() / 24).Div(decimal.NewFromInt(daysInMonth))
+	if months.LessThan(decimal.NewFromInt(1)) {
+		months = decimal.NewFromInt(1)
+	}
+
+	progress.MonthlyPace = decimal.Max(goal.GetSaved(), decimal.Zero).Div(months)
+	if !progress.MonthlyPace.IsPositive() {
+		return progress
+	}
+
+	days := remaining.Div(progress.MonthlyPace).Mul(decimal.NewFromInt(daysInMonth)).Ceil()
+	if days.GreaterThan(decimal.NewFromInt(projectionMaxDays)) {
+		return progress
+	}
+
+	progress.ProjectedDate = now.AddDate(0, 0, int(days.IntPart()))
+	progress.OnTrack = !progress.ProjectedDate.After(goal.GetDeadline())
+
+	return progress
+}
+
+// monthsUntil число ежемесячных взносов до срока, считая текущий месяц. Не меньше одного,
+// в том числе когда срок уже прошел.
+func monthsUntil(now, deadline time.Time) int {
+	months := (deadline.Year()-now.Year())*12 + int(deadline.Month()) - int(now.Month()) //nolint:gomnd
+	if deadline.Day() > now.Day() {
+		months++
+	}
+
+	if months < 1 {
+		return 1
+	}
+
+	return months
+}
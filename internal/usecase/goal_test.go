@@ -0,0 +1,238 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func newGoal(id entity.GoalID, name string, target, saved int64, createdAt time.Time) entity.Goal {
+	goal := entity.NewGoal(202, name, decimal.New(target, 0), "RUB", time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC),
+		createdAt)
+	goal.SetID(id)
+	goal.SetSaved(decimal.New(saved, 0))
+
+	return goal
+}
+
+func newGoalUsecase(t *testing.T) (*usecase.ExpenseUsecase, *mock_usecase.MockIGoalStorage,
+	*mock_usecase.MockIUserStorage, *mock_usecase.MockINotifier,
+) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	return expenseUsecase, goalStorage, userStorage, notifier
+}
+
+func TestSetGoal_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	expenseUsecase, goalStorage, userStorage, _ := newGoalUsecase(t)
+
+	goalStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "отпуск").Return(entity.Goal{}, false, nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	goalStorage.EXPECT().Create(gomock.Any(),
+		entity.NewGoal(202, "отпуск", decimal.New(150000, 0), "RUB", deadline, now)).Return(entity.GoalID(3), true, nil)
+
+	resp, err := expenseUsecase.SetGoal(ctx, usecase.SetGoalReqDTO{
+		UserID:   202,
+		Name:     "Отпуск",
+		Target:   decimal.New(150000, 0),
+		Currency: "",
+		Deadline: deadline,
+		Date:     now,
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.Created)
+	assert.Equal(t, "RUB", resp.Goal.Currency)
+	assert.False(t, resp.Goal.OnTrack)
+	assert.True(t, resp.Goal.ProjectedDate.IsZero())
+	// С ноября по май - 8 взносов
+	assert.True(t, decimal.New(18750, 0).Equal(resp.Goal.MonthlyNeeded), resp.Goal.MonthlyNeeded.String())
+}
+
+func TestSetGoal_CurrencyMismatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	expenseUsecase, goalStorage, _, _ := newGoalUsecase(t)
+
+	goalStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "отпуск").
+		Return(newGoal(3, "отпуск", 150000, 0, now), true, nil)
+
+	resp, err := expenseUsecase.SetGoal(ctx, usecase.SetGoalReqDTO{
+		UserID:   202,
+		Name:     "отпуск",
+		Target:   decimal.New(2000, 0),
+		Currency: "USD",
+		Deadline: time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC),
+		Date:     now,
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.CurrencyMismatch)
+	assert.Equal(t, "RUB", resp.Goal.Currency)
+}
+
+func TestSetGoal_DeadlinePassed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	expenseUsecase, _, _, _ := newGoalUsecase(t)
+
+	_, err := expenseUsecase.SetGoal(ctx, usecase.SetGoalReqDTO{
+		UserID:   202,
+		Name:     "отпуск",
+		Target:   decimal.New(150000, 0),
+		Currency: "",
+		Deadline: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Date:     now,
+	})
+	assert.ErrorIs(t, err, usecase.ErrGoalDeadlinePassed)
+}
+
+func TestContributeGoal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	expenseUsecase, goalStorage, _, _ := newGoalUsecase(t)
+
+	goal := newGoal(3, "отпуск", 150000, 20000, time.Date(2026, 8, 18, 12, 0, 0, 0, time.UTC))
+
+	gomock.InOrder(
+		goalStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "отпуск").Return(goal, true, nil),
+		goalStorage.EXPECT().AddContribution(gomock.Any(), entity.GoalID(3), decimal.New(10000, 0), now).Return(nil),
+	)
+
+	resp, err := expenseUsecase.ContributeGoal(ctx, usecase.ContributeGoalReqDTO{
+		UserID: 202,
+		Name:   "отпуск",
+		Amount: decimal.New(10000, 0),
+		Date:   now,
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.NotFound)
+	assert.True(t, decimal.New(30000, 0).Equal(resp.Goal.Saved))
+	assert.True(t, decimal.New(15000, 0).Equal(resp.Goal.MonthlyNeeded), resp.Goal.MonthlyNeeded.String())
+	// 30 000 за два месяца - меньше нужных 15 000 в месяц, к сроку не успеть
+	assert.False(t, resp.Goal.OnTrack)
+	assert.True(t, resp.Goal.ProjectedDate.After(goal.GetDeadline()))
+}
+
+func TestContributeGoal_Reached(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	expenseUsecase, goalStorage, _, _ := newGoalUsecase(t)
+
+	goal := newGoal(3, "отпуск", 150000, 140000, time.Date(2026, 8, 18, 12, 0, 0, 0, time.UTC))
+
+	goalStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "отпуск").Return(goal, true, nil)
+	goalStorage.EXPECT().AddContribution(gomock.Any(), entity.GoalID(3), decimal.New(10000, 0), now).Return(nil)
+
+	resp, err := expenseUsecase.ContributeGoal(ctx, usecase.ContributeGoalReqDTO{
+		UserID: 202,
+		Name:   "отпуск",
+		Amount: decimal.New(10000, 0),
+		Date:   now,
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.Goal.Reached)
+	assert.True(t, resp.Goal.OnTrack)
+	assert.True(t, resp.Goal.MonthlyNeeded.IsZero())
+}
+
+func TestContributeGoal_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	expenseUsecase, goalStorage, _, _ := newGoalUsecase(t)
+
+	goalStorage.EXPECT().GetByName(gomock.Any(), entity.UserID(202), "машина").Return(entity.Goal{}, false, nil)
+
+	resp, err := expenseUsecase.ContributeGoal(ctx, usecase.ContributeGoalReqDTO{
+		UserID: 202,
+		Name:   "машина",
+		Amount: decimal.New(10000, 0),
+		Date:   time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.NotFound)
+}
+
+func TestSendGoalNudges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	createdAt := time.Now().AddDate(0, -1, 0)
+
+	expenseUsecase, goalStorage, userStorage, notifier := newGoalUsecase(t)
+
+	goals := []entity.Goal{
+		newGoal(3, "отпуск", 150000, 0, createdAt),
+		newGoal(4, "ремонт", 300000, 50000, createdAt),
+	}
+
+	goalStorage.EXPECT().GetForNudge(gomock.Any(), gomock.Any(), gomock.Any()).Return(goals, nil)
+	userStorage.EXPECT().GetLocale(gomock.Any(), entity.UserID(202)).Return("", nil)
+
+	gomock.InOrder(
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, cmd usecase.Command) error {
+				assert.Equal(t, usecase.GoalNudgeCmdName, cmd.Name)
+				assert.Equal(t, int64(202), cmd.UserID)
+				assert.Len(t, cmd.GetGoalsRespDTO.Goals, 2)
+				assert.Equal(t, "ремонт", cmd.GetGoalsRespDTO.Goals[1].Name)
+
+				return nil
+			}),
+		goalStorage.EXPECT().MarkNudged(gomock.Any(), entity.GoalID(3), gomock.Any()).Return(nil),
+		goalStorage.EXPECT().MarkNudged(gomock.Any(), entity.GoalID(4), gomock.Any()).Return(nil),
+	)
+
+	err := expenseUsecase.SendGoalNudges(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/statement"
+	"go.opentelemetry.io/otel"
+)
+
+// Категория операций выписки, для которых не нашлось правила.
+const importDefaultCategory = "прочее"
+
+func (uc *ExpenseUsecase) SetImportColumns(ctx context.Context, req SetImportColumnsReqDTO,
+) (SetImportColumnsRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetImportColumns")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return SetImportColumnsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetImportColumns")
+	}
+
+	if (req.Date == 0) != (req.Amount == 0) || req.Date < 0 || req.Amount < 0 ||
+		req.Description < 0 || req.Currency < 0 {
+		return SetImportColumnsRespDTO{}, errors.New("invalid import columns")
+	}
+
+	columns := entity.NewImportColumns(req.Date, req.Amount, req.Description, req.Currency)
+
+	err = uc.importStorage.UpdateColumns(ctx, userID, columns)
+
+	return SetImportColumnsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetImportColumns")
+}
+
+func (uc *ExpenseUsecase) AddImportRule(ctx context.Context, req AddImportRuleReqDTO) (AddImportRuleRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddImportRule")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return AddImportRuleRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddImportRule")
+	}
+
+	category, err := uc.categoryStorage.Resolve(ctx, userID, req.Category)
+	if err != nil {
+		return AddImportRuleRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddImportRule")
+	}
+
+	err = uc.importStorage.UpdateRule(ctx, userID, entity.NewImportRule(req.Keyword, category))
+	if err != nil {
+		return AddImportRuleRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddImportRule")
+	}
+
+	return AddImportRuleRespDTO{Category: category}, nil
+}
+
+func (uc *ExpenseUsecase) DeleteImportRule(ctx context.Context, req DeleteImportRuleReqDTO,
+) (DeleteImportRuleRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "DeleteImportRule")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return DeleteImportRuleRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteImportRule")
+	}
+
+	found, err := uc.importStorage.DeleteRule(ctx, userID, req.Keyword)
+
+	return DeleteImportRuleRespDTO{Found: found}, errors.Wrap(err, "ExpenseUsecase.DeleteImportRule")
+}
+
+func (uc *ExpenseUsecase) GetImportSettings(ctx context.Context, req GetImportSettingsReqDTO,
+) (GetImportSettingsRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetImportSettings")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetImportSettingsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetImportSettings")
+	}
+
+	columns, err := uc.importStorage.GetColumns(ctx, userID)
+	if err != nil {
+		return GetImportSettingsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetImportSettings")
+	}
+
+	rules, err := uc.importStorage.GetRules(ctx, userID)
+	if err != nil {
+		return GetImportSettingsRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetImportSettings")
+	}
+
+	resp := GetImportSettingsRespDTO{
+		Date:        columns.GetDate(),
+		Amount:      columns.GetAmount(),
+		Description: columns.GetDescription(),
+		Currency:    columns.GetCurrency(),
+		Rules:       make([]ImportRuleDTO, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, ImportRuleDTO{
+			Keyword:  rule.GetKeyword(),
+			Category: rule.GetCategory(),
+		})
+	}
+
+	return resp, nil
+}
+
+// ImportExpenses загружает расходы из выписки банка. Каждой операции назначается внешний
+// идентификатор по ее содержимому, поэтому повторная загрузка того же файла ничего не меняет.
+// Операции задним числом не проверяются на лимиты, иначе импорт истории засыплет уведомлениями.
+func (uc *ExpenseUsecase) ImportExpenses(ctx context.Context, req ImportExpensesReqDTO,
+) (ImportExpensesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "ImportExpenses")
+	defer span.End()
+
+	userID, isMember, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	err = uc.tryUpdateRates(ctx, false)
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	columns, err := uc.importStorage.GetColumns(ctx, userID)
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	rules, err := uc.importStorage.GetRules(ctx, userID)
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	parsed, err := statement.Parse(req.Data, statementColumns(columns))
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	resp := ImportExpensesRespDTO{
+		Done:    true,
+		Skipped: parsed.Skipped,
+	}
+
+	if len(parsed.Rows) == 0 {
+		return resp, nil
+	}
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	externalIDs := importExternalIDs(userID, parsed.Rows)
+
+	existing, err := uc.expenseStorage.GetExternalIDs(ctx, userID, externalIDs)
+	if err != nil {
+		return ImportExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+	}
+
+	imported := make(map[string]bool, len(existing))
+	for _, externalID := range existing {
+		imported[externalID] = true
+	}
+
+	rates := make(map[string]entity.Rate)
+
+	for i, row := range parsed.Rows {
+		if imported[externalIDs[i]] {
+			resp.Duplicates++
+
+			continue
+		}
+
+		rowCurrency := row.Currency
+		if len(rowCurrency) == 0 {
+			rowCurrency = currency
+		}
+
+		// Операции из выписки переводятся в базовую валюту по курсу на их дату
+		rateKey := rowCurrency + " " + row.Date.Format(dayLayout)
+
+		rate, ok := rates[rateKey]
+		if !ok {
+			if !uc.isSupportedCurrencyCode(rowCurrency) {
+				resp.Skipped++
+
+				continue
+			}
+
+			rate, err = uc.getRateForDate(ctx, rowCurrency, row.Date)
+			if err != nil {
+				return resp, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+			}
+
+			rates[rateKey] = rate
+		}
+
+		category, err := uc.categoryStorage.Resolve(ctx, userID, matchImportRule(rules, row.Description))
+		if err != nil {
+			return resp, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+		}
+
+		expense := entity.NewExpense(category, row.Amount.Div(rate.GetRatio()), row.Date)
+		expense.SetOriginalPrice(row.Amount, rowCurrency)
+		expense.SetExternalID(externalIDs[i])
+		// Описание операции из выписки помогает потом найти расход
+		expense.SetNote(row.Description)
+
+		if isMember {
+			expense.SetMemberID(entity.UserID(req.UserID))
+		}
+
+		_, created, err := uc.expenseStorage.Create(ctx, userID, expense)
+		if err != nil {
+			return resp, errors.Wrap(err, "ExpenseUsecase.ImportExpenses")
+		}
+
+		// Та же выписка могла импортироваться параллельно
+		if !created {
+			resp.Duplicates++
+
+			continue
+		}
+
+		resp.Imported++
+	}
+
+	// Выписка затрагивает много интервалов, проще сбросить все отчеты пользователя
+	if resp.Imported != 0 {
+		uc.deleteAllReportsFromCache(int64(userID))
+	}
+
+	return resp, nil
+}
+
+func statementColumns(columns entity.ImportColumns) *statement.Columns {
+	if columns.GetDate() == 0 {
+		return nil
+	}
+
+	return &statement.Columns{
+		Date:        columns.GetDate() - 1,
+		Amount:      columns.GetAmount() - 1,
+		Description: columns.GetDescription() - 1,
+		Currency:    columns.GetCurrency() - 1,
+	}
+}
+
+// Одинаковые операции в одной выписке, например два одинаковых кофе за день,
+// различаются порядковым номером повтора.
+func importExternalIDs(userID entity.UserID, rows []statement.Row) []string {
+	externalIDs := make([]string, 0, len(rows))
+	repeats := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		// Валюта по умолчанию в ключ не входит, иначе после ее смены та же выписка загрузится повторно
+		key := fmt.Sprintf("%d|%s|%s|%s|%s", userID, row.Date.Format(time.RFC3339), row.Amount.String(),
+			row.Currency, row.Description)
+
+		repeats[key]++
+
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, repeats[key])))
+
+		externalIDs = append(externalIDs, "import_"+hex.EncodeToString(hash[:16]))
+	}
+
+	return externalIDs
+}
+
+// Из подходящих правил выбирается самое длинное ключевое слово, как самое точное.
+func matchImportRule(rules []entity.ImportRule, description string) string {
+	description = strings.ToLower(description)
+
+	category, keywordLen := importDefaultCategory, 0
+
+	for _, rule := range rules {
+		keyword := rule.GetKeyword()
+		if len(keyword) > keywordLen && strings.Contains(description, keyword) {
+			category, keywordLen = rule.GetCategory(), len(keyword)
+		}
+	}
+
+	return category
+}
@@ -0,0 +1,275 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+const testStatement = `Дата операции;Описание;Сумма;Валюта
+17.10.2026 09:30;Яндекс Такси;-300,50;RUB
+17.10.2026 10:00;ПЯТЕРОЧКА 1234;-1 250;RUB
+17.10.2026 10:00;ПЯТЕРОЧКА 1234;-1 250;RUB
+18.10.2026 12:00;Starbucks;-5;USD
+18.10.2026 13:00;Перевод;-100;KZT
+18.10.2026 14:00;Зарплата;50000;RUB
+`
+
+func TestImportExpenses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+	usd := entity.NewRate("USD", decimal.RequireFromString("0.01"), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil).AnyTimes()
+	currencyStorage.EXPECT().Get(gomock.Any(), "USD").Return(usd, nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("RUB", nil)
+
+	importStorage.EXPECT().GetColumns(gomock.Any(), entity.UserID(101)).Return(entity.ImportColumns{}, nil)
+	importStorage.EXPECT().GetRules(gomock.Any(), entity.UserID(101)).Return([]entity.ImportRule{
+		entity.NewImportRule("такси", "транспорт"),
+		entity.NewImportRule("яндекс такси", "такси"),
+		entity.NewImportRule("пятерочка", "продукты"),
+	}, nil)
+
+	var externalIDs []string
+
+	// Первая операция уже была загружена раньше
+	expenseStorage.EXPECT().GetExternalIDs(gomock.Any(), entity.UserID(101), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, ids []string) ([]string, error) {
+			externalIDs = ids
+
+			return ids[:1], nil
+		})
+
+	created := make([]entity.Expense, 0)
+
+	expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(101), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) (entity.ExpenseID, bool, error) {
+			created = append(created, expense)
+
+			return entity.ExpenseID(len(created)), true, nil
+		}).Times(3)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.ImportExpenses(ctx, usecase.ImportExpensesReqDTO{
+		UserID: 101,
+		Data:   []byte(testStatement),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.ImportExpensesRespDTO{
+		Done:       true,
+		Imported:   3,
+		Duplicates: 1,
+		Skipped:    1,
+	}, resp)
+
+	// Одинаковые операции в выписке получают разные идентификаторы
+	assert.Len(t, externalIDs, 5)
+	assert.NotEqual(t, externalIDs[1], externalIDs[2])
+
+	assert.Equal(t, "продукты", created[0].GetCategory())
+	assert.Equal(t, "продукты", created[1].GetCategory())
+	assert.Equal(t, externalIDs[2], created[1].GetExternalID())
+
+	assert.Equal(t, "прочее", created[2].GetCategory())
+	assert.True(t, decimal.New(500, 0).Equal(created[2].GetPrice()))
+	assert.Equal(t, "USD", created[2].GetOriginalCurrency())
+}
+
+// Без колонки валюты идентификаторы не зависят от валюты по умолчанию, и после ее смены
+// повторная загрузка той же выписки ничего не добавляет.
+func TestImportExpenses_ReimportAfterCurrencyChange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil).AnyTimes()
+	importStorage.EXPECT().GetColumns(gomock.Any(), entity.UserID(101)).Return(entity.ImportColumns{}, nil).Times(2)
+	importStorage.EXPECT().GetRules(gomock.Any(), entity.UserID(101)).Return(nil, nil).AnyTimes()
+
+	gomock.InOrder(
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("RUB", nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(101)).Return("USD", nil),
+	)
+
+	externalIDs := make([][]string, 0, 2)
+
+	// Все операции уже загружены, новых расходов нет
+	expenseStorage.EXPECT().GetExternalIDs(gomock.Any(), entity.UserID(101), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, ids []string) ([]string, error) {
+			externalIDs = append(externalIDs, ids)
+
+			return ids, nil
+		}).Times(2)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	data := []byte("Дата операции;Описание;Сумма\n17.10.2026 09:30;Яндекс Такси;-300,50\n")
+
+	for i := 0; i < 2; i++ {
+		resp, err := expenseUsecase.ImportExpenses(ctx, usecase.ImportExpensesReqDTO{
+			UserID: 101,
+			Data:   data,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Duplicates)
+	}
+
+	assert.Len(t, externalIDs[0], 1)
+	assert.Equal(t, externalIDs[0], externalIDs[1])
+}
+
+func TestImportExpenses_ColumnsNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil).AnyTimes()
+
+	importStorage.EXPECT().GetColumns(gomock.Any(), entity.UserID(101)).Return(entity.ImportColumns{}, nil)
+	importStorage.EXPECT().GetRules(gomock.Any(), entity.UserID(101)).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.ImportExpenses(ctx, usecase.ImportExpensesReqDTO{
+		UserID: 101,
+		Data:   []byte("a;b;c\n1;2;3\n"),
+	})
+	assert.Error(t, err)
+	assert.False(t, resp.Done)
+}
+
+func TestSetImportColumns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	importStorage.EXPECT().UpdateColumns(gomock.Any(), entity.UserID(101), entity.NewImportColumns(1, 4, 3, 0)).
+		Return(nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.SetImportColumns(ctx, usecase.SetImportColumnsReqDTO{
+		UserID:      101,
+		Date:        1,
+		Amount:      4,
+		Description: 3,
+	})
+	assert.NoError(t, err)
+
+	_, err = expenseUsecase.SetImportColumns(ctx, usecase.SetImportColumnsReqDTO{
+		UserID: 101,
+		Date:   1,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// AddIncome добавляет доход. Доходы попадают только в отчеты, лимиты считаются по расходам.
+func (uc *ExpenseUsecase) AddIncome(ctx context.Context, req AddIncomeReqDTO) (AddIncomeRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddIncome")
+	defer span.End()
+
+	userID, isMember, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+	}
+
+	err = uc.tryUpdateRates(ctx, false)
+	if err != nil {
+		return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+	}
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	rate, err := uc.currencyStorage.Get(ctx, currency)
+	if err != nil {
+		return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+	}
+
+	incomeCurrency, incomeRate := currency, rate
+
+	if len(req.Currency) != 0 && req.Currency != currency {
+		if !uc.isSupportedCurrencyCode(req.Currency) {
+			return AddIncomeRespDTO{}, errors.New("currency is unsupported")
+		}
+
+		incomeCurrency = req.Currency
+	}
+
+	// Доход задним числом переводится в базовую валюту по курсу на его дату
+	if incomeCurrency != currency || uc.isPastRate(currency, req.Date) {
+		incomeRate, err = uc.getRateForDate(ctx, incomeCurrency, req.Date)
+		if err != nil {
+			return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+		}
+	}
+
+	income := entity.NewIncome(strings.ToLower(req.Source), req.Amount.Div(incomeRate.GetRatio()), req.Date)
+	income.SetOriginalAmount(req.Amount, incomeCurrency)
+
+	if len(req.Account) != 0 {
+		account, found, err := uc.accountStorage.GetByName(ctx, userID, strings.ToLower(req.Account))
+		if err != nil {
+			return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+		}
+
+		if !found {
+			return AddIncomeRespDTO{AccountNotFound: true}, nil //nolint:exhaustruct
+		}
+
+		amount, err := uc.accountAmount(ctx, account, req.Amount, incomeCurrency, income.GetAmount())
+		if err != nil {
+			return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+		}
+
+		income.SetAccount(account.GetID(), amount)
+	}
+
+	if isMember {
+		income.SetMemberID(entity.UserID(req.UserID))
+	}
+
+	incomeID, err := uc.incomeStorage.Create(ctx, userID, income)
+	if err != nil {
+		return AddIncomeRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddIncome")
+	}
+
+	// Доходы входят в отчеты за период, поэтому закешированные отчеты устарели
+	uc.deleteReportFromCache(int64(userID), income.GetDate())
+
+	resp := AddIncomeRespDTO{
+		ID:              int64(incomeID),
+		Amount:          income.GetAmount().Mul(rate.GetRatio()),
+		Currency:        currency,
+		AccountNotFound: false,
+	}
+
+	return resp, nil
+}
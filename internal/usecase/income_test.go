@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func TestAddIncome(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetCurrencyCodes().Return([]string{"USD", "EUR"}).AnyTimes()
+
+	date := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	// Доход задним числом пересчитывается по курсу на его дату
+	currencyStorage.EXPECT().GetForDate(gomock.Any(), "USD", date).
+		Return(entity.NewRate("USD", decimal.New(1, -2), date), nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+
+	expected := entity.NewIncome("фриланс", decimal.New(10000, 0), date)
+	expected.SetOriginalAmount(decimal.New(100, 0), "USD")
+
+	// Доход не проверяет лимиты, поэтому к хранилищу лимитов обращений нет
+	incomeStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, income entity.Income) (entity.IncomeID, error) {
+			assert.Equal(t, expected.GetSource(), income.GetSource())
+			assert.True(t, expected.GetAmount().Equal(income.GetAmount()))
+			assert.Equal(t, expected.GetOriginalCurrency(), income.GetOriginalCurrency())
+			assert.Equal(t, date, income.GetDate())
+
+			return 5, nil
+		})
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddIncome(ctx, usecase.AddIncomeReqDTO{
+		UserID:   202,
+		Source:   "Фриланс",
+		Amount:   decimal.New(100, 0),
+		Currency: "USD",
+		Date:     date,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), resp.ID)
+	assert.Equal(t, "RUB", resp.Currency)
+	assert.True(t, decimal.New(10000, 0).Equal(resp.Amount))
+}
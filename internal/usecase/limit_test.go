@@ -0,0 +1,295 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestSetLimit_Category(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("RUB", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+			Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil),
+		categoryStorage.EXPECT().Resolve(gomock.Any(), entity.UserID(202), "кофейни").
+			Return("кафе", nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return(nil, nil),
+		limitStorage.EXPECT().Update(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, limit entity.Limit) error {
+				assert.Equal(t, "кафе", limit.GetCategory())
+				assert.Equal(t, utils.MonthInterval, limit.GetIntervalType())
+				assert.True(t, decimal.New(10000, 0).Equal(limit.GetAmount()))
+
+				return nil
+			}),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.SetLimit(ctx, usecase.SetLimitReqDTO{
+		UserID:       202,
+		Category:     "кофейни",
+		Limit:        decimal.New(10000, 0),
+		IntervalType: utils.MonthInterval,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.SetLimitRespDTO{Category: "кафе", Currency: "RUB"}, resp)
+}
+
+func TestAddExpense_CategoryLimit(t *testing.T) {
+	t.Parallel()
+
+	date := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	gomock.InOrder(
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			Return(entity.ExpenseID(3), true, nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Limit{
+				entity.NewLimit("", utils.DayInterval, decimal.New(5000, 0)),
+				entity.NewLimit("еда", utils.MonthInterval, decimal.New(1000, 0)),
+				entity.NewLimit("транспорт", utils.MonthInterval, decimal.New(1000, 0)),
+			}, nil),
+		categoryStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Category{
+				newCategory(3, "транспорт", 0),
+				newCategory(5, "такси", 3),
+				newCategory(7, "еда", 0),
+			}, nil),
+		expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(202), gomock.Any(), gomock.Any()).
+			Return([]entity.Expense{
+				entity.NewExpense("такси", decimal.New(700, 0), date),
+			}, nil),
+		expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(202), gomock.Any(), gomock.Any()).
+			Return([]entity.Expense{
+				entity.NewExpense("Транспорт", decimal.New(600, 0), date),
+				entity.NewExpense("такси", decimal.New(700, 0), date),
+				entity.NewExpense("еда", decimal.New(900, 0), date),
+			}, nil),
+		config.EXPECT().GetLimitThresholds().Return([]int{80, 100}),
+		limitStorage.EXPECT().GetNotified(gomock.Any(), entity.UserID(202),
+			entity.NewLimit("транспорт", utils.MonthInterval, decimal.New(1000, 0)),
+			timeHelper(2022, 11, 1)).
+			Return([]int{80}, nil),
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, cmd usecase.Command) error {
+				assert.Equal(t, usecase.LimitNotificationCmdName, cmd.Name)
+				assert.Equal(t, int64(202), cmd.UserID)
+				assert.Equal(t, "транспорт", cmd.LimitNotificationDTO.Category)
+				assert.Equal(t, 100, cmd.LimitNotificationDTO.Threshold)
+				assert.True(t, decimal.New(1300, 0).Equal(cmd.LimitNotificationDTO.Spent))
+
+				return nil
+			}),
+		limitStorage.EXPECT().MarkNotified(gomock.Any(), entity.UserID(202),
+			entity.NewLimit("транспорт", utils.MonthInterval, decimal.New(1000, 0)),
+			timeHelper(2022, 11, 1), 100).
+			Return(nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "такси",
+		Price:    decimal.New(700, 0),
+		Date:     date,
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, resp.Limits, 2)
+	assert.Equal(t, "", resp.Limits[0].Category)
+	assert.True(t, decimal.New(4300, 0).Equal(resp.Limits[0].Limit))
+	assert.Equal(t, "транспорт", resp.Limits[1].Category)
+	assert.True(t, decimal.New(-300, 0).Equal(resp.Limits[1].Limit))
+}
+
+func TestAddExpense_LimitNotificationFailed(t *testing.T) {
+	t.Parallel()
+
+	date := timeHelper(2022, 11, 10)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(60).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	gomock.InOrder(
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			Return(entity.ExpenseID(3), true, nil),
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Limit{
+				entity.NewLimit("", utils.MonthInterval, decimal.New(1000, 0)),
+			}, nil),
+		expenseStorage.EXPECT().Get(gomock.Any(), entity.UserID(202), gomock.Any(), gomock.Any()).
+			Return([]entity.Expense{
+				entity.NewExpense("такси", decimal.New(700, 0), date),
+				entity.NewExpense("еда", decimal.New(500, 0), date),
+			}, nil),
+		config.EXPECT().GetLimitThresholds().Return([]int{80, 100}),
+		limitStorage.EXPECT().GetNotified(gomock.Any(), entity.UserID(202),
+			entity.NewLimit("", utils.MonthInterval, decimal.New(1000, 0)),
+			timeHelper(2022, 11, 1)).
+			Return(nil, nil),
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errUnknown),
+	)
+
+	// Уведомление не отправлено, поэтому порог не отмечается и будет отправлен при следующем расходе
+	limitStorage.EXPECT().MarkNotified(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(0)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "такси",
+		Price:    decimal.New(700, 0),
+		Date:     date,
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, resp.Limits, 1)
+	assert.True(t, decimal.New(-200, 0).Equal(resp.Limits[0].Limit))
+}
+
+func TestGetLimits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	gomock.InOrder(
+		limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).
+			Return([]entity.Limit{
+				entity.NewLimit("кафе", utils.MonthInterval, decimal.New(1000, 0)),
+			}, nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).
+			Return("USD", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+			Return(entity.NewRate("USD", decimal.New(2, -2), time.Now()), nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.GetLimits(ctx, usecase.GetLimitsReqDTO{UserID: 202})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "USD", resp.Currency)
+	assert.Len(t, resp.Limits, 1)
+	assert.Equal(t, "кафе", resp.Limits[0].Category)
+	assert.True(t, decimal.New(20, 0).Equal(resp.Limits[0].Limit))
+}
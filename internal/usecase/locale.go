@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/i18n"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrUnknownLocale = errors.New("unknown locale")
+
+type localeKey struct{}
+
+// WithLocale сохраняет в контексте язык ответа на команду.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func localeFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+
+	return locale
+}
+
+// SetLocale запоминает язык, выбранный пользователем вместо языка из настроек Telegram.
+func (uc *ExpenseUsecase) SetLocale(ctx context.Context, req SetLocaleReqDTO) (SetLocaleRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetLocale")
+	defer span.End()
+
+	locale, ok := i18n.ParseLocale(req.Locale)
+	if !ok {
+		return SetLocaleRespDTO{}, errors.Wrap(ErrUnknownLocale, "ExpenseUsecase.SetLocale")
+	}
+
+	err := uc.userStorage.UpdateLocale(ctx, entity.UserID(req.UserID), locale)
+	if err != nil {
+		return SetLocaleRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetLocale")
+	}
+
+	return SetLocaleRespDTO{Locale: locale}, nil
+}
+
+// userLocale язык, выбранный пользователем. Пустой, если пользователь его не выбирал.
+func (uc *ExpenseUsecase) userLocale(ctx context.Context, userID int64) string {
+	locale, err := uc.userStorage.GetLocale(ctx, entity.UserID(userID))
+	if err != nil {
+		return ""
+	}
+
+	return locale
+}
@@ -68,181 +68,1146 @@ func (mr *MockICurrencyStorageMockRecorder) GetAll(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockICurrencyStorage)(nil).GetAll), arg0)
 }
 
+// GetForDate mocks base method.
+func (m *MockICurrencyStorage) GetForDate(arg0 context.Context, arg1 string, arg2 time.Time) (entity.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForDate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForDate indicates an expected call of GetForDate.
+func (mr *MockICurrencyStorageMockRecorder) GetForDate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForDate", reflect.TypeOf((*MockICurrencyStorage)(nil).GetForDate), arg0, arg1, arg2)
+}
+
+// GetRange mocks base method.
+func (m *MockICurrencyStorage) GetRange(ctx context.Context, currency string, start, end time.Time) ([]entity.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", ctx, currency, start, end)
+	ret0, _ := ret[0].([]entity.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockICurrencyStorageMockRecorder) GetRange(ctx, currency, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockICurrencyStorage)(nil).GetRange), ctx, currency, start, end)
+}
+
 // Update mocks base method.
 func (m *MockICurrencyStorage) Update(arg0 context.Context, arg1 entity.Rate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockICurrencyStorageMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockICurrencyStorage)(nil).Update), arg0, arg1)
+}
+
+// UpdateHistory mocks base method.
+func (m *MockICurrencyStorage) UpdateHistory(arg0 context.Context, arg1 entity.Rate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHistory indicates an expected call of UpdateHistory.
+func (mr *MockICurrencyStorageMockRecorder) UpdateHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistory", reflect.TypeOf((*MockICurrencyStorage)(nil).UpdateHistory), arg0, arg1)
+}
+
+// MockIUserStorage is a mock of IUserStorage interface.
+type MockIUserStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserStorageMockRecorder
+}
+
+// MockIUserStorageMockRecorder is the mock recorder for MockIUserStorage.
+type MockIUserStorageMockRecorder struct {
+	mock *MockIUserStorage
+}
+
+// NewMockIUserStorage creates a new mock instance.
+func NewMockIUserStorage(ctrl *gomock.Controller) *MockIUserStorage {
+	mock := &MockIUserStorage{ctrl: ctrl}
+	mock.recorder = &MockIUserStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserStorage) EXPECT() *MockIUserStorageMockRecorder {
+	return m.recorder
+}
+
+// GetDefaultCurrency mocks base method.
+func (m *MockIUserStorage) GetDefaultCurrency(arg0 context.Context, arg1 entity.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultCurrency", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultCurrency indicates an expected call of GetDefaultCurrency.
+func (mr *MockIUserStorageMockRecorder) GetDefaultCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultCurrency", reflect.TypeOf((*MockIUserStorage)(nil).GetDefaultCurrency), arg0, arg1)
+}
+
+// GetLocale mocks base method.
+func (m *MockIUserStorage) GetLocale(arg0 context.Context, arg1 entity.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocale", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocale indicates an expected call of GetLocale.
+func (mr *MockIUserStorageMockRecorder) GetLocale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocale", reflect.TypeOf((*MockIUserStorage)(nil).GetLocale), arg0, arg1)
+}
+
+// GetSummaryRecipients mocks base method.
+func (m *MockIUserStorage) GetSummaryRecipients(arg0 context.Context, arg1 int, arg2 time.Time) ([]entity.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaryRecipients", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaryRecipients indicates an expected call of GetSummaryRecipients.
+func (mr *MockIUserStorageMockRecorder) GetSummaryRecipients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryRecipients", reflect.TypeOf((*MockIUserStorage)(nil).GetSummaryRecipients), arg0, arg1, arg2)
+}
+
+// UpdateDefaultCurrency mocks base method.
+func (m *MockIUserStorage) UpdateDefaultCurrency(arg0 context.Context, arg1 entity.UserID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDefaultCurrency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDefaultCurrency indicates an expected call of UpdateDefaultCurrency.
+func (mr *MockIUserStorageMockRecorder) UpdateDefaultCurrency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDefaultCurrency", reflect.TypeOf((*MockIUserStorage)(nil).UpdateDefaultCurrency), arg0, arg1, arg2)
+}
+
+// UpdateLocale mocks base method.
+func (m *MockIUserStorage) UpdateLocale(arg0 context.Context, arg1 entity.UserID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocale", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocale indicates an expected call of UpdateLocale.
+func (mr *MockIUserStorageMockRecorder) UpdateLocale(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocale", reflect.TypeOf((*MockIUserStorage)(nil).UpdateLocale), arg0, arg1, arg2)
+}
+
+// UpdateSummaryInterval mocks base method.
+func (m *MockIUserStorage) UpdateSummaryInterval(arg0 context.Context, arg1 entity.UserID, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSummaryInterval", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSummaryInterval indicates an expected call of UpdateSummaryInterval.
+func (mr *MockIUserStorageMockRecorder) UpdateSummaryInterval(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSummaryInterval", reflect.TypeOf((*MockIUserStorage)(nil).UpdateSummaryInterval), arg0, arg1, arg2)
+}
+
+// UpdateSummarySentAt mocks base method.
+func (m *MockIUserStorage) UpdateSummarySentAt(arg0 context.Context, arg1 entity.UserID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSummarySentAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSummarySentAt indicates an expected call of UpdateSummarySentAt.
+func (mr *MockIUserStorageMockRecorder) UpdateSummarySentAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSummarySentAt", reflect.TypeOf((*MockIUserStorage)(nil).UpdateSummarySentAt), arg0, arg1, arg2)
+}
+
+// MockIExpenseStorage is a mock of IExpenseStorage interface.
+type MockIExpenseStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExpenseStorageMockRecorder
+}
+
+// MockIExpenseStorageMockRecorder is the mock recorder for MockIExpenseStorage.
+type MockIExpenseStorageMockRecorder struct {
+	mock *MockIExpenseStorage
+}
+
+// NewMockIExpenseStorage creates a new mock instance.
+func NewMockIExpenseStorage(ctrl *gomock.Controller) *MockIExpenseStorage {
+	mock := &MockIExpenseStorage{ctrl: ctrl}
+	mock.recorder = &MockIExpenseStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExpenseStorage) EXPECT() *MockIExpenseStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIExpenseStorage) Create(arg0 context.Context, arg1 entity.UserID, arg2 entity.Expense) (entity.ExpenseID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ExpenseID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIExpenseStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIExpenseStorage)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockIExpenseStorage) Delete(arg0 context.Context, arg1 entity.UserID, arg2 entity.ExpenseID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIExpenseStorageMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIExpenseStorage)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockIExpenseStorage) Get(arg0 context.Context, arg1 entity.UserID, arg2, arg3 time.Time) ([]entity.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entity.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIExpenseStorageMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIExpenseStorage)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
+// GetByID mocks base method.
+func (m *MockIExpenseStorage) GetByID(arg0 context.Context, arg1 entity.UserID, arg2 entity.ExpenseID) (entity.Expense, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Expense)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIExpenseStorageMockRecorder) GetByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIExpenseStorage)(nil).GetByID), arg0, arg1, arg2)
+}
+
+// GetExternalIDs mocks base method.
+func (m *MockIExpenseStorage) GetExternalIDs(arg0 context.Context, arg1 entity.UserID, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalIDs indicates an expected call of GetExternalIDs.
+func (mr *MockIExpenseStorageMockRecorder) GetExternalIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIDs", reflect.TypeOf((*MockIExpenseStorage)(nil).GetExternalIDs), arg0, arg1, arg2)
+}
+
+// Search mocks base method.
+func (m *MockIExpenseStorage) Search(arg0 context.Context, arg1 entity.UserID, arg2 string, arg3, arg4 time.Time, arg5 int) ([]entity.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]entity.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockIExpenseStorageMockRecorder) Search(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockIExpenseStorage)(nil).Search), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Update mocks base method.
+func (m *MockIExpenseStorage) Update(arg0 context.Context, arg1 entity.UserID, arg2 entity.Expense) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIExpenseStorageMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIExpenseStorage)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockIIncomeStorage is a mock of IIncomeStorage interface.
+type MockIIncomeStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIncomeStorageMockRecorder
+}
+
+// MockIIncomeStorageMockRecorder is the mock recorder for MockIIncomeStorage.
+type MockIIncomeStorageMockRecorder struct {
+	mock *MockIIncomeStorage
+}
+
+// NewMockIIncomeStorage creates a new mock instance.
+func NewMockIIncomeStorage(ctrl *gomock.Controller) *MockIIncomeStorage {
+	mock := &MockIIncomeStorage{ctrl: ctrl}
+	mock.recorder = &MockIIncomeStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIncomeStorage) EXPECT() *MockIIncomeStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIIncomeStorage) Create(arg0 context.Context, arg1 entity.UserID, arg2 entity.Income) (entity.IncomeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.IncomeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIIncomeStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIIncomeStorage)(nil).Create), arg0, arg1, arg2)
+}
+
+// MockIAccountStorage is a mock of IAccountStorage interface.
+type MockIAccountStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountStorageMockRecorder
+}
+
+// MockIAccountStorageMockRecorder is the mock recorder for MockIAccountStorage.
+type MockIAccountStorageMockRecorder struct {
+	mock *MockIAccountStorage
+}
+
+// NewMockIAccountStorage creates a new mock instance.
+func NewMockIAccountStorage(ctrl *gomock.Controller) *MockIAccountStorage {
+	mock := &MockIAccountStorage{ctrl: ctrl}
+	mock.recorder = &MockIAccountStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountStorage) EXPECT() *MockIAccountStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAccountStorage) Create(arg0 context.Context, arg1 entity.UserID, arg2 entity.Account) (entity.AccountID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.AccountID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAccountStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAccountStorage)(nil).Create), arg0, arg1, arg2)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockIAccountStorage) CreateTransfer(arg0 context.Context, arg1 entity.UserID, arg2 entity.Transfer) (entity.TransferID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.TransferID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockIAccountStorageMockRecorder) CreateTransfer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockIAccountStorage)(nil).CreateTransfer), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockIAccountStorage) GetAll(arg0 context.Context, arg1 entity.UserID) ([]entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIAccountStorageMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIAccountStorage)(nil).GetAll), arg0, arg1)
+}
+
+// GetByName mocks base method.
+func (m *MockIAccountStorage) GetByName(arg0 context.Context, arg1 entity.UserID, arg2 string) (entity.Account, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Account)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockIAccountStorageMockRecorder) GetByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockIAccountStorage)(nil).GetByName), arg0, arg1, arg2)
+}
+
+// MockIGoalStorage is a mock of IGoalStorage interface.
+type MockIGoalStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGoalStorageMockRecorder
+}
+
+// MockIGoalStorageMockRecorder is the mock recorder for MockIGoalStorage.
+type MockIGoalStorageMockRecorder struct {
+	mock *MockIGoalStorage
+}
+
+// NewMockIGoalStorage creates a new mock instance.
+func NewMockIGoalStorage(ctrl *gomock.Controller) *MockIGoalStorage {
+	mock := &MockIGoalStorage{ctrl: ctrl}
+	mock.recorder = &MockIGoalStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGoalStorage) EXPECT() *MockIGoalStorageMockRecorder {
+	return m.recorder
+}
+
+// AddContribution mocks base method.
+func (m *MockIGoalStorage) AddContribution(arg0 context.Context, arg1 entity.GoalID, arg2 decimal.Decimal, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContribution", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContribution indicates an expected call of AddContribution.
+func (mr *MockIGoalStorageMockRecorder) AddContribution(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContribution", reflect.TypeOf((*MockIGoalStorage)(nil).AddContribution), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockIGoalStorage) Create(arg0 context.Context, arg1 entity.Goal) (entity.GoalID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(entity.GoalID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIGoalStorageMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIGoalStorage)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIGoalStorage) Delete(arg0 context.Context, arg1 entity.UserID, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIGoalStorageMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIGoalStorage)(nil).Delete), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockIGoalStorage) GetAll(arg0 context.Context, arg1 entity.UserID) ([]entity.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIGoalStorageMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIGoalStorage)(nil).GetAll), arg0, arg1)
+}
+
+// GetByName mocks base method.
+func (m *MockIGoalStorage) GetByName(arg0 context.Context, arg1 entity.UserID, arg2 string) (entity.Goal, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Goal)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockIGoalStorageMockRecorder) GetByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockIGoalStorage)(nil).GetByName), arg0, arg1, arg2)
+}
+
+// GetForNudge mocks base method.
+func (m *MockIGoalStorage) GetForNudge(arg0 context.Context, arg1, arg2 time.Time) ([]entity.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForNudge", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForNudge indicates an expected call of GetForNudge.
+func (mr *MockIGoalStorageMockRecorder) GetForNudge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForNudge", reflect.TypeOf((*MockIGoalStorage)(nil).GetForNudge), arg0, arg1, arg2)
+}
+
+// MarkNudged mocks base method.
+func (m *MockIGoalStorage) MarkNudged(arg0 context.Context, arg1 entity.GoalID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNudged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNudged indicates an expected call of MarkNudged.
+func (mr *MockIGoalStorageMockRecorder) MarkNudged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNudged", reflect.TypeOf((*MockIGoalStorage)(nil).MarkNudged), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockIGoalStorage) Update(arg0 context.Context, arg1 entity.GoalID, arg2 decimal.Decimal, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIGoalStorageMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIGoalStorage)(nil).Update), arg0, arg1, arg2, arg3)
+}
+
+// MockILimitStorage is a mock of ILimitStorage interface.
+type MockILimitStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockILimitStorageMockRecorder
+}
+
+// MockILimitStorageMockRecorder is the mock recorder for MockILimitStorage.
+type MockILimitStorageMockRecorder struct {
+	mock *MockILimitStorage
+}
+
+// NewMockILimitStorage creates a new mock instance.
+func NewMockILimitStorage(ctrl *gomock.Controller) *MockILimitStorage {
+	mock := &MockILimitStorage{ctrl: ctrl}
+	mock.recorder = &MockILimitStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILimitStorage) EXPECT() *MockILimitStorageMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockILimitStorage) GetAll(arg0 context.Context, arg1 entity.UserID) ([]entity.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockILimitStorageMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockILimitStorage)(nil).GetAll), arg0, arg1)
+}
+
+// GetNotified mocks base method.
+func (m *MockILimitStorage) GetNotified(arg0 context.Context, arg1 entity.UserID, arg2 entity.Limit, arg3 time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotified", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotified indicates an expected call of GetNotified.
+func (mr *MockILimitStorageMockRecorder) GetNotified(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotified", reflect.TypeOf((*MockILimitStorage)(nil).GetNotified), arg0, arg1, arg2, arg3)
+}
+
+// MarkNotified mocks base method.
+func (m *MockILimitStorage) MarkNotified(arg0 context.Context, arg1 entity.UserID, arg2 entity.Limit, arg3 time.Time, arg4 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockILimitStorageMockRecorder) MarkNotified(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockILimitStorage)(nil).MarkNotified), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Update mocks base method.
+func (m *MockILimitStorage) Update(arg0 context.Context, arg1 entity.UserID, arg2 entity.Limit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockILimitStorageMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockILimitStorage)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockIRecurringExpenseStorage is a mock of IRecurringExpenseStorage interface.
+type MockIRecurringExpenseStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecurringExpenseStorageMockRecorder
+}
+
+// MockIRecurringExpenseStorageMockRecorder is the mock recorder for MockIRecurringExpenseStorage.
+type MockIRecurringExpenseStorageMockRecorder struct {
+	mock *MockIRecurringExpenseStorage
+}
+
+// NewMockIRecurringExpenseStorage creates a new mock instance.
+func NewMockIRecurringExpenseStorage(ctrl *gomock.Controller) *MockIRecurringExpenseStorage {
+	mock := &MockIRecurringExpenseStorage{ctrl: ctrl}
+	mock.recorder = &MockIRecurringExpenseStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecurringExpenseStorage) EXPECT() *MockIRecurringExpenseStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRecurringExpenseStorage) Create(arg0 context.Context, arg1 entity.RecurringExpense) (entity.RecurringExpenseID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(entity.RecurringExpenseID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRecurringExpenseStorageMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecurringExpenseStorage)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIRecurringExpenseStorage) Delete(arg0 context.Context, arg1 entity.UserID, arg2 entity.RecurringExpenseID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRecurringExpenseStorageMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRecurringExpenseStorage)(nil).Delete), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockIRecurringExpenseStorage) GetAll(arg0 context.Context, arg1 entity.UserID) ([]entity.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]entity.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIRecurringExpenseStorageMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIRecurringExpenseStorage)(nil).GetAll), arg0, arg1)
+}
+
+// GetDue mocks base method.
+func (m *MockIRecurringExpenseStorage) GetDue(arg0 context.Context, arg1 time.Time) ([]entity.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", arg0, arg1)
+	ret0, _ := ret[0].([]entity.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockIRecurringExpenseStorageMockRecorder) GetDue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockIRecurringExpenseStorage)(nil).GetDue), arg0, arg1)
+}
+
+// UpdateNextDate mocks base method.
+func (m *MockIRecurringExpenseStorage) UpdateNextDate(arg0 context.Context, arg1 entity.RecurringExpenseID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNextDate", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockICurrencyStorageMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+// UpdateNextDate indicates an expected call of UpdateNextDate.
+func (mr *MockIRecurringExpenseStorageMockRecorder) UpdateNextDate(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockICurrencyStorage)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNextDate", reflect.TypeOf((*MockIRecurringExpenseStorage)(nil).UpdateNextDate), arg0, arg1, arg2)
 }
 
-// MockIUserStorage is a mock of IUserStorage interface.
-type MockIUserStorage struct {
+// MockIImportStorage is a mock of IImportStorage interface.
+type MockIImportStorage struct {
 	ctrl     *gomock.Controller
-	recorder *MockIUserStorageMockRecorder
+	recorder *MockIImportStorageMockRecorder
 }
 
-// MockIUserStorageMockRecorder is the mock recorder for MockIUserStorage.
-type MockIUserStorageMockRecorder struct {
-	mock *MockIUserStorage
+// MockIImportStorageMockRecorder is the mock recorder for MockIImportStorage.
+type MockIImportStorageMockRecorder struct {
+	mock *MockIImportStorage
 }
 
-// NewMockIUserStorage creates a new mock instance.
-func NewMockIUserStorage(ctrl *gomock.Controller) *MockIUserStorage {
-	mock := &MockIUserStorage{ctrl: ctrl}
-	mock.recorder = &MockIUserStorageMockRecorder{mock}
+// NewMockIImportStorage creates a new mock instance.
+func NewMockIImportStorage(ctrl *gomock.Controller) *MockIImportStorage {
+	mock := &MockIImportStorage{ctrl: ctrl}
+	mock.recorder = &MockIImportStorageMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIUserStorage) EXPECT() *MockIUserStorageMockRecorder {
+func (m *MockIImportStorage) EXPECT() *MockIImportStorageMockRecorder {
 	return m.recorder
 }
 
-// GetDefaultCurrency mocks base method.
-func (m *MockIUserStorage) GetDefaultCurrency(arg0 context.Context, arg1 entity.UserID) (string, error) {
+// DeleteRule mocks base method.
+func (m *MockIImportStorage) DeleteRule(arg0 context.Context, arg1 entity.UserID, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDefaultCurrency", arg0, arg1)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDefaultCurrency indicates an expected call of GetDefaultCurrency.
-func (mr *MockIUserStorageMockRecorder) GetDefaultCurrency(arg0, arg1 interface{}) *gomock.Call {
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockIImportStorageMockRecorder) DeleteRule(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultCurrency", reflect.TypeOf((*MockIUserStorage)(nil).GetDefaultCurrency), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockIImportStorage)(nil).DeleteRule), arg0, arg1, arg2)
+}
+
+// GetColumns mocks base method.
+func (m *MockIImportStorage) GetColumns(arg0 context.Context, arg1 entity.UserID) (entity.ImportColumns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumns", arg0, arg1)
+	ret0, _ := ret[0].(entity.ImportColumns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetLimits mocks base method.
-func (m *MockIUserStorage) GetLimits(arg0 context.Context, arg1 entity.UserID) (decimal.Decimal, decimal.Decimal, decimal.Decimal, error) {
+// GetColumns indicates an expected call of GetColumns.
+func (mr *MockIImportStorageMockRecorder) GetColumns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockIImportStorage)(nil).GetColumns), arg0, arg1)
+}
+
+// GetRules mocks base method.
+func (m *MockIImportStorage) GetRules(arg0 context.Context, arg1 entity.UserID) ([]entity.ImportRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLimits", arg0, arg1)
-	ret0, _ := ret[0].(decimal.Decimal)
-	ret1, _ := ret[1].(decimal.Decimal)
-	ret2, _ := ret[2].(decimal.Decimal)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret := m.ctrl.Call(m, "GetRules", arg0, arg1)
+	ret0, _ := ret[0].([]entity.ImportRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetLimits indicates an expected call of GetLimits.
-func (mr *MockIUserStorageMockRecorder) GetLimits(arg0, arg1 interface{}) *gomock.Call {
+// GetRules indicates an expected call of GetRules.
+func (mr *MockIImportStorageMockRecorder) GetRules(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimits", reflect.TypeOf((*MockIUserStorage)(nil).GetLimits), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockIImportStorage)(nil).GetRules), arg0, arg1)
 }
 
-// UpdateDayLimit mocks base method.
-func (m *MockIUserStorage) UpdateDayLimit(arg0 context.Context, arg1 entity.UserID, arg2 decimal.Decimal) error {
+// UpdateColumns mocks base method.
+func (m *MockIImportStorage) UpdateColumns(arg0 context.Context, arg1 entity.UserID, arg2 entity.ImportColumns) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDayLimit", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateColumns", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateDayLimit indicates an expected call of UpdateDayLimit.
-func (mr *MockIUserStorageMockRecorder) UpdateDayLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockIImportStorageMockRecorder) UpdateColumns(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDayLimit", reflect.TypeOf((*MockIUserStorage)(nil).UpdateDayLimit), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockIImportStorage)(nil).UpdateColumns), arg0, arg1, arg2)
 }
 
-// UpdateDefaultCurrency mocks base method.
-func (m *MockIUserStorage) UpdateDefaultCurrency(arg0 context.Context, arg1 entity.UserID, arg2 string) error {
+// UpdateRule mocks base method.
+func (m *MockIImportStorage) UpdateRule(arg0 context.Context, arg1 entity.UserID, arg2 entity.ImportRule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDefaultCurrency", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateRule", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateDefaultCurrency indicates an expected call of UpdateDefaultCurrency.
-func (mr *MockIUserStorageMockRecorder) UpdateDefaultCurrency(arg0, arg1, arg2 interface{}) *gomock.Call {
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockIImportStorageMockRecorder) UpdateRule(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDefaultCurrency", reflect.TypeOf((*MockIUserStorage)(nil).UpdateDefaultCurrency), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockIImportStorage)(nil).UpdateRule), arg0, arg1, arg2)
+}
+
+// MockICategoryStorage is a mock of ICategoryStorage interface.
+type MockICategoryStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockICategoryStorageMockRecorder
+}
+
+// MockICategoryStorageMockRecorder is the mock recorder for MockICategoryStorage.
+type MockICategoryStorageMockRecorder struct {
+	mock *MockICategoryStorage
+}
+
+// NewMockICategoryStorage creates a new mock instance.
+func NewMockICategoryStorage(ctrl *gomock.Controller) *MockICategoryStorage {
+	mock := &MockICategoryStorage{ctrl: ctrl}
+	mock.recorder = &MockICategoryStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICategoryStorage) EXPECT() *MockICategoryStorageMockRecorder {
+	return m.recorder
+}
+
+// AddAlias mocks base method.
+func (m *MockICategoryStorage) AddAlias(arg0 context.Context, arg1 entity.UserID, arg2 string, arg3 entity.CategoryID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlias", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAlias indicates an expected call of AddAlias.
+func (mr *MockICategoryStorageMockRecorder) AddAlias(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlias", reflect.TypeOf((*MockICategoryStorage)(nil).AddAlias), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockICategoryStorage) Create(arg0 context.Context, arg1 entity.UserID, arg2 entity.Category) (entity.CategoryID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.CategoryID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICategoryStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICategoryStorage)(nil).Create), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockICategoryStorage) GetAll(arg0 context.Context, arg1 entity.UserID) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateMonthLimit mocks base method.
-func (m *MockIUserStorage) UpdateMonthLimit(arg0 context.Context, arg1 entity.UserID, arg2 decimal.Decimal) error {
+// GetAll indicates an expected call of GetAll.
+func (mr *MockICategoryStorageMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockICategoryStorage)(nil).GetAll), arg0, arg1)
+}
+
+// GetByName mocks base method.
+func (m *MockICategoryStorage) GetByName(arg0 context.Context, arg1 entity.UserID, arg2 string) (entity.Category, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Category)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockICategoryStorageMockRecorder) GetByName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockICategoryStorage)(nil).GetByName), arg0, arg1, arg2)
+}
+
+// Merge mocks base method.
+func (m *MockICategoryStorage) Merge(arg0 context.Context, arg1 entity.UserID, arg2, arg3 entity.CategoryID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMonthLimit", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateMonthLimit indicates an expected call of UpdateMonthLimit.
-func (mr *MockIUserStorageMockRecorder) UpdateMonthLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+// Merge indicates an expected call of Merge.
+func (mr *MockICategoryStorageMockRecorder) Merge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMonthLimit", reflect.TypeOf((*MockIUserStorage)(nil).UpdateMonthLimit), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockICategoryStorage)(nil).Merge), arg0, arg1, arg2, arg3)
 }
 
-// UpdateWeekLimit mocks base method.
-func (m *MockIUserStorage) UpdateWeekLimit(arg0 context.Context, arg1 entity.UserID, arg2 decimal.Decimal) error {
+// Rename mocks base method.
+func (m *MockICategoryStorage) Rename(arg0 context.Context, arg1 entity.UserID, arg2 entity.CategoryID, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWeekLimit", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateWeekLimit indicates an expected call of UpdateWeekLimit.
-func (mr *MockIUserStorageMockRecorder) UpdateWeekLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+// Rename indicates an expected call of Rename.
+func (mr *MockICategoryStorageMockRecorder) Rename(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWeekLimit", reflect.TypeOf((*MockIUserStorage)(nil).UpdateWeekLimit), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockICategoryStorage)(nil).Rename), arg0, arg1, arg2, arg3)
 }
 
-// MockIExpenseStorage is a mock of IExpenseStorage interface.
-type MockIExpenseStorage struct {
+// Resolve mocks base method.
+func (m *MockICategoryStorage) Resolve(arg0 context.Context, arg1 entity.UserID, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockICategoryStorageMockRecorder) Resolve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockICategoryStorage)(nil).Resolve), arg0, arg1, arg2)
+}
+
+// MockIBudgetStorage is a mock of IBudgetStorage interface.
+type MockIBudgetStorage struct {
 	ctrl     *gomock.Controller
-	recorder *MockIExpenseStorageMockRecorder
+	recorder *MockIBudgetStorageMockRecorder
 }
 
-// MockIExpenseStorageMockRecorder is the mock recorder for MockIExpenseStorage.
-type MockIExpenseStorageMockRecorder struct {
-	mock *MockIExpenseStorage
+// MockIBudgetStorageMockRecorder is the mock recorder for MockIBudgetStorage.
+type MockIBudgetStorageMockRecorder struct {
+	mock *MockIBudgetStorage
 }
 
-// NewMockIExpenseStorage creates a new mock instance.
-func NewMockIExpenseStorage(ctrl *gomock.Controller) *MockIExpenseStorage {
-	mock := &MockIExpenseStorage{ctrl: ctrl}
-	mock.recorder = &MockIExpenseStorageMockRecorder{mock}
+// NewMockIBudgetStorage creates a new mock instance.
+func NewMockIBudgetStorage(ctrl *gomock.Controller) *MockIBudgetStorage {
+	mock := &MockIBudgetStorage{ctrl: ctrl}
+	mock.recorder = &MockIBudgetStorageMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIExpenseStorage) EXPECT() *MockIExpenseStorageMockRecorder {
+func (m *MockIBudgetStorage) EXPECT() *MockIBudgetStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteMember mocks base method.
+func (m *MockIBudgetStorage) DeleteMember(arg0 context.Context, arg1, arg2 entity.UserID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockIBudgetStorageMockRecorder) DeleteMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockIBudgetStorage)(nil).DeleteMember), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockIBudgetStorage) Get(arg0 context.Context, arg1 entity.UserID) (entity.Budget, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(entity.Budget)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIBudgetStorageMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIBudgetStorage)(nil).Get), arg0, arg1)
+}
+
+// GetOwner mocks base method.
+func (m *MockIBudgetStorage) GetOwner(arg0 context.Context, arg1 entity.UserID) (entity.UserID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwner", arg0, arg1)
+	ret0, _ := ret[0].(entity.UserID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOwner indicates an expected call of GetOwner.
+func (mr *MockIBudgetStorageMockRecorder) GetOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwner", reflect.TypeOf((*MockIBudgetStorage)(nil).GetOwner), arg0, arg1)
+}
+
+// Join mocks base method.
+func (m *MockIBudgetStorage) Join(arg0 context.Context, arg1 entity.UserID, arg2 string) (entity.UserID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Join", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.UserID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Join indicates an expected call of Join.
+func (mr *MockIBudgetStorageMockRecorder) Join(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Join", reflect.TypeOf((*MockIBudgetStorage)(nil).Join), arg0, arg1, arg2)
+}
+
+// UpdateInviteCode mocks base method.
+func (m *MockIBudgetStorage) UpdateInviteCode(arg0 context.Context, arg1 entity.UserID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInviteCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInviteCode indicates an expected call of UpdateInviteCode.
+func (mr *MockIBudgetStorageMockRecorder) UpdateInviteCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInviteCode", reflect.TypeOf((*MockIBudgetStorage)(nil).UpdateInviteCode), arg0, arg1, arg2)
+}
+
+// MockIAuditStorage is a mock of IAuditStorage interface.
+type MockIAuditStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditStorageMockRecorder
+}
+
+// MockIAuditStorageMockRecorder is the mock recorder for MockIAuditStorage.
+type MockIAuditStorageMockRecorder struct {
+	mock *MockIAuditStorage
+}
+
+// NewMockIAuditStorage creates a new mock instance.
+func NewMockIAuditStorage(ctrl *gomock.Controller) *MockIAuditStorage {
+	mock := &MockIAuditStorage{ctrl: ctrl}
+	mock.recorder = &MockIAuditStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditStorage) EXPECT() *MockIAuditStorageMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockIExpenseStorage) Create(arg0 context.Context, arg1 entity.UserID, arg2 entity.Expense) error {
+func (m *MockIAuditStorage) Create(arg0 context.Context, arg1 entity.AuditRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIExpenseStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockIAuditStorageMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIExpenseStorage)(nil).Create), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAuditStorage)(nil).Create), arg0, arg1)
 }
 
-// Get mocks base method.
-func (m *MockIExpenseStorage) Get(arg0 context.Context, arg1 entity.UserID, arg2, arg3 time.Time) ([]entity.Expense, error) {
+// GetLast mocks base method.
+func (m *MockIAuditStorage) GetLast(arg0 context.Context, arg1, arg2 entity.UserID) (entity.AuditRecord, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].([]entity.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetLast", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.AuditRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockIExpenseStorageMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetLast indicates an expected call of GetLast.
+func (mr *MockIAuditStorageMockRecorder) GetLast(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIExpenseStorage)(nil).Get), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockIAuditStorage)(nil).GetLast), arg0, arg1, arg2)
+}
+
+// MarkUndone mocks base method.
+func (m *MockIAuditStorage) MarkUndone(arg0 context.Context, arg1 entity.AuditRecordID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUndone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUndone indicates an expected call of MarkUndone.
+func (mr *MockIAuditStorageMockRecorder) MarkUndone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUndone", reflect.TypeOf((*MockIAuditStorage)(nil).MarkUndone), arg0, arg1, arg2)
 }
 
 // MockIRatesUpdaterService is a mock of IRatesUpdaterService interface.
@@ -283,6 +1248,21 @@ func (mr *MockIRatesUpdaterServiceMockRecorder) Get(ctx, base, codes interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIRatesUpdaterService)(nil).Get), ctx, base, codes)
 }
 
+// GetForDate mocks base method.
+func (m *MockIRatesUpdaterService) GetForDate(ctx context.Context, base string, codes []string, date time.Time) ([]entity.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForDate", ctx, base, codes, date)
+	ret0, _ := ret[0].([]entity.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForDate indicates an expected call of GetForDate.
+func (mr *MockIRatesUpdaterServiceMockRecorder) GetForDate(ctx, base, codes, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForDate", reflect.TypeOf((*MockIRatesUpdaterService)(nil).GetForDate), ctx, base, codes, date)
+}
+
 // MockGetReportClient is a mock of GetReportClient interface.
 type MockGetReportClient struct {
 	ctrl     *gomock.Controller
@@ -321,6 +1301,43 @@ func (mr *MockGetReportClientMockRecorder) GetReport(ctx, req interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockGetReportClient)(nil).GetReport), ctx, req)
 }
 
+// MockINotifier is a mock of INotifier interface.
+type MockINotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotifierMockRecorder
+}
+
+// MockINotifierMockRecorder is the mock recorder for MockINotifier.
+type MockINotifierMockRecorder struct {
+	mock *MockINotifier
+}
+
+// NewMockINotifier creates a new mock instance.
+func NewMockINotifier(ctrl *gomock.Controller) *MockINotifier {
+	mock := &MockINotifier{ctrl: ctrl}
+	mock.recorder = &MockINotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotifier) EXPECT() *MockINotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockINotifier) Notify(arg0 context.Context, arg1 usecase.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockINotifierMockRecorder) Notify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockINotifier)(nil).Notify), arg0, arg1)
+}
+
 // MockIConfig is a mock of IConfig interface.
 type MockIConfig struct {
 	ctrl     *gomock.Controller
@@ -358,6 +1375,20 @@ func (mr *MockIConfigMockRecorder) GetBaseCurrencyCode() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseCurrencyCode", reflect.TypeOf((*MockIConfig)(nil).GetBaseCurrencyCode))
 }
 
+// GetConvertAtCurrentRate mocks base method.
+func (m *MockIConfig) GetConvertAtCurrentRate() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConvertAtCurrentRate")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetConvertAtCurrentRate indicates an expected call of GetConvertAtCurrentRate.
+func (mr *MockIConfigMockRecorder) GetConvertAtCurrentRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConvertAtCurrentRate", reflect.TypeOf((*MockIConfig)(nil).GetConvertAtCurrentRate))
+}
+
 // GetCurrencyCodes mocks base method.
 func (m *MockIConfig) GetCurrencyCodes() []string {
 	m.ctrl.T.Helper()
@@ -386,6 +1417,20 @@ func (mr *MockIConfigMockRecorder) GetFrequencyRateUpdateSec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrequencyRateUpdateSec", reflect.TypeOf((*MockIConfig)(nil).GetFrequencyRateUpdateSec))
 }
 
+// GetLimitThresholds mocks base method.
+func (m *MockIConfig) GetLimitThresholds() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitThresholds")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetLimitThresholds indicates an expected call of GetLimitThresholds.
+func (mr *MockIConfigMockRecorder) GetLimitThresholds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitThresholds", reflect.TypeOf((*MockIConfig)(nil).GetLimitThresholds))
+}
+
 // GetReportCacheEnable mocks base method.
 func (m *MockIConfig) GetReportCacheEnable() bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrInvalidSummaryInterval = errors.New("summary interval must be day or week")
+
+// Сводка отправляется за прошедший день или неделю.
+func isSummaryInterval(intervalType int) bool {
+	return intervalType == utils.DayInterval || intervalType == utils.WeekInterval
+}
+
+func (uc *ExpenseUsecase) SetSummary(ctx context.Context, req SetSummaryReqDTO) (SetSummaryRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SetSummary")
+	defer span.End()
+
+	if req.IntervalType != 0 && !isSummaryInterval(req.IntervalType) {
+		return SetSummaryRespDTO{}, errors.Wrap(ErrInvalidSummaryInterval, "ExpenseUsecase.SetSummary")
+	}
+
+	// Сводку каждый получает сам, по всему бюджету, а в групповом чате - весь чат
+	recipientID := req.UserID
+	if chatID, ok := groupChatID(ctx, req.UserID); ok {
+		recipientID = chatID
+	}
+
+	err := uc.userStorage.UpdateSummaryInterval(ctx, entity.UserID(recipientID), req.IntervalType)
+
+	return SetSummaryRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SetSummary")
+}
+
+// SendSummaries отправляет сводку за прошедший интервал всем, кому она еще не отправлялась.
+// Ошибка отправки одному пользователю не мешает остальным, он получит сводку при следующем запуске.
+func (uc *ExpenseUsecase) SendSummaries(ctx context.Context) error {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SendSummaries")
+	defer span.End()
+
+	now := time.Now()
+
+	for _, intervalType := range [...]int{utils.DayInterval, utils.WeekInterval} {
+		intervalStart, _ := utils.GetInterval(now, intervalType)
+
+		users, err := uc.userStorage.GetSummaryRecipients(ctx, intervalType, intervalStart)
+		if err != nil {
+			return errors.Wrap(err, "ExpenseUsecase.SendSummaries")
+		}
+
+		for _, userID := range users {
+			err := uc.sendSummary(ctx, userID, intervalType, now)
+			if err != nil {
+				logger.Errorf("can not send summary to user %d: %v", userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (uc *ExpenseUsecase) sendSummary(ctx context.Context, userID entity.UserID, intervalType int, now time.Time,
+) error {
+	req := GetReportReqDTO{
+		UserID:       int64(userID),
+		IntervalType: intervalType,
+	}
+	req.DateStart, req.DateEnd = utils.GetPrevInterval(now, intervalType)
+
+	resp, err := uc.GetReport(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "ExpenseUsecase.sendSummary")
+	}
+
+	err = uc.notifier.Notify(ctx, Command{
+		MessageInfo: MessageInfo{
+			UserID: int64(userID),
+			Date:   now,
+			Locale: uc.userLocale(ctx, int64(userID)),
+		},
+		Name:             SummaryCmdName,
+		GetReportReqDTO:  &req,
+		GetReportRespDTO: &resp,
+	})
+	if err != nil {
+		return errors.Wrap(err, "ExpenseUsecase.sendSummary")
+	}
+
+	err = uc.userStorage.UpdateSummarySentAt(ctx, userID, now)
+
+	return errors.Wrap(err, "ExpenseUsecase.sendSummary")
+}
+
+// notifyLimitThresholds уведомляет о достижении порогов лимитов. О каждом пороге
+// уведомление отправляется один раз за интервал, при одновременном достижении
+// нескольких порогов - только о наибольшем. Порог отмечается только после успешной
+// отправки: повторное уведомление лучше потерянного. Расход уже сохранен, поэтому ошибки
+// только логируются. Лимиты общие для бюджета userID, уведомление получает recipientID,
+// чей расход достиг порога.
+func (uc *ExpenseUsecase) notifyLimitThresholds(ctx context.Context, userID entity.UserID, recipientID int64,
+	date time.Time, usages []limitUsage, rate entity.Rate, currency string,
+) {
+	if len(usages) == 0 {
+		return
+	}
+
+	thresholds := uc.config.GetLimitThresholds()
+
+	for _, usage := range usages {
+		intervalStart, _ := utils.GetInterval(date, usage.limit.GetIntervalType())
+
+		reached, maxReached, err := uc.pendingThresholds(ctx, userID, usage, intervalStart, thresholds)
+		if err != nil {
+			logger.Errorf("can not get limit notifications: %v", err)
+
+			continue
+		}
+
+		if len(reached) == 0 {
+			continue
+		}
+
+		err = uc.notifier.Notify(ctx, Command{
+			MessageInfo: MessageInfo{
+				UserID: recipientID,
+				ChatID: chatIDFromContext(ctx),
+				Date:   time.Now(),
+				Locale: localeFromContext(ctx),
+			},
+			Name: LimitNotificationCmdName,
+			LimitNotificationDTO: &LimitNotificationDTO{
+				Category:     usage.limit.GetCategory(),
+				IntervalType: usage.limit.GetIntervalType(),
+				Threshold:    maxReached,
+				Spent:        usage.spent.Mul(rate.GetRatio()),
+				Limit:        usage.limit.GetAmount().Mul(rate.GetRatio()),
+				Currency:     currency,
+			},
+		})
+		if err != nil {
+			logger.Errorf("can not send limit notification: %v", err)
+
+			continue
+		}
+
+		for _, threshold := range reached {
+			err := uc.limitStorage.MarkNotified(ctx, userID, usage.limit, intervalStart, threshold)
+			if err != nil {
+				logger.Errorf("can not mark limit notification: %v", err)
+			}
+		}
+	}
+}
+
+// pendingThresholds возвращает достигнутые пороги лимита, о которых за интервал еще
+// не уведомляли, и наибольший из них.
+func (uc *ExpenseUsecase) pendingThresholds(ctx context.Context, userID entity.UserID, usage limitUsage,
+	intervalStart time.Time, thresholds []int,
+) ([]int, int, error) {
+	percents := decimal.New(100, 0) //nolint:gomnd
+	spentPercent := usage.spent.Mul(percents).Div(usage.limit.GetAmount())
+
+	crossed := make([]int, 0, len(thresholds))
+
+	for _, threshold := range thresholds {
+		if !spentPercent.LessThan(decimal.New(int64(threshold), 0)) {
+			crossed = append(crossed, threshold)
+		}
+	}
+
+	if len(crossed) == 0 {
+		return nil, 0, nil
+	}
+
+	notified, err := uc.limitStorage.GetNotified(ctx, userID, usage.limit, intervalStart)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "ExpenseUsecase.pendingThresholds")
+	}
+
+	sent := make(map[int]bool, len(notified))
+	for _, threshold := range notified {
+		sent[threshold] = true
+	}
+
+	reached := make([]int, 0, len(crossed))
+	maxReached := 0
+
+	for _, threshold := range crossed {
+		if sent[threshold] {
+			continue
+		}
+
+		reached = append(reached, threshold)
+
+		if threshold > maxReached {
+			maxReached = threshold
+		}
+	}
+
+	return reached, maxReached, nil
+}
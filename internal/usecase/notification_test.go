@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestSetSummary_InvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.SetSummary(ctx, usecase.SetSummaryReqDTO{
+		UserID:       202,
+		IntervalType: utils.MonthInterval,
+	})
+	assert.ErrorIs(t, err, usecase.ErrInvalidSummaryInterval)
+}
+
+func TestSendSummaries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := mock_usecase.NewMockICategoryStorage(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	report := usecase.GetReportRespDTO{
+		Currency: "RUB",
+		Expenses: []usecase.ExpenseReportDTO{
+			{Category: "такси", Sum: decimal.New(300, 0)},
+		},
+	}
+
+	gomock.InOrder(
+		userStorage.EXPECT().GetSummaryRecipients(gomock.Any(), utils.DayInterval, gomock.Any()).
+			Return([]entity.UserID{202}, nil),
+		reportClient.EXPECT().GetReport(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req usecase.GetReportReqDTO) (usecase.GetReportRespDTO, error) {
+				assert.Equal(t, int64(202), req.UserID)
+				assert.Equal(t, utils.DayInterval, req.IntervalType)
+				assert.Equal(t, req.DateStart.AddDate(0, 0, 1), req.DateEnd)
+
+				return report, nil
+			}),
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, cmd usecase.Command) error {
+				assert.Equal(t, usecase.SummaryCmdName, cmd.Name)
+				assert.Equal(t, int64(202), cmd.UserID)
+				assert.Equal(t, report, *cmd.GetReportRespDTO)
+
+				return nil
+			}),
+		userStorage.EXPECT().UpdateSummarySentAt(gomock.Any(), entity.UserID(202), gomock.Any()).
+			Return(nil),
+		userStorage.EXPECT().GetSummaryRecipients(gomock.Any(), utils.WeekInterval, gomock.Any()).
+			Return(nil, nil),
+	)
+
+	userStorage.EXPECT().GetLocale(gomock.Any(), entity.UserID(202)).Return("", nil).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	err := expenseUsecase.SendSummaries(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+	"go.opentelemetry.io/otel"
+)
+
+func (uc *ExpenseUsecase) AddRecurringExpense(ctx context.Context, req AddRecurringExpenseReqDTO,
+) (AddRecurringExpenseRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddRecurringExpense")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return AddRecurringExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddRecurringExpense")
+	}
+
+	if _, ok := utils.IntervalToStr(req.IntervalType); !ok {
+		return AddRecurringExpenseRespDTO{}, errors.New("unknown intervalType")
+	}
+
+	// Валюта фиксируется при создании, смена валюты по умолчанию не меняет сумму
+	currency := req.Currency
+	if len(currency) == 0 {
+		currency = uc.getCurrencyForUser(ctx, userID)
+	} else if !uc.isSupportedCurrencyCode(currency) {
+		return AddRecurringExpenseRespDTO{}, errors.New("currency is unsupported")
+	}
+
+	category, err := uc.categoryStorage.Resolve(ctx, userID, req.Category)
+	if err != nil {
+		return AddRecurringExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddRecurringExpense")
+	}
+
+	recurring := entity.NewRecurringExpense(userID, category, req.Price, currency, req.IntervalType, req.DateStart)
+
+	id, err := uc.recurringStorage.Create(ctx, recurring)
+	if err != nil {
+		return AddRecurringExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.AddRecurringExpense")
+	}
+
+	resp := AddRecurringExpenseRespDTO{
+		ID:       int64(id),
+		Category: category,
+		Currency: currency,
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) GetRecurringExpenses(ctx context.Context, req GetRecurringExpensesReqDTO,
+) (GetRecurringExpensesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "GetRecurringExpenses")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return GetRecurringExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetRecurringExpenses")
+	}
+
+	recurrings, err := uc.recurringStorage.GetAll(ctx, userID)
+	if err != nil {
+		return GetRecurringExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.GetRecurringExpenses")
+	}
+
+	resp := GetRecurringExpensesRespDTO{
+		RecurringExpenses: make([]RecurringExpenseDTO, 0, len(recurrings)),
+	}
+
+	for _, recurring := range recurrings {
+		resp.RecurringExpenses = append(resp.RecurringExpenses, RecurringExpenseDTO{
+			ID:           int64(recurring.GetID()),
+			Category:     recurring.GetCategory(),
+			Price:        recurring.GetPrice(),
+			Currency:     recurring.GetCurrency(),
+			IntervalType: recurring.GetIntervalType(),
+			NextDate:     recurring.GetNextDate(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (uc *ExpenseUsecase) DeleteRecurringExpense(ctx context.Context, req DeleteRecurringExpenseReqDTO,
+) (DeleteRecurringExpenseRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "DeleteRecurringExpense")
+	defer span.End()
+
+	userID, err := uc.ownerBudgetUserID(ctx, req.UserID)
+	if err != nil {
+		return DeleteRecurringExpenseRespDTO{}, errors.Wrap(err, "ExpenseUsecase.DeleteRecurringExpense")
+	}
+
+	found, err := uc.recurringStorage.Delete(ctx, userID, entity.RecurringExpenseID(req.ID))
+
+	return DeleteRecurringExpenseRespDTO{Found: found}, errors.Wrap(err, "ExpenseUsecase.DeleteRecurringExpense")
+}
+
+// AddDueRecurringExpenses добавляет наступившие регулярные расходы, в том числе пропущенные,
+// пока сервис не работал. Расходы добавляются с ключом идемпотентности, поэтому сбой между
+// добавлением расхода и сдвигом даты не приводит к повторному добавлению.
+func (uc *ExpenseUsecase) AddDueRecurringExpenses(ctx context.Context) error {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "AddDueRecurringExpenses")
+	defer span.End()
+
+	now := time.Now()
+
+	recurrings, err := uc.recurringStorage.GetDue(ctx, now)
+	if err != nil {
+		return errors.Wrap(err, "ExpenseUsecase.AddDueRecurringExpenses")
+	}
+
+	for _, recurring := range recurrings {
+		err := uc.addRecurringExpense(ctx, recurring, now)
+		if err != nil {
+			logger.Errorf("can not add recurring expense %d: %v", recurring.GetID(), err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *ExpenseUsecase) addRecurringExpense(ctx context.Context, recurring entity.RecurringExpense, now time.Time,
+) error {
+	if _, ok := utils.IntervalToStr(recurring.GetIntervalType()); !ok {
+		return errors.New("unknown intervalType")
+	}
+
+	for !recurring.GetNextDate().After(now) {
+		date := recurring.GetNextDate()
+
+		req := AddExpenseReqDTO{
+			UserID:     int64(recurring.GetUserID()),
+			Category:   recurring.GetCategory(),
+			Price:      recurring.GetPrice(),
+			Currency:   recurring.GetCurrency(),
+			Date:       date,
+			ExternalID: fmt.Sprintf("recurring_%d_%d", recurring.GetID(), date.Unix()),
+		}
+
+		resp, err := uc.AddExpense(ctx, req)
+		if err != nil {
+			return errors.Wrap(err, "ExpenseUsecase.addRecurringExpense")
+		}
+
+		nextDate := nextRecurringDate(recurring)
+
+		err = uc.recurringStorage.UpdateNextDate(ctx, recurring.GetID(), nextDate)
+		if err != nil {
+			return errors.Wrap(err, "ExpenseUsecase.addRecurringExpense")
+		}
+
+		recurring.SetNextDate(nextDate)
+
+		// О расходе, добавленном до сбоя, пользователь уже знает
+		if resp.Duplicate {
+			continue
+		}
+
+		// Пользователь получает такое же сообщение, как при добавлении расхода вручную
+		err = uc.notifier.Notify(ctx, Command{
+			MessageInfo: MessageInfo{
+				UserID: req.UserID,
+				Date:   now,
+				Locale: uc.userLocale(ctx, req.UserID),
+			},
+			Name:              AddExpenseCmdName,
+			AddExpenseReqDTO:  &req,
+			AddExpenseRespDTO: &resp,
+		})
+		if err != nil {
+			logger.Errorf("can not notify about recurring expense: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Даты считаются от даты начала, чтобы расход 31 числа после февраля снова был 31 числа.
+func nextRecurringDate(recurring entity.RecurringExpense) time.Time {
+	for count := 1; ; count++ {
+		date := utils.AddIntervals(recurring.GetStartDate(), recurring.GetIntervalType(), count)
+		if date.After(recurring.GetNextDate()) {
+			return date
+		}
+	}
+}
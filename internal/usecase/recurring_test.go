@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestAddDueRecurringExpenses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil).AnyTimes()
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil).AnyTimes()
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return(nil, nil).AnyTimes()
+
+	// Сервис не работал неделю, за это время наступило два еженедельных расхода
+	startDate := time.Now().AddDate(0, 0, -8)
+
+	recurring := entity.NewRecurringExpense(202, "спортзал", decimal.New(1500, 0), "RUB",
+		utils.WeekInterval, startDate)
+	recurring.SetID(5)
+
+	recurringStorage.EXPECT().GetDue(gomock.Any(), gomock.Any()).
+		Return([]entity.RecurringExpense{recurring}, nil)
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense,
+			) (entity.ExpenseID, bool, error) {
+				assert.Equal(t, fmt.Sprintf("recurring_5_%d", startDate.Unix()), expense.GetExternalID())
+				assert.Equal(t, startDate, expense.GetDate())
+
+				return 10, true, nil
+			}),
+		recurringStorage.EXPECT().UpdateNextDate(gomock.Any(), entity.RecurringExpenseID(5),
+			startDate.AddDate(0, 0, 7)).Return(nil),
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil),
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense,
+			) (entity.ExpenseID, bool, error) {
+				assert.Equal(t, fmt.Sprintf("recurring_5_%d", startDate.AddDate(0, 0, 7).Unix()),
+					expense.GetExternalID())
+
+				return 11, true, nil
+			}),
+		recurringStorage.EXPECT().UpdateNextDate(gomock.Any(), entity.RecurringExpenseID(5),
+			startDate.AddDate(0, 0, 14)).Return(nil),
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, cmd usecase.Command) error {
+				assert.Equal(t, usecase.AddExpenseCmdName, cmd.Name)
+				assert.Equal(t, int64(11), cmd.AddExpenseRespDTO.ID)
+
+				return nil
+			}),
+	)
+
+	userStorage.EXPECT().GetLocale(gomock.Any(), entity.UserID(202)).Return("", nil).AnyTimes()
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	err := expenseUsecase.AddDueRecurringExpenses(ctx)
+	assert.NoError(t, err)
+}
+
+// Повтор после сбоя: расход уже добавлен до падения, поэтому аудит, лимиты и уведомление
+// не повторяются, а дата следующего расхода сдвигается.
+func TestAddDueRecurringExpenses_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+
+	rub := entity.NewRate("RUB", decimal.New(1, 0), time.Now())
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").Return(rub, nil).AnyTimes()
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil).AnyTimes()
+
+	startDate := time.Now().AddDate(0, 0, -1)
+
+	recurring := entity.NewRecurringExpense(202, "спортзал", decimal.New(1500, 0), "RUB",
+		utils.WeekInterval, startDate)
+	recurring.SetID(5)
+
+	recurringStorage.EXPECT().GetDue(gomock.Any(), gomock.Any()).
+		Return([]entity.RecurringExpense{recurring}, nil)
+
+	gomock.InOrder(
+		expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+			Return(entity.ExpenseID(10), false, nil),
+		recurringStorage.EXPECT().UpdateNextDate(gomock.Any(), entity.RecurringExpenseID(5),
+			startDate.AddDate(0, 0, 7)).Return(nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	err := expenseUsecase.AddDueRecurringExpenses(ctx)
+	assert.NoError(t, err)
+}
+
+func TestAddRecurringExpense_DefaultCurrency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	date := timeHelper(2026, 11, 1)
+
+	gomock.InOrder(
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("EUR", nil),
+		recurringStorage.EXPECT().Create(gomock.Any(),
+			entity.NewRecurringExpense(202, "аренда", decimal.New(900, 0), "EUR", utils.MonthInterval, date)).
+			Return(entity.RecurringExpenseID(3), nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddRecurringExpense(ctx, usecase.AddRecurringExpenseReqDTO{
+		UserID:       202,
+		Category:     "аренда",
+		Price:        decimal.New(900, 0),
+		IntervalType: utils.MonthInterval,
+		DateStart:    date,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.AddRecurringExpenseRespDTO{ID: 3, Category: "аренда", Currency: "EUR"}, resp)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"go.opentelemetry.io/otel"
+)
+
+// Больше найденных расходов в одно сообщение не выводится.
+const searchLimit = 20
+
+// SearchExpenses ищет расходы, в заметке или категории которых есть слова запроса.
+func (uc *ExpenseUsecase) SearchExpenses(ctx context.Context, req SearchExpensesReqDTO,
+) (SearchExpensesRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "SearchExpenses")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return SearchExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SearchExpenses")
+	}
+
+	// Лишний расход запрашивается, чтобы узнать, что показаны не все
+	expenses, err := uc.expenseStorage.Search(ctx, userID, req.Query, req.DateStart, req.DateEnd, searchLimit+1)
+	if err != nil {
+		return SearchExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SearchExpenses")
+	}
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	rate, err := uc.currencyStorage.Get(ctx, currency)
+	if err != nil {
+		return SearchExpensesRespDTO{}, errors.Wrap(err, "ExpenseUsecase.SearchExpenses")
+	}
+
+	resp := SearchExpensesRespDTO{
+		Currency: currency,
+		Expenses: make([]FoundExpenseDTO, 0, len(expenses)),
+		HasMore:  len(expenses) > searchLimit,
+	}
+
+	if resp.HasMore {
+		expenses = expenses[:searchLimit]
+	}
+
+	for _, expense := range expenses {
+		resp.Expenses = append(resp.Expenses, newFoundExpenseDTO(expense, rate))
+	}
+
+	return resp, nil
+}
+
+func newFoundExpenseDTO(expense entity.Expense, rate entity.Rate) FoundExpenseDTO {
+	return FoundExpenseDTO{
+		ID:       int64(expense.GetID()),
+		Category: expense.GetCategory(),
+		Note:     expense.GetNote(),
+		Price:    expense.GetPrice().Mul(rate.GetRatio()),
+		Date:     expense.GetDate(),
+	}
+}
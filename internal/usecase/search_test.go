@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+)
+
+func TestAddExpense_Note(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+	config.EXPECT().GetFrequencyRateUpdateSec().Return(600).AnyTimes()
+	config.EXPECT().GetBaseCurrencyCode().Return("RUB").AnyTimes()
+
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil).AnyTimes()
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	expenseStorage.EXPECT().Create(gomock.Any(), entity.UserID(202), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, expense entity.Expense) (entity.ExpenseID, bool, error) {
+			assert.Equal(t, "ужин с Петей", expense.GetNote())
+
+			return 4, true, nil
+		})
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return(nil, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.AddExpense(ctx, usecase.AddExpenseReqDTO{
+		UserID:   202,
+		Category: "еда",
+		Price:    decimal.New(500, 0),
+		Date:     time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC),
+		Note:     "ужин с Петей",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), resp.ID)
+}
+
+func TestSearchExpenses(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := newAuditStorageMock(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	dateStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	dateEnd := dateStart.AddDate(0, 1, 0)
+
+	// Хранилище вернуло на один расход больше, чем выводится
+	expenses := make([]entity.Expense, 0, 21)
+
+	for i := 21; i > 0; i-- {
+		expense := entity.NewExpense("еда", decimal.New(100, 0), dateStart.AddDate(0, 0, i))
+		expense.SetID(entity.ExpenseID(i))
+		expense.SetNote("ужин")
+
+		expenses = append(expenses, expense)
+	}
+
+	expenseStorage.EXPECT().Search(gomock.Any(), entity.UserID(202), "ужин", dateStart, dateEnd, 21).
+		Return(expenses, nil)
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("USD", nil)
+	currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+		Return(entity.NewRate("USD", decimal.RequireFromString("0.5"), time.Now()), nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.SearchExpenses(ctx, usecase.SearchExpensesReqDTO{
+		UserID:    202,
+		Query:     "ужин",
+		DateStart: dateStart,
+		DateEnd:   dateEnd,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", resp.Currency)
+	assert.True(t, resp.HasMore)
+	assert.Len(t, resp.Expenses, 20)
+	assert.Equal(t, int64(21), resp.Expenses[0].ID)
+	assert.Equal(t, "ужин", resp.Expenses[0].Note)
+	assert.True(t, decimal.New(50, 0).Equal(resp.Expenses[0].Price))
+}
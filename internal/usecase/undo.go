@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+	"go.opentelemetry.io/otel"
+)
+
+// Значения, которые записываются в журнал изменений. Суммы хранятся в базовой валюте,
+// как и в хранилищах, чтобы отмена не зависела от смены валюты пользователя.
+
+type auditExpense struct {
+	ID       int64           `json:"id"`
+	Category string          `json:"category"`
+	Price    decimal.Decimal `json:"price"`
+	Date     time.Time       `json:"date"`
+}
+
+func newAuditExpense(expense entity.Expense) auditExpense {
+	return auditExpense{
+		ID:       int64(expense.GetID()),
+		Category: expense.GetCategory(),
+		Price:    expense.GetPrice(),
+		Date:     expense.GetDate(),
+	}
+}
+
+// auditLimit лимит до и после изменения. Нулевая сумма - лимита нет.
+type auditLimit struct {
+	Category     string          `json:"category"`
+	IntervalType int             `json:"interval_type"`
+	Amount       decimal.Decimal `json:"amount"`
+}
+
+func newAuditLimit(limit entity.Limit) auditLimit {
+	return auditLimit{
+		Category:     limit.GetCategory(),
+		IntervalType: limit.GetIntervalType(),
+		Amount:       limit.GetAmount(),
+	}
+}
+
+type auditCurrency struct {
+	Currency string `json:"currency"`
+}
+
+// audit записывает действие в журнал изменений. Действие к этому моменту уже
+// выполнено, поэтому ошибка журнала его не отменяет, а только логируется.
+func (uc *ExpenseUsecase) audit(ctx context.Context, userID int64, ownerID entity.UserID, action string,
+	oldValue, newValue any,
+) {
+	oldJSON, err := json.Marshal(oldValue)
+	if err != nil {
+		logger.Errorf("can not write audit record: %v", err)
+
+		return
+	}
+
+	newJSON, err := json.Marshal(newValue)
+	if err != nil {
+		logger.Errorf("can not write audit record: %v", err)
+
+		return
+	}
+
+	record := entity.NewAuditRecord(entity.UserID(userID), ownerID, action, string(oldJSON), string(newJSON),
+		time.Now())
+
+	err = uc.auditStorage.Create(ctx, record)
+	if err != nil {
+		logger.Errorf("can not write audit record: %v", err)
+	}
+}
+
+// getLimit возвращает лимит на категорию за интервал, нулевой, если его нет.
+func (uc *ExpenseUsecase) getLimit(ctx context.Context, userID entity.UserID, category string, intervalType int,
+) (entity.Limit, error) {
+	limits, err := uc.limitStorage.GetAll(ctx, userID)
+	if err != nil {
+		return entity.Limit{}, errors.Wrap(err, "ExpenseUsecase.getLimit")
+	}
+
+	for _, limit := range limits {
+		if limit.GetCategory() == category && limit.GetIntervalType() == intervalType {
+			return limit, nil
+		}
+	}
+
+	return entity.NewLimit(category, intervalType, decimal.Zero), nil
+}
+
+// Undo отменяет последнее неотмененное действие пользователя над данными текущего
+// бюджета. Повторная отмена возвращает предыдущее действие.
+func (uc *ExpenseUsecase) Undo(ctx context.Context, req UndoReqDTO) (UndoRespDTO, error) {
+	ctx, span := otel.Tracer("ExpenseUsecase").Start(ctx, "Undo")
+	defer span.End()
+
+	userID, _, err := uc.budgetUserID(ctx, req.UserID)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Undo")
+	}
+
+	record, found, err := uc.auditStorage.GetLast(ctx, entity.UserID(req.UserID), userID)
+	if err != nil || !found {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Undo")
+	}
+
+	var resp UndoRespDTO
+
+	switch record.GetAction() {
+	case entity.AuditAddExpense:
+		resp, err = uc.undoAddExpense(ctx, userID, record)
+	case entity.AuditSetLimit:
+		resp, err = uc.undoSetLimit(ctx, userID, record)
+	case entity.AuditSetCurrency:
+		resp, err = uc.undoSetCurrency(ctx, userID, record)
+	default:
+		err = errors.Errorf("unknown audit action: %s", record.GetAction())
+	}
+
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Undo")
+	}
+
+	err = uc.auditStorage.MarkUndone(ctx, record.GetID(), time.Now())
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.Undo")
+	}
+
+	resp.Found = true
+	resp.Action = record.GetAction()
+
+	return resp, nil
+}
+
+// undoAddExpense удаляет добавленный расход. Если его уже удалили командой, отмена
+// все равно считается выполненной.
+func (uc *ExpenseUsecase) undoAddExpense(ctx context.Context, userID entity.UserID, record entity.AuditRecord,
+) (UndoRespDTO, error) {
+	var expense auditExpense
+
+	err := json.Unmarshal([]byte(record.GetNewValue()), &expense)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoAddExpense")
+	}
+
+	err = uc.expenseStorage.Delete(ctx, userID, entity.ExpenseID(expense.ID))
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoAddExpense")
+	}
+
+	uc.deleteReportFromCache(int64(userID), expense.Date)
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	rate, err := uc.currencyStorage.Get(ctx, currency)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoAddExpense")
+	}
+
+	resp := UndoRespDTO{ //nolint:exhaustruct
+		Category: expense.Category,
+		Amount:   expense.Price.Mul(rate.GetRatio()),
+		Currency: currency,
+	}
+
+	return resp, nil
+}
+
+// undoSetLimit возвращает прежний лимит. Если лимита не было, новый удаляется.
+func (uc *ExpenseUsecase) undoSetLimit(ctx context.Context, userID entity.UserID, record entity.AuditRecord,
+) (UndoRespDTO, error) {
+	var limit auditLimit
+
+	err := json.Unmarshal([]byte(record.GetOldValue()), &limit)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoSetLimit")
+	}
+
+	err = uc.limitStorage.Update(ctx, userID, entity.NewLimit(limit.Category, limit.IntervalType, limit.Amount))
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoSetLimit")
+	}
+
+	currency := uc.getCurrencyForUser(ctx, userID)
+
+	rate, err := uc.currencyStorage.Get(ctx, currency)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoSetLimit")
+	}
+
+	resp := UndoRespDTO{ //nolint:exhaustruct
+		Category:     limit.Category,
+		IntervalType: limit.IntervalType,
+		Amount:       limit.Amount.Mul(rate.GetRatio()),
+		Currency:     currency,
+	}
+
+	return resp, nil
+}
+
+// undoSetCurrency возвращает прежнюю валюту. Отчеты в кеше построены в отменяемой
+// валюте, поэтому сбрасываются все.
+func (uc *ExpenseUsecase) undoSetCurrency(ctx context.Context, userID entity.UserID, record entity.AuditRecord,
+) (UndoRespDTO, error) {
+	var currency auditCurrency
+
+	err := json.Unmarshal([]byte(record.GetOldValue()), &currency)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoSetCurrency")
+	}
+
+	err = uc.userStorage.UpdateDefaultCurrency(ctx, userID, currency.Currency)
+	if err != nil {
+		return UndoRespDTO{}, errors.Wrap(err, "ExpenseUsecase.undoSetCurrency")
+	}
+
+	uc.deleteAllReportsFromCache(int64(userID))
+
+	return UndoRespDTO{Currency: currency.Currency}, nil //nolint:exhaustruct
+}
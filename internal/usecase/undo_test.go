@@ -0,0 +1,305 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/entity"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/usecase/mock_usecase"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func auditRecord(action, oldValue, newValue string) entity.AuditRecord {
+	record := entity.NewAuditRecord(202, 202, action, oldValue, newValue, time.Now())
+	record.SetID(7)
+
+	return record
+}
+
+func TestSetLimit_WritesAudit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil)
+	limitStorage.EXPECT().GetAll(gomock.Any(), entity.UserID(202)).Return([]entity.Limit{
+		entity.NewLimit("", utils.MonthInterval, decimal.New(50000, 0)),
+		entity.NewLimit("кафе", utils.MonthInterval, decimal.New(5000, 0)),
+	}, nil)
+	limitStorage.EXPECT().Update(gomock.Any(), entity.UserID(202), gomock.Any()).Return(nil)
+	// В журнал попадает прежний лимит на ту же категорию и интервал
+	auditStorage.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, record entity.AuditRecord) error {
+			assert.Equal(t, entity.UserID(202), record.GetUserID())
+			assert.Equal(t, entity.UserID(202), record.GetOwnerID())
+			assert.Equal(t, entity.AuditSetLimit, record.GetAction())
+			assert.JSONEq(t, `{"category":"кафе","interval_type":3,"amount":"5000"}`, record.GetOldValue())
+			assert.JSONEq(t, `{"category":"кафе","interval_type":3,"amount":"10000"}`, record.GetNewValue())
+
+			return nil
+		})
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.SetLimit(ctx, usecase.SetLimitReqDTO{
+		UserID:       202,
+		Category:     "кафе",
+		IntervalType: utils.MonthInterval,
+		Limit:        decimal.New(10000, 0),
+	})
+	assert.NoError(t, err)
+}
+
+func TestUndo_Nothing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	auditStorage.EXPECT().GetLast(gomock.Any(), entity.UserID(202), entity.UserID(202)).
+		Return(entity.AuditRecord{}, false, nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Undo(ctx, usecase.UndoReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.False(t, resp.Found)
+}
+
+func TestUndo_AddExpense(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(true).AnyTimes()
+	config.EXPECT().GetReportCacheSize().Return(10).AnyTimes()
+	config.EXPECT().GetReportCacheTTL().Return(3600).AnyTimes()
+
+	weekReq := usecase.GetReportReqDTO{
+		UserID:       202,
+		DateStart:    timeHelper(2022, 9, 26),
+		DateEnd:      timeHelper(2022, 10, 3),
+		IntervalType: utils.WeekInterval,
+	}
+
+	gomock.InOrder(
+		reportClient.EXPECT().GetReport(gomock.Any(), weekReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil),
+		auditStorage.EXPECT().GetLast(gomock.Any(), entity.UserID(202), entity.UserID(202)).
+			Return(auditRecord(entity.AuditAddExpense, "null",
+				`{"id":17,"category":"такси","price":"300","date":"2022-09-28T00:00:00Z"}`), true, nil),
+		expenseStorage.EXPECT().Delete(gomock.Any(), entity.UserID(202), entity.ExpenseID(17)).Return(nil),
+		userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("USD", nil),
+		currencyStorage.EXPECT().Get(gomock.Any(), "USD").
+			Return(entity.NewRate("USD", decimal.RequireFromString("0.5"), time.Now()), nil),
+		auditStorage.EXPECT().MarkUndone(gomock.Any(), entity.AuditRecordID(7), gomock.Any()).Return(nil),
+		reportClient.EXPECT().GetReport(gomock.Any(), weekReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.GetReport(ctx, weekReq)
+	assert.NoError(t, err)
+
+	resp, err := expenseUsecase.Undo(ctx, usecase.UndoReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.True(t, resp.Found)
+	assert.Equal(t, entity.AuditAddExpense, resp.Action)
+	assert.Equal(t, "такси", resp.Category)
+	assert.True(t, decimal.New(150, 0).Equal(resp.Amount))
+	assert.Equal(t, "USD", resp.Currency)
+
+	// Отчет за неделю удаленного расхода строится заново
+	_, err = expenseUsecase.GetReport(ctx, weekReq)
+	assert.NoError(t, err)
+}
+
+func TestUndo_SetLimit_Removed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(false).AnyTimes()
+
+	auditStorage.EXPECT().GetLast(gomock.Any(), entity.UserID(202), entity.UserID(202)).
+		Return(auditRecord(entity.AuditSetLimit, `{"category":"кафе","interval_type":3,"amount":"0"}`,
+			`{"category":"кафе","interval_type":3,"amount":"10000"}`), true, nil)
+	// Лимита до изменения не было, поэтому он удаляется
+	limitStorage.EXPECT().Update(gomock.Any(), entity.UserID(202), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ entity.UserID, limit entity.Limit) error {
+			assert.Equal(t, "кафе", limit.GetCategory())
+			assert.Equal(t, utils.MonthInterval, limit.GetIntervalType())
+			assert.True(t, limit.GetAmount().IsZero())
+
+			return nil
+		})
+	userStorage.EXPECT().GetDefaultCurrency(gomock.Any(), entity.UserID(202)).Return("RUB", nil)
+	currencyStorage.EXPECT().Get(gomock.Any(), "RUB").
+		Return(entity.NewRate("RUB", decimal.New(1, 0), time.Now()), nil)
+	auditStorage.EXPECT().MarkUndone(gomock.Any(), entity.AuditRecordID(7), gomock.Any()).Return(nil)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	resp, err := expenseUsecase.Undo(ctx, usecase.UndoReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.True(t, resp.Found)
+	assert.Equal(t, entity.AuditSetLimit, resp.Action)
+	assert.Equal(t, "кафе", resp.Category)
+	assert.Equal(t, utils.MonthInterval, resp.IntervalType)
+	assert.True(t, resp.Amount.IsZero())
+}
+
+func TestUndo_SetCurrency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	currencyStorage := mock_usecase.NewMockICurrencyStorage(ctrl)
+	userStorage := mock_usecase.NewMockIUserStorage(ctrl)
+	expenseStorage := mock_usecase.NewMockIExpenseStorage(ctrl)
+	incomeStorage := mock_usecase.NewMockIIncomeStorage(ctrl)
+	accountStorage := mock_usecase.NewMockIAccountStorage(ctrl)
+	goalStorage := mock_usecase.NewMockIGoalStorage(ctrl)
+	categoryStorage := newCategoryStorageMock(ctrl)
+	limitStorage := mock_usecase.NewMockILimitStorage(ctrl)
+	recurringStorage := mock_usecase.NewMockIRecurringExpenseStorage(ctrl)
+	importStorage := mock_usecase.NewMockIImportStorage(ctrl)
+	budgetStorage := newBudgetStorageMock(ctrl)
+	auditStorage := mock_usecase.NewMockIAuditStorage(ctrl)
+	notifier := mock_usecase.NewMockINotifier(ctrl)
+	ratesUpdaterService := mock_usecase.NewMockIRatesUpdaterService(ctrl)
+	reportClient := mock_usecase.NewMockGetReportClient(ctrl)
+	config := mock_usecase.NewMockIConfig(ctrl)
+
+	config.EXPECT().GetReportCacheEnable().Return(true).AnyTimes()
+	config.EXPECT().GetReportCacheSize().Return(10).AnyTimes()
+	config.EXPECT().GetReportCacheTTL().Return(3600).AnyTimes()
+
+	monthReq := usecase.GetReportReqDTO{
+		UserID:       202,
+		DateStart:    timeHelper(2022, 9, 1),
+		DateEnd:      timeHelper(2022, 10, 1),
+		IntervalType: utils.MonthInterval,
+	}
+
+	gomock.InOrder(
+		reportClient.EXPECT().GetReport(gomock.Any(), monthReq).
+			Return(usecase.GetReportRespDTO{Currency: "USD"}, nil),
+		auditStorage.EXPECT().GetLast(gomock.Any(), entity.UserID(202), entity.UserID(202)).
+			Return(auditRecord(entity.AuditSetCurrency, `{"currency":"RUB"}`, `{"currency":"USD"}`), true, nil),
+		userStorage.EXPECT().UpdateDefaultCurrency(gomock.Any(), entity.UserID(202), "RUB").Return(nil),
+		auditStorage.EXPECT().MarkUndone(gomock.Any(), entity.AuditRecordID(7), gomock.Any()).Return(nil),
+		reportClient.EXPECT().GetReport(gomock.Any(), monthReq).
+			Return(usecase.GetReportRespDTO{Currency: "RUB"}, nil),
+	)
+
+	expenseUsecase := usecase.NewExpenseUsecase(currencyStorage, userStorage, expenseStorage, incomeStorage,
+		accountStorage, goalStorage, categoryStorage, limitStorage, recurringStorage, importStorage, budgetStorage,
+		auditStorage, notifier, ratesUpdaterService, reportClient, config)
+
+	_, err := expenseUsecase.GetReport(ctx, monthReq)
+	assert.NoError(t, err)
+
+	resp, err := expenseUsecase.Undo(ctx, usecase.UndoReqDTO{UserID: 202})
+	assert.NoError(t, err)
+	assert.True(t, resp.Found)
+	assert.Equal(t, entity.AuditSetCurrency, resp.Action)
+	assert.Equal(t, "RUB", resp.Currency)
+
+	// Отчет в прежней валюте не берется из кеша
+	report, err := expenseUsecase.GetReport(ctx, monthReq)
+	assert.NoError(t, err)
+	assert.Equal(t, "RUB", report.Currency)
+}
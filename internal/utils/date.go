@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseDate разбирает дату относительно момента now: "сегодня", "вчера",
+// "позавчера", "15.10", "15.10.2026" или "2026-10-15". Время суток берется из now.
+// Дата без года, попавшая в будущее, относится к прошлому году. Дата с годом
+// может быть в будущем, например срок цели. Несуществующие даты вроде "31.02"
+// или "29.02" не в високосный год не принимаются.
+func ParseDate(text string, now time.Time) (time.Time, bool) {
+	switch strings.ToLower(text) {
+	case "сегодня":
+		return now, true
+	case "вчера":
+		return now.AddDate(0, 0, -1), true
+	case "позавчера":
+		return now.AddDate(0, 0, -2), true
+	}
+
+	for _, layout := range []string{"02.01.2006", "2006-01-02", "2.1.2006"} {
+		date, err := time.ParseInLocation(layout, text, now.Location())
+		if err == nil {
+			return withClock(date, now), true
+		}
+	}
+
+	for _, layout := range []string{"02.01", "2.1"} {
+		parsed, err := time.ParseInLocation(layout, text, now.Location())
+		if err != nil {
+			continue
+		}
+
+		year := now.Year()
+
+		date := withClock(time.Date(year, parsed.Month(), parsed.Day(), 0, 0, 0, 0, now.Location()), now)
+		if date.After(now) {
+			year--
+			date = withClock(time.Date(year, parsed.Month(), parsed.Day(), 0, 0, 0, 0, now.Location()), now)
+		}
+
+		// time.Date переносит 29.02 невисокосного года на 1 марта
+		if date.Day() != parsed.Day() {
+			return time.Time{}, false
+		}
+
+		return date, true
+	}
+
+	return time.Time{}, false
+}
+
+// ParsePastDate разбирает дату, как ParseDate, но не принимает даты в будущем:
+// расход или доход не может быть совершен позже now.
+func ParsePastDate(text string, now time.Time) (time.Time, bool) {
+	date, ok := ParseDate(text, now)
+	if !ok || date.After(now) {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+func withClock(date time.Time, clock time.Time) time.Time {
+	y, m, d := date.Date()
+
+	return time.Date(y, m, d, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+}
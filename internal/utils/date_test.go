@@ -0,0 +1,96 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		text string
+		date time.Time
+		ok   bool
+	}{
+		{text: "сегодня", date: now, ok: true},
+		{text: "Вчера", date: at(2026, 10, 17), ok: true},
+		{text: "позавчера", date: at(2026, 10, 16), ok: true},
+		{text: "15.10.2026", date: at(2026, 10, 15), ok: true},
+		{text: "2026-10-15", date: at(2026, 10, 15), ok: true},
+		{text: "5.1.2026", date: at(2026, 1, 5), ok: true},
+		{text: "15.10", date: at(2026, 10, 15), ok: true},
+		{text: "5.1", date: at(2026, 1, 5), ok: true},
+		{text: "18.10", date: now, ok: true},
+		// Дата без года в будущем относится к прошлому году
+		{text: "19.10", date: at(2025, 10, 19), ok: true},
+		{text: "31.12", date: at(2025, 12, 31), ok: true},
+		// Дата с годом может быть в будущем
+		{text: "01.06.2027", date: at(2027, 6, 1), ok: true},
+		{text: "29.02.2028", date: at(2028, 2, 29), ok: true},
+		{text: "29.02.2026", ok: false},
+		{text: "31.04.2026", ok: false},
+		{text: "29.02", ok: false},
+		{text: "31.02", ok: false},
+		{text: "32.10", ok: false},
+		{text: "15.13", ok: false},
+		{text: "2026/10/15", ok: false},
+		{text: "завтра", ok: false},
+		{text: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		date, ok := utils.ParseDate(tt.text, now)
+
+		assert.Equal(t, tt.ok, ok, tt.text)
+		assert.Equal(t, tt.date, date, tt.text)
+	}
+}
+
+func TestParseDate_LeapDayWithoutYear(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2028, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	date, ok := utils.ParseDate("29.02", now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2028, 2, 29, 9, 30, 0, 0, time.UTC), date)
+
+	// 29.02.2028 еще не наступило, а в 2027 году такого дня нет
+	_, ok = utils.ParseDate("29.02", time.Date(2028, 2, 20, 9, 30, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestParsePastDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		ok   bool
+	}{
+		{text: "сегодня", ok: true},
+		{text: "вчера", ok: true},
+		{text: "18.10.2026", ok: true},
+		{text: "19.10", ok: true},
+		{text: "19.10.2026", ok: false},
+		{text: "2027-01-01", ok: false},
+		{text: "31.02.2026", ok: false},
+	}
+
+	for _, tt := range tests {
+		_, ok := utils.ParsePastDate(tt.text, now)
+
+		assert.Equal(t, tt.ok, ok, tt.text)
+	}
+}
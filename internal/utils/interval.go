@@ -6,6 +6,7 @@ const (
 	DayInterval   = 1
 	WeekInterval  = 2
 	MonthInterval = 3
+	YearInterval  = 4
 
 	DaysOfWeek = 7
 )
@@ -23,16 +24,21 @@ func GetInterval(date time.Time, intervalType int) (time.Time, time.Time) {
 	case DayInterval:
 		return date, date.AddDate(0, 0, 1)
 	case WeekInterval:
-		offsetToStart := int(date.Weekday() - time.Monday)
+		offsetToStart := (int(date.Weekday()-time.Monday) + DaysOfWeek) % DaysOfWeek
 		start := date.AddDate(0, 0, -offsetToStart)
 		end := start.AddDate(0, 0, DaysOfWeek)
 
 		return start, end
 	case MonthInterval:
-		offsetToStart := date.Day()
+		offsetToStart := date.Day() - 1
 		start := date.AddDate(0, 0, -offsetToStart)
 		end := start.AddDate(0, 1, 0)
 
+		return start, end
+	case YearInterval:
+		start := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+		end := start.AddDate(1, 0, 0)
+
 		return start, end
 	default:
 		return date, date
@@ -47,6 +53,8 @@ func IntervalFromStr(interval string) (int, bool) {
 		return WeekInterval, true
 	case "месяц":
 		return MonthInterval, true
+	case "год":
+		return YearInterval, true
 	default:
 		return 0, false
 	}
@@ -60,7 +68,46 @@ func IntervalToStr(interval int) (string, bool) {
 		return "неделя", true
 	case MonthInterval:
 		return "месяц", true
+	case YearInterval:
+		return "год", true
 	default:
 		return "", false
 	}
 }
+
+// GetPrevInterval возвращает интервал того же типа, предшествующий интервалу с датой date.
+func GetPrevInterval(date time.Time, intervalType int) (time.Time, time.Time) {
+	start, _ := GetInterval(date, intervalType)
+
+	return GetInterval(start.AddDate(0, 0, -1), intervalType)
+}
+
+// AddIntervals сдвигает дату на count интервалов. Если в месяце нет такого дня,
+// как у date, используется последний день месяца: 31.01 + месяц = 28.02.
+func AddIntervals(date time.Time, intervalType int, count int) time.Time {
+	months := 0
+
+	switch intervalType {
+	case DayInterval:
+		return date.AddDate(0, 0, count)
+	case WeekInterval:
+		return date.AddDate(0, 0, count*DaysOfWeek)
+	case MonthInterval:
+		months = count
+	case YearInterval:
+		months = count * 12 //nolint:gomnd
+	default:
+		return date
+	}
+
+	firstDay := time.Date(date.Year(), date.Month(), 1, date.Hour(), date.Minute(), date.Second(),
+		date.Nanosecond(), date.Location()).AddDate(0, months, 0)
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstDay.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,172 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/utils"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		date         time.Time
+		intervalType int
+		start        time.Time
+		end          time.Time
+	}{
+		{
+			name:         "день",
+			date:         time.Date(2026, 10, 15, 18, 30, 0, 0, time.UTC),
+			intervalType: utils.DayInterval,
+			start:        date(2026, 10, 15),
+			end:          date(2026, 10, 16),
+		},
+		{
+			name:         "неделя с понедельника",
+			date:         date(2026, 10, 12),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 12),
+			end:          date(2026, 10, 19),
+		},
+		{
+			name:         "неделя в среду",
+			date:         date(2026, 10, 14),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 12),
+			end:          date(2026, 10, 19),
+		},
+		{
+			name:         "воскресенье относится к текущей неделе",
+			date:         time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 12),
+			end:          date(2026, 10, 19),
+		},
+		{
+			name:         "неделя на стыке месяцев",
+			date:         date(2026, 11, 1),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 26),
+			end:          date(2026, 11, 2),
+		},
+		{
+			name:         "первый день месяца",
+			date:         date(2026, 10, 1),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 10, 1),
+			end:          date(2026, 11, 1),
+		},
+		{
+			name:         "последний день месяца",
+			date:         date(2026, 10, 31),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 10, 1),
+			end:          date(2026, 11, 1),
+		},
+		{
+			name:         "февраль",
+			date:         date(2028, 2, 29),
+			intervalType: utils.MonthInterval,
+			start:        date(2028, 2, 1),
+			end:          date(2028, 3, 1),
+		},
+		{
+			name:         "декабрь",
+			date:         date(2026, 12, 31),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 12, 1),
+			end:          date(2027, 1, 1),
+		},
+		{
+			name:         "год",
+			date:         date(2026, 12, 31),
+			intervalType: utils.YearInterval,
+			start:        date(2026, 1, 1),
+			end:          date(2027, 1, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		start, end := utils.GetInterval(tt.date, tt.intervalType)
+
+		assert.Equal(t, tt.start, start, tt.name)
+		assert.Equal(t, tt.end, end, tt.name)
+	}
+}
+
+func TestGetPrevInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		date         time.Time
+		intervalType int
+		start        time.Time
+		end          time.Time
+	}{
+		{
+			name:         "прошлый день",
+			date:         date(2026, 11, 1),
+			intervalType: utils.DayInterval,
+			start:        date(2026, 10, 31),
+			end:          date(2026, 11, 1),
+		},
+		{
+			name:         "прошлая неделя из понедельника",
+			date:         date(2026, 10, 12),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 5),
+			end:          date(2026, 10, 12),
+		},
+		{
+			name:         "прошлая неделя из воскресенья",
+			date:         date(2026, 10, 18),
+			intervalType: utils.WeekInterval,
+			start:        date(2026, 10, 5),
+			end:          date(2026, 10, 12),
+		},
+		{
+			name:         "прошлый месяц из первого числа",
+			date:         date(2026, 10, 1),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 9, 1),
+			end:          date(2026, 10, 1),
+		},
+		{
+			name:         "прошлый месяц из 31 марта",
+			date:         date(2026, 3, 31),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 2, 1),
+			end:          date(2026, 3, 1),
+		},
+		{
+			name:         "прошлый месяц в январе",
+			date:         date(2027, 1, 15),
+			intervalType: utils.MonthInterval,
+			start:        date(2026, 12, 1),
+			end:          date(2027, 1, 1),
+		},
+		{
+			name:         "прошлый год",
+			date:         date(2026, 1, 1),
+			intervalType: utils.YearInterval,
+			start:        date(2025, 1, 1),
+			end:          date(2026, 1, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		start, end := utils.GetPrevInterval(tt.date, tt.intervalType)
+
+		assert.Equal(t, tt.start, start, tt.name)
+		assert.Equal(t, tt.end, end, tt.name)
+	}
+}
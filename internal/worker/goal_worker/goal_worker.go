@@ -0,0 +1,55 @@
+package goalworker
+
+import (
+	"context"
+	"time"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+)
+
+type usecase interface {
+	SendGoalNudges(context.Context) error
+}
+
+type config interface {
+	GetFrequencyGoalCheckSec() int
+}
+
+// GoalWorker периодически проверяет цели накоплений и напоминает, сколько откладывать в месяц.
+type GoalWorker struct {
+	usecase usecase
+	cfg     config
+}
+
+func New(usecase usecase, cfg config) *GoalWorker {
+	return &GoalWorker{
+		usecase: usecase,
+		cfg:     cfg,
+	}
+}
+
+func (w GoalWorker) Run(ctx context.Context) {
+	err := w.usecase.SendGoalNudges(ctx)
+	if err != nil {
+		logger.Errorf("can not send goal nudges: %v", err)
+	}
+
+	ticker := time.NewTicker(time.Duration(w.cfg.GetFrequencyGoalCheckSec()) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := w.usecase.SendGoalNudges(ctx)
+				if err != nil {
+					logger.Errorf("can not send goal nudges: %v", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package recurringexpenseworker
+
+import (
+	"context"
+	"time"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+)
+
+type usecase interface {
+	AddDueRecurringExpenses(context.Context) error
+}
+
+type config interface {
+	GetFrequencyRecurringCheckSec() int
+}
+
+// RecurringExpenseWorker периодически добавляет наступившие регулярные расходы.
+type RecurringExpenseWorker struct {
+	usecase usecase
+	cfg     config
+}
+
+func New(usecase usecase, cfg config) *RecurringExpenseWorker {
+	return &RecurringExpenseWorker{
+		usecase: usecase,
+		cfg:     cfg,
+	}
+}
+
+func (w RecurringExpenseWorker) Run(ctx context.Context) {
+	err := w.usecase.AddDueRecurringExpenses(ctx)
+	if err != nil {
+		logger.Errorf("can not add recurring expenses: %v", err)
+	}
+
+	ticker := time.NewTicker(time.Duration(w.cfg.GetFrequencyRecurringCheckSec()) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := w.usecase.AddDueRecurringExpenses(ctx)
+				if err != nil {
+					logger.Errorf("can not add recurring expenses: %v", err)
+				}
+			}
+		}
+	}
+}
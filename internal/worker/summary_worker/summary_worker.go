@@ -0,0 +1,55 @@
+package summaryworker
+
+import (
+	"context"
+	"time"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/logger"
+)
+
+type usecase interface {
+	SendSummaries(context.Context) error
+}
+
+type config interface {
+	GetFrequencySummaryCheckSec() int
+}
+
+// SummaryWorker периодически проверяет, кому пора отправить сводку расходов.
+type SummaryWorker struct {
+	usecase usecase
+	cfg     config
+}
+
+func New(usecase usecase, cfg config) *SummaryWorker {
+	return &SummaryWorker{
+		usecase: usecase,
+		cfg:     cfg,
+	}
+}
+
+func (w SummaryWorker) Run(ctx context.Context) {
+	err := w.usecase.SendSummaries(ctx)
+	if err != nil {
+		logger.Errorf("can not send summaries: %v", err)
+	}
+
+	ticker := time.NewTicker(time.Duration(w.cfg.GetFrequencySummaryCheckSec()) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				err := w.usecase.SendSummaries(ctx)
+				if err != nil {
+					logger.Errorf("can not send summaries: %v", err)
+				}
+			}
+		}
+	}
+}
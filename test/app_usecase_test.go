@@ -120,9 +120,9 @@ func TestApp_Integration(t *testing.T) { //nolint:paralleltest,maintidx
 			date:        timeHelper(11),
 			text:        `лимиты`,
 			textExpected: `Текущие лимиты:
-Дневной - 0.00 USD
-Недельный - 0.00 USD
-Месячный - 0.00 USD`,
+Дневной - 0,00 USD
+Недельный - 0,00 USD
+Месячный - 0,00 USD`,
 		},
 
 		{
@@ -130,14 +130,14 @@ func TestApp_Integration(t *testing.T) { //nolint:paralleltest,maintidx
 			userID:       1,
 			date:         timeHelper(20),
 			text:         `лимит день 10`,
-			textExpected: `Установил лимит: день - 10.00 - USD`,
+			textExpected: `Установил лимит: день - 10,00 - USD`,
 		},
 		{
 			description:  "setLimit",
 			userID:       1,
 			date:         timeHelper(21),
 			text:         `лимит неделя 50`,
-			textExpected: `Установил лимит: неделя - 50.00 - USD`,
+			textExpected: `Установил лимит: неделя - 50,00 - USD`,
 		},
 		{
 			description: "GetLimits",
@@ -145,9 +145,9 @@ func TestApp_Integration(t *testing.T) { //nolint:paralleltest,maintidx
 			date:        timeHelper(22),
 			text:        `лимиты`,
 			textExpected: `Текущие лимиты:
-Дневной - 10.00 USD
-Недельный - 50.00 USD
-Месячный - 0.00 USD`,
+Дневной - 10,00 USD
+Недельный - 50,00 USD
+Месячный - 0,00 USD`,
 		},
 
 		{
@@ -155,7 +155,7 @@ func TestApp_Integration(t *testing.T) { //nolint:paralleltest,maintidx
 			userID:       1,
 			date:         timeHelper(30),
 			text:         `расход Netflix 4.50`,
-			textExpected: `Добавил Netflix - 4.50 USD Wed, 09 Nov 2022 16:30:00 MSK`,
+			textExpected: `Добавил #1 Netflix - 4,50 USD 09.11.2022 16:30 MSK`,
 		},
 		{
 			description: "GetReportDay",
@@ -163,7 +163,7 @@ func TestApp_Integration(t *testing.T) { //nolint:paralleltest,maintidx
 			date:        timeHelper(31),
 			text:        `отчет день`,
 			textExpected: `Расходы по категориям за день:
-Netflix - 4.50`,
+Netflix - 4,50`,
 		},
 		{
 			description: "GetReporWeek",
@@ -171,7 +171,7 @@ Netflix - 4.50`,
 			date:        timeHelper(32),
 			text:        `отчет неделя`,
 			textExpected: `Расходы по категориям за неделя:
-Netflix - 4.50`,
+Netflix - 4,50`,
 		},
 
 		{
@@ -179,7 +179,7 @@ Netflix - 4.50`,
 			userID:       1,
 			date:         timeHelper(40),
 			text:         `расход AppStore 5.00`,
-			textExpected: `Добавил AppStore - 5.00 USD Wed, 09 Nov 2022 16:40:00 MSK`,
+			textExpected: `Добавил #2 AppStore - 5,00 USD 09.11.2022 16:40 MSK`,
 		},
 		{
 			description: "GetReportDay",
@@ -187,8 +187,8 @@ Netflix - 4.50`,
 			date:        timeHelper(41),
 			text:        `отчет день`,
 			textExpected: `Расходы по категориям за день:
-AppStore - 5.00
-Netflix - 4.50`,
+AppStore - 5,00
+Netflix - 4,50`,
 		},
 		{
 			description: "GetReporWeek",
@@ -196,8 +196,8 @@ Netflix - 4.50`,
 			date:        timeHelper(42),
 			text:        `отчет неделя`,
 			textExpected: `Расходы по категориям за неделя:
-AppStore - 5.00
-Netflix - 4.50`,
+AppStore - 5,00
+Netflix - 4,50`,
 		},
 
 		{
@@ -205,8 +205,8 @@ Netflix - 4.50`,
 			userID:      1,
 			date:        timeHelper(50),
 			text:        `расход AppStore 2.00`,
-			textExpected: `Добавил AppStore - 2.00 USD Wed, 09 Nov 2022 16:50:00 MSK
-Внимание! Превышен лимит: день - 1.50`,
+			textExpected: `Добавил #3 AppStore - 2,00 USD 09.11.2022 16:50 MSK
+Внимание! Превышен лимит: день - 1,50`,
 		},
 		{
 			description: "GetReportDay",
@@ -214,8 +214,8 @@ Netflix - 4.50`,
 			date:        timeHelper(51),
 			text:        `отчет день`,
 			textExpected: `Расходы по категориям за день:
-AppStore - 7.00
-Netflix - 4.50`,
+AppStore - 7,00
+Netflix - 4,50`,
 		},
 		{
 			description: "GetReporWeek",
@@ -223,8 +223,8 @@ Netflix - 4.50`,
 			date:        timeHelper(52),
 			text:        `отчет неделя`,
 			textExpected: `Расходы по категориям за неделя:
-AppStore - 7.00
-Netflix - 4.50`,
+AppStore - 7,00
+Netflix - 4,50`,
 		},
 
 		{
@@ -232,7 +232,7 @@ Netflix - 4.50`,
 			userID:       1,
 			date:         timeHelper(24*60 + 60),
 			text:         `расход Food 6.00`,
-			textExpected: `Добавил Food - 6.00 USD Thu, 10 Nov 2022 17:00:00 MSK`,
+			textExpected: `Добавил #4 Food - 6,00 USD 10.11.2022 17:00 MSK`,
 		},
 		{
 			description: "GetReportDay",
@@ -240,7 +240,7 @@ Netflix - 4.50`,
 			date:        timeHelper(24*60 + 61),
 			text:        `отчет день`,
 			textExpected: `Расходы по категориям за день:
-Food - 6.00`,
+Food - 6,00`,
 		},
 		{
 			description: "GetReporWeek",
@@ -248,9 +248,9 @@ Food - 6.00`,
 			date:        timeHelper(24*60 + 62),
 			text:        `отчет неделя`,
 			textExpected: `Расходы по категориям за неделя:
-AppStore - 7.00
-Food - 6.00
-Netflix - 4.50`,
+AppStore - 7,00
+Food - 6,00
+Netflix - 4,50`,
 		},
 
 		{
@@ -258,9 +258,9 @@ Netflix - 4.50`,
 			userID:      1,
 			date:        timeHelper(24*60 + 70),
 			text:        `расход Steam 100.00`,
-			textExpected: `Добавил Steam - 100.00 USD Thu, 10 Nov 2022 17:10:00 MSK
-Внимание! Превышен лимит: день - 96.00
-Внимание! Превышен лимит: неделя - 67.50`,
+			textExpected: `Добавил #5 Steam - 100,00 USD 10.11.2022 17:10 MSK
+Внимание! Превышен лимит: день - 96,00
+Внимание! Превышен лимит: неделя - 67,50`,
 		},
 		{
 			description: "GetReportDay",
@@ -268,8 +268,8 @@ Netflix - 4.50`,
 			date:        timeHelper(24*60 + 71),
 			text:        `отчет день`,
 			textExpected: `Расходы по категориям за день:
-Food - 6.00
-Steam - 100.00`,
+Food - 6,00
+Steam - 100,00`,
 		},
 		{
 			description: "GetReporWeek",
@@ -277,10 +277,40 @@ Steam - 100.00`,
 			date:        timeHelper(24*60 + 72),
 			text:        `отчет неделя`,
 			textExpected: `Расходы по категориям за неделя:
-AppStore - 7.00
-Food - 6.00
-Netflix - 4.50
-Steam - 100.00`,
+AppStore - 7,00
+Food - 6,00
+Netflix - 4,50
+Steam - 100,00`,
+		},
+
+		{
+			description:  "UpdateExpense",
+			userID:       1,
+			date:         timeHelper(24*60 + 80),
+			text:         `изменить 5 Steam 10.00`,
+			textExpected: `Изменил #5 Steam - 10,00 USD`,
+		},
+		{
+			description:  "DeleteExpense",
+			userID:       1,
+			date:         timeHelper(24*60 + 81),
+			text:         `удалить #4`,
+			textExpected: `Удалил #4 Food - 6,00 USD`,
+		},
+		{
+			description:  "DeleteExpenseNotFound",
+			userID:       1,
+			date:         timeHelper(24*60 + 82),
+			text:         `удалить 4`,
+			textExpected: `Расход #4 не найден`,
+		},
+		{
+			description: "GetReportDay",
+			userID:      1,
+			date:        timeHelper(24*60 + 83),
+			text:        `отчет день`,
+			textExpected: `Расходы по категориям за день:
+Steam - 10,00`,
 		},
 	}
 
@@ -351,7 +381,7 @@ Steam - 100.00`,
 
 	for i, scenario := range tests { //nolint:paralleltest
 		t.Run(scenario.description, func(t *testing.T) {
-			assert.Equal(t, scenario.userID, messagesAct[i].UserID)
+			assert.Equal(t, scenario.userID, messagesAct[i].ChatID)
 			assert.Equal(t, scenario.textExpected, messagesAct[i].Text)
 		})
 	}
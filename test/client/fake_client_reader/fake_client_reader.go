@@ -3,12 +3,20 @@ package fakeclientreader
 import (
 	"context"
 	"time"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/clients/tg"
 )
 
+// Message сообщение пользователя. С непустым FileName это файл, а Text - подпись к нему.
+// Без ChatID сообщение приходит из личного чата пользователя.
 type Message struct {
-	UserID int64
-	Date   time.Time
-	Text   string
+	UserID       int64
+	ChatID       int64
+	LanguageCode string
+	Date         time.Time
+	Text         string
+	FileName     string
+	FileData     []byte
 }
 
 type FakeClientReader struct {
@@ -23,10 +31,22 @@ func New(messages []Message, duration time.Duration) *FakeClientReader {
 	}
 }
 
-func (c FakeClientReader) Read(ctx context.Context, callback func(context.Context, int64, time.Time, string)) {
+func (c FakeClientReader) Read(ctx context.Context, callback tg.MsgCallback, documentCallback tg.DocumentCallback) {
 	for _, message := range c.messages {
 		time.Sleep(c.duration)
 
-		callback(ctx, message.UserID, message.Date, message.Text)
+		chatID := message.ChatID
+		if chatID == 0 {
+			chatID = message.UserID
+		}
+
+		if len(message.FileName) != 0 {
+			documentCallback(ctx, chatID, message.UserID, message.LanguageCode, message.Date, message.Text,
+				message.FileName, message.FileData)
+
+			continue
+		}
+
+		callback(ctx, chatID, message.UserID, message.LanguageCode, message.Date, message.Text)
 	}
 }
@@ -2,35 +2,88 @@ package fakeclientwriter
 
 import (
 	"context"
+
+	"gitlab.ozon.dev/myasnikov.alexander.s/telegram-bot/internal/clients/tg"
 )
 
 type Client interface {
 }
 
 type Message struct {
-	UserID int64
-	Text   string
+	ChatID   int64
+	Text     string
+	Keyboard [][]tg.Button
+}
+
+type Document struct {
+	ChatID int64
+	Name   string
+	Data   []byte
 }
 
 type FakeClientWriter struct {
-	messages []Message
+	messages  []Message
+	documents []Document
+	photos    []Document
 }
 
 func New() *FakeClientWriter {
 	return &FakeClientWriter{
-		messages: make([]Message, 0),
+		messages:  make([]Message, 0),
+		documents: make([]Document, 0),
+		photos:    make([]Document, 0),
 	}
 }
 
-func (c *FakeClientWriter) Write(ctx context.Context, text string, userID int64) error {
+func (c *FakeClientWriter) Write(ctx context.Context, text string, chatID int64) error {
 	c.messages = append(c.messages, Message{
-		UserID: userID,
+		ChatID: chatID,
 		Text:   text,
 	})
 
 	return nil
 }
 
+func (c *FakeClientWriter) WriteKeyboard(ctx context.Context, text string, keyboard [][]tg.Button,
+	chatID int64,
+) error {
+	c.messages = append(c.messages, Message{
+		ChatID:   chatID,
+		Text:     text,
+		Keyboard: keyboard,
+	})
+
+	return nil
+}
+
+func (c *FakeClientWriter) WriteDocument(ctx context.Context, name string, data []byte, chatID int64) error {
+	c.documents = append(c.documents, Document{
+		ChatID: chatID,
+		Name:   name,
+		Data:   data,
+	})
+
+	return nil
+}
+
+func (c *FakeClientWriter) WritePhoto(ctx context.Context, name string, data []byte, chatID int64) error {
+	c.photos = append(c.photos, Document{
+		ChatID: chatID,
+		Name:   name,
+		Data:   data,
+	})
+
+	return nil
+}
+
+func (c FakeClientWriter) GetPhotos() []Document {
+	return c.photos
+}
+
+func (c FakeClientWriter) GetDocuments() []Document {
+	return c.documents
+}
+
 func (c FakeClientWriter) GetMessages() []Message {
 	return c.messages
 }